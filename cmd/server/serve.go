@@ -0,0 +1,95 @@
+package cmd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"database/sql"
+	"net"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	"github.com/bhojpur/gui/pkg/service"
+	"github.com/bhojpur/gui/pkg/store"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+var serveCmdOpts struct {
+	Listen   string
+	DB       string
+	SpecDir  string
+	ReadOnly bool
+	Instance string
+}
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Starts the Bhojpur GUI engine service",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg := service.Config{Instance: serveCmdOpts.Instance}
+		if serveCmdOpts.DB != "" {
+			db, err := sql.Open("postgres", serveCmdOpts.DB)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			cfg.Engines, err = store.NewSQLEngines(db)
+			if err != nil {
+				return err
+			}
+			cfg.Logs, err = store.NewSQLLogs(db)
+			if err != nil {
+				return err
+			}
+		}
+		svc, err := service.NewService(cfg)
+		if err != nil {
+			return err
+		}
+
+		l, err := net.Listen("tcp", serveCmdOpts.Listen)
+		if err != nil {
+			return err
+		}
+
+		srv := grpc.NewServer()
+		v1.RegisterGuiServiceServer(srv, svc)
+		v1.RegisterGuiUIServer(srv, service.NewUIService(service.UIConfig{
+			SpecDir:  serveCmdOpts.SpecDir,
+			ReadOnly: serveCmdOpts.ReadOnly,
+		}))
+
+		log.WithField("addr", l.Addr().String()).Info("Bhojpur GUI engine service is listening")
+		return srv.Serve(l)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveCmdOpts.Listen, "listen", ":7777", "address the gRPC service listens on")
+	serveCmd.Flags().StringVar(&serveCmdOpts.DB, "db", "", "PostgreSQL connection string for persisting engines and their logs (keeps everything in memory if empty)")
+	serveCmd.Flags().StringVar(&serveCmdOpts.SpecDir, "spec-dir", "", "directory containing the engine YAML specs offered in the web user interface")
+	serveCmd.Flags().StringVar(&serveCmdOpts.Instance, "instance", "", "name of this service among all services sharing the database, only its own interrupted engines are recovered on start (defaults to the host name)")
+	serveCmd.Flags().BoolVar(&serveCmdOpts.ReadOnly, "read-only", false, "tells the web user interface not to offer starting engines")
+}
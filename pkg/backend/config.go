@@ -21,6 +21,7 @@ package backend
 // THE SOFTWARE.
 
 import (
+	"crypto/tls"
 	"fmt"
 	"log"
 	"os"
@@ -46,7 +47,17 @@ type (
 			Name string
 			Rank string
 		}
-		Log string
+		Log      string
+		Listener listener
+		Sink     SinkConfig
+	}
+
+	listener struct {
+		MaxConnections  int      `toml:"max_connections"`
+		ReadTimeout     duration `toml:"read_timeout"`
+		ShutdownTimeout duration `toml:"shutdown_timeout"`
+		TLSCert         string   `toml:"tls_cert"`
+		TLSKey          string   `toml:"tls_key"`
 	}
 
 	server struct {
@@ -99,6 +110,35 @@ func (c *hosting) GetLogFileName() string {
 	return logname
 }
 
+//NewServer creates a server with the listener and sink settings of the loaded configuration
+func NewServer() (*Server, error) {
+	l := defaultConfig.Listener
+	s := &Server{
+		MaxConnections:  l.MaxConnections,
+		ReadTimeout:     l.ReadTimeout.Duration,
+		ShutdownTimeout: l.ShutdownTimeout.Duration,
+	}
+
+	if l.TLSCert != "" || l.TLSKey != "" {
+		cert, err := tls.LoadX509KeyPair(l.TLSCert, l.TLSKey)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading TLS certificate: %v\n", err)
+		}
+		s.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS12,
+		}
+	}
+
+	sink, err := NewSink(defaultConfig.Sink)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating sink: %v\n", err)
+	}
+	s.Sink = sink
+
+	return s, nil
+}
+
 //LoadConfig load configuration from file
 func LoadConfig(confPath string) error {
 	f := "server.toml"
@@ -21,15 +21,61 @@ package backend
 // THE SOFTWARE.
 
 import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net"
-	"strings"
+	"sync"
+	"time"
 )
 
+// ErrServerClosed is returned by Run after the server was shut down or closed
+var ErrServerClosed = errors.New("backend: Server closed")
+
 type Server struct {
 	l net.Listener
+
+	// TLSConfig enables TLS on the listener if set. It must be set before Init.
+	TLSConfig *tls.Config
+
+	// MaxConnections limits the number of connections handled at the same time.
+	// Further clients wait until a connection is finished. Zero means no limit.
+	MaxConnections int
+
+	// ReadTimeout is the longest a connection may take to deliver its next message.
+	// Zero means no timeout.
+	ReadTimeout time.Duration
+
+	// ShutdownTimeout is the longest Run waits for connections to drain after its context
+	// is done, the remaining connections are then closed. Zero means no timeout.
+	ShutdownTimeout time.Duration
+
+	// Sink receives every accepted record. If it is nil records are only logged.
+	// The server does not close the sink.
+	Sink Sink
+
+	mu       sync.Mutex
+	conns    map[*serverConn]struct{}
+	shutdown bool
+	wg       sync.WaitGroup
+
+	// ctx is the parent of the connection contexts, it is cancelled when connections are closed forcibly
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// serverConn is a connection with the state needed to drain it on shutdown
+type serverConn struct {
+	net.Conn
+	// ctx is passed to the sink and is cancelled when the connection is closed by the server
+	ctx    context.Context
+	cancel context.CancelFunc
+	// idle is true while the connection waits for the next message
+	idle bool
 }
 
 //Init initialize Bhojpur GUI server instance
@@ -40,39 +86,274 @@ func (s *Server) Init(addr string) error {
 	if err != nil {
 		return fmt.Errorf("Error start server: %v\n", err)
 	}
+	if s.TLSConfig != nil {
+		s.l = tls.NewListener(s.l, s.TLSConfig)
+	}
 	log.Println("Bhojpur GUI - Server Listener start ", addr)
 
 	return err
 }
 
-//Run start listen connection on server
-func (s *Server) Run() {
+//Addr returns the address the server listens on
+func (s *Server) Addr() net.Addr {
+	return s.l.Addr()
+}
+
+//Run start listen connection on server until the context is done, then shuts the
+//server down gracefully. It always returns a non-nil error, ErrServerClosed after
+//a shutdown or close.
+func (s *Server) Run(ctx context.Context) error {
+	var sem chan struct{}
+	if s.MaxConnections > 0 {
+		sem = make(chan struct{}, s.MaxConnections)
+	}
+
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			s.shutdownWithTimeout()
+		case <-stop:
+		}
+	}()
+	defer func() {
+		// after a cancellation Run only returns once all connections are drained
+		if ctx.Err() == nil {
+			close(stop)
+		}
+		<-stopped
+	}()
+
+	var delay time.Duration
 	for {
+		if sem != nil {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return ErrServerClosed
+			}
+		}
+
 		conn, err := s.l.Accept()
 		if err != nil {
-			log.Printf("Accept error: %v\n", err)
-		} else {
-			go s.handlerConn(conn)
+			if sem != nil {
+				<-sem
+			}
+			if s.isShutdown() {
+				return ErrServerClosed
+			}
+			var ne net.Error
+			if errors.As(err, &ne) && ne.Temporary() {
+				if delay == 0 {
+					delay = 5 * time.Millisecond
+				} else if delay *= 2; delay > time.Second {
+					delay = time.Second
+				}
+				log.Printf("Accept error: %v; retrying in %v\n", err, delay)
+				time.Sleep(delay)
+				continue
+			}
+			return err
+		}
+		delay = 0
+
+		sc := s.track(conn)
+		if sc == nil {
+			conn.Close()
+			if sem != nil {
+				<-sem
+			}
+			return ErrServerClosed
 		}
+		go func() {
+			defer func() {
+				if sem != nil {
+					<-sem
+				}
+			}()
+			s.handlerConn(sc)
+		}()
 	}
 }
 
-//handlerConn handler incoming connection
-func (s *Server) handlerConn(c net.Conn) {
-	defer c.Close()
-	buf := make([]byte, 2048)
-	rcvPacketSize, err := c.Read(buf)
-	if err != nil && err != io.EOF {
-		log.Println("Read error: ", err)
-		return
+//Shutdown stops accepting connections and waits until every connection has finished
+//the message it is working on. Idle connections are closed right away. If the context
+//is done before all connections are drained the remaining ones are closed forcibly
+//and the contexts of the records they are forwarding are cancelled.
+func (s *Server) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	s.shutdown = true
+	err := s.l.Close()
+	for c := range s.conns {
+		if c.idle {
+			c.Close()
+		}
 	}
-	data := buf[:rcvPacketSize]
+	s.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(drained)
+	}()
 
-	rec := strings.Split(string(data), " ")
-	log.Println("Received data from client: ", rec)
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		s.closeConns()
+		<-drained
+		return ctx.Err()
+	}
+	if errors.Is(err, net.ErrClosed) {
+		err = nil
+	}
+	return err
+}
 
-	// rec must have 3 field (as at form)
-	if len(rec) <= 3 {
-		log.Printf("Forward record in target system: %v\n", rec)
+//shutdownWithTimeout shuts the server down, waiting at most ShutdownTimeout for connections to drain
+func (s *Server) shutdownWithTimeout() error {
+	ctx := context.Background()
+	if s.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.ShutdownTimeout)
+		defer cancel()
+	}
+	return s.Shutdown(ctx)
+}
+
+//Close stops the server immediately, closing the listener and all connections.
+//Records that are still being forwarded have their context cancelled.
+func (s *Server) Close() error {
+	s.mu.Lock()
+	s.shutdown = true
+	err := s.l.Close()
+	s.mu.Unlock()
+
+	s.closeConns()
+	if errors.Is(err, net.ErrClosed) {
+		err = nil
+	}
+	return err
+}
+
+func (s *Server) closeConns() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cancel != nil {
+		s.cancel() // stop sinks that are still delivering, such as a RetrySink waiting to retry
+	}
+	for c := range s.conns {
+		c.Close()
 	}
 }
+
+func (s *Server) isShutdown() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shutdown
+}
+
+//track registers a new connection, it returns nil if the server is shutting down
+func (s *Server) track(c net.Conn) *serverConn {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.shutdown {
+		return nil
+	}
+	if s.conns == nil {
+		s.conns = make(map[*serverConn]struct{})
+		s.ctx, s.cancel = context.WithCancel(context.Background())
+	}
+	sc := &serverConn{Conn: c, idle: true}
+	sc.ctx, sc.cancel = context.WithCancel(s.ctx)
+	s.conns[sc] = struct{}{}
+	s.wg.Add(1)
+	return sc
+}
+
+func (s *Server) untrack(sc *serverConn) {
+	sc.cancel()
+	s.mu.Lock()
+	delete(s.conns, sc)
+	s.mu.Unlock()
+	s.wg.Done()
+}
+
+//setIdle marks whether a connection waits for its next message. It returns false
+//if the connection should be closed because the server is shutting down.
+func (s *Server) setIdle(sc *serverConn, idle bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sc.idle = idle
+	return !s.shutdown
+}
+
+//handlerConn handler incoming connection. A client may send any number of records
+//over one connection, each of them is answered with an acknowledgement or an error.
+func (s *Server) handlerConn(sc *serverConn) {
+	defer s.untrack(sc)
+	defer sc.Close()
+
+	r := bufio.NewReader(sc)
+	for {
+		if !s.setIdle(sc, true) {
+			return
+		}
+		if s.ReadTimeout > 0 {
+			sc.SetReadDeadline(time.Now().Add(s.ReadTimeout))
+		}
+		// wait for the start of the next message before the connection counts as busy
+		if _, err := r.Peek(1); err != nil {
+			if err != io.EOF && !s.isShutdown() {
+				log.Println("Read error: ", err)
+			}
+			return
+		}
+		s.setIdle(sc, false)
+
+		msg, err := ReadMessage(r)
+		if err != nil {
+			log.Println("Read error: ", err)
+			// the stream cannot be resynchronised after a broken frame
+			s.reply(sc, &Message{Type: MessageError, Text: err.Error()})
+			return
+		}
+
+		reply := s.handleMessage(sc.ctx, msg)
+		if err := s.reply(sc, reply); err != nil {
+			return
+		}
+	}
+}
+
+//handleMessage processes a single message and returns the reply to it
+func (s *Server) handleMessage(ctx context.Context, msg *Message) *Message {
+	if msg.Type != MessageRecord {
+		return &Message{Type: MessageError, ID: msg.ID, Text: fmt.Sprintf("unexpected message type %q", msg.Type)}
+	}
+	if len(msg.Record) == 0 {
+		return &Message{Type: MessageError, ID: msg.ID, Text: "record has no fields"}
+	}
+
+	sink := s.Sink
+	if sink == nil {
+		sink = logSink{}
+	}
+	if err := sink.Forward(ctx, msg.Record); err != nil {
+		log.Printf("Forward error: %v\n", err)
+		return &Message{Type: MessageError, ID: msg.ID, Text: fmt.Sprintf("record could not be stored: %v", err)}
+	}
+	return &Message{Type: MessageAck, ID: msg.ID, Text: "Record received"}
+}
+
+func (s *Server) reply(c net.Conn, msg *Message) error {
+	err := WriteMessage(c, msg)
+	if err != nil {
+		log.Println("Write error: ", err)
+	}
+	return err
+}
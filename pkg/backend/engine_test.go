@@ -0,0 +1,223 @@
+package backend
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func startServer(t *testing.T, s *Server) (addr string, stop func() error) {
+	require.NoError(t, s.Init("127.0.0.1:0"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+
+	return s.Addr().String(), func() error {
+		cancel()
+		return <-done
+	}
+}
+
+func TestServer_HandlerConn(t *testing.T) {
+	client, server := net.Pipe()
+	s := &Server{}
+	go s.handlerConn(s.track(server))
+	defer client.Close()
+
+	go func() {
+		WriteMessage(client, &Message{Type: MessageRecord, ID: 1, Record: Record{"name": "Jane Doe", "email": "jane@example.com"}})
+		WriteMessage(client, &Message{Type: MessageRecord, ID: 2})
+		WriteMessage(client, &Message{Type: MessageAck, ID: 3})
+	}()
+
+	reply, err := ReadMessage(client)
+	require.NoError(t, err)
+	assert.Equal(t, &Message{Type: MessageAck, ID: 1, Text: "Record received"}, reply)
+
+	reply, err = ReadMessage(client)
+	require.NoError(t, err)
+	assert.Equal(t, MessageError, reply.Type)
+	assert.Equal(t, uint64(2), reply.ID)
+
+	reply, err = ReadMessage(client)
+	require.NoError(t, err)
+	assert.Equal(t, MessageError, reply.Type)
+	assert.Equal(t, uint64(3), reply.ID)
+}
+
+func TestServer_Shutdown(t *testing.T) {
+	s := &Server{}
+	addr, stop := startServer(t, s)
+
+	idle, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer idle.Close()
+	busy, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer busy.Close()
+
+	// start a message on the busy connection but do not finish it yet
+	require.NoError(t, WriteMessage(busy, &Message{Type: MessageRecord, ID: 1, Record: Record{"name": "Jane"}}))
+	reply, err := ReadMessage(busy)
+	require.NoError(t, err)
+	assert.Equal(t, MessageAck, reply.Type)
+	_, err = busy.Write([]byte{0, 0})
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- stop() }()
+
+	// the idle connection is closed right away
+	idle.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = ReadMessage(idle)
+	assert.Error(t, err)
+
+	select {
+	case <-stopped:
+		t.Fatal("server stopped before the busy connection was drained")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// finishing the message lets the server drain the connection
+	rest := &Message{Type: MessageRecord, ID: 2, Record: Record{"name": "John"}}
+	buf := &bytesWriter{}
+	require.NoError(t, WriteMessage(buf, rest))
+	_, err = busy.Write(buf.data[2:])
+	require.NoError(t, err)
+	reply, err = ReadMessage(busy)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), reply.ID)
+
+	select {
+	case err := <-stopped:
+		assert.Equal(t, ErrServerClosed, err)
+	case <-time.After(time.Second):
+		t.Fatal("server did not stop")
+	}
+
+	_, err = net.Dial("tcp", addr)
+	assert.Error(t, err)
+}
+
+func TestServer_ShutdownTimeout(t *testing.T) {
+	s := &Server{ShutdownTimeout: 50 * time.Millisecond}
+	addr, stop := startServer(t, s)
+
+	busy, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer busy.Close()
+	_, err = busy.Write([]byte{0, 0}) // a message that is never finished
+	require.NoError(t, err)
+	time.Sleep(50 * time.Millisecond)
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- stop() }()
+	select {
+	case err := <-stopped:
+		assert.Equal(t, ErrServerClosed, err)
+	case <-time.After(time.Second):
+		t.Fatal("server did not close the busy connection after the timeout")
+	}
+
+	busy.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = ReadMessage(busy)
+	assert.Error(t, err)
+	assert.False(t, isTimeout(err), "server should have closed the connection")
+}
+
+func TestServer_MaxConnections(t *testing.T) {
+	s := &Server{MaxConnections: 1}
+	addr, stop := startServer(t, s)
+	defer stop()
+
+	first, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	second, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer second.Close()
+
+	require.NoError(t, WriteMessage(second, &Message{Type: MessageRecord, ID: 1, Record: Record{"name": "Jane"}}))
+	second.SetReadDeadline(time.Now().Add(100 * time.Millisecond))
+	_, err = ReadMessage(second)
+	assert.Error(t, err, "second connection must wait for the first one")
+
+	first.Close()
+	second.SetReadDeadline(time.Now().Add(time.Second))
+	reply, err := ReadMessage(second)
+	require.NoError(t, err)
+	assert.Equal(t, MessageAck, reply.Type)
+}
+
+func TestServer_ReadTimeout(t *testing.T) {
+	s := &Server{ReadTimeout: 50 * time.Millisecond}
+	addr, stop := startServer(t, s)
+	defer stop()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, err = ReadMessage(conn)
+	assert.Error(t, err)
+	assert.False(t, isTimeout(err), "server should have closed the connection")
+}
+
+func TestServer_TLS(t *testing.T) {
+	defaultConfig.Listener = listener{TLSCert: "testdata/server.crt", TLSKey: "testdata/server.key"}
+	defer func() { defaultConfig.Listener = listener{} }()
+	s, err := NewServer()
+	require.NoError(t, err)
+	addr, stop := startServer(t, s)
+	defer stop()
+
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true})
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, WriteMessage(conn, &Message{Type: MessageRecord, ID: 1, Record: Record{"name": "Jane"}}))
+	reply, err := ReadMessage(conn)
+	require.NoError(t, err)
+	assert.Equal(t, MessageAck, reply.Type)
+}
+
+type bytesWriter struct {
+	data []byte
+}
+
+func (w *bytesWriter) Write(p []byte) (int, error) {
+	w.data = append(w.data, p...)
+	return len(p), nil
+}
+
+func isTimeout(err error) bool {
+	ne, ok := err.(net.Error)
+	return ok && ne.Timeout()
+}
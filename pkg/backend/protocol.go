@@ -0,0 +1,104 @@
+package backend
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MaxMessageSize is the largest encoded message accepted by ReadMessage
+const MaxMessageSize = 1 << 20
+
+// MessageType identifies the purpose of a Message
+type MessageType string
+
+const (
+	// MessageRecord carries a record from the client to the server
+	MessageRecord MessageType = "record"
+	// MessageAck confirms that the server accepted the record with the same ID
+	MessageAck MessageType = "ack"
+	// MessageError reports that the server could not accept the record with the same ID
+	MessageError MessageType = "error"
+)
+
+// Record is a single data set submitted by a client, keyed by field name
+type Record map[string]string
+
+// Message is a single frame exchanged between client and server. On the wire every
+// message is a 4 byte big-endian length followed by that many bytes of JSON.
+type Message struct {
+	Type MessageType `json:"type"`
+	// ID correlates replies with the record they answer
+	ID     uint64 `json:"id"`
+	Record Record `json:"record,omitempty"`
+	// Text is a human readable description of an acknowledgement or error
+	Text string `json:"text,omitempty"`
+}
+
+// WriteMessage encodes a message and writes it as a single frame
+func WriteMessage(w io.Writer, msg *Message) error {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(payload) > MaxMessageSize {
+		return fmt.Errorf("message of %d bytes exceeds maximum size of %d bytes", len(payload), MaxMessageSize)
+	}
+
+	frame := make([]byte, 4+len(payload))
+	binary.BigEndian.PutUint32(frame, uint32(len(payload)))
+	copy(frame[4:], payload)
+	_, err = w.Write(frame)
+	return err
+}
+
+// ReadMessage reads a single frame and decodes the message it contains. It returns
+// io.EOF if the stream ended cleanly before a new frame.
+func ReadMessage(r io.Reader) (*Message, error) {
+	var header [4]byte
+	_, err := io.ReadFull(r, header[:])
+	if err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[:])
+	if size > MaxMessageSize {
+		return nil, fmt.Errorf("message of %d bytes exceeds maximum size of %d bytes", size, MaxMessageSize)
+	}
+	payload := make([]byte, size)
+	_, err = io.ReadFull(r, payload)
+	if err == io.EOF {
+		err = io.ErrUnexpectedEOF
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var msg Message
+	err = json.Unmarshal(payload, &msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message: %v", err)
+	}
+	return &msg, nil
+}
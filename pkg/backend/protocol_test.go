@@ -0,0 +1,62 @@
+package backend
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessage_RoundTrip(t *testing.T) {
+	buf := &bytes.Buffer{}
+	large := strings.Repeat("x", 10000)
+	require.NoError(t, WriteMessage(buf, &Message{Type: MessageRecord, ID: 1, Record: Record{"name": "Jane Doe", "notes": large}}))
+	require.NoError(t, WriteMessage(buf, &Message{Type: MessageAck, ID: 1, Text: "ok"}))
+
+	msg, err := ReadMessage(buf)
+	require.NoError(t, err)
+	assert.Equal(t, MessageRecord, msg.Type)
+	assert.Equal(t, "Jane Doe", msg.Record["name"])
+	assert.Equal(t, large, msg.Record["notes"])
+
+	msg, err = ReadMessage(buf)
+	require.NoError(t, err)
+	assert.Equal(t, MessageAck, msg.Type)
+
+	_, err = ReadMessage(buf)
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestReadMessage_Invalid(t *testing.T) {
+	_, err := ReadMessage(bytes.NewReader([]byte{0xff, 0xff, 0xff, 0xff}))
+	assert.Error(t, err)
+
+	_, err = ReadMessage(bytes.NewReader([]byte{0, 0, 0, 10, '{'}))
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+
+	_, err = ReadMessage(bytes.NewReader([]byte{0, 0, 0, 1, '{'}))
+	assert.Error(t, err)
+}
@@ -0,0 +1,318 @@
+package backend
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	// registers the "postgres" driver used by SQLSink
+	_ "github.com/lib/pq"
+)
+
+// Sink is the target system received records are forwarded to
+type Sink interface {
+	// Forward delivers a single record. The record must not be modified afterwards.
+	Forward(ctx context.Context, rec Record) error
+
+	// Close releases the resources held by the sink
+	Close() error
+}
+
+// sinkEntry is the JSON line written for every record by FileSink
+type sinkEntry struct {
+	Time   time.Time `json:"time"`
+	Record Record    `json:"record"`
+	// Error is the reason a record ended up in the dead-letter file
+	Error string `json:"error,omitempty"`
+}
+
+// MemorySink keeps all forwarded records in memory. It is meant for tests and demos.
+type MemorySink struct {
+	mu      sync.Mutex
+	records []Record
+}
+
+var _ Sink = &MemorySink{}
+
+// Forward stores a copy of the record
+func (s *MemorySink) Forward(ctx context.Context, rec Record) error {
+	cpy := make(Record, len(rec))
+	for k, v := range rec {
+		cpy[k] = v
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records = append(s.records, cpy)
+	return nil
+}
+
+// Records returns all records forwarded so far in the order they were received
+func (s *MemorySink) Records() []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]Record, len(s.records))
+	copy(res, s.records)
+	return res
+}
+
+// Close does nothing
+func (s *MemorySink) Close() error {
+	return nil
+}
+
+// FileSink appends every record as a line of JSON to a file
+type FileSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+var _ Sink = &FileSink{}
+
+// NewFileSink opens the file records are appended to, creating it if necessary
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{f: f}, nil
+}
+
+// Forward appends the record to the file
+func (s *FileSink) Forward(ctx context.Context, rec Record) error {
+	return s.write(sinkEntry{Time: time.Now().UTC(), Record: rec})
+}
+
+func (s *FileSink) write(entry sinkEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// a single write keeps lines intact even if the file is shared with other processes
+	_, err = s.f.Write(line)
+	return err
+}
+
+// Close closes the file
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+// sqlSinkSchema is applied whenever a SQL sink is created
+const sqlSinkSchema = `
+CREATE TABLE IF NOT EXISTS backend_record (
+	id BIGSERIAL PRIMARY KEY,
+	received TIMESTAMPTZ NOT NULL,
+	data JSONB NOT NULL
+);
+`
+
+// SQLSink stores records in the backend_record table of a PostgreSQL database
+type SQLSink struct {
+	DB *sql.DB
+}
+
+var _ Sink = &SQLSink{}
+
+// NewSQLSink creates a new SQL sink, creating the required table if necessary
+func NewSQLSink(db *sql.DB) (*SQLSink, error) {
+	_, err := db.Exec(sqlSinkSchema)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLSink{DB: db}, nil
+}
+
+// Forward inserts the record
+func (s *SQLSink) Forward(ctx context.Context, rec Record) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = s.DB.ExecContext(ctx, `INSERT INTO backend_record (received, data) VALUES ($1, $2)`, time.Now().UTC(), data)
+	return err
+}
+
+// Close closes the database
+func (s *SQLSink) Close() error {
+	return s.DB.Close()
+}
+
+// RetrySink retries failed deliveries to another sink. Records which cannot be
+// delivered at all are written to a dead-letter file so that they are not lost.
+type RetrySink struct {
+	Sink Sink
+
+	// Attempts is the number of deliveries tried before a record is given up.
+	// Values below one mean a single attempt.
+	Attempts int
+
+	// Delay is the wait before the first retry, it doubles with every further retry
+	Delay time.Duration
+
+	// DeadLetter receives all records which could not be delivered. If it is nil such
+	// records are dropped.
+	DeadLetter *FileSink
+}
+
+var _ Sink = &RetrySink{}
+
+// Forward delivers the record to the wrapped sink. If all attempts fail the record is
+// written to the dead-letter file and the last delivery error is returned.
+func (s *RetrySink) Forward(ctx context.Context, rec Record) error {
+	delay := s.Delay
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = s.Sink.Forward(ctx, rec)
+		if err == nil {
+			return nil
+		}
+		if attempt >= s.Attempts {
+			break
+		}
+		log.Printf("Forward error (attempt %d of %d): %v\n", attempt, s.Attempts, err)
+
+		t := time.NewTimer(delay)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			err = ctx.Err()
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		delay *= 2
+	}
+
+	if s.DeadLetter == nil {
+		return fmt.Errorf("record was dropped: %v", err)
+	}
+	dlErr := s.DeadLetter.write(sinkEntry{Time: time.Now().UTC(), Record: rec, Error: err.Error()})
+	if dlErr != nil {
+		return fmt.Errorf("record was dropped: %v (dead-letter file: %v)", err, dlErr)
+	}
+	return fmt.Errorf("record was moved to the dead-letter file: %v", err)
+}
+
+// Close closes the wrapped sink and the dead-letter file
+func (s *RetrySink) Close() error {
+	err := s.Sink.Close()
+	if s.DeadLetter != nil {
+		if dlErr := s.DeadLetter.Close(); err == nil {
+			err = dlErr
+		}
+	}
+	return err
+}
+
+// logSink only logs records, it is used if no sink is configured
+type logSink struct{}
+
+func (logSink) Forward(ctx context.Context, rec Record) error {
+	log.Printf("Forward record in target system: %v\n", rec)
+	return nil
+}
+
+func (logSink) Close() error {
+	return nil
+}
+
+// SinkConfig selects and configures the sink records are forwarded to
+type SinkConfig struct {
+	// Type is one of "log", "memory", "file" or "sql". It defaults to "log".
+	Type string `toml:"type"`
+	// Path is the JSONL file of the file sink
+	Path string `toml:"path"`
+	// DSN is the PostgreSQL connection string of the SQL sink
+	DSN string `toml:"dsn"`
+
+	// Retries is the number of additional delivery attempts after a failure
+	Retries    int      `toml:"retries"`
+	RetryDelay duration `toml:"retry_delay"`
+	// DeadLetter is the JSONL file records which could not be delivered are written to
+	DeadLetter string `toml:"dead_letter"`
+}
+
+// NewSink creates the sink described by the configuration
+func NewSink(cfg SinkConfig) (Sink, error) {
+	var (
+		sink Sink
+		err  error
+	)
+	switch cfg.Type {
+	case "", "log":
+		sink = logSink{}
+	case "memory":
+		sink = &MemorySink{}
+	case "file":
+		if cfg.Path == "" {
+			return nil, errors.New("file sink requires a path")
+		}
+		sink, err = NewFileSink(cfg.Path)
+	case "sql":
+		if cfg.DSN == "" {
+			return nil, errors.New("sql sink requires a dsn")
+		}
+		var db *sql.DB
+		db, err = sql.Open("postgres", cfg.DSN)
+		if err != nil {
+			return nil, err
+		}
+		sink, err = NewSQLSink(db)
+		if err != nil {
+			db.Close()
+		}
+	default:
+		return nil, fmt.Errorf("unknown sink type %q", cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.Retries == 0 && cfg.DeadLetter == "" {
+		return sink, nil
+	}
+	rs := &RetrySink{Sink: sink, Attempts: cfg.Retries + 1, Delay: cfg.RetryDelay.Duration}
+	if cfg.DeadLetter != "" {
+		rs.DeadLetter, err = NewFileSink(cfg.DeadLetter)
+		if err != nil {
+			sink.Close()
+			return nil, err
+		}
+	}
+	return rs, nil
+}
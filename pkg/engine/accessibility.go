@@ -0,0 +1,89 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// AccessibleRole describes the purpose of an accessible object to assistive technology.
+//
+// Since: 2.3
+type AccessibleRole string
+
+const (
+	// AccessibleRoleWindow is the root of an accessibility tree.
+	AccessibleRoleWindow AccessibleRole = "window"
+	// AccessibleRoleText is static text that cannot be edited.
+	AccessibleRoleText AccessibleRole = "text"
+	// AccessibleRoleButton is an element that performs an action when tapped.
+	AccessibleRoleButton AccessibleRole = "button"
+	// AccessibleRoleCheckBox is an element that can be toggled between checked and unchecked.
+	AccessibleRoleCheckBox AccessibleRole = "checkbox"
+	// AccessibleRoleTextField is an element that allows the user to enter text.
+	AccessibleRoleTextField AccessibleRole = "textfield"
+	// AccessibleRoleSlider is an element that picks a value from a range.
+	AccessibleRoleSlider AccessibleRole = "slider"
+	// AccessibleRoleComboBox is an element that picks a value from a list of options.
+	AccessibleRoleComboBox AccessibleRole = "combobox"
+	// AccessibleRoleRadioGroup is a group of radio buttons of which only one can be checked.
+	AccessibleRoleRadioGroup AccessibleRole = "radiogroup"
+	// AccessibleRoleRadioButton is a single option of a radio group.
+	AccessibleRoleRadioButton AccessibleRole = "radio"
+	// AccessibleRoleList is a list of items.
+	AccessibleRoleList AccessibleRole = "list"
+	// AccessibleRoleListItem is a single item of a list.
+	AccessibleRoleListItem AccessibleRole = "listitem"
+	// AccessibleRoleTable is a grid of cells.
+	AccessibleRoleTable AccessibleRole = "table"
+	// AccessibleRoleTree is a hierarchical list of items.
+	AccessibleRoleTree AccessibleRole = "tree"
+	// AccessibleRoleTreeItem is a single item, branch or leaf, of a tree.
+	AccessibleRoleTreeItem AccessibleRole = "treeitem"
+)
+
+// AccessibleState holds the state flags of an accessible object.
+//
+// Since: 2.3
+type AccessibleState struct {
+	Checked   bool `json:"checked,omitempty"`
+	Disabled  bool `json:"disabled,omitempty"`
+	Expanded  bool `json:"expanded,omitempty"`
+	Focused   bool `json:"focused,omitempty"`
+	Protected bool `json:"protected,omitempty"` // The value is hidden, like the text of a password entry
+	Selected  bool `json:"selected,omitempty"`
+}
+
+// AccessibilityInfo describes an accessible object.
+//
+// Since: 2.3
+type AccessibilityInfo struct {
+	Role AccessibleRole
+	// Name is the label of the object. If it is empty the texts contained in the object are used.
+	Name  string
+	Value string
+	State AccessibleState
+}
+
+// Accessible describes any CanvasObject that can describe itself to assistive technology.
+// The disabled and focused states are filled in from the Disableable and Focusable interfaces
+// and the canvas, so they do not need to be reported.
+//
+// Since: 2.3
+type Accessible interface {
+	AccessibilityInfo() AccessibilityInfo
+}
@@ -0,0 +1,208 @@
+// Package accessibility builds a semantic tree of the accessible objects of a canvas.
+package accessibility
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/internal/driver"
+)
+
+// Node is a single accessible object in the semantic tree of a canvas.
+//
+// Since: 2.3
+type Node struct {
+	Role     gui.AccessibleRole  `json:"role"`
+	Name     string              `json:"name,omitempty"`
+	Value    string              `json:"value,omitempty"`
+	State    gui.AccessibleState `json:"state"`
+	Position gui.Position        `json:"position"` // The absolute position of the object on the canvas
+	Size     gui.Size            `json:"size"`
+	Children []*Node             `json:"children,omitempty"`
+
+	// Object is the canvas object described by this node.
+	Object gui.CanvasObject `json:"-"`
+}
+
+// leafRoles lists the roles whose objects are described completely by their own information.
+// The objects inside of them are not part of the tree.
+var leafRoles = map[gui.AccessibleRole]bool{
+	gui.AccessibleRoleButton:      true,
+	gui.AccessibleRoleCheckBox:    true,
+	gui.AccessibleRoleComboBox:    true,
+	gui.AccessibleRoleRadioButton: true,
+	gui.AccessibleRoleSlider:      true,
+	gui.AccessibleRoleText:        true,
+	gui.AccessibleRoleTextField:   true,
+}
+
+// contentNamedRoles lists the roles whose objects are named by the text they contain if they do not name themselves.
+var contentNamedRoles = map[gui.AccessibleRole]bool{
+	gui.AccessibleRoleListItem: true,
+	gui.AccessibleRoleTreeItem: true,
+}
+
+// Tree walks the visible objects of the canvas, including its overlays,
+// and returns the semantic tree of the accessible objects found.
+// Text which is not part of an accessible object is reported with the text role.
+//
+// Since: 2.3
+func Tree(c gui.Canvas) *Node {
+	root := &Node{Role: gui.AccessibleRoleWindow, Size: c.Size()}
+	if content := c.Content(); content != nil {
+		root.Object = content
+		walk(c, content, root)
+	}
+	for _, overlay := range c.Overlays().List() {
+		walk(c, overlay, root)
+	}
+	return root
+}
+
+// Export writes the semantic tree of the canvas to w as indented JSON.
+//
+// Since: 2.3
+func Export(w io.Writer, c gui.Canvas) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(Tree(c))
+}
+
+// Find returns the first node, in depth first order, with the given role and name.
+// An empty role or name matches any node. If no node matches nil is returned.
+//
+// Since: 2.3
+func (n *Node) Find(role gui.AccessibleRole, name string) *Node {
+	var found *Node
+	n.Walk(func(node *Node) bool {
+		if (role == "" || node.Role == role) && (name == "" || node.Name == name) {
+			found = node
+			return true
+		}
+		return false
+	})
+	return found
+}
+
+// FindAll returns all nodes with the given role in depth first order.
+//
+// Since: 2.3
+func (n *Node) FindAll(role gui.AccessibleRole) []*Node {
+	var found []*Node
+	n.Walk(func(node *Node) bool {
+		if node.Role == role {
+			found = append(found, node)
+		}
+		return false
+	})
+	return found
+}
+
+// Walk calls f for this node and all its descendants in depth first order.
+// The walk stops as soon as f returns true.
+//
+// Since: 2.3
+func (n *Node) Walk(f func(*Node) bool) bool {
+	if f(n) {
+		return true
+	}
+	for _, child := range n.Children {
+		if child.Walk(f) {
+			return true
+		}
+	}
+	return false
+}
+
+func walk(c gui.Canvas, obj gui.CanvasObject, root *Node) {
+	stack := []*Node{root}
+	var leaf gui.CanvasObject
+	driver.WalkVisibleObjectTree(obj, func(o gui.CanvasObject, pos, _ gui.Position, _ gui.Size) bool {
+		if leaf != nil {
+			return false
+		}
+		node := newNode(c, o)
+		if node == nil {
+			return false
+		}
+		node.Position = pos
+		node.Size = o.Size()
+
+		parent := stack[len(stack)-1]
+		parent.Children = append(parent.Children, node)
+		stack = append(stack, node)
+		if leafRoles[node.Role] {
+			leaf = o
+		}
+		return false
+	}, func(o, _ gui.CanvasObject) {
+		if o == leaf {
+			leaf = nil
+		}
+		top := stack[len(stack)-1]
+		if top.Object != o || top == root {
+			return
+		}
+		stack = stack[:len(stack)-1]
+		if top.Name == "" && contentNamedRoles[top.Role] {
+			top.Name = contentName(top)
+		}
+	})
+}
+
+func newNode(c gui.Canvas, o gui.CanvasObject) *Node {
+	switch obj := o.(type) {
+	case gui.Accessible:
+		info := obj.AccessibilityInfo()
+		if d, ok := o.(gui.Disableable); ok && d.Disabled() {
+			info.State.Disabled = true
+		}
+		if f, ok := o.(gui.Focusable); ok && c.Focused() == f {
+			info.State.Focused = true
+		}
+		return &Node{Role: info.Role, Name: info.Name, Value: info.Value, State: info.State, Object: o}
+	case *canvas.Text:
+		if strings.TrimSpace(obj.Text) == "" {
+			return nil
+		}
+		return &Node{Role: gui.AccessibleRoleText, Name: obj.Text, Object: o}
+	}
+	return nil
+}
+
+// contentName joins the text found inside a node.
+func contentName(n *Node) string {
+	var texts []string
+	for _, child := range n.Children {
+		child.Walk(func(node *Node) bool {
+			if node.Role == gui.AccessibleRoleText {
+				texts = append(texts, node.Name)
+			}
+			return false
+		})
+	}
+	return strings.Join(texts, " ")
+}
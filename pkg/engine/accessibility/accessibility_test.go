@@ -0,0 +1,169 @@
+package accessibility_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/accessibility"
+	"github.com/bhojpur/gui/pkg/engine/container"
+	"github.com/bhojpur/gui/pkg/engine/test"
+	"github.com/bhojpur/gui/pkg/engine/widget"
+)
+
+func TestTree(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	disabled := widget.NewButton("Disabled", nil)
+	disabled.Disable()
+	check := widget.NewCheck("Remember", nil)
+	check.SetChecked(true)
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("Name")
+	entry.SetText("Jo")
+	password := widget.NewPasswordEntry()
+	password.SetPlaceHolder("Password")
+	password.SetText("secret")
+	slider := widget.NewSlider(0, 10)
+	slider.SetValue(3)
+	sel := widget.NewSelect([]string{"A", "B"}, nil)
+	sel.SetSelected("B")
+	radio := widget.NewRadioGroup([]string{"X", "Y"}, nil)
+	radio.SetSelected("Y")
+
+	w := test.NewWindow(container.NewVBox(
+		widget.NewLabel("Login"),
+		widget.NewButton("OK", nil),
+		disabled,
+		check,
+		entry,
+		password,
+		slider,
+		sel,
+		radio,
+	))
+	defer w.Close()
+	w.Canvas().Focus(entry)
+
+	root := accessibility.Tree(w.Canvas())
+	assert.Equal(t, gui.AccessibleRoleWindow, root.Role)
+	require.Len(t, root.Children, 9)
+
+	label := root.Children[0]
+	assert.Equal(t, gui.AccessibleRoleText, label.Role)
+	assert.Equal(t, "Login", label.Name)
+
+	ok := root.Find(gui.AccessibleRoleButton, "OK")
+	require.NotNil(t, ok)
+	assert.Empty(t, ok.Children)
+	assert.False(t, ok.State.Disabled)
+	assert.Equal(t, gui.AccessibleState{Disabled: true}, root.Find(gui.AccessibleRoleButton, "Disabled").State)
+
+	assert.True(t, root.Find(gui.AccessibleRoleCheckBox, "Remember").State.Checked)
+
+	name := root.Find(gui.AccessibleRoleTextField, "Name")
+	require.NotNil(t, name)
+	assert.Equal(t, "Jo", name.Value)
+	assert.True(t, name.State.Focused)
+	assert.Same(t, entry, name.Object)
+
+	pass := root.Find(gui.AccessibleRoleTextField, "Password")
+	require.NotNil(t, pass)
+	assert.Equal(t, "••••••", pass.Value)
+	assert.True(t, pass.State.Protected)
+
+	assert.Equal(t, "3", root.Find(gui.AccessibleRoleSlider, "").Value)
+	assert.Equal(t, "B", root.Find(gui.AccessibleRoleComboBox, "").Value)
+
+	group := root.Find(gui.AccessibleRoleRadioGroup, "")
+	require.NotNil(t, group)
+	assert.Equal(t, "Y", group.Value)
+	require.Len(t, group.Children, 2)
+	assert.Equal(t, "X", group.Children[0].Name)
+	assert.False(t, group.Children[0].State.Checked)
+	assert.True(t, group.Children[1].State.Checked)
+}
+
+func TestTree_Collections(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	items := []string{"Apple", "Banana"}
+	list := widget.NewList(
+		func() int { return len(items) },
+		func() gui.CanvasObject { return widget.NewLabel("") },
+		func(id widget.ListItemID, o gui.CanvasObject) { o.(*widget.Label).SetText(items[id]) },
+	)
+	list.Select(1)
+	tree := widget.NewTreeWithStrings(map[string][]string{"": {"fruit"}, "fruit": {"cherry"}})
+	tree.OpenBranch("fruit")
+
+	w := test.NewWindow(container.NewGridWithColumns(2, list, tree))
+	defer w.Close()
+	w.Resize(gui.NewSize(300, 200))
+
+	root := accessibility.Tree(w.Canvas())
+	items1 := root.FindAll(gui.AccessibleRoleListItem)
+	require.Len(t, items1, 2)
+	assert.Equal(t, "Apple", items1[0].Name)
+	assert.False(t, items1[0].State.Selected)
+	assert.Equal(t, "Banana", items1[1].Name)
+	assert.True(t, items1[1].State.Selected)
+	assert.NotNil(t, root.Find(gui.AccessibleRoleList, ""))
+
+	nodes := root.Find(gui.AccessibleRoleTree, "").FindAll(gui.AccessibleRoleTreeItem)
+	require.Len(t, nodes, 2)
+	assert.Equal(t, "fruit", nodes[0].Name)
+	assert.True(t, nodes[0].State.Expanded)
+	assert.Equal(t, "cherry", nodes[1].Name)
+	assert.False(t, nodes[1].State.Expanded)
+}
+
+func TestExport(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	check := widget.NewCheck("Remember", nil)
+	check.SetChecked(true)
+	w := test.NewWindow(check)
+	defer w.Close()
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, accessibility.Export(buf, w.Canvas()))
+
+	var root map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &root))
+	assert.Equal(t, "window", root["role"])
+	children := root["children"].([]interface{})
+	require.Len(t, children, 1)
+	node := children[0].(map[string]interface{})
+	assert.Equal(t, "checkbox", node["role"])
+	assert.Equal(t, "Remember", node["name"])
+	assert.Equal(t, map[string]interface{}{"checked": true}, node["state"])
+	assert.NotContains(t, node, "children")
+}
@@ -1,5 +1,5 @@
-//go:build !ci && !android && !ios && !mobile
-// +build !ci,!android,!ios,!mobile
+//go:build !ci && !android && !ios && !mobile && !remote
+// +build !ci,!android,!ios,!mobile,!remote
 
 // Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
 
@@ -23,6 +23,7 @@ package container
 import (
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/internal/cache"
 	"github.com/bhojpur/gui/pkg/engine/layout"
 	"github.com/bhojpur/gui/pkg/engine/theme"
 	"github.com/bhojpur/gui/pkg/engine/widget"
@@ -31,6 +32,12 @@ import (
 // Declare conformity with Widget interface.
 var _ gui.Widget = (*DocTabs)(nil)
 
+// Declare conformity with DropTarget interface.
+var _ gui.DropTarget = (*DocTabs)(nil)
+
+// tabItemDragType is the DragData type of a tab being dragged along the tab bar.
+const tabItemDragType = "container.TabItem"
+
 // DocTabs container is used to display various pieces of content identified by tabs.
 // The tabs contain text and/or an icon and allow the user to switch between the content specified in each TabItem.
 // Each item is represented by a button at the edge of the container.
@@ -46,6 +53,10 @@ type DocTabs struct {
 	OnClosed       func(*TabItem)
 	OnSelected     func(*TabItem)
 	OnUnselected   func(*TabItem)
+	// OnReordered is called after a tab has been dragged to a new position in Items.
+	//
+	// Since: 2.3
+	OnReordered func(*TabItem)
 
 	current         int
 	location        TabLocation
@@ -81,9 +92,11 @@ func (t *DocTabs) CreateRenderer() gui.WidgetRenderer {
 			indicator:   canvas.NewRectangle(theme.PrimaryColor()),
 			buttonCache: make(map[*TabItem]*tabButton),
 		},
-		docTabs:  t,
-		scroller: NewScroll(nil),
+		docTabs:       t,
+		dropIndicator: canvas.NewRectangle(theme.PrimaryColor()),
+		scroller:      NewScroll(nil),
 	}
+	r.dropIndicator.Hide()
 	r.action = r.buildAllTabsButton()
 	r.create = r.buildCreateTabsButton()
 	r.box = NewHBox(r.create, r.action)
@@ -105,6 +118,75 @@ func (t *DocTabs) CreateRenderer() gui.WidgetRenderer {
 	return r
 }
 
+// DragLeave is called when a dragged tab leaves the tab bar.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (t *DocTabs) DragLeave() {
+	if r, ok := cache.Renderer(t).(*docTabsRenderer); ok {
+		r.moveDropIndicator(-1)
+	}
+}
+
+// DragOver is called when a tab is dragged over this DocTabs.
+// The tabs of this container are accepted when they are over the tab bar.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (t *DocTabs) DragOver(data *gui.DragData, pos gui.Position) bool {
+	r, ok := cache.Renderer(t).(*docTabsRenderer)
+	if !ok || t.draggedIndex(data) < 0 {
+		return false
+	}
+
+	index := r.dropIndexAt(pos)
+	r.moveDropIndicator(index)
+	return index >= 0
+}
+
+// Drop is called when a tab is dropped on the tab bar and moves it to the drop position.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (t *DocTabs) Drop(data *gui.DragData, pos gui.Position) {
+	r, ok := cache.Renderer(t).(*docTabsRenderer)
+	if !ok {
+		return
+	}
+	r.moveDropIndicator(-1)
+	from := t.draggedIndex(data)
+	to := r.dropIndexAt(pos)
+	if from < 0 || to < 0 {
+		return
+	}
+	if to > from {
+		to-- // the tab is removed before being inserted
+	}
+	if to == from {
+		return
+	}
+
+	item := t.Items[from]
+	items := append(append([]*TabItem{}, t.Items[:from]...), t.Items[from+1:]...)
+	t.Items = append(items[:to], append([]*TabItem{item}, items[to:]...)...)
+	switch {
+	case t.current == from:
+		t.current = to
+	case from < t.current && t.current <= to:
+		t.current--
+	case to <= t.current && t.current < from:
+		t.current++
+	}
+	t.Refresh()
+
+	if f := t.OnReordered; f != nil {
+		f(item)
+	}
+}
+
 // Hide hides the widget.
 //
 // Implements: gui.CanvasObject
@@ -179,6 +261,19 @@ func (t *DocTabs) Show() {
 	t.Refresh()
 }
 
+// draggedIndex returns the index of the tab carried by data, or -1 if it is not one of these tabs.
+func (t *DocTabs) draggedIndex(data *gui.DragData) int {
+	if data.Type != tabItemDragType {
+		return -1
+	}
+	for i, item := range t.Items {
+		if item == data.Value {
+			return i
+		}
+	}
+	return -1
+}
+
 func (t *DocTabs) close(item *TabItem) {
 	if f := t.CloseIntercept; f != nil {
 		f(item)
@@ -231,11 +326,12 @@ var _ gui.WidgetRenderer = (*docTabsRenderer)(nil)
 
 type docTabsRenderer struct {
 	baseTabsRenderer
-	docTabs      *DocTabs
-	scroller     *Scroll
-	box          *gui.Container
-	create       *widget.Button
-	lastSelected int
+	docTabs       *DocTabs
+	scroller      *Scroll
+	box           *gui.Container
+	create        *widget.Button
+	dropIndicator *canvas.Rectangle
+	lastSelected  int
 }
 
 func (r *docTabsRenderer) Layout(size gui.Size) {
@@ -254,7 +350,7 @@ func (r *docTabsRenderer) MinSize() gui.Size {
 }
 
 func (r *docTabsRenderer) Objects() []gui.CanvasObject {
-	return r.objects(r.docTabs)
+	return append(r.objects(r.docTabs), r.dropIndicator)
 }
 
 func (r *docTabsRenderer) Refresh() {
@@ -268,6 +364,7 @@ func (r *docTabsRenderer) Refresh() {
 	}
 
 	r.refresh(r.docTabs)
+	r.dropIndicator.FillColor = theme.PrimaryColor()
 
 	canvas.Refresh(r.docTabs)
 }
@@ -339,6 +436,9 @@ func (r *docTabsRenderer) buildTabButtons(count int) *gui.Container {
 			button = &tabButton{
 				onTapped: func() { r.docTabs.Select(item) },
 				onClosed: func() { r.docTabs.close(item) },
+				onDragData: func() *gui.DragData {
+					return &gui.DragData{Type: tabItemDragType, Value: item}
+				},
 			}
 			r.buttonCache[item] = button
 		}
@@ -358,6 +458,53 @@ func (r *docTabsRenderer) buildTabButtons(count int) *gui.Container {
 	return buttons
 }
 
+// dropIndexAt returns the index a tab dropped at pos would be inserted at, or -1 if pos is not over the tab bar.
+func (r *docTabsRenderer) dropIndexAt(pos gui.Position) int {
+	barPos, barSize := r.bar.Position(), r.bar.Size()
+	if pos.X < barPos.X || pos.Y < barPos.Y || pos.X >= barPos.X+barSize.Width || pos.Y >= barPos.Y+barSize.Height {
+		return -1
+	}
+
+	vertical := r.docTabs.location == TabLocationLeading || r.docTabs.location == TabLocationTrailing
+	origin := barPos.Add(r.scroller.Position()).Subtract(r.scroller.Offset)
+	for i, button := range r.scroller.Content.(*gui.Container).Objects {
+		center := origin.Add(button.Position()).Add(gui.NewPos(button.Size().Width/2, button.Size().Height/2))
+		if (vertical && pos.Y < center.Y) || (!vertical && pos.X < center.X) {
+			return i
+		}
+	}
+	return len(r.docTabs.Items)
+}
+
+// moveDropIndicator shows the line at which a dragged tab would be inserted, or hides it for an index of -1.
+func (r *docTabsRenderer) moveDropIndicator(index int) {
+	if index < 0 {
+		r.dropIndicator.Hide()
+		return
+	}
+
+	buttons := r.scroller.Content.(*gui.Container).Objects
+	origin := r.bar.Position().Add(r.scroller.Position()).Subtract(r.scroller.Offset)
+	var edge gui.Position
+	if index < len(buttons) {
+		edge = origin.Add(buttons[index].Position())
+	} else if len(buttons) > 0 {
+		last := buttons[len(buttons)-1]
+		edge = origin.Add(last.Position()).Add(gui.NewPos(last.Size().Width, last.Size().Height))
+	}
+
+	thickness := theme.Padding() / 2
+	if r.docTabs.location == TabLocationLeading || r.docTabs.location == TabLocationTrailing {
+		r.dropIndicator.Move(gui.NewPos(origin.X, edge.Y-thickness/2))
+		r.dropIndicator.Resize(gui.NewSize(r.scroller.Size().Width, thickness))
+	} else {
+		r.dropIndicator.Move(gui.NewPos(edge.X-thickness/2, origin.Y))
+		r.dropIndicator.Resize(gui.NewSize(thickness, r.scroller.Size().Height))
+	}
+	r.dropIndicator.Show()
+	r.dropIndicator.Refresh()
+}
+
 func (r *docTabsRenderer) scrollToSelected() {
 	buttons := r.scroller.Content.(*gui.Container)
 	button := buttons.Objects[r.docTabs.current]
@@ -25,6 +25,8 @@ import (
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/internal/cache"
+	"github.com/bhojpur/gui/pkg/engine/internal/driver"
+	"github.com/bhojpur/gui/pkg/engine/test"
 	"github.com/bhojpur/gui/pkg/engine/widget"
 
 	"github.com/stretchr/testify/assert"
@@ -50,3 +52,49 @@ func TestDocTabs_tabButtonRenderer_SetText(t *testing.T) {
 	renderer = cache.Renderer(button).(*tabButtonRenderer)
 	assert.Equal(t, "Replace", renderer.label.Text)
 }
+
+func TestDocTabs_Reorder(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	item1 := &TabItem{Text: "Test1", Content: widget.NewLabel("Text1")}
+	item2 := &TabItem{Text: "Test2", Content: widget.NewLabel("Text2")}
+	item3 := &TabItem{Text: "Test3", Content: widget.NewLabel("Text3")}
+	tabs := NewDocTabs(item1, item2, item3)
+	tabs.Select(item2)
+	var reordered *TabItem
+	tabs.OnReordered = func(item *TabItem) {
+		reordered = item
+	}
+
+	w := test.NewWindow(tabs)
+	defer w.Close()
+	w.SetPadded(false)
+	w.Resize(gui.NewSize(400, 200))
+
+	r := cache.Renderer(tabs).(*docTabsRenderer)
+	buttons := r.scroller.Content.(*gui.Container).Objects
+	center := func(o gui.CanvasObject) gui.Position {
+		pos := driver.AbsolutePositionForObject(o, []gui.CanvasObject{tabs})
+		return pos.Add(gui.NewPos(o.Size().Width/2, o.Size().Height/2))
+	}
+
+	last := buttons[2]
+	test.DragAndDrop(w.Canvas(), center(buttons[0]), center(last).Add(gui.NewPos(last.Size().Width/2-1, 0)))
+	assert.Equal(t, []*TabItem{item2, item3, item1}, tabs.Items)
+	assert.Equal(t, item1, reordered)
+	assert.Equal(t, item2, tabs.Selected())
+	assert.Equal(t, 0, tabs.SelectedIndex())
+	assert.False(t, r.dropIndicator.Visible())
+
+	buttons = r.scroller.Content.(*gui.Container).Objects
+	test.DragAndDrop(w.Canvas(), center(buttons[2]), center(buttons[0]).Subtract(gui.NewPos(1, 0)))
+	assert.Equal(t, []*TabItem{item1, item2, item3}, tabs.Items)
+	assert.Equal(t, 1, tabs.SelectedIndex())
+
+	reordered = nil
+	buttons = r.scroller.Content.(*gui.Container).Objects
+	test.DragAndDrop(w.Canvas(), center(buttons[1]), center(tabs.Selected().Content))
+	assert.Equal(t, []*TabItem{item1, item2, item3}, tabs.Items)
+	assert.Nil(t, reordered)
+}
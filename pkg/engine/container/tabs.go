@@ -25,6 +25,7 @@ import (
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/dnd"
 	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
 	"github.com/bhojpur/gui/pkg/engine/internal"
 	"github.com/bhojpur/gui/pkg/engine/theme"
@@ -402,6 +403,9 @@ const (
 var _ gui.Widget = (*tabButton)(nil)
 var _ gui.Tappable = (*tabButton)(nil)
 var _ desktop.Hoverable = (*tabButton)(nil)
+var _ gui.Draggable = (*tabButton)(nil)
+var _ gui.DragSource = (*tabButton)(nil)
+var _ gui.DragPreviewer = (*tabButton)(nil)
 
 type tabButton struct {
 	widget.BaseWidget
@@ -411,8 +415,10 @@ type tabButton struct {
 	importance    widget.ButtonImportance
 	onTapped      func()
 	onClosed      func()
+	onDragData    func() *gui.DragData // returns the payload when the tab can be dragged
 	text          string
 	textAlignment gui.TextAlign
+	tracker       dnd.Tracker
 }
 
 func (b *tabButton) CreateRenderer() gui.WidgetRenderer {
@@ -455,6 +461,38 @@ func (b *tabButton) CreateRenderer() gui.WidgetRenderer {
 	return r
 }
 
+func (b *tabButton) DragData(gui.Position) *gui.DragData {
+	if b.onDragData == nil {
+		return nil
+	}
+	return b.onDragData()
+}
+
+func (b *tabButton) DragEnd() {
+	b.tracker.DragEnd()
+}
+
+func (b *tabButton) DragFinished(*gui.DragData, bool) {
+}
+
+func (b *tabButton) DragPreview(*gui.DragData) gui.CanvasObject {
+	preview := &tabButton{
+		icon:          b.icon,
+		iconPosition:  b.iconPosition,
+		importance:    widget.HighImportance,
+		text:          b.text,
+		textAlignment: b.textAlignment,
+	}
+	preview.ExtendBaseWidget(preview)
+	return preview
+}
+
+func (b *tabButton) Dragged(ev *gui.DragEvent) {
+	if b.onDragData != nil {
+		b.tracker.Dragged(b, ev)
+	}
+}
+
 func (b *tabButton) MinSize() gui.Size {
 	b.ExtendBaseWidget(b)
 	return b.BaseWidget.MinSize()
@@ -31,9 +31,13 @@ import (
 )
 
 var (
+	errInvalidPath = errors.New("invalid binding path")
 	errKeyNotFound = errors.New("key not found")
+	errNilPath     = errors.New("nil value in binding path")
 	errOutOfBounds = errors.New("index out of bounds")
 	errParseFailed = errors.New("format did not match 1 value")
+	errPathType    = errors.New("value at binding path is a different type")
+	errReadOnly    = errors.New("derived binding is read only")
 
 	// As an optimisation we connect any listeners asking for the same key, so that there is only 1 per preference item.
 	prefBinds = newPreferencesMap()
@@ -116,11 +120,7 @@ func (b *base) trigger() {
 // Untyped supports binding a interface{} value.
 //
 // Since: 2.1
-type Untyped interface {
-	DataItem
-	Get() (interface{}, error)
-	Set(interface{}) error
-}
+type Untyped = Item[interface{}]
 
 // NewUntyped returns a bindable interface{} value that is managed internally.
 //
@@ -160,10 +160,7 @@ func (b *boundUntyped) Set(val interface{}) error {
 // ExternalUntyped supports binding a interface{} value to an external value.
 //
 // Since: 2.1
-type ExternalUntyped interface {
-	Untyped
-	Reload() error
-}
+type ExternalUntyped = ExternalItem[interface{}]
 
 // BindUntyped returns a bindable interface{} value that is bound to an external type.
 // The parameter must be a pointer to the type you wish to bind.
@@ -3,31 +3,27 @@
 
 package binding
 
-import gui "github.com/bhojpur/gui/pkg/engine"
+import (
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+)
 
 // Bool supports binding a bool value.
 //
 // Since: 2.0
-type Bool interface {
-	DataItem
-	Get() (bool, error)
-	Set(bool) error
-}
+type Bool = Item[bool]
 
 // ExternalBool supports binding a bool value to an external value.
 //
 // Since: 2.0
-type ExternalBool interface {
-	Bool
-	Reload() error
-}
+type ExternalBool = ExternalItem[bool]
 
 // NewBool returns a bindable bool value that is managed internally.
 //
 // Since: 2.0
 func NewBool() Bool {
-	var blank bool = false
-	return &boundBool{val: &blank}
+	return NewItem[bool]()
 }
 
 // BindBool returns a new bindable value that controls the contents of the provided bool variable.
@@ -35,90 +31,24 @@ func NewBool() Bool {
 //
 // Since: 2.0
 func BindBool(v *bool) ExternalBool {
-	if v == nil {
-		var blank bool = false
-		v = &blank // never allow a nil value pointer
-	}
-	b := &boundExternalBool{}
-	b.val = v
-	b.old = *v
-	return b
-}
-
-type boundBool struct {
-	base
-
-	val *bool
-}
-
-func (b *boundBool) Get() (bool, error) {
-	b.lock.RLock()
-	defer b.lock.RUnlock()
-
-	if b.val == nil {
-		return false, nil
-	}
-	return *b.val, nil
-}
-
-func (b *boundBool) Set(val bool) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if *b.val == val {
-		return nil
-	}
-	*b.val = val
-
-	b.trigger()
-	return nil
-}
-
-type boundExternalBool struct {
-	boundBool
-
-	old bool
-}
-
-func (b *boundExternalBool) Set(val bool) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if b.old == val {
-		return nil
-	}
-	*b.val = val
-	b.old = val
-
-	b.trigger()
-	return nil
-}
-
-func (b *boundExternalBool) Reload() error {
-	return b.Set(*b.val)
+	return BindItem(v)
 }
 
 // Float supports binding a float64 value.
 //
 // Since: 2.0
-type Float interface {
-	DataItem
-	Get() (float64, error)
-	Set(float64) error
-}
+type Float = Item[float64]
 
 // ExternalFloat supports binding a float64 value to an external value.
 //
 // Since: 2.0
-type ExternalFloat interface {
-	Float
-	Reload() error
-}
+type ExternalFloat = ExternalItem[float64]
 
 // NewFloat returns a bindable float64 value that is managed internally.
 //
 // Since: 2.0
 func NewFloat() Float {
-	var blank float64 = 0.0
-	return &boundFloat{val: &blank}
+	return NewItem[float64]()
 }
 
 // BindFloat returns a new bindable value that controls the contents of the provided float64 variable.
@@ -126,90 +56,24 @@ func NewFloat() Float {
 //
 // Since: 2.0
 func BindFloat(v *float64) ExternalFloat {
-	if v == nil {
-		var blank float64 = 0.0
-		v = &blank // never allow a nil value pointer
-	}
-	b := &boundExternalFloat{}
-	b.val = v
-	b.old = *v
-	return b
-}
-
-type boundFloat struct {
-	base
-
-	val *float64
-}
-
-func (b *boundFloat) Get() (float64, error) {
-	b.lock.RLock()
-	defer b.lock.RUnlock()
-
-	if b.val == nil {
-		return 0.0, nil
-	}
-	return *b.val, nil
-}
-
-func (b *boundFloat) Set(val float64) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if *b.val == val {
-		return nil
-	}
-	*b.val = val
-
-	b.trigger()
-	return nil
-}
-
-type boundExternalFloat struct {
-	boundFloat
-
-	old float64
-}
-
-func (b *boundExternalFloat) Set(val float64) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if b.old == val {
-		return nil
-	}
-	*b.val = val
-	b.old = val
-
-	b.trigger()
-	return nil
-}
-
-func (b *boundExternalFloat) Reload() error {
-	return b.Set(*b.val)
+	return BindItem(v)
 }
 
 // Int supports binding a int value.
 //
 // Since: 2.0
-type Int interface {
-	DataItem
-	Get() (int, error)
-	Set(int) error
-}
+type Int = Item[int]
 
 // ExternalInt supports binding a int value to an external value.
 //
 // Since: 2.0
-type ExternalInt interface {
-	Int
-	Reload() error
-}
+type ExternalInt = ExternalItem[int]
 
 // NewInt returns a bindable int value that is managed internally.
 //
 // Since: 2.0
 func NewInt() Int {
-	var blank int = 0
-	return &boundInt{val: &blank}
+	return NewItem[int]()
 }
 
 // BindInt returns a new bindable value that controls the contents of the provided int variable.
@@ -217,90 +81,24 @@ func NewInt() Int {
 //
 // Since: 2.0
 func BindInt(v *int) ExternalInt {
-	if v == nil {
-		var blank int = 0
-		v = &blank // never allow a nil value pointer
-	}
-	b := &boundExternalInt{}
-	b.val = v
-	b.old = *v
-	return b
-}
-
-type boundInt struct {
-	base
-
-	val *int
-}
-
-func (b *boundInt) Get() (int, error) {
-	b.lock.RLock()
-	defer b.lock.RUnlock()
-
-	if b.val == nil {
-		return 0, nil
-	}
-	return *b.val, nil
-}
-
-func (b *boundInt) Set(val int) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if *b.val == val {
-		return nil
-	}
-	*b.val = val
-
-	b.trigger()
-	return nil
-}
-
-type boundExternalInt struct {
-	boundInt
-
-	old int
-}
-
-func (b *boundExternalInt) Set(val int) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if b.old == val {
-		return nil
-	}
-	*b.val = val
-	b.old = val
-
-	b.trigger()
-	return nil
-}
-
-func (b *boundExternalInt) Reload() error {
-	return b.Set(*b.val)
+	return BindItem(v)
 }
 
 // Rune supports binding a rune value.
 //
 // Since: 2.0
-type Rune interface {
-	DataItem
-	Get() (rune, error)
-	Set(rune) error
-}
+type Rune = Item[rune]
 
 // ExternalRune supports binding a rune value to an external value.
 //
 // Since: 2.0
-type ExternalRune interface {
-	Rune
-	Reload() error
-}
+type ExternalRune = ExternalItem[rune]
 
 // NewRune returns a bindable rune value that is managed internally.
 //
 // Since: 2.0
 func NewRune() Rune {
-	var blank rune = rune(0)
-	return &boundRune{val: &blank}
+	return NewItem[rune]()
 }
 
 // BindRune returns a new bindable value that controls the contents of the provided rune variable.
@@ -308,90 +106,24 @@ func NewRune() Rune {
 //
 // Since: 2.0
 func BindRune(v *rune) ExternalRune {
-	if v == nil {
-		var blank rune = rune(0)
-		v = &blank // never allow a nil value pointer
-	}
-	b := &boundExternalRune{}
-	b.val = v
-	b.old = *v
-	return b
-}
-
-type boundRune struct {
-	base
-
-	val *rune
-}
-
-func (b *boundRune) Get() (rune, error) {
-	b.lock.RLock()
-	defer b.lock.RUnlock()
-
-	if b.val == nil {
-		return rune(0), nil
-	}
-	return *b.val, nil
-}
-
-func (b *boundRune) Set(val rune) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if *b.val == val {
-		return nil
-	}
-	*b.val = val
-
-	b.trigger()
-	return nil
-}
-
-type boundExternalRune struct {
-	boundRune
-
-	old rune
-}
-
-func (b *boundExternalRune) Set(val rune) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if b.old == val {
-		return nil
-	}
-	*b.val = val
-	b.old = val
-
-	b.trigger()
-	return nil
-}
-
-func (b *boundExternalRune) Reload() error {
-	return b.Set(*b.val)
+	return BindItem(v)
 }
 
 // String supports binding a string value.
 //
 // Since: 2.0
-type String interface {
-	DataItem
-	Get() (string, error)
-	Set(string) error
-}
+type String = Item[string]
 
 // ExternalString supports binding a string value to an external value.
 //
 // Since: 2.0
-type ExternalString interface {
-	String
-	Reload() error
-}
+type ExternalString = ExternalItem[string]
 
 // NewString returns a bindable string value that is managed internally.
 //
 // Since: 2.0
 func NewString() String {
-	var blank string = ""
-	return &boundString{val: &blank}
+	return NewItem[string]()
 }
 
 // BindString returns a new bindable value that controls the contents of the provided string variable.
@@ -399,90 +131,24 @@ func NewString() String {
 //
 // Since: 2.0
 func BindString(v *string) ExternalString {
-	if v == nil {
-		var blank string = ""
-		v = &blank // never allow a nil value pointer
-	}
-	b := &boundExternalString{}
-	b.val = v
-	b.old = *v
-	return b
-}
-
-type boundString struct {
-	base
-
-	val *string
-}
-
-func (b *boundString) Get() (string, error) {
-	b.lock.RLock()
-	defer b.lock.RUnlock()
-
-	if b.val == nil {
-		return "", nil
-	}
-	return *b.val, nil
-}
-
-func (b *boundString) Set(val string) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if *b.val == val {
-		return nil
-	}
-	*b.val = val
-
-	b.trigger()
-	return nil
-}
-
-type boundExternalString struct {
-	boundString
-
-	old string
-}
-
-func (b *boundExternalString) Set(val string) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if b.old == val {
-		return nil
-	}
-	*b.val = val
-	b.old = val
-
-	b.trigger()
-	return nil
-}
-
-func (b *boundExternalString) Reload() error {
-	return b.Set(*b.val)
+	return BindItem(v)
 }
 
 // URI supports binding a gui.URI value.
 //
 // Since: 2.1
-type URI interface {
-	DataItem
-	Get() (gui.URI, error)
-	Set(gui.URI) error
-}
+type URI = Item[gui.URI]
 
 // ExternalURI supports binding a gui.URI value to an external value.
 //
 // Since: 2.1
-type ExternalURI interface {
-	URI
-	Reload() error
-}
+type ExternalURI = ExternalItem[gui.URI]
 
 // NewURI returns a bindable gui.URI value that is managed internally.
 //
 // Since: 2.1
 func NewURI() URI {
-	var blank gui.URI = gui.URI(nil)
-	return &boundURI{val: &blank}
+	return NewItemWithComparator(compareURI)
 }
 
 // BindURI returns a new bindable value that controls the contents of the provided gui.URI variable.
@@ -490,63 +156,30 @@ func NewURI() URI {
 //
 // Since: 2.1
 func BindURI(v *gui.URI) ExternalURI {
-	if v == nil {
-		var blank gui.URI = gui.URI(nil)
-		v = &blank // never allow a nil value pointer
-	}
-	b := &boundExternalURI{}
-	b.val = v
-	b.old = *v
-	return b
-}
-
-type boundURI struct {
-	base
-
-	val *gui.URI
-}
-
-func (b *boundURI) Get() (gui.URI, error) {
-	b.lock.RLock()
-	defer b.lock.RUnlock()
-
-	if b.val == nil {
-		return gui.URI(nil), nil
-	}
-	return *b.val, nil
-}
-
-func (b *boundURI) Set(val gui.URI) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if compareURI(*b.val, val) {
-		return nil
-	}
-	*b.val = val
-
-	b.trigger()
-	return nil
+	return BindItemWithComparator(v, compareURI)
 }
 
-type boundExternalURI struct {
-	boundURI
-
-	old gui.URI
-}
+// Time supports binding a time.Time value.
+//
+// Since: 2.3
+type Time = Item[time.Time]
 
-func (b *boundExternalURI) Set(val gui.URI) error {
-	b.lock.Lock()
-	defer b.lock.Unlock()
-	if compareURI(b.old, val) {
-		return nil
-	}
-	*b.val = val
-	b.old = val
+// ExternalTime supports binding a time.Time value to an external value.
+//
+// Since: 2.3
+type ExternalTime = ExternalItem[time.Time]
 
-	b.trigger()
-	return nil
+// NewTime returns a bindable time.Time value that is managed internally.
+//
+// Since: 2.3
+func NewTime() Time {
+	return NewItemWithComparator(compareTime)
 }
 
-func (b *boundExternalURI) Reload() error {
-	return b.Set(*b.val)
+// BindTime returns a new bindable value that controls the contents of the provided time.Time variable.
+// If your code changes the content of the variable this refers to you should call Reload() to inform the bindings.
+//
+// Since: 2.3
+func BindTime(v *time.Time) ExternalTime {
+	return BindItemWithComparator(v, compareTime)
 }
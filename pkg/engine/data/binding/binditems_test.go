@@ -22,6 +22,7 @@ package binding
 
 import (
 	"testing"
+	"time"
 
 	"github.com/bhojpur/gui/pkg/engine/storage"
 	"github.com/stretchr/testify/assert"
@@ -266,3 +267,53 @@ func TestNewURI_TriggerOnlyWhenChange(t *testing.T) {
 	waitForItems()
 	assert.Equal(t, 1, triggered)
 }
+
+func TestBindTimeItem(t *testing.T) {
+	val := time.Date(2022, time.March, 4, 10, 30, 0, 0, time.UTC)
+	b := BindTime(&val)
+	v, err := b.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, val, v)
+
+	called := false
+	b.AddListener(NewDataListener(func() {
+		called = true
+	}))
+	waitForItems()
+	assert.True(t, called)
+
+	called = false
+	err = b.Set(val.AddDate(0, 0, 1))
+	assert.Nil(t, err)
+	waitForItems()
+	assert.Equal(t, 5, val.Day())
+	assert.True(t, called)
+
+	called = false
+	err = b.Set(val.In(time.FixedZone("CET", 3600))) // the same instant is not a change
+	assert.Nil(t, err)
+	waitForItems()
+	assert.False(t, called)
+
+	val = time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)
+	_ = b.Reload()
+	waitForItems()
+	assert.True(t, called)
+	v, err = b.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 2023, v.Year())
+}
+
+func TestNewTimeItem(t *testing.T) {
+	b := NewTime()
+	v, err := b.Get()
+	assert.Nil(t, err)
+	assert.True(t, v.IsZero())
+
+	now := time.Now()
+	err = b.Set(now)
+	assert.Nil(t, err)
+	v, err = b.Get()
+	assert.Nil(t, err)
+	assert.True(t, now.Equal(v))
+}
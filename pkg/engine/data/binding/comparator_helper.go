@@ -20,7 +20,19 @@ package binding
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
 // THE SOFTWARE.
 
-import gui "github.com/bhojpur/gui/pkg/engine"
+import (
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+)
+
+func equal[T comparable](v1, v2 T) bool {
+	return v1 == v2
+}
+
+func compareUntyped(v1, v2 interface{}) bool {
+	return v1 == v2
+}
 
 func compareURI(v1, v2 gui.URI) bool {
 	if v1 == nil && v1 == v2 {
@@ -31,3 +43,7 @@ func compareURI(v1, v2 gui.URI) bool {
 	}
 	return v1.String() == v2.String()
 }
+
+func compareTime(v1, v2 time.Time) bool {
+	return v1.Equal(v2)
+}
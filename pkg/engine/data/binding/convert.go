@@ -3,29 +3,13 @@
 
 package binding
 
-import (
-	"fmt"
-
-	gui "github.com/bhojpur/gui/pkg/engine"
-)
-
-type stringFromBool struct {
-	base
-
-	format string
-
-	from Bool
-}
-
 // BoolToString creates a binding that connects a Bool data item to a String.
 // Changes to the Bool will be pushed to the String and setting the string will parse and set the
 // Bool if the parse was successful.
 //
 // Since: 2.0
 func BoolToString(v Bool) String {
-	str := &stringFromBool{from: v}
-	v.AddListener(str)
-	return str
+	return newStringFrom(v, formatter(formatBool), parseBool, equal[bool])
 }
 
 // BoolToStringWithFormat creates a binding that connects a Bool data item to a String and is
@@ -38,70 +22,8 @@ func BoolToStringWithFormat(v Bool, format string) String {
 		return BoolToString(v)
 	}
 
-	str := &stringFromBool{from: v, format: format}
-	v.AddListener(str)
-	return str
-}
-
-func (s *stringFromBool) Get() (string, error) {
-	val, err := s.from.Get()
-	if err != nil {
-		return "", err
-	}
-
-	if s.format != "" {
-		return fmt.Sprintf(s.format, val), nil
-	}
-
-	return formatBool(val), nil
-}
-
-func (s *stringFromBool) Set(str string) error {
-	var val bool
-	if s.format != "" {
-		safe := stripFormatPrecision(s.format)
-		n, err := fmt.Sscanf(str, safe+" ", &val) // " " denotes match to end of string
-		if err != nil {
-			return err
-		}
-		if n != 1 {
-			return errParseFailed
-		}
-	} else {
-		new, err := parseBool(str)
-		if err != nil {
-			return err
-		}
-		val = new
-	}
-
-	old, err := s.from.Get()
-	if err != nil {
-		return err
-	}
-	if val == old {
-		return nil
-	}
-	if err = s.from.Set(val); err != nil {
-		return err
-	}
-
-	s.DataChanged()
-	return nil
-}
-
-func (s *stringFromBool) DataChanged() {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.trigger()
-}
-
-type stringFromFloat struct {
-	base
-
-	format string
-
-	from Float
+	return newStringFrom(v, sprintfFormatter[bool](format),
+		sscanfParser[bool](stripFormatPrecision(format)), equal[bool])
 }
 
 // FloatToString creates a binding that connects a Float data item to a String.
@@ -110,9 +32,7 @@ type stringFromFloat struct {
 //
 // Since: 2.0
 func FloatToString(v Float) String {
-	str := &stringFromFloat{from: v}
-	v.AddListener(str)
-	return str
+	return newStringFrom(v, formatter(formatFloat), parseFloat, equal[float64])
 }
 
 // FloatToStringWithFormat creates a binding that connects a Float data item to a String and is
@@ -125,70 +45,8 @@ func FloatToStringWithFormat(v Float, format string) String {
 		return FloatToString(v)
 	}
 
-	str := &stringFromFloat{from: v, format: format}
-	v.AddListener(str)
-	return str
-}
-
-func (s *stringFromFloat) Get() (string, error) {
-	val, err := s.from.Get()
-	if err != nil {
-		return "", err
-	}
-
-	if s.format != "" {
-		return fmt.Sprintf(s.format, val), nil
-	}
-
-	return formatFloat(val), nil
-}
-
-func (s *stringFromFloat) Set(str string) error {
-	var val float64
-	if s.format != "" {
-		safe := stripFormatPrecision(s.format)
-		n, err := fmt.Sscanf(str, safe+" ", &val) // " " denotes match to end of string
-		if err != nil {
-			return err
-		}
-		if n != 1 {
-			return errParseFailed
-		}
-	} else {
-		new, err := parseFloat(str)
-		if err != nil {
-			return err
-		}
-		val = new
-	}
-
-	old, err := s.from.Get()
-	if err != nil {
-		return err
-	}
-	if val == old {
-		return nil
-	}
-	if err = s.from.Set(val); err != nil {
-		return err
-	}
-
-	s.DataChanged()
-	return nil
-}
-
-func (s *stringFromFloat) DataChanged() {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.trigger()
-}
-
-type stringFromInt struct {
-	base
-
-	format string
-
-	from Int
+	return newStringFrom(v, sprintfFormatter[float64](format),
+		sscanfParser[float64](stripFormatPrecision(format)), equal[float64])
 }
 
 // IntToString creates a binding that connects a Int data item to a String.
@@ -197,9 +55,7 @@ type stringFromInt struct {
 //
 // Since: 2.0
 func IntToString(v Int) String {
-	str := &stringFromInt{from: v}
-	v.AddListener(str)
-	return str
+	return newStringFrom(v, formatter(formatInt), parseInt, equal[int])
 }
 
 // IntToStringWithFormat creates a binding that connects a Int data item to a String and is
@@ -212,68 +68,8 @@ func IntToStringWithFormat(v Int, format string) String {
 		return IntToString(v)
 	}
 
-	str := &stringFromInt{from: v, format: format}
-	v.AddListener(str)
-	return str
-}
-
-func (s *stringFromInt) Get() (string, error) {
-	val, err := s.from.Get()
-	if err != nil {
-		return "", err
-	}
-
-	if s.format != "" {
-		return fmt.Sprintf(s.format, val), nil
-	}
-
-	return formatInt(val), nil
-}
-
-func (s *stringFromInt) Set(str string) error {
-	var val int
-	if s.format != "" {
-		safe := stripFormatPrecision(s.format)
-		n, err := fmt.Sscanf(str, safe+" ", &val) // " " denotes match to end of string
-		if err != nil {
-			return err
-		}
-		if n != 1 {
-			return errParseFailed
-		}
-	} else {
-		new, err := parseInt(str)
-		if err != nil {
-			return err
-		}
-		val = new
-	}
-
-	old, err := s.from.Get()
-	if err != nil {
-		return err
-	}
-	if val == old {
-		return nil
-	}
-	if err = s.from.Set(val); err != nil {
-		return err
-	}
-
-	s.DataChanged()
-	return nil
-}
-
-func (s *stringFromInt) DataChanged() {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.trigger()
-}
-
-type stringFromURI struct {
-	base
-
-	from URI
+	return newStringFrom(v, sprintfFormatter[int](format),
+		sscanfParser[int](stripFormatPrecision(format)), equal[int])
 }
 
 // URIToString creates a binding that connects a URI data item to a String.
@@ -282,53 +78,16 @@ type stringFromURI struct {
 //
 // Since: 2.1
 func URIToString(v URI) String {
-	str := &stringFromURI{from: v}
-	v.AddListener(str)
-	return str
-}
-
-func (s *stringFromURI) Get() (string, error) {
-	val, err := s.from.Get()
-	if err != nil {
-		return "", err
-	}
-
-	return uriToString(val)
-}
-
-func (s *stringFromURI) Set(str string) error {
-	val, err := uriFromString(str)
-	if err != nil {
-		return err
-	}
-
-	old, err := s.from.Get()
-	if err != nil {
-		return err
-	}
-	if val == old {
-		return nil
-	}
-	if err = s.from.Set(val); err != nil {
-		return err
-	}
-
-	s.DataChanged()
-	return nil
+	return newStringFrom(v, uriToString, uriFromString, compareURI)
 }
 
-func (s *stringFromURI) DataChanged() {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.trigger()
-}
-
-type stringToBool struct {
-	base
-
-	format string
-
-	from String
+// TimeToString creates a binding that connects a Time data item to a String.
+// Changes to the Time will be pushed to the String and setting the string will parse and set the
+// Time if the parse was successful.
+//
+// Since: 2.3
+func TimeToString(v Time) String {
+	return newStringFrom(v, timeToString, timeFromString, compareTime)
 }
 
 // StringToBool creates a binding that connects a String data item to a Bool.
@@ -337,9 +96,7 @@ type stringToBool struct {
 //
 // Since: 2.0
 func StringToBool(str String) Bool {
-	v := &stringToBool{from: str}
-	str.AddListener(v)
-	return v
+	return newStringTo(str, parseBool, formatter(formatBool))
 }
 
 // StringToBoolWithFormat creates a binding that connects a String data item to a Bool and is
@@ -353,70 +110,7 @@ func StringToBoolWithFormat(str String, format string) Bool {
 		return StringToBool(str)
 	}
 
-	v := &stringToBool{from: str, format: format}
-	str.AddListener(v)
-	return v
-}
-
-func (s *stringToBool) Get() (bool, error) {
-	str, err := s.from.Get()
-	if str == "" || err != nil {
-		return false, err
-	}
-
-	var val bool
-	if s.format != "" {
-		n, err := fmt.Sscanf(str, s.format+" ", &val) // " " denotes match to end of string
-		if err != nil {
-			return false, err
-		}
-		if n != 1 {
-			return false, errParseFailed
-		}
-	} else {
-		new, err := parseBool(str)
-		if err != nil {
-			return false, err
-		}
-		val = new
-	}
-
-	return val, nil
-}
-
-func (s *stringToBool) Set(val bool) error {
-	var str string
-	if s.format != "" {
-		str = fmt.Sprintf(s.format, val)
-	} else {
-		str = formatBool(val)
-	}
-
-	old, err := s.from.Get()
-	if str == old {
-		return err
-	}
-
-	if err = s.from.Set(str); err != nil {
-		return err
-	}
-
-	s.DataChanged()
-	return nil
-}
-
-func (s *stringToBool) DataChanged() {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.trigger()
-}
-
-type stringToFloat struct {
-	base
-
-	format string
-
-	from String
+	return newStringTo(str, sscanfParser[bool](format), sprintfFormatter[bool](format))
 }
 
 // StringToFloat creates a binding that connects a String data item to a Float.
@@ -425,9 +119,7 @@ type stringToFloat struct {
 //
 // Since: 2.0
 func StringToFloat(str String) Float {
-	v := &stringToFloat{from: str}
-	str.AddListener(v)
-	return v
+	return newStringTo(str, parseFloat, formatter(formatFloat))
 }
 
 // StringToFloatWithFormat creates a binding that connects a String data item to a Float and is
@@ -441,70 +133,7 @@ func StringToFloatWithFormat(str String, format string) Float {
 		return StringToFloat(str)
 	}
 
-	v := &stringToFloat{from: str, format: format}
-	str.AddListener(v)
-	return v
-}
-
-func (s *stringToFloat) Get() (float64, error) {
-	str, err := s.from.Get()
-	if str == "" || err != nil {
-		return 0.0, err
-	}
-
-	var val float64
-	if s.format != "" {
-		n, err := fmt.Sscanf(str, s.format+" ", &val) // " " denotes match to end of string
-		if err != nil {
-			return 0.0, err
-		}
-		if n != 1 {
-			return 0.0, errParseFailed
-		}
-	} else {
-		new, err := parseFloat(str)
-		if err != nil {
-			return 0.0, err
-		}
-		val = new
-	}
-
-	return val, nil
-}
-
-func (s *stringToFloat) Set(val float64) error {
-	var str string
-	if s.format != "" {
-		str = fmt.Sprintf(s.format, val)
-	} else {
-		str = formatFloat(val)
-	}
-
-	old, err := s.from.Get()
-	if str == old {
-		return err
-	}
-
-	if err = s.from.Set(str); err != nil {
-		return err
-	}
-
-	s.DataChanged()
-	return nil
-}
-
-func (s *stringToFloat) DataChanged() {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.trigger()
-}
-
-type stringToInt struct {
-	base
-
-	format string
-
-	from String
+	return newStringTo(str, sscanfParser[float64](format), sprintfFormatter[float64](format))
 }
 
 // StringToInt creates a binding that connects a String data item to a Int.
@@ -513,9 +142,7 @@ type stringToInt struct {
 //
 // Since: 2.0
 func StringToInt(str String) Int {
-	v := &stringToInt{from: str}
-	str.AddListener(v)
-	return v
+	return newStringTo(str, parseInt, formatter(formatInt))
 }
 
 // StringToIntWithFormat creates a binding that connects a String data item to a Int and is
@@ -529,68 +156,7 @@ func StringToIntWithFormat(str String, format string) Int {
 		return StringToInt(str)
 	}
 
-	v := &stringToInt{from: str, format: format}
-	str.AddListener(v)
-	return v
-}
-
-func (s *stringToInt) Get() (int, error) {
-	str, err := s.from.Get()
-	if str == "" || err != nil {
-		return 0, err
-	}
-
-	var val int
-	if s.format != "" {
-		n, err := fmt.Sscanf(str, s.format+" ", &val) // " " denotes match to end of string
-		if err != nil {
-			return 0, err
-		}
-		if n != 1 {
-			return 0, errParseFailed
-		}
-	} else {
-		new, err := parseInt(str)
-		if err != nil {
-			return 0, err
-		}
-		val = new
-	}
-
-	return val, nil
-}
-
-func (s *stringToInt) Set(val int) error {
-	var str string
-	if s.format != "" {
-		str = fmt.Sprintf(s.format, val)
-	} else {
-		str = formatInt(val)
-	}
-
-	old, err := s.from.Get()
-	if str == old {
-		return err
-	}
-
-	if err = s.from.Set(str); err != nil {
-		return err
-	}
-
-	s.DataChanged()
-	return nil
-}
-
-func (s *stringToInt) DataChanged() {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.trigger()
-}
-
-type stringToURI struct {
-	base
-
-	from String
+	return newStringTo(str, sscanfParser[int](format), sprintfFormatter[int](format))
 }
 
 // StringToURI creates a binding that connects a String data item to a URI.
@@ -599,40 +165,14 @@ type stringToURI struct {
 //
 // Since: 2.1
 func StringToURI(str String) URI {
-	v := &stringToURI{from: str}
-	str.AddListener(v)
-	return v
+	return newStringTo(str, uriFromString, uriToString)
 }
 
-func (s *stringToURI) Get() (gui.URI, error) {
-	str, err := s.from.Get()
-	if str == "" || err != nil {
-		return gui.URI(nil), err
-	}
-
-	return uriFromString(str)
-}
-
-func (s *stringToURI) Set(val gui.URI) error {
-	str, err := uriToString(val)
-	if err != nil {
-		return err
-	}
-	old, err := s.from.Get()
-	if str == old {
-		return err
-	}
-
-	if err = s.from.Set(str); err != nil {
-		return err
-	}
-
-	s.DataChanged()
-	return nil
-}
-
-func (s *stringToURI) DataChanged() {
-	s.lock.RLock()
-	defer s.lock.RUnlock()
-	s.trigger()
+// StringToTime creates a binding that connects a String data item to a Time.
+// Changes to the String will be parsed and pushed to the Time if the parse was successful, and setting
+// the Time update the String binding.
+//
+// Since: 2.3
+func StringToTime(str String) Time {
+	return newStringTo(str, timeFromString, timeToString)
 }
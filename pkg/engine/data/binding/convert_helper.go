@@ -23,6 +23,7 @@ package binding
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/storage"
@@ -84,6 +85,22 @@ func uriToString(in gui.URI) (string, error) {
 	return in.String(), nil
 }
 
+func timeFromString(in string) (time.Time, error) {
+	if in == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, in)
+}
+
+func timeToString(in time.Time) (string, error) {
+	if in.IsZero() {
+		return "", nil
+	}
+
+	return in.Format(time.RFC3339), nil
+}
+
 func parseBool(in string) (bool, error) {
 	out, err := strconv.ParseBool(in)
 	if err != nil {
@@ -22,6 +22,7 @@ package binding
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -383,3 +384,51 @@ func TestURIToString(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "file:///tmp/test.txt", v2.String())
 }
+
+func TestStringToTime(t *testing.T) {
+	s := NewString()
+	d := StringToTime(s)
+	v, err := d.Get()
+	assert.Nil(t, err)
+	assert.True(t, v.IsZero())
+
+	err = s.Set("2022-03-04T10:30:00Z")
+	assert.Nil(t, err)
+	v, err = d.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2022, time.March, 4, 10, 30, 0, 0, time.UTC), v)
+
+	err = s.Set("wrong")
+	assert.Nil(t, err)
+	_, err = d.Get()
+	assert.NotNil(t, err)
+
+	err = d.Set(time.Date(2021, time.December, 25, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	v2, err := s.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "2021-12-25T00:00:00Z", v2)
+}
+
+func TestTimeToString(t *testing.T) {
+	d := NewTime()
+	s := TimeToString(d)
+	v, err := s.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "", v)
+
+	err = d.Set(time.Date(2022, time.March, 4, 10, 30, 0, 0, time.UTC))
+	assert.Nil(t, err)
+	v, err = s.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "2022-03-04T10:30:00Z", v)
+
+	err = s.Set("wrong")
+	assert.NotNil(t, err)
+
+	err = s.Set("2021-12-25T00:00:00Z")
+	assert.Nil(t, err)
+	v2, err := d.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 2021, v2.Year())
+}
@@ -0,0 +1,180 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "fmt"
+
+// ItemToString creates a binding that connects an Item of any type to a String.
+// Changes to the Item will be pushed to the String using the format function and setting the string will
+// parse and set the Item if the parse was successful.
+//
+// Since: 2.3
+func ItemToString[T any](v Item[T], format func(T) (string, error), parse func(string) (T, error)) String {
+	return newStringFrom(v, format, parse, nil)
+}
+
+// StringToItem creates a binding that connects a String data item to an Item of any type.
+// Changes to the String will be parsed and pushed to the Item if the parse was successful, and setting
+// the Item will update the String binding using the format function.
+//
+// Since: 2.3
+func StringToItem[T any](str String, parse func(string) (T, error), format func(T) (string, error)) Item[T] {
+	return newStringTo(str, parse, format)
+}
+
+type stringFrom[T any] struct {
+	base
+
+	comparator func(T, T) bool
+	format     func(T) (string, error)
+	parse      func(string) (T, error)
+	from       Item[T]
+}
+
+// newStringFrom connects an item to a String. If comparator is nil values are compared by their formatted form.
+func newStringFrom[T any](v Item[T], format func(T) (string, error), parse func(string) (T, error),
+	comparator func(T, T) bool) String {
+	str := &stringFrom[T]{from: v, format: format, parse: parse, comparator: comparator}
+	v.AddListener(str)
+	return str
+}
+
+func (s *stringFrom[T]) Get() (string, error) {
+	val, err := s.from.Get()
+	if err != nil {
+		return "", err
+	}
+
+	return s.format(val)
+}
+
+func (s *stringFrom[T]) Set(str string) error {
+	val, err := s.parse(str)
+	if err != nil {
+		return err
+	}
+
+	old, err := s.from.Get()
+	if err != nil {
+		return err
+	}
+	if s.equal(val, old) {
+		return nil
+	}
+	if err = s.from.Set(val); err != nil {
+		return err
+	}
+
+	s.DataChanged()
+	return nil
+}
+
+func (s *stringFrom[T]) DataChanged() {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.trigger()
+}
+
+func (s *stringFrom[T]) equal(v1, v2 T) bool {
+	if s.comparator != nil {
+		return s.comparator(v1, v2)
+	}
+
+	str1, err1 := s.format(v1)
+	str2, err2 := s.format(v2)
+	return err1 == nil && err2 == nil && str1 == str2
+}
+
+type stringTo[T any] struct {
+	base
+
+	format func(T) (string, error)
+	parse  func(string) (T, error)
+	from   String
+}
+
+func newStringTo[T any](str String, parse func(string) (T, error), format func(T) (string, error)) Item[T] {
+	v := &stringTo[T]{from: str, parse: parse, format: format}
+	str.AddListener(v)
+	return v
+}
+
+func (s *stringTo[T]) Get() (T, error) {
+	str, err := s.from.Get()
+	if str == "" || err != nil {
+		var blank T
+		return blank, err
+	}
+
+	return s.parse(str)
+}
+
+func (s *stringTo[T]) Set(val T) error {
+	str, err := s.format(val)
+	if err != nil {
+		return err
+	}
+
+	old, err := s.from.Get()
+	if str == old {
+		return err
+	}
+
+	if err = s.from.Set(str); err != nil {
+		return err
+	}
+
+	s.DataChanged()
+	return nil
+}
+
+func (s *stringTo[T]) DataChanged() {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	s.trigger()
+}
+
+func formatter[T any](format func(T) string) func(T) (string, error) {
+	return func(val T) (string, error) {
+		return format(val), nil
+	}
+}
+
+func sprintfFormatter[T any](format string) func(T) (string, error) {
+	return func(val T) (string, error) {
+		return fmt.Sprintf(format, val), nil
+	}
+}
+
+func sscanfParser[T any](format string) func(string) (T, error) {
+	return func(str string) (T, error) {
+		var val, blank T
+		n, err := fmt.Sscanf(str, format+" ", &val) // " " denotes match to end of string
+		if err != nil {
+			return blank, err
+		}
+		if n != 1 {
+			return blank, errParseFailed
+		}
+
+		return val, nil
+	}
+}
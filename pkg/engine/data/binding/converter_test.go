@@ -0,0 +1,99 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func formatTestLevel(l testLevel) (string, error) {
+	switch l {
+	case testLevelLow:
+		return "low", nil
+	case testLevelHigh:
+		return "high", nil
+	}
+	return "", errors.New("unknown level")
+}
+
+func parseTestLevel(s string) (testLevel, error) {
+	switch s {
+	case "low":
+		return testLevelLow, nil
+	case "high":
+		return testLevelHigh, nil
+	}
+	return testLevelLow, errParseFailed
+}
+
+func TestItemToString(t *testing.T) {
+	l := NewItem[testLevel]()
+	s := ItemToString(l, formatTestLevel, parseTestLevel)
+	v, err := s.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "low", v)
+
+	err = l.Set(testLevelHigh)
+	assert.Nil(t, err)
+	v, err = s.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "high", v)
+
+	err = s.Set("low")
+	assert.Nil(t, err)
+	lv, err := l.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelLow, lv)
+
+	err = s.Set("medium")
+	assert.NotNil(t, err)
+	lv, err = l.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelLow, lv)
+}
+
+func TestStringToItem(t *testing.T) {
+	s := NewString()
+	l := StringToItem(s, parseTestLevel, formatTestLevel)
+	v, err := l.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelLow, v)
+
+	err = s.Set("high")
+	assert.Nil(t, err)
+	v, err = l.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelHigh, v)
+
+	err = l.Set(testLevelLow)
+	assert.Nil(t, err)
+	str, err := s.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "low", str)
+
+	err = s.Set("medium")
+	assert.Nil(t, err)
+	_, err = l.Get()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,235 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Derive returns a read only binding whose value is computed from any number of source data items.
+// The compute function is called again each time one of the sources changes and listeners are informed
+// if the resulting value is different. Get returns the result of the last computation.
+//
+// Since: 2.3
+func Derive[R any](compute func() (R, error), sources ...DataItem) Item[R] {
+	d := &derivedItem[R]{compute: compute, sources: sources}
+	d.val, d.err = compute()
+	for _, s := range sources {
+		s.AddListener(d)
+	}
+	return d
+}
+
+// Combine returns a read only binding whose value is computed from the current values of all of
+// the source items. This is useful, for example, to derive a Bool that reports if a number of String
+// fields in a form are valid.
+//
+// Since: 2.3
+func Combine[T, R any](combine func([]T) R, sources ...Item[T]) Item[R] {
+	items := make([]DataItem, len(sources))
+	for i, s := range sources {
+		items[i] = s
+	}
+
+	return Derive(func() (R, error) {
+		vals := make([]T, len(sources))
+		for i, s := range sources {
+			val, err := s.Get()
+			if err != nil {
+				var blank R
+				return blank, err
+			}
+			vals[i] = val
+		}
+
+		return combine(vals), nil
+	}, items...)
+}
+
+// MapItem returns a read only binding that applies the transform function to each value of the source item.
+//
+// Since: 2.3
+func MapItem[T, R any](source Item[T], transform func(T) R) Item[R] {
+	return Derive(func() (R, error) {
+		val, err := source.Get()
+		if err != nil {
+			var blank R
+			return blank, err
+		}
+
+		return transform(val), nil
+	}, source)
+}
+
+// Debounce returns a binding that follows the source item but only reports a change once the source has
+// not changed for the specified delay. Setting the value of the returned binding sets the source.
+//
+// Since: 2.3
+func Debounce[T any](source Item[T], delay time.Duration) Item[T] {
+	d := &debouncedItem[T]{source: source, delay: delay}
+	d.val, d.err = source.Get()
+	source.AddListener(d)
+	return d
+}
+
+// Detach disconnects a binding returned by Derive, Combine, MapItem, Debounce, MapList, FilterList
+// or SortList from its sources. The binding keeps its last value and no longer changes, so that it
+// can be garbage collected while the sources are still in use. Other data items are not affected.
+//
+// Since: 2.3
+func Detach(item DataItem) {
+	if d, ok := item.(detachable); ok {
+		d.detach()
+	}
+}
+
+// detachable is a binding that listens to its sources until it is detached.
+type detachable interface {
+	detach()
+}
+
+type derivedItem[R any] struct {
+	base
+
+	compute  func() (R, error)
+	sources  []DataItem
+	detached bool
+	err      error
+	val      R
+}
+
+func (d *derivedItem[R]) DataChanged() {
+	val, err := d.compute()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.detached || (sameError(err, d.err) && reflect.DeepEqual(val, d.val)) {
+		return
+	}
+	d.val, d.err = val, err
+
+	d.trigger()
+}
+
+func (d *derivedItem[R]) Get() (R, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.val, d.err
+}
+
+func (d *derivedItem[R]) Set(R) error {
+	return errReadOnly
+}
+
+func (d *derivedItem[R]) detach() {
+	d.lock.Lock()
+	sources := d.sources
+	d.sources = nil
+	d.detached = true
+	d.lock.Unlock()
+
+	for _, s := range sources {
+		s.RemoveListener(d)
+	}
+}
+
+type debouncedItem[T any] struct {
+	base
+
+	delay    time.Duration
+	source   Item[T]
+	timer    *time.Timer
+	detached bool
+	err      error
+	val      T
+
+	timerLock sync.Mutex
+}
+
+func (d *debouncedItem[T]) DataChanged() {
+	d.timerLock.Lock()
+	defer d.timerLock.Unlock()
+
+	if d.detached {
+		return
+	}
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timer = time.AfterFunc(d.delay, func() {
+		queueItem(d.settle)
+	})
+}
+
+func (d *debouncedItem[T]) Get() (T, error) {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+
+	return d.val, d.err
+}
+
+func (d *debouncedItem[T]) Set(val T) error {
+	return d.source.Set(val)
+}
+
+func (d *debouncedItem[T]) detach() {
+	d.timerLock.Lock()
+	d.detached = true
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.timerLock.Unlock()
+
+	d.source.RemoveListener(d)
+}
+
+func (d *debouncedItem[T]) settle() {
+	d.timerLock.Lock()
+	detached := d.detached
+	d.timerLock.Unlock()
+	if detached {
+		return
+	}
+
+	val, err := d.source.Get()
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if sameError(err, d.err) && reflect.DeepEqual(val, d.val) {
+		return
+	}
+	d.val, d.err = val, err
+
+	d.trigger()
+}
+
+// sameError returns true if both errors are nil or describe the same failure,
+// compute functions often return a new error value each time that they fail.
+func sameError(a, b error) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return errors.Is(a, b) || a.Error() == b.Error()
+}
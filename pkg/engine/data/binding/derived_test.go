@@ -0,0 +1,204 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCombine(t *testing.T) {
+	name, email, password := NewString(), NewString(), NewString()
+	valid := Combine(func(vals []string) bool {
+		for _, v := range vals {
+			if v == "" {
+				return false
+			}
+		}
+		return true
+	}, name, email, password)
+
+	calls := 0
+	valid.AddListener(NewDataListener(func() {
+		calls++
+	}))
+	waitForDerived()
+	assert.Equal(t, 1, calls)
+	v, err := valid.Get()
+	assert.Nil(t, err)
+	assert.False(t, v)
+
+	name.Set("Jane")
+	email.Set("jane@example.com")
+	waitForDerived()
+	assert.Equal(t, 1, calls) // still invalid, so no change reported
+
+	password.Set("secret")
+	waitForDerived()
+	assert.Equal(t, 2, calls)
+	v, err = valid.Get()
+	assert.Nil(t, err)
+	assert.True(t, v)
+
+	assert.NotNil(t, valid.Set(false))
+}
+
+func TestDerive(t *testing.T) {
+	count := NewInt()
+	label := NewString()
+	summary := Derive(func() (string, error) {
+		c, err := count.Get()
+		if err != nil {
+			return "", err
+		}
+		l, err := label.Get()
+		return strings.Repeat(l, c), err
+	}, count, label)
+
+	count.Set(3)
+	label.Set("a")
+	waitForDerived()
+	v, err := summary.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "aaa", v)
+}
+
+func TestDerive_CachedValue(t *testing.T) {
+	count := NewInt()
+	computed := 0
+	failing := Derive(func() (int, error) {
+		computed++
+		c, _ := count.Get()
+		return 0, errors.New("failed " + strings.Repeat("!", c/10))
+	}, count)
+
+	calls := 0
+	failing.AddListener(NewDataListener(func() {
+		calls++
+	}))
+	waitForDerived()
+	assert.Equal(t, 1, calls)
+
+	before := computed
+	_, err := failing.Get()
+	assert.Equal(t, "failed ", err.Error())
+	_, _ = failing.Get()
+	assert.Equal(t, before, computed) // Get returns the cached result
+
+	count.Set(1) // a new error with the same message is not a change
+	waitForDerived()
+	assert.Equal(t, before+1, computed)
+	assert.Equal(t, 1, calls)
+
+	count.Set(10)
+	waitForDerived()
+	assert.Equal(t, 2, calls)
+	_, err = failing.Get()
+	assert.Equal(t, "failed !", err.Error())
+}
+
+func TestDetach(t *testing.T) {
+	f := NewFloat()
+	percent := MapItem(f, func(v float64) int {
+		return int(v * 100)
+	})
+	debounced := Debounce(f, 10*time.Millisecond)
+	nums := []int{2, 1}
+	list := BindIntList(&nums)
+	sorted := SortList[int](list, func(a, b int) bool {
+		return a < b
+	})
+
+	f.Set(0.5)
+	time.Sleep(50 * time.Millisecond)
+	waitForDerived()
+	Detach(percent)
+	Detach(debounced)
+	Detach(sorted)
+	Detach(f) // not derived, has no effect
+
+	f.Set(0.75)
+	list.Append(0)
+	time.Sleep(50 * time.Millisecond)
+	waitForDerived()
+	v, err := percent.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 50, v)
+	d, err := debounced.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 0.5, d)
+	vals, err := sorted.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, vals)
+	v2, err := f.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 0.75, v2)
+}
+
+func TestMapItem(t *testing.T) {
+	f := NewFloat()
+	percent := MapItem(f, func(v float64) int {
+		return int(v * 100)
+	})
+
+	f.Set(0.25)
+	waitForDerived()
+	v, err := percent.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 25, v)
+}
+
+func TestDebounce(t *testing.T) {
+	s := NewString()
+	d := Debounce(s, 50*time.Millisecond)
+
+	calls := 0
+	d.AddListener(NewDataListener(func() {
+		calls++
+	}))
+	waitForDerived()
+	assert.Equal(t, 1, calls)
+
+	s.Set("a")
+	s.Set("ab")
+	d.Set("abc")
+	waitForDerived()
+	v, err := d.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "", v)
+
+	time.Sleep(150 * time.Millisecond)
+	waitForDerived()
+	assert.Equal(t, 2, calls)
+	v, err = d.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", v)
+}
+
+// waitForDerived waits for a change to pass through a derived binding and then on to its listeners.
+func waitForDerived() {
+	waitForItems()
+	waitForItems()
+}
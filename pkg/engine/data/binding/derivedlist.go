@@ -0,0 +1,247 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"reflect"
+	"sort"
+)
+
+// MapList returns a read only list binding that applies the transform function to each value of the source list.
+//
+// Since: 2.3
+func MapList[T, R any](source List[T], transform func(T) R) List[R] {
+	return newDerivedList(source, func() ([]R, error) {
+		vals, err := source.Get()
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]R, len(vals))
+		for i, v := range vals {
+			ret[i] = transform(v)
+		}
+		return ret, nil
+	})
+}
+
+// FilterList returns a read only list binding that contains only the values of the source list for which
+// the keep function returns true.
+//
+// Since: 2.3
+func FilterList[T any](source List[T], keep func(T) bool) List[T] {
+	return newDerivedList(source, func() ([]T, error) {
+		vals, err := source.Get()
+		if err != nil {
+			return nil, err
+		}
+
+		var ret []T
+		for _, v := range vals {
+			if keep(v) {
+				ret = append(ret, v)
+			}
+		}
+		return ret, nil
+	})
+}
+
+// SortList returns a read only list binding that contains the values of the source list ordered
+// using the less function. Values that compare equal keep their original order.
+//
+// Since: 2.3
+func SortList[T any](source List[T], less func(a, b T) bool) List[T] {
+	return newDerivedList(source, func() ([]T, error) {
+		vals, err := source.Get()
+		if err != nil {
+			return nil, err
+		}
+
+		ret := make([]T, len(vals))
+		copy(ret, vals)
+		sort.SliceStable(ret, func(i, j int) bool {
+			return less(ret[i], ret[j])
+		})
+		return ret, nil
+	})
+}
+
+type derivedList[T any] struct {
+	listBase
+
+	compute     func() ([]T, error)
+	source      DataList
+	sourceItems []DataItem
+	detached    bool
+	val         []T
+}
+
+func newDerivedList[T any](source DataList, compute func() ([]T, error)) List[T] {
+	l := &derivedList[T]{source: source, compute: compute}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.val, _ = compute()
+	for i := range l.val {
+		l.appendItem(&derivedListItem[T]{list: l, index: i})
+	}
+	l.watchItems()
+	source.AddListener(l)
+
+	return l
+}
+
+func (l *derivedList[T]) Append(T) error {
+	return errReadOnly
+}
+
+func (l *derivedList[T]) DataChanged() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.detached {
+		return
+	}
+	l.watchItems()
+	vals, err := l.compute()
+	if err != nil {
+		return // keep the last known values
+	}
+	l.doReload(vals)
+}
+
+func (l *derivedList[T]) Get() ([]T, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if l.val == nil {
+		return nil, nil
+	}
+	ret := make([]T, len(l.val))
+	copy(ret, l.val)
+	return ret, nil
+}
+
+func (l *derivedList[T]) GetValue(i int) (T, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if i < 0 || i >= len(l.val) {
+		var blank T
+		return blank, errOutOfBounds
+	}
+
+	return l.val[i], nil
+}
+
+func (l *derivedList[T]) Prepend(T) error {
+	return errReadOnly
+}
+
+func (l *derivedList[T]) Set([]T) error {
+	return errReadOnly
+}
+
+func (l *derivedList[T]) SetValue(int, T) error {
+	return errReadOnly
+}
+
+func (l *derivedList[T]) detach() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.detached {
+		return
+	}
+	l.detached = true
+	l.source.RemoveListener(l)
+	for _, item := range l.sourceItems {
+		item.RemoveListener(l)
+	}
+	l.sourceItems = nil
+}
+
+func (l *derivedList[T]) doReload(vals []T) {
+	old := l.val
+	l.val = vals
+
+	oldLen := len(l.items)
+	newLen := len(vals)
+	if oldLen > newLen {
+		for i := oldLen - 1; i >= newLen; i-- {
+			l.deleteItem(i)
+		}
+		l.trigger()
+	} else if oldLen < newLen {
+		for i := oldLen; i < newLen; i++ {
+			l.appendItem(&derivedListItem[T]{list: l, index: i})
+		}
+		l.trigger()
+	}
+
+	for i, item := range l.items {
+		if i >= oldLen || i >= newLen {
+			break
+		}
+		if reflect.DeepEqual(old[i], vals[i]) {
+			continue
+		}
+
+		item.(*derivedListItem[T]).lock.RLock()
+		item.(*derivedListItem[T]).trigger()
+		item.(*derivedListItem[T]).lock.RUnlock()
+	}
+}
+
+// watchItems makes sure that we are listening to each item of the source list,
+// as changes to the value of an item are not reported by the list itself.
+func (l *derivedList[T]) watchItems() {
+	count := l.source.Length()
+	for i := len(l.sourceItems); i < count; i++ {
+		item, err := l.source.GetItem(i)
+		if err != nil {
+			break
+		}
+
+		item.AddListener(l)
+		l.sourceItems = append(l.sourceItems, item)
+	}
+
+	for i := len(l.sourceItems) - 1; i >= count; i-- {
+		l.sourceItems[i].RemoveListener(l)
+		l.sourceItems = l.sourceItems[:i]
+	}
+}
+
+type derivedListItem[T any] struct {
+	base
+
+	list  *derivedList[T]
+	index int
+}
+
+func (b *derivedListItem[T]) Get() (T, error) {
+	return b.list.GetValue(b.index)
+}
+
+func (b *derivedListItem[T]) Set(T) error {
+	return errReadOnly
+}
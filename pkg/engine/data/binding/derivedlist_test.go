@@ -0,0 +1,125 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterList(t *testing.T) {
+	names := NewStringList()
+	names.Set([]string{"apple", "banana", "avocado"})
+	filtered := FilterList(names, func(s string) bool {
+		return strings.HasPrefix(s, "a")
+	})
+	waitForDerived()
+	assert.Equal(t, 2, filtered.Length())
+
+	calls := 0
+	filtered.AddListener(NewDataListener(func() {
+		calls++
+	}))
+	waitForDerived()
+	assert.Equal(t, 1, calls)
+
+	names.Append("apricot")
+	waitForDerived()
+	assert.Equal(t, 2, calls)
+	vals, err := filtered.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"apple", "avocado", "apricot"}, vals)
+
+	item, err := filtered.GetItem(1)
+	assert.Nil(t, err)
+	itemCalls := 0
+	item.AddListener(NewDataListener(func() {
+		itemCalls++
+	}))
+	waitForDerived()
+	assert.Equal(t, 1, itemCalls)
+
+	names.SetValue(1, "almond") // the source item changed, not the list length
+	waitForDerived()
+	assert.Equal(t, 4, filtered.Length())
+	assert.Equal(t, 2, itemCalls)
+	v, err := item.(String).Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "almond", v)
+
+	assert.NotNil(t, filtered.Append("ant"))
+	assert.NotNil(t, item.(String).Set("ant"))
+}
+
+func TestSortList(t *testing.T) {
+	nums := []int{3, 1, 2}
+	source := BindIntList(&nums)
+	sorted := SortList[int](source, func(a, b int) bool {
+		return a < b
+	})
+	waitForDerived()
+	vals, err := sorted.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, vals)
+	assert.Equal(t, []int{3, 1, 2}, nums)
+
+	vals[0] = 5 // changing the returned slice does not change the binding
+	vals, err = sorted.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2, 3}, vals)
+
+	nums[0] = 0
+	source.Reload()
+	waitForDerived()
+	vals, err = sorted.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 1, 2}, vals)
+}
+
+func TestMapList_Untyped(t *testing.T) {
+	source := NewUntypedList()
+	source.Set([]interface{}{1, "two", 3.0})
+	names := MapList(source, func(v interface{}) string {
+		switch v.(type) {
+		case int:
+			return "int"
+		case string:
+			return "string"
+		}
+		return "other"
+	})
+	waitForDerived()
+
+	v, err := names.GetValue(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "string", v)
+
+	source.Prepend(true)
+	waitForDerived()
+	vals, err := names.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"other", "int", "string", "other"}, vals)
+
+	_, err = names.GetValue(4)
+	assert.NotNil(t, err)
+}
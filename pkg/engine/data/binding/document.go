@@ -0,0 +1,432 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/storage"
+	"gopkg.in/yaml.v3"
+)
+
+// documentSaveDelay is how long to wait after the last change before writing a document.
+var documentSaveDelay = 500 * time.Millisecond
+
+// DocumentBinding is a binding to the content of a JSON or YAML document.
+// Values inside the document are bound using a path in the same way as DeepStruct, such as `server.ports[0]`.
+//
+// Since: 2.3
+type DocumentBinding interface {
+	DeepStruct
+
+	// Close writes any pending changes and stops watching the document for external changes.
+	Close() error
+	// Save writes the current content to the document immediately.
+	Save() error
+	// SetOnConflict sets a function that is called when the document is changed by another application while
+	// there are local changes that have not yet been written. Return true to keep the local changes, which will
+	// overwrite the document, or false to load the external changes. If no function is set the external
+	// changes are loaded and the conflict is logged.
+	SetOnConflict(func() bool)
+	// URI returns the location of the document that is bound.
+	URI() gui.URI
+}
+
+// BindDocument loads the JSON or YAML document at the URI into a tree of bindings.
+// Documents with a ".yaml" or ".yml" extension are read as YAML and all others as JSON.
+// YAML documents keep their key order and comments when written, JSON documents are written with sorted keys.
+// If the document does not exist it is created when the first change is written.
+// Changes made through the bindings are written back to the document shortly after the last change and,
+// for file URIs, changes made to the document by other applications are loaded automatically.
+// Calling Reload() will read the document again, discarding any changes that are not yet written.
+//
+// Since: 2.3
+func BindDocument(u gui.URI) (DocumentBinding, error) {
+	d := &document{uri: u, yaml: isYAMLDocument(u)}
+	d.deepStruct = newDeepStruct(reflect.ValueOf(&d.data).Elem())
+	d.changed = d.scheduleSave
+
+	if err := d.Reload(); err != nil {
+		return nil, err
+	}
+
+	d.watch()
+	return d, nil
+}
+
+type document struct {
+	*deepStruct
+
+	data interface{}
+	uri  gui.URI
+	yaml bool
+
+	docLock  sync.Mutex
+	conflict func() bool
+	content  []byte     // the content last read or written
+	node     *yaml.Node // the YAML document last read or written, so that its layout and comments are kept
+	dirty    bool
+	timer    *time.Timer
+	watcher  interface{}
+}
+
+func (d *document) Close() error {
+	d.stopWatching()
+
+	d.docLock.Lock()
+	defer d.docLock.Unlock()
+	if !d.dirty {
+		return nil
+	}
+	return d.save()
+}
+
+func (d *document) Reload() error {
+	content, err := d.read()
+	if err != nil {
+		return err
+	}
+
+	return d.load(content)
+}
+
+func (d *document) Save() error {
+	d.docLock.Lock()
+	defer d.docLock.Unlock()
+
+	return d.save()
+}
+
+func (d *document) SetOnConflict(f func() bool) {
+	d.docLock.Lock()
+	defer d.docLock.Unlock()
+
+	d.conflict = f
+}
+
+func (d *document) URI() gui.URI {
+	return d.uri
+}
+
+func (d *document) decode(content []byte) (interface{}, *yaml.Node, error) {
+	if len(bytes.TrimSpace(content)) == 0 {
+		return map[string]interface{}{}, nil, nil
+	}
+
+	var data interface{}
+	if !d.yaml {
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		err := decoder.Decode(&data)
+		return normalizeJSON(data), nil, err
+	}
+
+	node := &yaml.Node{}
+	if err := yaml.Unmarshal(content, node); err != nil {
+		return nil, nil, err
+	}
+	err := node.Decode(&data)
+	return normalizeYAML(data), node, err
+}
+
+// encode returns the content of the document, the docLock must be held when calling this.
+func (d *document) encode() ([]byte, error) {
+	d.dataLock.RLock()
+	defer d.dataLock.RUnlock()
+
+	if d.yaml {
+		if d.node == nil || len(d.node.Content) == 0 {
+			d.node = &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{}}}
+		}
+		if err := updateYAMLNode(d.node.Content[0], d.data); err != nil {
+			return nil, err
+		}
+
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(d.node); err != nil {
+			return nil, err
+		}
+		err := encoder.Close()
+		return buf.Bytes(), err
+	}
+
+	content, err := json.MarshalIndent(d.data, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+// fileChanged is called when the document may have been changed by another application.
+func (d *document) fileChanged() {
+	d.docLock.Lock() // don't read while we are writing
+	content, err := d.read()
+	if err != nil {
+		d.docLock.Unlock()
+		gui.LogError("Unable to read bound document", err)
+		return
+	}
+	if bytes.Equal(content, d.content) { // our own write, or no change
+		d.docLock.Unlock()
+		return
+	}
+	dirty, conflict := d.dirty, d.conflict
+	d.docLock.Unlock()
+
+	if dirty {
+		if conflict == nil {
+			gui.LogError("Bound document changed externally, discarding local changes to "+d.uri.String(), nil)
+		} else if conflict() {
+			if err := d.Save(); err != nil {
+				gui.LogError("Unable to write bound document", err)
+			}
+			return
+		}
+	}
+
+	if err := d.load(content); err != nil {
+		gui.LogError("Unable to load bound document", err)
+	}
+}
+
+func (d *document) load(content []byte) error {
+	data, node, err := d.decode(content)
+	if err != nil {
+		return err
+	}
+
+	d.docLock.Lock()
+	d.stopTimer()
+	d.content = content
+	d.node = node
+	d.dirty = false
+	d.docLock.Unlock()
+
+	d.dataLock.Lock()
+	d.val.Set(reflect.ValueOf(&data).Elem())
+	d.dataLock.Unlock()
+
+	d.refresh()
+	return nil
+}
+
+func (d *document) read() ([]byte, error) {
+	if ok, err := storage.Exists(d.uri); err != nil || !ok {
+		return nil, err
+	}
+
+	r, err := storage.Reader(d.uri)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return io.ReadAll(r)
+}
+
+// save writes the document, the docLock must be held when calling this.
+func (d *document) save() error {
+	d.stopTimer()
+	content, err := d.encode()
+	if err != nil {
+		return err
+	}
+
+	w, err := storage.Writer(d.uri)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(content)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	d.content = content
+	d.dirty = false
+	return nil
+}
+
+func (d *document) scheduleSave() {
+	d.docLock.Lock()
+	defer d.docLock.Unlock()
+
+	d.dirty = true
+	d.stopTimer()
+	d.timer = time.AfterFunc(documentSaveDelay, func() {
+		d.docLock.Lock()
+		defer d.docLock.Unlock()
+		if !d.dirty {
+			return
+		}
+
+		if err := d.save(); err != nil {
+			gui.LogError("Unable to write bound document", err)
+		}
+	})
+}
+
+func (d *document) stopTimer() {
+	if d.timer == nil {
+		return
+	}
+
+	d.timer.Stop()
+	d.timer = nil
+}
+
+func isYAMLDocument(u gui.URI) bool {
+	ext := strings.ToLower(u.Extension())
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// normalizeJSON converts numbers to int if they are whole numbers, or float64 otherwise,
+// so that they are bound in the same way as numbers read from YAML.
+func normalizeJSON(data interface{}) interface{} {
+	switch v := data.(type) {
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, val := range v {
+			v[key] = normalizeJSON(val)
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeJSON(val)
+		}
+	}
+	return data
+}
+
+// normalizeYAML converts the map[interface{}]interface{} values that YAML decodes to
+// map[string]interface{}, so that the data can be addressed by path and encoded again.
+func normalizeYAML(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[interface{}]interface{}:
+		ret := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			ret[fmt.Sprint(key)] = normalizeYAML(val)
+		}
+		return ret
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeYAML(val)
+		}
+	}
+	return data
+}
+
+// updateYAMLNode changes a YAML node to hold the data, keeping the order, style and comments of
+// the values that are not changed. New mapping keys are added in sorted order.
+func updateYAMLNode(node *yaml.Node, data interface{}) error {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		if node.Kind != yaml.MappingNode {
+			return replaceYAMLNode(node, data)
+		}
+
+		seen := make(map[string]bool, len(v))
+		content := node.Content[:0]
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, val := node.Content[i], node.Content[i+1]
+			item, ok := v[key.Value]
+			if !ok || seen[key.Value] {
+				continue // removed
+			}
+			if err := updateYAMLNode(val, item); err != nil {
+				return err
+			}
+			seen[key.Value] = true
+			content = append(content, key, val)
+		}
+
+		var added []string
+		for key := range v {
+			if !seen[key] {
+				added = append(added, key)
+			}
+		}
+		sort.Strings(added)
+		for _, key := range added {
+			val := &yaml.Node{}
+			if err := val.Encode(v[key]); err != nil {
+				return err
+			}
+			content = append(content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, val)
+		}
+		node.Content = content
+	case []interface{}:
+		if node.Kind != yaml.SequenceNode {
+			return replaceYAMLNode(node, data)
+		}
+
+		for i, item := range v {
+			if i >= len(node.Content) {
+				val := &yaml.Node{}
+				if err := val.Encode(item); err != nil {
+					return err
+				}
+				node.Content = append(node.Content, val)
+				continue
+			}
+			if err := updateYAMLNode(node.Content[i], item); err != nil {
+				return err
+			}
+		}
+		node.Content = node.Content[:len(v)]
+	default:
+		var current interface{}
+		if node.Kind == yaml.ScalarNode && node.Decode(&current) == nil && reflect.DeepEqual(current, data) {
+			return nil // unchanged, keep the quoting and formatting
+		}
+		return replaceYAMLNode(node, data)
+	}
+	return nil
+}
+
+// replaceYAMLNode sets a YAML node to the encoded data, keeping the comments of the node.
+func replaceYAMLNode(node *yaml.Node, data interface{}) error {
+	val := &yaml.Node{}
+	if err := val.Encode(data); err != nil {
+		return err
+	}
+
+	val.HeadComment, val.LineComment, val.FootComment = node.HeadComment, node.LineComment, node.FootComment
+	*node = *val
+	return nil
+}
@@ -0,0 +1,76 @@
+//go:build !android && !ios && !mobile && !js && !wasm && !test_web_driver
+// +build !android,!ios,!mobile,!js,!wasm,!test_web_driver
+
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"path/filepath"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/fsnotify/fsnotify"
+)
+
+func (d *document) stopWatching() {
+	d.docLock.Lock()
+	watcher := d.watcher
+	d.watcher = nil
+	d.docLock.Unlock()
+	if watcher == nil {
+		return
+	}
+
+	watcher.(*fsnotify.Watcher).Close()
+}
+
+// watch looks for changes to file documents, the parent directory is watched so that
+// editors that replace the file when saving are also detected.
+func (d *document) watch() {
+	if d.uri.Scheme() != "file" {
+		return
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		gui.LogError("Failed to watch bound document", err)
+		return
+	}
+
+	d.docLock.Lock()
+	d.watcher = watcher
+	d.docLock.Unlock()
+
+	path := filepath.Clean(d.uri.Path())
+	go func() {
+		for event := range watcher.Events {
+			if filepath.Clean(event.Name) != path || event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+
+			d.fileChanged()
+		}
+	}()
+
+	if err = watcher.Add(filepath.Dir(path)); err != nil {
+		gui.LogError("Failed to watch bound document", err)
+	}
+}
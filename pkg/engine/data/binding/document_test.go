@@ -0,0 +1,229 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	intRepo "github.com/bhojpur/gui/pkg/engine/internal/repository"
+	"github.com/bhojpur/gui/pkg/engine/storage"
+	"github.com/bhojpur/gui/pkg/engine/storage/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func init() {
+	repository.Register("file", intRepo.NewFileRepository()) // file uri resolving (avoid test import loop)
+}
+
+func TestBindDocument_JSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"server": {"name": "main", "ports": [80, 443]}, "debug": false}`), 0600)
+
+	doc, err := BindDocument(storage.NewFileURI(path))
+	assert.Nil(t, err)
+	defer doc.Close()
+
+	name, err := doc.GetString("server.name")
+	assert.Nil(t, err)
+	v, err := name.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "main", v)
+
+	port, err := doc.GetInt(`server["ports"][1]`)
+	assert.Nil(t, err)
+	p, err := port.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 443, p)
+
+	err = port.Set(8443)
+	assert.Nil(t, err)
+	err = doc.SetValue("debug", true)
+	assert.Nil(t, err)
+	err = doc.Save()
+	assert.Nil(t, err)
+
+	content, _ := os.ReadFile(path)
+	assert.JSONEq(t, `{"server": {"name": "main", "ports": [80, 8443]}, "debug": true}`, string(content))
+}
+
+func TestBindDocument_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("server:\n  name: main\n  ports:\n  - 80\n"), 0600)
+
+	doc, err := BindDocument(storage.NewFileURI(path))
+	assert.Nil(t, err)
+
+	port, err := doc.GetInt("server.ports[0]")
+	assert.Nil(t, err)
+	p, err := port.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 80, p)
+
+	err = doc.SetValue("server.name", "backup")
+	assert.Nil(t, err)
+	err = doc.Close()
+	assert.Nil(t, err)
+
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, "server:\n  name: backup\n  ports:\n    - 80\n", string(content))
+}
+
+func TestBindDocument_YAMLKeepsLayout(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("# settings\nzone: \"eu\" # region\nname: main\nports:\n  - 80\n  - 443\n"), 0600)
+
+	doc, err := BindDocument(storage.NewFileURI(path))
+	assert.Nil(t, err)
+
+	err = doc.SetValue("name", "backup")
+	assert.Nil(t, err)
+	err = doc.SetValue("ports[1]", 8443)
+	assert.Nil(t, err)
+	err = doc.SetValue("debug", true)
+	assert.Nil(t, err)
+	err = doc.Close()
+	assert.Nil(t, err)
+
+	content, _ := os.ReadFile(path)
+	assert.Equal(t, "# settings\nzone: \"eu\" # region\nname: backup\nports:\n  - 80\n  - 8443\ndebug: true\n", string(content))
+}
+
+func TestBindDocument_Missing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "new.json")
+	doc, err := BindDocument(storage.NewFileURI(path))
+	assert.Nil(t, err)
+	defer doc.Close()
+
+	_, err = doc.GetValue("name")
+	assert.Equal(t, errKeyNotFound, err)
+
+	err = doc.SetValue("name", "created")
+	assert.Nil(t, err)
+	err = doc.Save()
+	assert.Nil(t, err)
+	content, _ := os.ReadFile(path)
+	assert.JSONEq(t, `{"name": "created"}`, string(content))
+
+	broken := filepath.Join(t.TempDir(), "broken.json")
+	os.WriteFile(broken, []byte("{"), 0600)
+	_, err = BindDocument(storage.NewFileURI(broken))
+	assert.NotNil(t, err)
+}
+
+func TestBindDocument_DelayedSave(t *testing.T) {
+	delay := documentSaveDelay
+	documentSaveDelay = 20 * time.Millisecond
+	defer func() { documentSaveDelay = delay }()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"count": 1}`), 0600)
+	doc, err := BindDocument(storage.NewFileURI(path))
+	assert.Nil(t, err)
+	defer doc.Close()
+
+	count, err := doc.GetInt("count")
+	assert.Nil(t, err)
+	count.Set(2)
+	count.Set(3)
+	content, _ := os.ReadFile(path)
+	assert.JSONEq(t, `{"count": 1}`, string(content))
+
+	assert.Eventually(t, func() bool {
+		content, _ := os.ReadFile(path)
+		return string(content) == "{\n  \"count\": 3\n}\n"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestBindDocument_ExternalChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"title": "one", "size": 2}`), 0600)
+	doc, err := BindDocument(storage.NewFileURI(path))
+	assert.Nil(t, err)
+	defer doc.Close()
+
+	title, err := doc.GetString("title")
+	assert.Nil(t, err)
+	size, err := doc.GetFloat("size")
+	assert.Nil(t, err)
+	titleCalls, sizeCalls := 0, 0
+	title.AddListener(NewDataListener(func() {
+		titleCalls++
+	}))
+	size.AddListener(NewDataListener(func() {
+		sizeCalls++
+	}))
+	waitForItems()
+
+	os.WriteFile(path, []byte(`{"title": "two", "size": 2}`), 0600)
+	assert.Eventually(t, func() bool {
+		v, _ := title.Get()
+		return v == "two"
+	}, 2*time.Second, 10*time.Millisecond)
+	waitForItems()
+	assert.Equal(t, 2, titleCalls)
+	assert.Equal(t, 1, sizeCalls)
+
+	os.WriteFile(path, []byte(`{"title": "three", "size": 2}`), 0600)
+	doc.(*document).fileChanged()
+	v, err := title.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "three", v)
+}
+
+func TestBindDocument_Conflict(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"title": "one"}`), 0600)
+	doc, err := BindDocument(storage.NewFileURI(path))
+	assert.Nil(t, err)
+	doc.(*document).stopWatching() // drive changes directly
+	defer doc.Close()
+
+	conflicts := 0
+	keepLocal := true
+	doc.SetOnConflict(func() bool {
+		conflicts++
+		return keepLocal
+	})
+
+	doc.SetValue("title", "local")
+	os.WriteFile(path, []byte(`{"title": "external"}`), 0600)
+	doc.(*document).fileChanged()
+	assert.Equal(t, 1, conflicts)
+	v, _ := doc.GetValue("title")
+	assert.Equal(t, "local", v)
+	content, _ := os.ReadFile(path)
+	assert.JSONEq(t, `{"title": "local"}`, string(content))
+
+	keepLocal = false
+	doc.SetValue("title", "local again")
+	os.WriteFile(path, []byte(`{"title": "external"}`), 0600)
+	doc.(*document).fileChanged()
+	assert.Equal(t, 2, conflicts)
+	v, _ = doc.GetValue("title")
+	assert.Equal(t, "external", v)
+
+	os.WriteFile(path, []byte(`{"title": "no local changes"}`), 0600)
+	doc.(*document).fileChanged()
+	assert.Equal(t, 2, conflicts)
+}
@@ -0,0 +1,132 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Item supports binding a value of any type.
+//
+// Since: 2.3
+type Item[T any] interface {
+	DataItem
+	Get() (T, error)
+	Set(T) error
+}
+
+// ExternalItem supports binding a value of any type to an external variable.
+//
+// Since: 2.3
+type ExternalItem[T any] interface {
+	Item[T]
+	Reload() error
+}
+
+// NewItem returns a bindable value of a comparable type that is managed internally.
+//
+// Since: 2.3
+func NewItem[T comparable]() Item[T] {
+	return NewItemWithComparator(equal[T])
+}
+
+// NewItemWithComparator returns a bindable value of any type that is managed internally.
+// The comparator reports if two values are the same, so that listeners are only told about changes.
+//
+// Since: 2.3
+func NewItemWithComparator[T any](comparator func(T, T) bool) Item[T] {
+	var blank T
+	return &boundItem[T]{val: &blank, comparator: comparator}
+}
+
+// BindItem returns a new bindable value that controls the contents of the provided variable of a comparable type.
+// If your code changes the content of the variable this refers to you should call Reload() to inform the bindings.
+//
+// Since: 2.3
+func BindItem[T comparable](v *T) ExternalItem[T] {
+	return BindItemWithComparator(v, equal[T])
+}
+
+// BindItemWithComparator returns a new bindable value that controls the contents of the provided variable.
+// The comparator reports if two values are the same, so that listeners are only told about changes.
+// If your code changes the content of the variable this refers to you should call Reload() to inform the bindings.
+//
+// Since: 2.3
+func BindItemWithComparator[T any](v *T, comparator func(T, T) bool) ExternalItem[T] {
+	if v == nil {
+		var blank T
+		v = &blank // never allow a nil value pointer
+	}
+	b := &boundExternalItem[T]{}
+	b.comparator = comparator
+	b.val = v
+	b.old = *v
+	return b
+}
+
+type boundItem[T any] struct {
+	base
+
+	comparator func(T, T) bool
+	val        *T
+}
+
+func (b *boundItem[T]) Get() (T, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if b.val == nil {
+		var blank T
+		return blank, nil
+	}
+	return *b.val, nil
+}
+
+func (b *boundItem[T]) Set(val T) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.comparator(*b.val, val) {
+		return nil
+	}
+	*b.val = val
+
+	b.trigger()
+	return nil
+}
+
+type boundExternalItem[T any] struct {
+	boundItem[T]
+
+	old T
+}
+
+func (b *boundExternalItem[T]) Set(val T) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.comparator(b.old, val) {
+		return nil
+	}
+	*b.val = val
+	b.old = val
+
+	b.trigger()
+	return nil
+}
+
+func (b *boundExternalItem[T]) Reload() error {
+	return b.Set(*b.val)
+}
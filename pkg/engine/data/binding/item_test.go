@@ -0,0 +1,141 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLevel int
+
+const (
+	testLevelLow testLevel = iota
+	testLevelHigh
+)
+
+type testPoint struct {
+	X, Y  int
+	Label string
+}
+
+func compareTestPoint(p1, p2 testPoint) bool {
+	return p1.X == p2.X && p1.Y == p2.Y
+}
+
+func TestBindItem(t *testing.T) {
+	val := testLevelHigh
+	l := BindItem(&val)
+	v, err := l.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelHigh, v)
+
+	called := false
+	l.AddListener(NewDataListener(func() {
+		called = true
+	}))
+	waitForItems()
+	assert.True(t, called)
+
+	called = false
+	err = l.Set(testLevelLow)
+	assert.Nil(t, err)
+	waitForItems()
+	assert.Equal(t, testLevelLow, val)
+	assert.True(t, called)
+
+	called = false
+	val = testLevelHigh
+	l.Reload()
+	waitForItems()
+	assert.True(t, called)
+	v, err = l.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelHigh, v)
+}
+
+func TestNewItem(t *testing.T) {
+	l := NewItem[testLevel]()
+	v, err := l.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelLow, v)
+
+	err = l.Set(testLevelHigh)
+	assert.Nil(t, err)
+	v, err = l.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelHigh, v)
+}
+
+func TestNewItemWithComparator(t *testing.T) {
+	p := NewItemWithComparator(compareTestPoint)
+	triggered := 0
+	p.AddListener(NewDataListener(func() {
+		triggered++
+	}))
+	waitForItems()
+	assert.Equal(t, 1, triggered)
+
+	p.Set(testPoint{X: 1, Y: 2})
+	waitForItems()
+	assert.Equal(t, 2, triggered)
+
+	p.Set(testPoint{X: 1, Y: 2, Label: "same"})
+	waitForItems()
+	assert.Equal(t, 2, triggered)
+	v, err := p.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "", v.Label)
+}
+
+func TestBindItemWithComparator(t *testing.T) {
+	val := testPoint{X: 1, Y: 2}
+	p := BindItemWithComparator(&val, compareTestPoint)
+	triggered := 0
+	p.AddListener(NewDataListener(func() {
+		triggered++
+	}))
+	waitForItems()
+	assert.Equal(t, 1, triggered)
+
+	val.Label = "ignored"
+	p.Reload()
+	waitForItems()
+	assert.Equal(t, 1, triggered)
+
+	val.X = 3
+	p.Reload()
+	waitForItems()
+	assert.Equal(t, 2, triggered)
+}
+
+func TestItem_Alias(t *testing.T) {
+	var s Item[string] = NewString()
+	s.Set("alias")
+
+	var str String = NewItem[string]()
+	str.Set("alias")
+
+	v1, _ := s.Get()
+	v2, _ := str.Get()
+	assert.Equal(t, v1, v2)
+}
@@ -0,0 +1,264 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// List supports binding a list of values of any type.
+//
+// Since: 2.3
+type List[T any] interface {
+	DataList
+
+	Append(value T) error
+	Get() ([]T, error)
+	GetValue(index int) (T, error)
+	Prepend(value T) error
+	Set(list []T) error
+	SetValue(index int, value T) error
+}
+
+// ExternalList supports binding a list of values of any type from an external variable.
+//
+// Since: 2.3
+type ExternalList[T any] interface {
+	List[T]
+
+	Reload() error
+}
+
+// NewList returns a bindable list of values of a comparable type.
+//
+// Since: 2.3
+func NewList[T comparable]() List[T] {
+	return NewListWithComparator(equal[T])
+}
+
+// NewListWithComparator returns a bindable list of values of any type.
+// The comparator reports if two values are the same, so that listeners are only told about changes.
+//
+// Since: 2.3
+func NewListWithComparator[T any](comparator func(T, T) bool) List[T] {
+	return &boundList[T]{val: &[]T{}, comparator: comparator}
+}
+
+// BindList returns a bound list of values of a comparable type, based on the contents of the passed slice.
+// If your code changes the content of the slice this refers to you should call Reload() to inform the bindings.
+//
+// Since: 2.3
+func BindList[T comparable](v *[]T) ExternalList[T] {
+	return BindListWithComparator(v, equal[T])
+}
+
+// BindListWithComparator returns a bound list of values of any type, based on the contents of the passed slice.
+// The comparator reports if two values are the same, so that listeners are only told about changes.
+// If your code changes the content of the slice this refers to you should call Reload() to inform the bindings.
+//
+// Since: 2.3
+func BindListWithComparator[T any](v *[]T, comparator func(T, T) bool) ExternalList[T] {
+	if v == nil {
+		return NewListWithComparator(comparator).(ExternalList[T])
+	}
+
+	b := &boundList[T]{val: v, comparator: comparator, updateExternal: true}
+
+	for i := range *v {
+		b.appendItem(b.bindListItem(i))
+	}
+
+	return b
+}
+
+type boundList[T any] struct {
+	listBase
+
+	comparator     func(T, T) bool
+	updateExternal bool
+	val            *[]T
+}
+
+func (l *boundList[T]) Append(val T) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	*l.val = append(*l.val, val)
+
+	return l.doReload()
+}
+
+func (l *boundList[T]) Get() ([]T, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	return *l.val, nil
+}
+
+func (l *boundList[T]) GetValue(i int) (T, error) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	if i < 0 || i >= l.Length() {
+		var blank T
+		return blank, errOutOfBounds
+	}
+
+	return (*l.val)[i], nil
+}
+
+func (l *boundList[T]) Prepend(val T) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	*l.val = append([]T{val}, *l.val...)
+
+	return l.doReload()
+}
+
+func (l *boundList[T]) Reload() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.doReload()
+}
+
+func (l *boundList[T]) Set(v []T) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	*l.val = v
+
+	return l.doReload()
+}
+
+func (l *boundList[T]) doReload() (retErr error) {
+	oldLen := len(l.items)
+	newLen := len(*l.val)
+	if oldLen > newLen {
+		for i := oldLen - 1; i >= newLen; i-- {
+			l.deleteItem(i)
+		}
+		l.trigger()
+	} else if oldLen < newLen {
+		for i := oldLen; i < newLen; i++ {
+			l.appendItem(l.bindListItem(i))
+		}
+		l.trigger()
+	}
+
+	for i, item := range l.items {
+		if i > oldLen || i > newLen {
+			break
+		}
+
+		var err error
+		if l.updateExternal {
+			item.(*boundExternalListItem[T]).lock.Lock()
+			err = item.(*boundExternalListItem[T]).setIfChanged((*l.val)[i])
+			item.(*boundExternalListItem[T]).lock.Unlock()
+		} else {
+			item.(*boundListItem[T]).lock.Lock()
+			err = item.(*boundListItem[T]).doSet((*l.val)[i])
+			item.(*boundListItem[T]).lock.Unlock()
+		}
+		if err != nil {
+			retErr = err
+		}
+	}
+	return
+}
+
+func (l *boundList[T]) SetValue(i int, v T) error {
+	l.lock.RLock()
+	len := l.Length()
+	l.lock.RUnlock()
+
+	if i < 0 || i >= len {
+		return errOutOfBounds
+	}
+
+	l.lock.Lock()
+	(*l.val)[i] = v
+	l.lock.Unlock()
+
+	item, err := l.GetItem(i)
+	if err != nil {
+		return err
+	}
+	return item.(Item[T]).Set(v)
+}
+
+func (l *boundList[T]) bindListItem(i int) Item[T] {
+	if l.updateExternal {
+		ret := &boundExternalListItem[T]{old: (*l.val)[i], comparator: l.comparator}
+		ret.val = l.val
+		ret.index = i
+		return ret
+	}
+
+	return &boundListItem[T]{val: l.val, index: i}
+}
+
+type boundListItem[T any] struct {
+	base
+
+	val   *[]T
+	index int
+}
+
+func (b *boundListItem[T]) Get() (T, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.index < 0 || b.index >= len(*b.val) {
+		var blank T
+		return blank, errOutOfBounds
+	}
+
+	return (*b.val)[b.index], nil
+}
+
+func (b *boundListItem[T]) Set(val T) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.doSet(val)
+}
+
+func (b *boundListItem[T]) doSet(val T) error {
+	(*b.val)[b.index] = val
+
+	b.trigger()
+	return nil
+}
+
+type boundExternalListItem[T any] struct {
+	boundListItem[T]
+
+	comparator func(T, T) bool
+	old        T
+}
+
+func (b *boundExternalListItem[T]) setIfChanged(val T) error {
+	if b.comparator(val, b.old) {
+		return nil
+	}
+	(*b.val)[b.index] = val
+	b.old = val
+
+	b.trigger()
+	return nil
+}
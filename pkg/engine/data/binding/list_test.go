@@ -0,0 +1,90 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewList(t *testing.T) {
+	l := NewList[testLevel]()
+	assert.Equal(t, 0, l.Length())
+
+	l.Append(testLevelHigh)
+	assert.Equal(t, 1, l.Length())
+	l.Prepend(testLevelLow)
+	assert.Equal(t, 2, l.Length())
+
+	v, err := l.GetValue(0)
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelLow, v)
+	_, err = l.GetValue(2)
+	assert.NotNil(t, err)
+
+	item, err := l.GetItem(1)
+	assert.Nil(t, err)
+	v, err = item.(Item[testLevel]).Get()
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelHigh, v)
+}
+
+func TestBindList(t *testing.T) {
+	val := []testLevel{testLevelLow, testLevelHigh}
+	l := BindList(&val)
+	assert.Equal(t, 2, l.Length())
+
+	err := l.SetValue(0, testLevelHigh)
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelHigh, val[0])
+
+	val = append(val, testLevelLow)
+	l.Reload()
+	assert.Equal(t, 3, l.Length())
+	v, err := l.GetValue(2)
+	assert.Nil(t, err)
+	assert.Equal(t, testLevelLow, v)
+}
+
+func TestBindListWithComparator(t *testing.T) {
+	val := []testPoint{{X: 1}, {X: 2}}
+	l := BindListWithComparator(&val, compareTestPoint)
+	item, err := l.GetItem(0)
+	assert.Nil(t, err)
+
+	triggered := 0
+	item.AddListener(NewDataListener(func() {
+		triggered++
+	}))
+	waitForItems()
+	assert.Equal(t, 1, triggered)
+
+	val[0].Label = "ignored"
+	l.Reload()
+	waitForItems()
+	assert.Equal(t, 1, triggered)
+
+	val[0].X = 5
+	l.Reload()
+	waitForItems()
+	assert.Equal(t, 2, triggered)
+}
@@ -0,0 +1,250 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Map supports binding a map of keys of any comparable type to values of any type.
+//
+// Since: 2.3
+type Map[K comparable, V any] interface {
+	DataItem
+	Delete(K)
+	Get() (map[K]V, error)
+	GetItem(K) (DataItem, error)
+	GetValue(K) (V, error)
+	Keys() []K
+	Set(map[K]V) error
+	SetValue(K, V) error
+}
+
+// ExternalMap supports binding a map of keys to values connected to an external data source.
+//
+// Since: 2.3
+type ExternalMap[K comparable, V any] interface {
+	Map[K, V]
+	Reload() error
+}
+
+// NewMap creates a new, empty map binding with values of a comparable type.
+//
+// Since: 2.3
+func NewMap[K, V comparable]() Map[K, V] {
+	return NewMapWithComparator[K](equal[V])
+}
+
+// NewMapWithComparator creates a new, empty map binding with values of any type.
+// The comparator reports if two values are the same, so that listeners are only told about changes.
+//
+// Since: 2.3
+func NewMapWithComparator[K comparable, V any](comparator func(V, V) bool) Map[K, V] {
+	return &boundMap[K, V]{items: make(map[K]*boundMapItem[K, V]), val: &map[K]V{}, comparator: comparator}
+}
+
+// BindMap creates a new map binding with values of a comparable type based on the data passed.
+// If your code changes the content of the map this refers to you should call Reload() to inform the bindings.
+//
+// Since: 2.3
+func BindMap[K, V comparable](d *map[K]V) ExternalMap[K, V] {
+	return BindMapWithComparator(d, equal[V])
+}
+
+// BindMapWithComparator creates a new map binding with values of any type based on the data passed.
+// The comparator reports if two values are the same, so that listeners are only told about changes.
+// If your code changes the content of the map this refers to you should call Reload() to inform the bindings.
+//
+// Since: 2.3
+func BindMapWithComparator[K comparable, V any](d *map[K]V, comparator func(V, V) bool) ExternalMap[K, V] {
+	if d == nil {
+		return NewMapWithComparator[K](comparator).(ExternalMap[K, V])
+	}
+	m := &boundMap[K, V]{items: make(map[K]*boundMapItem[K, V]), val: d, comparator: comparator}
+
+	for k := range *d {
+		m.setItem(k, m.bindMapItem(k))
+	}
+
+	return m
+}
+
+type boundMap[K comparable, V any] struct {
+	base
+
+	comparator func(V, V) bool
+	items      map[K]*boundMapItem[K, V]
+	val        *map[K]V
+}
+
+func (b *boundMap[K, V]) Delete(key K) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	delete(b.items, key)
+	delete(*b.val, key)
+
+	b.trigger()
+}
+
+func (b *boundMap[K, V]) Get() (map[K]V, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	return *b.val, nil
+}
+
+func (b *boundMap[K, V]) GetItem(key K) (DataItem, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if v, ok := b.items[key]; ok {
+		return v, nil
+	}
+
+	return nil, errKeyNotFound
+}
+
+func (b *boundMap[K, V]) GetValue(key K) (V, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if i, ok := b.items[key]; ok {
+		return i.Get()
+	}
+
+	var blank V
+	return blank, errKeyNotFound
+}
+
+func (b *boundMap[K, V]) Keys() []K {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	ret := make([]K, 0, len(b.items))
+	for k := range b.items {
+		ret = append(ret, k)
+	}
+
+	return ret
+}
+
+func (b *boundMap[K, V]) Reload() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	return b.doReload()
+}
+
+func (b *boundMap[K, V]) Set(v map[K]V) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	*b.val = v
+	return b.doReload()
+}
+
+func (b *boundMap[K, V]) SetValue(key K, v V) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if i, ok := b.items[key]; ok {
+		return i.Set(v)
+	}
+
+	(*b.val)[key] = v
+	b.setItem(key, b.bindMapItem(key))
+	return nil
+}
+
+func (b *boundMap[K, V]) bindMapItem(key K) *boundMapItem[K, V] {
+	return &boundMapItem[K, V]{val: b.val, key: key, old: (*b.val)[key], comparator: b.comparator}
+}
+
+func (b *boundMap[K, V]) doReload() (retErr error) {
+	changed := false
+	// add new
+	for key := range *b.val {
+		if _, ok := b.items[key]; !ok {
+			b.items[key] = b.bindMapItem(key)
+			changed = true
+		}
+	}
+
+	// remove old
+	for key := range b.items {
+		if _, ok := (*b.val)[key]; !ok {
+			delete(b.items, key)
+			changed = true
+		}
+	}
+	if changed {
+		b.trigger()
+	}
+
+	for k, item := range b.items {
+		if err := item.setIfChanged((*b.val)[k]); err != nil {
+			retErr = err
+		}
+	}
+	return
+}
+
+func (b *boundMap[K, V]) setItem(key K, d *boundMapItem[K, V]) {
+	b.items[key] = d
+
+	b.trigger()
+}
+
+type boundMapItem[K comparable, V any] struct {
+	base
+
+	comparator func(V, V) bool
+	key        K
+	old        V
+	val        *map[K]V
+}
+
+func (b *boundMapItem[K, V]) Get() (V, error) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
+	if v, ok := (*b.val)[b.key]; ok {
+		return v, nil
+	}
+
+	var blank V
+	return blank, errKeyNotFound
+}
+
+func (b *boundMapItem[K, V]) Set(val V) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	(*b.val)[b.key] = val
+	return b.setIfChanged(val)
+}
+
+func (b *boundMapItem[K, V]) setIfChanged(val V) error {
+	if b.comparator(val, b.old) {
+		return nil
+	}
+	b.old = val
+
+	b.trigger()
+	return nil
+}
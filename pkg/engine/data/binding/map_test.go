@@ -0,0 +1,113 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMap(t *testing.T) {
+	m := NewMap[int, string]()
+	assert.Equal(t, 0, len(m.Keys()))
+
+	err := m.SetValue(1, "one")
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1}, m.Keys())
+	v, err := m.GetValue(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "one", v)
+
+	_, err = m.GetValue(2)
+	assert.NotNil(t, err)
+	_, err = m.GetItem(2)
+	assert.NotNil(t, err)
+
+	item, err := m.GetItem(1)
+	assert.Nil(t, err)
+	called := false
+	item.AddListener(NewDataListener(func() {
+		called = true
+	}))
+	waitForItems()
+	called = false
+	m.SetValue(1, "uno")
+	waitForItems()
+	assert.True(t, called)
+	v, err = item.(Item[string]).Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "uno", v)
+
+	m.Delete(1)
+	assert.Equal(t, 0, len(m.Keys()))
+	val, err := m.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, len(val))
+}
+
+func TestBindMap(t *testing.T) {
+	val := map[int]string{1: "one", 2: "two"}
+	m := BindMap(&val)
+	assert.Equal(t, 2, len(m.Keys()))
+
+	item, err := m.GetItem(2)
+	assert.Nil(t, err)
+	calls := 0
+	item.AddListener(NewDataListener(func() {
+		calls++
+	}))
+	waitForItems()
+	assert.Equal(t, 1, calls)
+
+	val[2] = "two"
+	val[3] = "three"
+	delete(val, 1)
+	m.Reload()
+	waitForItems()
+	assert.Equal(t, 1, calls)
+	assert.ElementsMatch(t, []int{2, 3}, m.Keys())
+
+	val[2] = "deux"
+	m.Reload()
+	waitForItems()
+	assert.Equal(t, 2, calls)
+}
+
+func TestBindMapWithComparator(t *testing.T) {
+	val := map[string]testPoint{"a": {X: 1}}
+	m := BindMapWithComparator(&val, compareTestPoint)
+	item, err := m.GetItem("a")
+	assert.Nil(t, err)
+	calls := 0
+	item.AddListener(NewDataListener(func() {
+		calls++
+	}))
+	waitForItems()
+
+	m.SetValue("a", testPoint{X: 1, Label: "ignored"})
+	waitForItems()
+	assert.Equal(t, 1, calls)
+
+	m.SetValue("a", testPoint{X: 2})
+	waitForItems()
+	assert.Equal(t, 2, calls)
+}
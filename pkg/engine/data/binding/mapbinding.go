@@ -39,22 +39,12 @@ type DataMap interface {
 // ExternalUntypedMap is a map data binding with all values untyped (interface{}), connected to an external data source.
 //
 // Since: 2.0
-type ExternalUntypedMap interface {
-	UntypedMap
-	Reload() error
-}
+type ExternalUntypedMap = ExternalMap[string, interface{}]
 
 // UntypedMap is a map data binding with all values Untyped (interface{}).
 //
 // Since: 2.0
-type UntypedMap interface {
-	DataMap
-	Delete(string)
-	Get() (map[string]interface{}, error)
-	GetValue(string) (interface{}, error)
-	Set(map[string]interface{}) error
-	SetValue(string, interface{}) error
-}
+type UntypedMap = Map[string, interface{}]
 
 // NewUntypedMap creates a new, empty map binding of string to interface{}.
 //
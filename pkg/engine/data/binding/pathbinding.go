@@ -0,0 +1,672 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+)
+
+// DeepStruct is a binding to a struct that allows nested fields, slices and maps to be bound using a path.
+// A path is made up of exported field names separated by dots, slice and array indexes and map keys in
+// square brackets, such as `Order.Items[2].Price` or `Settings["theme"]`. Map keys that are valid
+// identifiers may also be separated by dots, such as `Settings.theme`.
+// Listeners added to the DeepStruct itself are informed when any value inside the struct changes.
+//
+// Since: 2.3
+type DeepStruct interface {
+	DataItem
+	GetBool(path string) (Bool, error)
+	GetFloat(path string) (Float, error)
+	GetInt(path string) (Int, error)
+	GetItem(path string) (DataItem, error)
+	GetList(path string) (DataList, error)
+	GetString(path string) (String, error)
+	GetValue(path string) (interface{}, error)
+	SetValue(path string, value interface{}) error
+	Reload() error
+}
+
+// BindDeepStruct creates a new binding to the struct passed, which must be a pointer to a struct.
+// Values of any depth inside the struct can then be bound using a path.
+// If your code changes the content of the struct you should call Reload() to inform the bindings,
+// only those bindings whose value actually changed will be triggered.
+// The struct must not contain pointer cycles.
+//
+// Since: 2.3
+func BindDeepStruct(i interface{}) DeepStruct {
+	v := reflect.ValueOf(i)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		gui.LogError("Invalid type passed to BindDeepStruct, must be pointer to struct", nil)
+		v = reflect.ValueOf(&struct{}{})
+	}
+
+	return newDeepStruct(v.Elem())
+}
+
+// PathItem returns a binding for the value at the path inside a DeepStruct, as the type requested.
+// Numeric, bool and string values can be bound as any type with the same kind and integers can be bound as floats.
+//
+// Since: 2.3
+func PathItem[T any](s DeepStruct, path string) (Item[T], error) {
+	tree, ok := s.(*deepStruct)
+	if !ok {
+		return nil, errInvalidPath
+	}
+	item, val, err := tree.bindPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var blank T
+	if _, ok := convertValue(val, reflect.TypeOf(&blank).Elem()); !ok {
+		return nil, errPathType
+	}
+	return &pathValue[T]{item}, nil
+}
+
+type deepStruct struct {
+	*pathItem // the root of the struct, triggered for any change
+
+	changed  func() // called after a value is set through a binding
+	dataLock sync.RWMutex
+	items    map[string]*pathItem
+	val      reflect.Value
+}
+
+// newDeepStruct binds the addressable value passed, which may be of any type that a path can navigate.
+func newDeepStruct(val reflect.Value) *deepStruct {
+	s := &deepStruct{items: make(map[string]*pathItem), val: val}
+	s.pathItem = &pathItem{tree: s, old: deepCopy(val)}
+	s.items[""] = s.pathItem
+	return s
+}
+
+func (s *deepStruct) GetBool(path string) (Bool, error) {
+	return PathItem[bool](s, path)
+}
+
+func (s *deepStruct) GetFloat(path string) (Float, error) {
+	return PathItem[float64](s, path)
+}
+
+func (s *deepStruct) GetInt(path string) (Int, error) {
+	return PathItem[int](s, path)
+}
+
+// GetItem returns a binding for the value at the path. Bool, Float, Int and String bindings are returned for
+// values of those kinds and Untyped is returned for all other values.
+func (s *deepStruct) GetItem(path string) (DataItem, error) {
+	item, val, err := s.bindPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kindClass(val.Kind()) {
+	case reflect.Bool:
+		return &pathValue[bool]{item}, nil
+	case reflect.Int:
+		return &pathValue[int]{item}, nil
+	case reflect.Float64:
+		return &pathValue[float64]{item}, nil
+	case reflect.String:
+		return &pathValue[string]{item}, nil
+	}
+	return &pathValue[interface{}]{item}, nil
+}
+
+// GetList returns a list binding for the slice or array at the path.
+// The items of the list are bound in the same way as GetItem.
+func (s *deepStruct) GetList(path string) (DataList, error) {
+	item, val, err := s.bindPath(path)
+	if err != nil {
+		return nil, err
+	}
+	if val.Kind() != reflect.Slice && val.Kind() != reflect.Array {
+		return nil, errPathType
+	}
+
+	return &pathList{pathItem: item}, nil
+}
+
+func (s *deepStruct) GetString(path string) (String, error) {
+	return PathItem[string](s, path)
+}
+
+func (s *deepStruct) GetValue(path string) (interface{}, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	s.dataLock.RLock()
+	defer s.dataLock.RUnlock()
+	val, err := resolvePath(s.val, segments)
+	if err != nil {
+		return nil, err
+	}
+	return val.Interface(), nil
+}
+
+func (s *deepStruct) Reload() error {
+	s.refresh()
+	return nil
+}
+
+func (s *deepStruct) SetValue(path string, value interface{}) error {
+	segments, err := parsePath(path)
+	if err != nil {
+		return err
+	}
+
+	return s.setPath(segments, reflect.ValueOf(&value).Elem())
+}
+
+// bindPath returns the item for a path, creating it if this path was not bound before.
+// The current value at the path is also returned.
+func (s *deepStruct) bindPath(path string) (*pathItem, reflect.Value, error) {
+	segments, err := parsePath(path)
+	if err != nil {
+		return nil, reflect.Value{}, err
+	}
+
+	s.dataLock.Lock()
+	defer s.dataLock.Unlock()
+	val, err := resolvePath(s.val, segments)
+	if err != nil {
+		return nil, reflect.Value{}, err
+	}
+
+	key := formatPath(segments)
+	if item, ok := s.items[key]; ok {
+		return item, val, nil
+	}
+	item := &pathItem{tree: s, path: segments, old: deepCopy(val)}
+	s.items[key] = item
+	return item, val, nil
+}
+
+// refresh triggers each bound item whose value is different to when it last changed.
+func (s *deepStruct) refresh() {
+	s.dataLock.Lock()
+	defer s.dataLock.Unlock()
+
+	for _, item := range s.items {
+		val, err := resolvePath(s.val, item.path)
+		if err == item.err && (err != nil || reflect.DeepEqual(item.old.Interface(), val.Interface())) {
+			continue
+		}
+
+		item.err = err
+		if err == nil {
+			item.old = deepCopy(val)
+		} else {
+			item.old = reflect.Value{}
+		}
+		item.lock.RLock()
+		item.trigger()
+		item.lock.RUnlock()
+	}
+}
+
+func (s *deepStruct) setPath(path []pathSegment, val reflect.Value) error {
+	if err := s.setValue(path, val); err != nil {
+		return err
+	}
+
+	s.refresh()
+	if s.changed != nil {
+		s.changed()
+	}
+	return nil
+}
+
+func (s *deepStruct) setValue(path []pathSegment, val reflect.Value) (err error) {
+	s.dataLock.Lock()
+	defer s.dataLock.Unlock()
+	defer func() {
+		if r := recover(); r != nil {
+			err = errors.New("unable to set value in data binding")
+		}
+	}()
+
+	return setPathValue(s.val, path, val)
+}
+
+type pathItem struct {
+	base
+
+	err  error
+	old  reflect.Value
+	path []pathSegment
+	tree *deepStruct
+}
+
+func (p *pathItem) get() (reflect.Value, error) {
+	p.tree.dataLock.RLock()
+	defer p.tree.dataLock.RUnlock()
+
+	return resolvePath(p.tree.val, p.path)
+}
+
+type pathValue[T any] struct {
+	*pathItem
+}
+
+func (p *pathValue[T]) Get() (T, error) {
+	var blank T
+	val, err := p.get()
+	if err != nil {
+		return blank, err
+	}
+
+	conv, ok := convertValue(val, reflect.TypeOf(&blank).Elem())
+	if !ok {
+		return blank, errPathType
+	}
+	return conv.Interface().(T), nil
+}
+
+func (p *pathValue[T]) Set(val T) error {
+	return p.tree.setPath(p.path, reflect.ValueOf(&val).Elem())
+}
+
+type pathList struct {
+	*pathItem
+}
+
+func (p *pathList) GetItem(i int) (DataItem, error) {
+	if i < 0 || i >= p.Length() {
+		return nil, errOutOfBounds
+	}
+
+	path := append(append([]pathSegment{}, p.path...), pathSegment{key: strconv.Itoa(i), index: true})
+	return p.tree.GetItem(formatPath(path))
+}
+
+func (p *pathList) Length() int {
+	val, err := p.get()
+	if err != nil {
+		return 0
+	}
+	return val.Len()
+}
+
+type pathSegment struct {
+	key   string
+	index bool
+}
+
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	for i := 0; i < len(path); {
+		switch {
+		case path[i] == '[':
+			key, n, err := parsePathKey(path[i+1:])
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, pathSegment{key: key, index: true})
+			i += n + 1
+		case path[i] == '.' && len(segments) > 0:
+			i++
+			fallthrough
+		case len(segments) == 0:
+			name := pathIdentifier(path[i:])
+			if name == "" {
+				return nil, errInvalidPath
+			}
+			segments = append(segments, pathSegment{key: name})
+			i += len(name)
+		default:
+			return nil, errInvalidPath
+		}
+	}
+
+	return segments, nil
+}
+
+// parsePathKey reads the content of an index or key up to the closing bracket,
+// returning the key and the number of bytes consumed.
+func parsePathKey(path string) (string, int, error) {
+	if strings.HasPrefix(path, "\"") {
+		quoted, err := strconv.QuotedPrefix(path)
+		if err != nil || len(path) == len(quoted) || path[len(quoted)] != ']' {
+			return "", 0, errInvalidPath
+		}
+		key, _ := strconv.Unquote(quoted)
+		return key, len(quoted) + 1, nil
+	}
+
+	end := strings.IndexByte(path, ']')
+	if end < 1 {
+		return "", 0, errInvalidPath
+	}
+	return strings.TrimSpace(path[:end]), end + 1, nil
+}
+
+func pathIdentifier(path string) string {
+	for i, r := range path {
+		if r != '_' && !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			return path[:i]
+		}
+	}
+	return path
+}
+
+func formatPath(path []pathSegment) string {
+	var b strings.Builder
+	for i, segment := range path {
+		switch {
+		case !segment.index:
+			if i > 0 {
+				b.WriteRune('.')
+			}
+			b.WriteString(segment.key)
+		case isPathIndex(segment.key):
+			b.WriteString("[" + segment.key + "]")
+		default:
+			b.WriteString("[" + strconv.Quote(segment.key) + "]")
+		}
+	}
+	return b.String()
+}
+
+func isPathIndex(key string) bool {
+	for _, r := range key {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return key != ""
+}
+
+func resolvePath(v reflect.Value, path []pathSegment) (reflect.Value, error) {
+	var err error
+	for _, segment := range path {
+		if v, err = indirectValue(v); err != nil {
+			return v, err
+		}
+		if v, err = pathChild(v, segment); err != nil {
+			return v, err
+		}
+	}
+
+	return indirectValue(v)
+}
+
+// pathChild returns the value found by following one segment of a path.
+func pathChild(v reflect.Value, segment pathSegment) (reflect.Value, error) {
+	if !segment.index && v.Kind() != reflect.Map {
+		if v.Kind() != reflect.Struct {
+			return v, errInvalidPath
+		}
+		field, ok := v.Type().FieldByName(segment.key)
+		if !ok || field.PkgPath != "" {
+			return v, errKeyNotFound
+		}
+		f, err := v.FieldByIndexErr(field.Index)
+		if err != nil {
+			return v, errNilPath
+		}
+		return f, nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		i, err := strconv.Atoi(segment.key)
+		if err != nil {
+			return v, errInvalidPath
+		}
+		if i < 0 || i >= v.Len() {
+			return v, errOutOfBounds
+		}
+		return v.Index(i), nil
+	case reflect.Map:
+		key, err := mapKey(v.Type().Key(), segment.key)
+		if err != nil {
+			return v, err
+		}
+		val := v.MapIndex(key)
+		if !val.IsValid() {
+			return v, errKeyNotFound
+		}
+		return val, nil
+	}
+	return v, errInvalidPath
+}
+
+func setPathValue(v reflect.Value, path []pathSegment, val reflect.Value) error {
+	if len(path) == 0 {
+		if conv, ok := convertValue(val, v.Type()); ok && v.CanSet() {
+			v.Set(conv)
+			return nil
+		}
+	}
+
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			if !v.CanSet() {
+				return errNilPath
+			}
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		v = v.Elem()
+	}
+
+	if len(path) == 0 {
+		conv, ok := convertValue(val, v.Type())
+		if !ok {
+			return errPathType
+		}
+		if !v.CanSet() {
+			return errInvalidPath
+		}
+		v.Set(conv)
+		return nil
+	}
+
+	switch {
+	case v.Kind() == reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return errNilPath
+		}
+		// values held in an interface are not addressable, so we update a copy
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		if err := setPathValue(elem, path, val); err != nil {
+			return err
+		}
+		v.Set(elem)
+		return nil
+	case v.Kind() == reflect.Map:
+		key, err := mapKey(v.Type().Key(), path[0].key)
+		if err != nil {
+			return err
+		}
+		old := v.MapIndex(key)
+		if !old.IsValid() && len(path) > 1 {
+			return errKeyNotFound
+		}
+
+		// map values are not addressable, so we update a copy and put it back in the map
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if old.IsValid() {
+			elem.Set(old)
+		}
+		if err := setPathValue(elem, path[1:], val); err != nil {
+			return err
+		}
+		if v.IsNil() {
+			if !v.CanSet() {
+				return errNilPath
+			}
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	}
+
+	child, err := pathChild(v, path[0])
+	if err != nil {
+		return err
+	}
+	return setPathValue(child, path[1:], val)
+}
+
+func indirectValue(v reflect.Value) (reflect.Value, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return v, errNilPath
+		}
+		v = v.Elem()
+	}
+	return v, nil
+}
+
+func mapKey(t reflect.Type, key string) (reflect.Value, error) {
+	k := reflect.New(t).Elem()
+	switch kindClass(t.Kind()) {
+	case reflect.String:
+		k.SetString(key)
+	case reflect.Int:
+		i, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return k, errInvalidPath
+		}
+		if t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uintptr {
+			k.SetUint(uint64(i))
+		} else {
+			k.SetInt(i)
+		}
+	default:
+		return k, errInvalidPath
+	}
+	return k, nil
+}
+
+// convertValue returns the value as the type requested if it can be assigned or is of the same kind.
+func convertValue(v reflect.Value, t reflect.Type) (reflect.Value, bool) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		switch t.Kind() {
+		case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map:
+			return reflect.Zero(t), true
+		}
+		return v, false
+	}
+
+	if v.Type().AssignableTo(t) {
+		return v, true
+	}
+	kind := kindClass(v.Kind())
+	if kind != reflect.Invalid && kind == kindClass(t.Kind()) {
+		return v.Convert(t), true
+	}
+	if kind == reflect.Int && kindClass(t.Kind()) == reflect.Float64 { // whole numbers can be used as floats
+		return v.Convert(t), true
+	}
+	return v, false
+}
+
+// kindClass groups kinds that can be converted between without changing their meaning.
+func kindClass(k reflect.Kind) reflect.Kind {
+	switch k {
+	case reflect.Bool, reflect.String:
+		return k
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return reflect.Int
+	case reflect.Float32, reflect.Float64:
+		return reflect.Float64
+	}
+	return reflect.Invalid
+}
+
+// deepCopy returns a copy of the value that shares no slices, maps or pointers with the original,
+// so that it can be compared with the value after a change.
+func deepCopy(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Invalid:
+		return v
+	case reflect.Ptr:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.New(v.Type().Elem())
+		c.Elem().Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem()))
+		return c
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < c.NumField(); i++ {
+			if c.Field(i).CanSet() {
+				c.Field(i).Set(deepCopy(v.Field(i)))
+			}
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i)))
+		}
+		return c
+	case reflect.Map:
+		if v.IsNil() {
+			return reflect.Zero(v.Type())
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return c
+	}
+
+	// copy so that the result does not refer to an addressable field
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+	return c
+}
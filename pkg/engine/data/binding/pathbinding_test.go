@@ -0,0 +1,270 @@
+package binding
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testLineItem struct {
+	Name     string
+	Price    float64
+	Quantity int8
+}
+
+type testOrder struct {
+	ID    int
+	Items []testLineItem
+	Notes map[string]string
+	Ship  *testAddress
+}
+
+type testAddress struct {
+	City string
+}
+
+type testConfig struct {
+	Order    testOrder
+	Settings map[string]testAddress
+	Enabled  bool
+
+	hidden string
+}
+
+func newTestConfig() *testConfig {
+	return &testConfig{
+		Order: testOrder{
+			ID: 7,
+			Items: []testLineItem{
+				{Name: "Pen", Price: 1.5, Quantity: 3},
+				{Name: "Ink", Price: 4, Quantity: 1},
+				{Name: "Pad", Price: 2.25, Quantity: 2},
+			},
+			Notes: map[string]string{"gift": "yes"},
+		},
+		Settings: map[string]testAddress{"home": {City: "Leeds"}},
+	}
+}
+
+func TestBindDeepStruct_GetItem(t *testing.T) {
+	c := newTestConfig()
+	s := BindDeepStruct(c)
+
+	price, err := s.GetItem("Order.Items[2].Price")
+	assert.Nil(t, err)
+	v, err := price.(Float).Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 2.25, v)
+
+	qty, err := s.GetItem("Order.Items[0].Quantity")
+	assert.Nil(t, err)
+	q, err := qty.(Int).Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, q)
+
+	note, err := s.GetString(`Order.Notes["gift"]`)
+	assert.Nil(t, err)
+	n, err := note.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "yes", n)
+
+	_, err = s.GetItem("Order.Items[3].Price")
+	assert.Equal(t, errOutOfBounds, err)
+	_, err = s.GetItem("Order.Missing")
+	assert.Equal(t, errKeyNotFound, err)
+	_, err = s.GetItem("hidden")
+	assert.Equal(t, errKeyNotFound, err)
+	_, err = s.GetItem("Order..ID")
+	assert.Equal(t, errInvalidPath, err)
+	_, err = s.GetItem("Order.Ship.City")
+	assert.Equal(t, errNilPath, err)
+	_, err = s.GetInt("Order.Items[0].Name")
+	assert.Equal(t, errPathType, err)
+}
+
+func TestBindDeepStruct_Set(t *testing.T) {
+	c := newTestConfig()
+	s := BindDeepStruct(c)
+
+	price, err := s.GetFloat("Order.Items[1].Price")
+	assert.Nil(t, err)
+	called := false
+	price.AddListener(NewDataListener(func() {
+		called = true
+	}))
+	waitForItems()
+	called = false
+
+	err = price.Set(5.5)
+	assert.Nil(t, err)
+	waitForItems()
+	assert.True(t, called)
+	assert.Equal(t, 5.5, c.Order.Items[1].Price)
+
+	city, err := PathItem[string](s, `Settings["home"].City`)
+	assert.Nil(t, err)
+	err = city.Set("York")
+	assert.Nil(t, err)
+	assert.Equal(t, "York", c.Settings["home"].City)
+
+	err = s.SetValue("Order.Ship.City", "Hull")
+	assert.Nil(t, err)
+	assert.Equal(t, "Hull", c.Order.Ship.City)
+
+	err = s.SetValue("Order.Notes[wrap]", "no")
+	assert.Nil(t, err)
+	assert.Equal(t, "no", c.Order.Notes["wrap"])
+
+	err = s.SetValue("Order.ID", "seven")
+	assert.Equal(t, errPathType, err)
+	assert.Equal(t, 7, c.Order.ID)
+}
+
+func TestBindDeepStruct_Propagation(t *testing.T) {
+	c := newTestConfig()
+	s := BindDeepStruct(c)
+
+	line, err := s.GetItem("Order.Items[0]")
+	assert.Nil(t, err)
+	name, err := s.GetString("Order.Items[0].Name")
+	assert.Nil(t, err)
+
+	rootCalls, lineCalls, nameCalls := 0, 0, 0
+	s.AddListener(NewDataListener(func() {
+		rootCalls++
+	}))
+	line.AddListener(NewDataListener(func() {
+		lineCalls++
+	}))
+	name.AddListener(NewDataListener(func() {
+		nameCalls++
+	}))
+	waitForItems()
+
+	err = name.Set("Pencil")
+	assert.Nil(t, err)
+	waitForItems()
+	assert.Equal(t, 2, rootCalls)
+	assert.Equal(t, 2, lineCalls)
+	assert.Equal(t, 2, nameCalls)
+
+	err = line.(Untyped).Set(testLineItem{Name: "Brush", Price: 3})
+	assert.Nil(t, err)
+	waitForItems()
+	assert.Equal(t, 3, lineCalls)
+	assert.Equal(t, 3, nameCalls)
+	v, err := name.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "Brush", v)
+
+	err = name.Set("Brush") // no change
+	assert.Nil(t, err)
+	waitForItems()
+	assert.Equal(t, 3, rootCalls)
+	assert.Equal(t, 3, nameCalls)
+}
+
+func TestBindDeepStruct_Reload(t *testing.T) {
+	c := newTestConfig()
+	s := BindDeepStruct(c)
+
+	first, err := s.GetFloat("Order.Items[0].Price")
+	assert.Nil(t, err)
+	last, err := s.GetFloat("Order.Items[2].Price")
+	assert.Nil(t, err)
+
+	firstCalls, lastCalls := 0, 0
+	first.AddListener(NewDataListener(func() {
+		firstCalls++
+	}))
+	last.AddListener(NewDataListener(func() {
+		lastCalls++
+	}))
+	waitForItems()
+
+	c.Order.Items[2].Price = 9
+	s.Reload()
+	waitForItems()
+	assert.Equal(t, 1, firstCalls)
+	assert.Equal(t, 2, lastCalls)
+
+	c.Order.Items = c.Order.Items[:2]
+	s.Reload()
+	waitForItems()
+	assert.Equal(t, 1, firstCalls)
+	assert.Equal(t, 3, lastCalls)
+	_, err = last.Get()
+	assert.Equal(t, errOutOfBounds, err)
+
+	s.Reload()
+	waitForItems()
+	assert.Equal(t, 3, lastCalls)
+}
+
+func TestBindDeepStruct_GetList(t *testing.T) {
+	c := newTestConfig()
+	s := BindDeepStruct(c)
+
+	list, err := s.GetList("Order.Items")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, list.Length())
+
+	item, err := list.GetItem(1)
+	assert.Nil(t, err)
+	v, err := item.(Untyped).Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "Ink", v.(testLineItem).Name)
+
+	calls := 0
+	list.AddListener(NewDataListener(func() {
+		calls++
+	}))
+	waitForItems()
+	c.Order.Items = append(c.Order.Items, testLineItem{Name: "Tape"})
+	s.Reload()
+	waitForItems()
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 4, list.Length())
+
+	_, err = list.GetItem(4)
+	assert.Equal(t, errOutOfBounds, err)
+	_, err = s.GetList("Order.ID")
+	assert.Equal(t, errPathType, err)
+}
+
+func TestParsePath(t *testing.T) {
+	path, err := parsePath(`Order.Items[2].Price`)
+	assert.Nil(t, err)
+	assert.Equal(t, []pathSegment{{key: "Order"}, {key: "Items"}, {key: "2", index: true}, {key: "Price"}}, path)
+	assert.Equal(t, "Order.Items[2].Price", formatPath(path))
+
+	path, err = parsePath(`Settings["a]b"][ 3 ]`)
+	assert.Nil(t, err)
+	assert.Equal(t, []pathSegment{{key: "Settings"}, {key: "a]b", index: true}, {key: "3", index: true}}, path)
+	assert.Equal(t, `Settings["a]b"][3]`, formatPath(path))
+
+	for _, invalid := range []string{".Order", "Order.", "Order[", "Order[]", `Order["a"`, "Order-ID"} {
+		_, err = parsePath(invalid)
+		assert.Equal(t, errInvalidPath, err, invalid)
+	}
+}
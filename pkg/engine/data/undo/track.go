@@ -0,0 +1,84 @@
+package undo
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"reflect"
+
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+)
+
+// Track returns a binding that records each value set through it in the undo history of the manager.
+// Bind widgets to the returned binding so that user edits can be undone, changes made directly to the
+// original binding are not recorded. Changes that follow quickly after each other, such as typing,
+// are combined into a single step.
+//
+// Since: 2.3
+func Track[T any](m *Manager, item binding.Item[T]) binding.Item[T] {
+	return &trackedItem[T]{Item: item, manager: m}
+}
+
+type trackedItem[T any] struct {
+	binding.Item[T]
+
+	manager *Manager
+}
+
+func (t *trackedItem[T]) Set(val T) error {
+	old, err := t.Item.Get()
+	if err != nil {
+		return err
+	}
+	if reflect.DeepEqual(old, val) {
+		return nil
+	}
+	if err = t.Item.Set(val); err != nil {
+		return err
+	}
+
+	t.manager.lock.Lock()
+	defer t.manager.lock.Unlock()
+	t.manager.record(&setCommand[T]{item: t.Item, old: old, new: val}, t)
+	return nil
+}
+
+type setCommand[T any] struct {
+	item     binding.Item[T]
+	old, new T
+}
+
+func (s *setCommand[T]) Do() {
+	s.item.Set(s.new)
+}
+
+func (s *setCommand[T]) Undo() {
+	s.item.Set(s.old)
+}
+
+func (s *setCommand[T]) merge(c Command) bool {
+	next, ok := c.(*setCommand[T])
+	if !ok || next.item != s.item {
+		return false
+	}
+
+	s.new = next.new
+	return true
+}
@@ -0,0 +1,93 @@
+package undo
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrack(t *testing.T) {
+	m := NewManager()
+	data := binding.NewString()
+	tracked := Track(m, data)
+
+	tracked.Set("one")
+	other := Track[int](m, binding.NewInt())
+	other.Set(5) // a different binding starts a new step
+	tracked.Set("two")
+
+	assert.True(t, m.Undo())
+	v, _ := data.Get()
+	assert.Equal(t, "one", v)
+
+	assert.True(t, m.Undo())
+	i, _ := other.Get()
+	assert.Equal(t, 0, i)
+
+	assert.True(t, m.Undo())
+	v, _ = data.Get()
+	assert.Equal(t, "", v)
+	assert.False(t, m.Undo())
+
+	assert.True(t, m.Redo())
+	v, _ = tracked.Get()
+	assert.Equal(t, "one", v)
+}
+
+func TestTrack_Merge(t *testing.T) {
+	m := NewManager()
+	data := binding.NewString()
+	tracked := Track(m, data)
+
+	tracked.Set("h")
+	tracked.Set("he")
+	tracked.Set("hey")
+	tracked.Set("hey") // no change
+
+	assert.True(t, m.Undo())
+	v, _ := data.Get()
+	assert.Equal(t, "", v)
+	assert.False(t, m.Undo())
+
+	assert.True(t, m.Redo())
+	v, _ = data.Get()
+	assert.Equal(t, "hey", v)
+}
+
+func TestTrack_Untracked(t *testing.T) {
+	m := NewManager()
+	data := binding.NewFloat()
+	tracked := Track(m, data)
+
+	data.Set(0.5) // changes to the original binding are not recorded
+	assert.False(t, m.Undo())
+
+	m.Begin()
+	tracked.Set(1)
+	tracked.Set(2)
+	m.End()
+	m.Undo()
+	v, _ := tracked.Get()
+	assert.Equal(t, 0.5, v)
+}
@@ -0,0 +1,269 @@
+// Package undo provides an undo and redo history for changes to data and bindings
+package undo
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"sync"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+)
+
+// mergeInterval is how close together changes to the same tracked binding must be to become a single step.
+const mergeInterval = time.Second
+
+// Command is a change that can be undone and then redone.
+//
+// Since: 2.3
+type Command interface {
+	// Do applies the change, it is called when a command is executed and each time it is redone.
+	Do()
+	// Undo reverts the change.
+	Undo()
+}
+
+// NewCommand returns a Command that calls the do and undo functions passed.
+//
+// Since: 2.3
+func NewCommand(do, undo func()) Command {
+	return &funcCommand{do: do, undo: undo}
+}
+
+// Manager records a history of changes so that they can be undone and redone.
+// Changes can be recorded as Command values or by tracking bindings, see Track.
+// All methods are safe to call from any goroutine.
+//
+// Since: 2.3
+type Manager struct {
+	// Limit is the maximum number of steps that can be undone, 0 means there is no limit.
+	Limit int
+
+	lock        sync.Mutex
+	undos       []*step
+	redos       []*step
+	transaction *step
+	depth       int
+
+	undoable, redoable binding.Bool
+	canUndo, canRedo   binding.Bool // read only views of undoable and redoable
+}
+
+// NewManager creates a new, empty, undo history.
+//
+// Since: 2.3
+func NewManager() *Manager {
+	m := &Manager{undoable: binding.NewBool(), redoable: binding.NewBool()}
+	m.canUndo = binding.MapItem(m.undoable, func(b bool) bool { return b })
+	m.canRedo = binding.MapItem(m.redoable, func(b bool) bool { return b })
+	return m
+}
+
+// AddShortcuts registers the undo and redo shortcuts with the canvas so that they call Undo and Redo.
+// Focused widgets that handle shortcuts themselves, such as entries, will receive the shortcuts instead.
+func (m *Manager) AddShortcuts(c gui.Canvas) {
+	c.AddShortcut(&gui.ShortcutUndo{}, func(gui.Shortcut) {
+		m.Undo()
+	})
+	c.AddShortcut(&gui.ShortcutRedo{}, func(gui.Shortcut) {
+		m.Redo()
+	})
+}
+
+// Begin starts a transaction, all changes recorded until the matching call to End are grouped together
+// so that they are undone and redone as a single step. Transactions may be nested.
+func (m *Manager) Begin() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.depth == 0 {
+		m.transaction = &step{}
+	}
+	m.depth++
+}
+
+// CanRedo returns a binding that reports if there are any changes that can be redone.
+// This is useful for setting the enabled state of a redo button.
+func (m *Manager) CanRedo() binding.Bool {
+	return m.canRedo
+}
+
+// CanUndo returns a binding that reports if there are any changes that can be undone.
+// This is useful for setting the enabled state of an undo button.
+func (m *Manager) CanUndo() binding.Bool {
+	return m.canUndo
+}
+
+// Clear removes all changes from the history.
+func (m *Manager) Clear() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.undos, m.redos = nil, nil
+	m.updateState()
+}
+
+// End finishes a transaction that was started with Begin.
+func (m *Manager) End() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.depth == 0 {
+		return
+	}
+	m.depth--
+	if m.depth > 0 {
+		return
+	}
+
+	t := m.transaction
+	m.transaction = nil
+	if len(t.commands) > 0 {
+		m.push(t)
+	}
+}
+
+// Execute applies the command and records it so that it can be undone.
+func (m *Manager) Execute(c Command) {
+	c.Do()
+	m.Record(c)
+}
+
+// HandleShortcut calls Undo or Redo if the shortcut is an undo or redo shortcut.
+// It returns true if the shortcut was handled. This allows widgets that handle shortcuts to pass them on.
+func (m *Manager) HandleShortcut(s gui.Shortcut) bool {
+	switch s.(type) {
+	case *gui.ShortcutUndo:
+		m.Undo()
+	case *gui.ShortcutRedo:
+		m.Redo()
+	default:
+		return false
+	}
+	return true
+}
+
+// Record adds a command that has already been applied to the history, so that it can be undone.
+func (m *Manager) Record(c Command) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.record(c, nil)
+}
+
+// Redo applies the most recently undone step again. It returns false if there is nothing to redo.
+func (m *Manager) Redo() bool {
+	m.lock.Lock()
+	if m.depth > 0 || len(m.redos) == 0 {
+		m.lock.Unlock()
+		return false
+	}
+	s := m.redos[len(m.redos)-1]
+	m.redos = m.redos[:len(m.redos)-1]
+	m.undos = append(m.undos, s)
+	m.updateState()
+	m.lock.Unlock()
+
+	for _, c := range s.commands {
+		c.Do()
+	}
+	return true
+}
+
+// Undo reverts the most recent step. It returns false if there is nothing to undo.
+func (m *Manager) Undo() bool {
+	m.lock.Lock()
+	if m.depth > 0 || len(m.undos) == 0 {
+		m.lock.Unlock()
+		return false
+	}
+	s := m.undos[len(m.undos)-1]
+	m.undos = m.undos[:len(m.undos)-1]
+	m.redos = append(m.redos, s)
+	m.updateState()
+	m.lock.Unlock()
+
+	for i := len(s.commands) - 1; i >= 0; i-- {
+		s.commands[i].Undo()
+	}
+	return true
+}
+
+func (m *Manager) push(s *step) {
+	m.undos = append(m.undos, s)
+	if m.Limit > 0 && len(m.undos) > m.Limit {
+		m.undos = m.undos[len(m.undos)-m.Limit:]
+	}
+	m.redos = nil
+	m.updateState()
+}
+
+// record adds a command, merging it with the last step if it changes the same source shortly after.
+// The lock must be held when calling this.
+func (m *Manager) record(c Command, source interface{}) {
+	if m.transaction != nil {
+		m.transaction.commands = append(m.transaction.commands, c)
+		return
+	}
+
+	now := time.Now()
+	if last := len(m.undos) - 1; source != nil && last >= 0 && len(m.redos) == 0 {
+		s := m.undos[last]
+		if s.source == source && now.Sub(s.time) < mergeInterval {
+			if merge, ok := s.commands[0].(merger); ok && merge.merge(c) {
+				s.time = now
+				return
+			}
+		}
+	}
+
+	m.push(&step{commands: []Command{c}, source: source, time: now})
+}
+
+func (m *Manager) updateState() {
+	m.undoable.Set(len(m.undos) > 0)
+	m.redoable.Set(len(m.redos) > 0)
+}
+
+type funcCommand struct {
+	do, undo func()
+}
+
+func (f *funcCommand) Do() {
+	f.do()
+}
+
+func (f *funcCommand) Undo() {
+	f.undo()
+}
+
+// merger is a command that can absorb a following change to the same source.
+type merger interface {
+	merge(Command) bool
+}
+
+// step is a group of commands that are undone and redone together.
+type step struct {
+	commands []Command
+	source   interface{}
+	time     time.Time
+}
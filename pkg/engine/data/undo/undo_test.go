@@ -0,0 +1,158 @@
+package undo
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func waitForBinding() {
+	time.Sleep(time.Millisecond * 100) // data resolves on background thread
+}
+
+func TestManager_Execute(t *testing.T) {
+	m := NewManager()
+	total := 0
+	add := func(n int) Command {
+		return NewCommand(func() { total += n }, func() { total -= n })
+	}
+
+	m.Execute(add(1))
+	m.Execute(add(2))
+	assert.Equal(t, 3, total)
+
+	assert.True(t, m.Undo())
+	assert.Equal(t, 1, total)
+	assert.True(t, m.Undo())
+	assert.Equal(t, 0, total)
+	assert.False(t, m.Undo())
+
+	assert.True(t, m.Redo())
+	assert.Equal(t, 1, total)
+
+	m.Execute(add(5)) // a new change removes the redo history
+	assert.Equal(t, 6, total)
+	assert.False(t, m.Redo())
+}
+
+func TestManager_Record(t *testing.T) {
+	m := NewManager()
+	name := "new"
+	m.Record(NewCommand(func() { name = "new" }, func() { name = "old" }))
+	assert.Equal(t, "new", name)
+
+	m.Undo()
+	assert.Equal(t, "old", name)
+	m.Redo()
+	assert.Equal(t, "new", name)
+}
+
+func TestManager_Transaction(t *testing.T) {
+	m := NewManager()
+	var order []string
+	cmd := func(name string) Command {
+		return NewCommand(func() { order = append(order, "do "+name) }, func() { order = append(order, "undo "+name) })
+	}
+
+	m.Begin()
+	m.Execute(cmd("a"))
+	m.Begin()
+	m.Execute(cmd("b"))
+	m.End()
+	assert.False(t, m.Undo()) // transaction still open
+	m.Execute(cmd("c"))
+	m.End()
+
+	order = nil
+	assert.True(t, m.Undo())
+	assert.Equal(t, []string{"undo c", "undo b", "undo a"}, order)
+	assert.False(t, m.Undo())
+
+	order = nil
+	assert.True(t, m.Redo())
+	assert.Equal(t, []string{"do a", "do b", "do c"}, order)
+
+	m.Begin()
+	m.End()
+	assert.True(t, m.Undo())
+	assert.False(t, m.Undo()) // empty transaction was not recorded
+}
+
+func TestManager_Limit(t *testing.T) {
+	m := NewManager()
+	m.Limit = 2
+	count := 0
+	for i := 0; i < 3; i++ {
+		m.Execute(NewCommand(func() { count++ }, func() { count-- }))
+	}
+
+	assert.True(t, m.Undo())
+	assert.True(t, m.Undo())
+	assert.False(t, m.Undo())
+	assert.Equal(t, 1, count)
+}
+
+func TestManager_CanUndoRedo(t *testing.T) {
+	m := NewManager()
+	canUndo, canRedo := m.CanUndo(), m.CanRedo()
+	assert.NotNil(t, canUndo.Set(true)) // read only
+
+	m.Execute(NewCommand(func() {}, func() {}))
+	waitForBinding()
+	undo, _ := canUndo.Get()
+	redo, _ := canRedo.Get()
+	assert.True(t, undo)
+	assert.False(t, redo)
+
+	m.Undo()
+	waitForBinding()
+	undo, _ = canUndo.Get()
+	redo, _ = canRedo.Get()
+	assert.False(t, undo)
+	assert.True(t, redo)
+
+	m.Clear()
+	waitForBinding()
+	redo, _ = canRedo.Get()
+	assert.False(t, redo)
+}
+
+func TestManager_Shortcuts(t *testing.T) {
+	m := NewManager()
+	value := 1
+	m.Execute(NewCommand(func() { value = 2 }, func() { value = 1 }))
+
+	c := test.NewCanvas()
+	m.AddShortcuts(c)
+	c.(gui.Shortcutable).TypedShortcut(&gui.ShortcutUndo{})
+	assert.Equal(t, 1, value)
+	c.(gui.Shortcutable).TypedShortcut(&gui.ShortcutRedo{})
+	assert.Equal(t, 2, value)
+
+	assert.True(t, m.HandleShortcut(&gui.ShortcutUndo{}))
+	assert.Equal(t, 1, value)
+	assert.False(t, m.HandleShortcut(&gui.ShortcutSelectAll{}))
+}
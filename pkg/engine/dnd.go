@@ -0,0 +1,66 @@
+package engine
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// DragData is the payload carried by a drag and drop operation.
+//
+// Since: 2.3
+type DragData struct {
+	// Type names the kind of value being dragged so that drop targets can decide whether to accept it.
+	Type string
+	// Value is the content being dragged.
+	Value interface{}
+	// Source is the object the drag started from, it is set when the drag starts.
+	Source CanvasObject
+}
+
+// DragSource describes any CanvasObject that can start a drag and drop operation.
+// It should also be Draggable so that the driver delivers the pointer movements that carry the payload.
+//
+// Since: 2.3
+type DragSource interface {
+	// DragData returns the payload for a drag starting at the given position within the object.
+	// Returning nil means that no drag should start.
+	DragData(pos Position) *DragData
+	// DragFinished is called once the drag has ended, dropped reports whether a target accepted the payload.
+	DragFinished(data *DragData, dropped bool)
+}
+
+// DragPreviewer is an optional extension of DragSource that provides the object shown under the
+// pointer while dragging. Without it a plain highlight the size of the source is shown.
+//
+// Since: 2.3
+type DragPreviewer interface {
+	DragPreview(data *DragData) CanvasObject
+}
+
+// DropTarget describes any CanvasObject that can accept a drag and drop payload.
+// The positions passed are relative to the target.
+//
+// Since: 2.3
+type DropTarget interface {
+	// DragOver is called as a drag moves over the target and returns true if the payload could be dropped there.
+	DragOver(data *DragData, pos Position) bool
+	// DragLeave is called when a drag that the target accepted moves away or ends without dropping on it.
+	DragLeave()
+	// Drop is called when a payload accepted by DragOver is released over the target.
+	Drop(data *DragData, pos Position)
+}
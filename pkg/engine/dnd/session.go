@@ -0,0 +1,204 @@
+// Package dnd provides drag and drop sessions that carry a gui.DragData payload from a
+// gui.DragSource to a gui.DropTarget on the same canvas.
+//
+// Since: 2.3
+package dnd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/internal/driver"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+// Session is a drag and drop operation in progress on a canvas.
+// While it is active a preview of the payload is shown on the overlay stack and follows the pointer.
+//
+// Since: 2.3
+type Session struct {
+	canvas  gui.Canvas
+	data    *gui.DragData
+	source  gui.DragSource
+	preview gui.CanvasObject
+
+	origin gui.Position // absolute position of the preview when the pointer is at start
+	start  gui.Position // absolute position of the pointer when the drag started
+
+	target    gui.DropTarget
+	targetPos gui.Position // absolute position of the current target
+	pointer   gui.Position
+	finished  bool
+}
+
+// Start begins dragging the payload of source, which must be a gui.DragSource, from the absolute
+// position pos on the canvas c. It returns nil if the source does not provide a payload.
+//
+// Since: 2.3
+func Start(c gui.Canvas, source gui.CanvasObject, pos gui.Position) *Session {
+	src, ok := source.(gui.DragSource)
+	if !ok || c == nil {
+		return nil
+	}
+
+	origin := driver.AbsolutePositionForObject(source, canvasRoots(c))
+	data := src.DragData(pos.Subtract(origin))
+	if data == nil {
+		return nil
+	}
+	data.Source = source
+
+	var preview gui.CanvasObject
+	if p, ok := source.(gui.DragPreviewer); ok {
+		preview = p.DragPreview(data)
+	}
+	if preview == nil {
+		rect := canvas.NewRectangle(theme.HoverColor())
+		rect.StrokeColor = theme.PrimaryColor()
+		rect.StrokeWidth = 1
+		preview = rect
+	}
+	preview.Resize(source.Size().Max(preview.MinSize()))
+	preview.Move(origin)
+
+	s := &Session{canvas: c, data: data, source: src, preview: preview, origin: origin, start: pos, pointer: pos}
+	c.Overlays().Add(preview)
+	s.updateTarget()
+	return s
+}
+
+// Data returns the payload that is being dragged.
+func (s *Session) Data() *gui.DragData {
+	return s.data
+}
+
+// Preview returns the object shown under the pointer on the overlay stack.
+func (s *Session) Preview() gui.CanvasObject {
+	return s.preview
+}
+
+// Target returns the drop target that accepted the payload at the current pointer position, if any.
+func (s *Session) Target() gui.DropTarget {
+	return s.target
+}
+
+// Move updates the session for the pointer being at the absolute position pos.
+// The preview follows the pointer and the drop target under it is asked whether it accepts the payload.
+func (s *Session) Move(pos gui.Position) {
+	if s.finished {
+		return
+	}
+
+	s.pointer = pos
+	s.preview.Move(s.origin.Add(pos.Subtract(s.start)))
+	canvas.Refresh(s.preview)
+	s.updateTarget()
+}
+
+// Drop releases the payload at the current pointer position and ends the session.
+// It returns true if a drop target accepted the payload.
+func (s *Session) Drop() bool {
+	if s.finished {
+		return false
+	}
+
+	s.finish()
+	target := s.target
+	if target != nil {
+		target.Drop(s.data, s.pointer.Subtract(s.targetPos))
+	}
+	s.source.DragFinished(s.data, target != nil)
+	return target != nil
+}
+
+// Cancel ends the session without dropping the payload.
+func (s *Session) Cancel() {
+	if s.finished {
+		return
+	}
+
+	s.finish()
+	if s.target != nil {
+		s.target.DragLeave()
+		s.target = nil
+	}
+	s.source.DragFinished(s.data, false)
+}
+
+func (s *Session) finish() {
+	s.finished = true
+	s.canvas.Overlays().Remove(s.preview)
+}
+
+// updateTarget finds the innermost drop target under the pointer that accepts the payload.
+func (s *Session) updateTarget() {
+	var found gui.DropTarget
+	var foundPos gui.Position
+	candidates, positions := s.targetsAt(s.pointer)
+	for i := len(candidates) - 1; i >= 0; i-- {
+		if candidates[i].DragOver(s.data, s.pointer.Subtract(positions[i])) {
+			found = candidates[i]
+			foundPos = positions[i]
+			break
+		}
+	}
+
+	if s.target != nil && s.target != found {
+		s.target.DragLeave()
+	}
+	s.target = found
+	s.targetPos = foundPos
+}
+
+// targetsAt returns the drop targets under the absolute position pos from the outermost to the innermost,
+// together with their absolute positions. Only the top-most interactive layer below the preview is searched.
+func (s *Session) targetsAt(pos gui.Position) (targets []gui.DropTarget, positions []gui.Position) {
+	root := s.canvas.Content()
+	for _, o := range s.canvas.Overlays().List() {
+		if o != s.preview {
+			root = o
+		}
+	}
+	if root == nil {
+		return nil, nil
+	}
+
+	driver.WalkVisibleObjectTree(root, func(o gui.CanvasObject, p, clipPos gui.Position, clipSize gui.Size) bool {
+		if !within(pos, clipPos, clipSize) || !within(pos, p, o.Size()) {
+			return false
+		}
+		if t, ok := o.(gui.DropTarget); ok {
+			targets = append(targets, t)
+			positions = append(positions, p)
+		}
+		return false
+	}, nil)
+	return targets, positions
+}
+
+func canvasRoots(c gui.Canvas) []gui.CanvasObject {
+	return append([]gui.CanvasObject{c.Content()}, c.Overlays().List()...)
+}
+
+func within(pos, origin gui.Position, size gui.Size) bool {
+	return pos.X >= origin.X && pos.Y >= origin.Y && pos.X < origin.X+size.Width && pos.Y < origin.Y+size.Height
+}
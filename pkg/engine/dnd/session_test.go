@@ -0,0 +1,191 @@
+package dnd_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"image/color"
+	"testing"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/dnd"
+	"github.com/bhojpur/gui/pkg/engine/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDragAndDrop(t *testing.T) {
+	w, src, target := setupDragAndDrop(t)
+	defer w.Close()
+
+	test.DragAndDrop(w.Canvas(), gui.NewPos(10, 10), gui.NewPos(110, 20))
+	assert.True(t, src.finished)
+	assert.True(t, src.dropped)
+	assert.Equal(t, "color", target.data.Type)
+	assert.Equal(t, color.Black, target.data.Value)
+	assert.Equal(t, src, target.data.Source)
+	assert.Equal(t, gui.NewPos(10, 20), target.dropPos)
+	assert.Empty(t, w.Canvas().Overlays().List())
+
+	src.finished, target.data = false, nil
+	test.DragAndDrop(w.Canvas(), gui.NewPos(10, 10), gui.NewPos(10, 80))
+	assert.True(t, src.finished)
+	assert.False(t, src.dropped)
+	assert.Nil(t, target.data)
+
+	target.accept = false
+	test.DragAndDrop(w.Canvas(), gui.NewPos(10, 10), gui.NewPos(110, 20))
+	assert.False(t, src.dropped)
+	assert.Nil(t, target.data)
+}
+
+func TestSession(t *testing.T) {
+	w, src, target := setupDragAndDrop(t)
+	defer w.Close()
+	c := w.Canvas()
+
+	assert.Nil(t, dnd.Start(c, canvas.NewRectangle(color.White), gui.NewPos(10, 10)))
+
+	s := dnd.Start(c, src, gui.NewPos(10, 10))
+	assert.NotNil(t, s)
+	assert.Equal(t, []gui.CanvasObject{s.Preview()}, c.Overlays().List())
+	assert.Equal(t, gui.NewPos(0, 0), s.Preview().Position())
+	assert.Equal(t, gui.NewSize(50, 50), s.Preview().Size())
+	assert.Nil(t, s.Target())
+
+	s.Move(gui.NewPos(120, 30))
+	assert.Equal(t, gui.NewPos(110, 20), s.Preview().Position())
+	assert.Equal(t, target, s.Target())
+	assert.Equal(t, []gui.Position{gui.NewPos(20, 30)}, target.over)
+
+	s.Move(gui.NewPos(60, 30))
+	assert.Nil(t, s.Target())
+	assert.Equal(t, 1, target.left)
+
+	s.Move(gui.NewPos(120, 30))
+	s.Cancel()
+	assert.Equal(t, 2, target.left)
+	assert.Nil(t, target.data)
+	assert.True(t, src.finished)
+	assert.False(t, src.dropped)
+	assert.Empty(t, c.Overlays().List())
+	assert.False(t, s.Drop())
+}
+
+func TestSession_NestedTargets(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	src := newSource()
+	outer := newTarget()
+	outer.Resize(gui.NewSize(100, 100))
+	outer.Move(gui.NewPos(100, 0))
+	inner := newTarget()
+	inner.Resize(gui.NewSize(50, 50))
+	inner.Move(gui.NewPos(110, 10))
+	w := test.NewWindow(gui.NewContainerWithoutLayout(src, outer, inner))
+	defer w.Close()
+	w.SetPadded(false)
+	w.Resize(gui.NewSize(200, 100))
+
+	s := dnd.Start(w.Canvas(), src, gui.NewPos(10, 10))
+	s.Move(gui.NewPos(120, 20))
+	assert.Equal(t, inner, s.Target())
+
+	inner.accept = false
+	s.Move(gui.NewPos(121, 20))
+	assert.Equal(t, outer, s.Target())
+	assert.Equal(t, 1, inner.left)
+
+	assert.True(t, s.Drop())
+	assert.Equal(t, gui.NewPos(21, 20), outer.dropPos)
+	assert.Nil(t, inner.data)
+}
+
+func setupDragAndDrop(t *testing.T) (gui.Window, *source, *target) {
+	test.NewApp()
+	t.Cleanup(func() { test.NewApp() })
+
+	src := newSource()
+	dst := newTarget()
+	dst.Resize(gui.NewSize(50, 50))
+	dst.Move(gui.NewPos(100, 0))
+	w := test.NewWindow(gui.NewContainerWithoutLayout(src, dst))
+	w.SetPadded(false)
+	w.Resize(gui.NewSize(200, 100))
+	return w, src, dst
+}
+
+type source struct {
+	*canvas.Rectangle
+	tracker           dnd.Tracker
+	finished, dropped bool
+}
+
+func newSource() *source {
+	s := &source{Rectangle: canvas.NewRectangle(color.White)}
+	s.Resize(gui.NewSize(50, 50))
+	return s
+}
+
+func (s *source) DragData(gui.Position) *gui.DragData {
+	return &gui.DragData{Type: "color", Value: color.Black}
+}
+
+func (s *source) DragFinished(_ *gui.DragData, dropped bool) {
+	s.finished = true
+	s.dropped = dropped
+}
+
+func (s *source) DragEnd() {
+	s.tracker.DragEnd()
+}
+
+func (s *source) Dragged(ev *gui.DragEvent) {
+	s.tracker.Dragged(s, ev)
+}
+
+type target struct {
+	*canvas.Rectangle
+	accept  bool
+	over    []gui.Position
+	left    int
+	data    *gui.DragData
+	dropPos gui.Position
+}
+
+func newTarget() *target {
+	return &target{Rectangle: canvas.NewRectangle(color.Black), accept: true}
+}
+
+func (t *target) DragLeave() {
+	t.left++
+}
+
+func (t *target) DragOver(_ *gui.DragData, pos gui.Position) bool {
+	t.over = append(t.over, pos)
+	return t.accept
+}
+
+func (t *target) Drop(data *gui.DragData, pos gui.Position) {
+	t.data = data
+	t.dropPos = pos
+}
@@ -0,0 +1,69 @@
+package dnd
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	gui "github.com/bhojpur/gui/pkg/engine"
+)
+
+// Tracker turns the gui.Draggable events received by a drag source into a drag and drop session.
+// A widget that is a gui.DragSource keeps a Tracker and forwards its Dragged and DragEnd calls to it.
+//
+// Since: 2.3
+type Tracker struct {
+	session *Session
+	started bool
+}
+
+// Active returns whether a drag and drop session is in progress.
+func (t *Tracker) Active() bool {
+	return t.session != nil
+}
+
+// Dragged starts a session for source on the first drag event and moves it on the following ones.
+func (t *Tracker) Dragged(source gui.CanvasObject, ev *gui.DragEvent) {
+	if !t.started {
+		t.started = true
+		c := gui.CurrentApp().Driver().CanvasForObject(source)
+		start := ev.AbsolutePosition.Subtract(gui.NewPos(ev.Dragged.DX, ev.Dragged.DY))
+		t.session = Start(c, source, start)
+	}
+
+	if t.session != nil {
+		t.session.Move(ev.AbsolutePosition)
+	}
+}
+
+// DragEnd drops the payload of the current session and returns true if a drop target accepted it.
+func (t *Tracker) DragEnd() bool {
+	s := t.session
+	t.session = nil
+	t.started = false
+	if s == nil {
+		return false
+	}
+	return s.Drop()
+}
+
+// Session returns the drag and drop session in progress, or nil if there is none.
+func (t *Tracker) Session() *Session {
+	return t.session
+}
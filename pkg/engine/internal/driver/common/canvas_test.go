@@ -29,6 +29,7 @@ import (
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
 	"github.com/bhojpur/gui/pkg/engine/container"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
 	"github.com/bhojpur/gui/pkg/engine/test"
 	"github.com/bhojpur/gui/pkg/engine/theme"
 	"github.com/stretchr/testify/assert"
@@ -396,6 +397,33 @@ func Prepend(c *gui.Container, object gui.CanvasObject) {
 	c.Refresh()
 }
 
+func TestCanvas_TypedShortcutUndoFallback(t *testing.T) {
+	c := &Canvas{}
+	var typed []gui.Shortcut
+	handler := func(s gui.Shortcut) {
+		typed = append(typed, s)
+	}
+	c.AddShortcut(&desktop.CustomShortcut{KeyName: gui.KeyZ, Modifier: gui.KeyModifierShortcutDefault}, handler)
+	c.AddShortcut(&desktop.CustomShortcut{KeyName: gui.KeyZ, Modifier: gui.KeyModifierShortcutDefault | gui.KeyModifierShift}, handler)
+
+	c.TypedShortcut(&gui.ShortcutUndo{})
+	c.TypedShortcut(&gui.ShortcutRedo{})
+	assert.Equal(t, []gui.Shortcut{
+		&desktop.CustomShortcut{KeyName: gui.KeyZ, Modifier: gui.KeyModifierShortcutDefault},
+		&desktop.CustomShortcut{KeyName: gui.KeyZ, Modifier: gui.KeyModifierShortcutDefault | gui.KeyModifierShift},
+	}, typed)
+
+	typed = nil
+	c.AddShortcut(&gui.ShortcutUndo{}, handler)
+	c.TypedShortcut(&gui.ShortcutUndo{})
+	assert.Equal(t, []gui.Shortcut{&gui.ShortcutUndo{}}, typed)
+
+	typed = nil
+	c.RemoveShortcut(&gui.ShortcutUndo{})
+	c.TypedShortcut(&gui.ShortcutUndo{})
+	assert.Equal(t, []gui.Shortcut{&desktop.CustomShortcut{KeyName: gui.KeyZ, Modifier: gui.KeyModifierShortcutDefault}}, typed)
+}
+
 func TestRefreshCount(t *testing.T) { // Issue 2548.
 	var (
 		c              = &Canvas{}
@@ -866,9 +866,17 @@ func (w *window) triggersShortcut(localizedKeyName gui.KeyName, key gui.KeyName,
 		case gui.KeyA:
 			// detect selectAll shortcut
 			shortcut = &gui.ShortcutSelectAll{}
+		case gui.KeyZ:
+			// detect undo shortcut
+			shortcut = &gui.ShortcutUndo{}
 		}
 	}
 
+	if modifier == ctrlMod|gui.KeyModifierShift && keyName == gui.KeyZ {
+		// detect redo shortcut
+		shortcut = &gui.ShortcutRedo{}
+	}
+
 	if modifier == gui.KeyModifierShift {
 		switch keyName {
 		case gui.KeyInsert:
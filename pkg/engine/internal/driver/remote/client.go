@@ -0,0 +1,114 @@
+package remote
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"image"
+	"sync"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"golang.org/x/net/websocket"
+)
+
+// client is a browser connected over a WebSocket. Frames are sent by a goroutine of
+// its own so that a slow connection only skips frames instead of blocking the driver.
+type client struct {
+	ws *websocket.Conn
+
+	mu     sync.Mutex
+	latest *image.NRGBA
+	notify chan struct{}
+}
+
+func newClient(ws *websocket.Conn) *client {
+	return &client{ws: ws, notify: make(chan struct{}, 1)}
+}
+
+// update replaces the frame waiting to be sent
+func (c *client) update(img *image.NRGBA) {
+	c.mu.Lock()
+	c.latest = img
+	c.mu.Unlock()
+
+	select {
+	case c.notify <- struct{}{}:
+	default:
+	}
+}
+
+// started returns true once the client was handed its first frame
+func (c *client) started() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latest != nil
+}
+
+// writeFrames sends the changes between the frames handed to update until done is closed
+func (c *client) writeFrames(done <-chan struct{}) {
+	var last *image.NRGBA
+	for {
+		select {
+		case <-c.notify:
+		case <-done:
+			return
+		}
+
+		c.mu.Lock()
+		img := c.latest
+		c.mu.Unlock()
+
+		rects := diffRects(last, img)
+		if len(rects) == 0 {
+			continue
+		}
+		msg, err := encodeFrame(img, rects)
+		if err != nil {
+			gui.LogError("Unable to encode frame", err)
+			continue
+		}
+		if err = websocket.JSON.Send(c.ws, msg); err != nil {
+			c.ws.Close()
+			return
+		}
+		last = img
+	}
+}
+
+// serveClient handles a browser connection until it is closed
+func (d *Driver) serveClient(ws *websocket.Conn) {
+	c := newClient(ws)
+	d.addClient(c)
+	defer d.removeClient(c)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	go c.writeFrames(stop)
+
+	for {
+		ev := &inputEvent{}
+		if err := websocket.JSON.Receive(ws, ev); err != nil {
+			return
+		}
+		if !d.post(ev) {
+			return
+		}
+	}
+}
@@ -0,0 +1,252 @@
+package remote
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// It provides a headless Bhojpur GUI driver which renders an application on
+// the server with the software painter. The result is shown in a web browser
+// that is connected over a WebSocket: frames are sent as changed rectangles and
+// mouse and keyboard input of the browser is fed back into the application.
+
+import (
+	"image"
+	"image/draw"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/internal/painter/software"
+	"github.com/bhojpur/gui/pkg/engine/test"
+	"golang.org/x/net/websocket"
+)
+
+// addrEnvKey names the environment variable which overrides the default listen address
+const addrEnvKey = "BHOJPUR_GUI_REMOTE_ADDR"
+
+// recordEnvKey names the environment variable with the file the input of a session is recorded to
+const recordEnvKey = "BHOJPUR_GUI_RECORD"
+
+// DefaultAddr is the address the driver listens on if nothing else is configured
+const DefaultAddr = "localhost:8070"
+
+// frameInterval is how often the window is captured to pick up changes which are
+// not caused by input, e.g. animations or a blinking cursor
+const frameInterval = 50 * time.Millisecond
+
+// Driver is a gui.Driver which serves the application to web browsers.
+// Window management is shared with the test driver, all windows are rendered in memory.
+type Driver struct {
+	gui.Driver
+
+	// Addr is the TCP address Run listens on
+	Addr string
+
+	// Recorder receives all input handled by the driver if it is set
+	Recorder *test.Recorder
+	// RecordPath is the file the recorded input script is written to on Quit
+	RecordPath string
+
+	painter *software.Painter
+
+	events chan *inputEvent
+	done   chan struct{}
+	once   sync.Once
+
+	mu      sync.Mutex
+	clients map[*client]struct{}
+
+	// input state, only accessed by the run loop
+	input inputState
+}
+
+// Declare conformity with Driver
+var _ gui.Driver = (*Driver)(nil)
+
+// NewDriver creates a new remote driver. It listens on the address in the
+// BHOJPUR_GUI_REMOTE_ADDR environment variable or on DefaultAddr. If the
+// BHOJPUR_GUI_RECORD environment variable names a file, the input of the session
+// is written to it as a test script when the driver quits.
+func NewDriver() *Driver {
+	addr := os.Getenv(addrEnvKey)
+	if addr == "" {
+		addr = DefaultAddr
+	}
+
+	painter := software.NewPainter()
+	d := &Driver{
+		Driver:  test.NewDriverWithPainter(painter),
+		Addr:    addr,
+		painter: painter,
+		events:  make(chan *inputEvent, 64),
+		done:    make(chan struct{}),
+		clients: make(map[*client]struct{}),
+	}
+	if path := os.Getenv(recordEnvKey); path != "" {
+		d.Recorder = test.NewRecorder()
+		d.RecordPath = path
+	}
+	return d
+}
+
+// Run serves the application until Quit is called
+func (d *Driver) Run() {
+	l, err := net.Listen("tcp", d.Addr)
+	if err != nil {
+		gui.LogError("Unable to start remote driver", err)
+		return
+	}
+	log.Println("Bhojpur GUI - remote application at http://" + l.Addr().String())
+
+	srv := &http.Server{Handler: d}
+	go srv.Serve(l)
+	defer srv.Close()
+
+	d.runLoop()
+}
+
+// Quit stops serving the application and closes all connections
+func (d *Driver) Quit() {
+	d.once.Do(func() {
+		close(d.done)
+	})
+}
+
+// ServeHTTP serves the page showing the application and the WebSocket it connects to
+func (d *Driver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/":
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(indexHTML))
+	case "/ws":
+		websocket.Handler(d.serveClient).ServeHTTP(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// runLoop owns the application objects: it handles all input and captures the frames
+func (d *Driver) runLoop() {
+	ticker := time.NewTicker(frameInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-d.done:
+			d.closeClients()
+			d.saveRecording()
+			return
+		case ev := <-d.events:
+			d.handleEvent(ev)
+			d.publish()
+		case <-ticker.C:
+			d.publish()
+		}
+	}
+}
+
+func (d *Driver) saveRecording() {
+	if d.Recorder == nil || d.RecordPath == "" {
+		return
+	}
+	if err := d.Recorder.Script().Save(d.RecordPath); err != nil {
+		gui.LogError("Unable to save recorded input", err)
+	}
+}
+
+// window returns the window shown to clients, which is the one created last
+func (d *Driver) window() gui.Window {
+	windows := d.AllWindows()
+	if len(windows) == 0 {
+		return nil
+	}
+	return windows[len(windows)-1]
+}
+
+// capture renders the current window, it returns nil if there is no window
+func (d *Driver) capture() *image.NRGBA {
+	w := d.window()
+	if w == nil {
+		return nil
+	}
+
+	img := w.Canvas().Capture()
+	if nrgba, ok := img.(*image.NRGBA); ok {
+		return nrgba
+	}
+	nrgba := image.NewNRGBA(img.Bounds())
+	draw.Draw(nrgba, nrgba.Bounds(), img, img.Bounds().Min, draw.Src)
+	return nrgba
+}
+
+// publish captures the window and hands the frame to all clients
+func (d *Driver) publish() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.clients) == 0 {
+		return
+	}
+
+	img := d.capture()
+	if img == nil {
+		return
+	}
+	// only areas damaged since the last capture can differ from the previous frame
+	changed := len(d.painter.Damage(d.window().Canvas())) > 0
+	for c := range d.clients {
+		if changed || !c.started() {
+			c.update(img)
+		}
+	}
+}
+
+// post queues an input event for the run loop, it returns false once the driver quit
+func (d *Driver) post(ev *inputEvent) bool {
+	select {
+	case d.events <- ev:
+		return true
+	case <-d.done:
+		return false
+	}
+}
+
+func (d *Driver) addClient(c *client) {
+	d.mu.Lock()
+	d.clients[c] = struct{}{}
+	d.mu.Unlock()
+}
+
+func (d *Driver) removeClient(c *client) {
+	d.mu.Lock()
+	delete(d.clients, c)
+	d.mu.Unlock()
+}
+
+func (d *Driver) closeClients() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for c := range d.clients {
+		c.ws.Close()
+	}
+}
@@ -0,0 +1,172 @@
+package remote
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/container"
+	"github.com/bhojpur/gui/pkg/engine/test"
+	"github.com/bhojpur/gui/pkg/engine/widget"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+func TestDiffRects(t *testing.T) {
+	prev := image.NewNRGBA(image.Rect(0, 0, 100, 70))
+	cur := image.NewNRGBA(prev.Bounds())
+
+	assert.Equal(t, []image.Rectangle{prev.Bounds()}, diffRects(nil, cur))
+	assert.Empty(t, diffRects(prev, cur))
+
+	cur.Set(5, 5, color.White)
+	assert.Equal(t, []image.Rectangle{image.Rect(0, 0, 32, 32)}, diffRects(prev, cur))
+
+	// neighbouring tiles are merged, also at the edges of the frame
+	cur.Set(40, 5, color.White)
+	cur.Set(40, 35, color.White)
+	cur.Set(5, 35, color.White)
+	cur.Set(99, 69, color.White)
+	assert.Equal(t, []image.Rectangle{
+		image.Rect(0, 0, 64, 64),
+		image.Rect(96, 64, 100, 70),
+	}, diffRects(prev, cur))
+
+	assert.Equal(t, []image.Rectangle{image.Rect(0, 0, 50, 50)}, diffRects(prev, image.NewNRGBA(image.Rect(0, 0, 50, 50))))
+}
+
+func TestEncodeFrame(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 64, 64))
+	img.Set(40, 40, color.White)
+
+	msg, err := encodeFrame(img, []image.Rectangle{image.Rect(32, 32, 64, 64)})
+	require.NoError(t, err)
+	assert.Equal(t, 64, msg.Width)
+	require.Len(t, msg.Rects, 1)
+	assert.Equal(t, 32, msg.Rects[0].X)
+
+	part, err := png.Decode(bytes.NewReader(msg.Rects[0].Data))
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 32, 32), part.Bounds())
+	r, _, _, _ := part.At(8, 8).RGBA()
+	assert.Equal(t, uint32(0xffff), r)
+}
+
+// remoteApp is the test app running on a remote driver
+type remoteApp struct {
+	gui.App
+	driver *Driver
+}
+
+func (a *remoteApp) Driver() gui.Driver {
+	return a.driver
+}
+
+func newTestDriver(t *testing.T, content gui.CanvasObject) *Driver {
+	d := NewDriver()
+	gui.SetCurrentApp(&remoteApp{App: test.NewApp(), driver: d})
+	t.Cleanup(func() { test.NewApp() })
+
+	w := d.CreateWindow("")
+	w.SetContent(content)
+	w.Resize(gui.NewSize(200, 100))
+	return d
+}
+
+func TestDriver_HandleEvent(t *testing.T) {
+	tapped := false
+	button := widget.NewButton("Tap", func() { tapped = true })
+	entry := widget.NewEntry()
+	d := newTestDriver(t, container.NewVBox(button, entry))
+
+	d.handleEvent(&inputEvent{Type: "down", X: 10, Y: 10})
+	d.handleEvent(&inputEvent{Type: "up", X: 10, Y: 10})
+	assert.True(t, tapped)
+
+	pos := entry.Position()
+	d.handleEvent(&inputEvent{Type: "down", X: pos.X + 10, Y: pos.Y + 10})
+	d.handleEvent(&inputEvent{Type: "up", X: pos.X + 10, Y: pos.Y + 10})
+	d.handleEvent(&inputEvent{Type: "rune", Rune: "Hi"})
+	d.handleEvent(&inputEvent{Type: "key", Key: "Backspace"})
+	d.handleEvent(&inputEvent{Type: "rune", Rune: "!"})
+	assert.Equal(t, "H!", entry.Text)
+
+	d.handleEvent(&inputEvent{Type: "resize", Width: 300, Height: 150})
+	assert.Equal(t, gui.NewSize(300, 150), d.window().Canvas().Size())
+}
+
+func TestDriver_ServeHTTP(t *testing.T) {
+	d := newTestDriver(t, widget.NewLabel("Remote"))
+	srv := httptest.NewServer(d)
+	defer srv.Close()
+	done := make(chan struct{})
+	go func() {
+		d.runLoop()
+		close(done)
+	}()
+	defer func() {
+		d.Quit()
+		<-done
+	}()
+
+	page, err := srv.Client().Get(srv.URL)
+	require.NoError(t, err)
+	page.Body.Close()
+	assert.Equal(t, 200, page.StatusCode)
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws", "", srv.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+
+	require.NoError(t, websocket.JSON.Send(ws, &inputEvent{Type: "resize", Width: 120, Height: 80}))
+	var msg frameMessage
+	for msg.Width != 120 {
+		require.NoError(t, websocket.JSON.Receive(ws, &msg))
+	}
+	assert.Equal(t, "frame", msg.Type)
+	assert.Equal(t, 80, msg.Height)
+	require.Len(t, msg.Rects, 1)
+	assert.Equal(t, 120, msg.Rects[0].W)
+}
+
+func TestDriver_Recorder(t *testing.T) {
+	d := newTestDriver(t, widget.NewEntry())
+	d.Recorder = test.NewRecorder()
+
+	d.handleEvent(&inputEvent{Type: "resize", Width: 300, Height: 150})
+	d.handleEvent(&inputEvent{Type: "down", X: 10, Y: 10})
+	d.handleEvent(&inputEvent{Type: "up", X: 10, Y: 10})
+	d.handleEvent(&inputEvent{Type: "rune", Rune: "H"})
+	d.handleEvent(&inputEvent{Type: "rune", Rune: "i"})
+	d.handleEvent(&inputEvent{Type: "key", Key: "Enter"})
+	d.handleEvent(&inputEvent{Type: "key", Key: "a", Ctrl: true})
+
+	assert.Equal(t, "resize 300 150\ntap 10 10\ntype \"Hi\"\nkey Return\nshortcut selectall\n",
+		d.Recorder.Script().WithoutTiming().String())
+}
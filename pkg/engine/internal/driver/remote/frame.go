@@ -0,0 +1,136 @@
+package remote
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+)
+
+// tileSize is the edge length in pixels of the tiles frames are compared in
+const tileSize = 32
+
+// frameMessage is sent to the browser whenever the window content changed
+type frameMessage struct {
+	Type   string        `json:"type"`
+	Width  int           `json:"width"`
+	Height int           `json:"height"`
+	Rects  []rectMessage `json:"rects"`
+}
+
+// rectMessage is a changed part of the window encoded as PNG
+type rectMessage struct {
+	X    int    `json:"x"`
+	Y    int    `json:"y"`
+	W    int    `json:"w"`
+	H    int    `json:"h"`
+	Data []byte `json:"data"`
+}
+
+var pngEncoder = &png.Encoder{CompressionLevel: png.BestSpeed}
+
+// diffRects returns the areas in which cur differs from prev. If there is no previous
+// frame or its size differs the whole frame is returned.
+func diffRects(prev, cur *image.NRGBA) []image.Rectangle {
+	bounds := cur.Bounds()
+	if bounds.Empty() {
+		return nil
+	}
+	if prev == nil || prev.Bounds() != bounds {
+		return []image.Rectangle{bounds}
+	}
+
+	var rects []image.Rectangle
+	// rectangles in the previous row of tiles which may still grow downwards
+	var open []image.Rectangle
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += tileSize {
+		var row []image.Rectangle
+		for x := bounds.Min.X; x < bounds.Max.X; x += tileSize {
+			tile := image.Rect(x, y, x+tileSize, y+tileSize).Intersect(bounds)
+			if tileEqual(prev, cur, tile) {
+				continue
+			}
+			if n := len(row); n > 0 && row[n-1].Max.X == tile.Min.X {
+				row[n-1].Max.X = tile.Max.X
+				continue
+			}
+			row = append(row, tile)
+		}
+
+		// extend rectangles of the row above which span exactly the same columns
+		next := make([]image.Rectangle, 0, len(row))
+		for _, r := range row {
+			merged := false
+			for i, o := range open {
+				if o.Min.X == r.Min.X && o.Max.X == r.Max.X {
+					o.Max.Y = r.Max.Y
+					next = append(next, o)
+					open = append(open[:i], open[i+1:]...)
+					merged = true
+					break
+				}
+			}
+			if !merged {
+				next = append(next, r)
+			}
+		}
+		rects = append(rects, open...)
+		open = next
+	}
+	return append(rects, open...)
+}
+
+func tileEqual(a, b *image.NRGBA, r image.Rectangle) bool {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		ia, ib := a.PixOffset(r.Min.X, y), b.PixOffset(r.Min.X, y)
+		n := r.Dx() * 4
+		if !bytes.Equal(a.Pix[ia:ia+n], b.Pix[ib:ib+n]) {
+			return false
+		}
+	}
+	return true
+}
+
+// encodeFrame creates the message which updates the given areas of a frame
+func encodeFrame(img *image.NRGBA, rects []image.Rectangle) (*frameMessage, error) {
+	msg := &frameMessage{
+		Type:   "frame",
+		Width:  img.Bounds().Dx(),
+		Height: img.Bounds().Dy(),
+		Rects:  make([]rectMessage, 0, len(rects)),
+	}
+	for _, r := range rects {
+		var buf bytes.Buffer
+		err := pngEncoder.Encode(&buf, img.SubImage(r))
+		if err != nil {
+			return nil, err
+		}
+		msg.Rects = append(msg.Rects, rectMessage{
+			X:    r.Min.X - img.Bounds().Min.X,
+			Y:    r.Min.Y - img.Bounds().Min.Y,
+			W:    r.Dx(),
+			H:    r.Dy(),
+			Data: buf.Bytes(),
+		})
+	}
+	return msg, nil
+}
@@ -0,0 +1,321 @@
+package remote
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/internal/driver"
+	"github.com/bhojpur/gui/pkg/engine/test"
+)
+
+// inputEvent is a mouse, keyboard or window event sent by the browser
+type inputEvent struct {
+	// Type is one of "down", "up", "move", "double", "wheel", "key", "rune" or "resize"
+	Type string `json:"type"`
+
+	// X and Y are the pointer position for mouse events
+	X float32 `json:"x"`
+	Y float32 `json:"y"`
+	// Button is the browser button number: 0 primary, 1 tertiary and 2 secondary
+	Button int `json:"button"`
+	// DX and DY is the distance scrolled in browser orientation
+	DX float32 `json:"dx"`
+	DY float32 `json:"dy"`
+
+	// Key is the browser key name, e.g. "Enter" or "a"
+	Key   string `json:"key"`
+	Shift bool   `json:"shift"`
+	Ctrl  bool   `json:"ctrl"`
+	Alt   bool   `json:"alt"`
+	Meta  bool   `json:"meta"`
+	// Rune is the character typed for rune events
+	Rune string `json:"rune"`
+
+	// Width and Height are the size of the browser view for resize events
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+}
+
+// inputState remembers the pointer between events
+type inputState struct {
+	pressed   bool
+	button    desktop.MouseButton
+	pressPos  gui.Position
+	lastPos   gui.Position
+	mouseable desktop.Mouseable
+	dragged   gui.Draggable
+	dragging  bool
+}
+
+// browserKeys maps browser key names to their gui.KeyName where they differ
+var browserKeys = map[string]gui.KeyName{
+	"Enter":      gui.KeyReturn,
+	"Backspace":  gui.KeyBackspace,
+	"ArrowLeft":  gui.KeyLeft,
+	"ArrowRight": gui.KeyRight,
+	"ArrowUp":    gui.KeyUp,
+	"ArrowDown":  gui.KeyDown,
+	"PageUp":     gui.KeyPageUp,
+	"PageDown":   gui.KeyPageDown,
+	" ":          gui.KeySpace,
+}
+
+func (e *inputEvent) position() gui.Position {
+	return gui.NewPos(e.X, e.Y)
+}
+
+func (e *inputEvent) mouseButton() desktop.MouseButton {
+	switch e.Button {
+	case 1:
+		return desktop.MouseButtonTertiary
+	case 2:
+		return desktop.MouseButtonSecondary
+	default:
+		return desktop.MouseButtonPrimary
+	}
+}
+
+func (e *inputEvent) modifier() gui.KeyModifier {
+	var mod gui.KeyModifier
+	if e.Shift {
+		mod |= gui.KeyModifierShift
+	}
+	if e.Ctrl {
+		mod |= gui.KeyModifierControl
+	}
+	if e.Alt {
+		mod |= gui.KeyModifierAlt
+	}
+	if e.Meta {
+		mod |= gui.KeyModifierSuper
+	}
+	return mod
+}
+
+func (e *inputEvent) keyName() gui.KeyName {
+	if name, ok := browserKeys[e.Key]; ok {
+		return name
+	}
+	if len([]rune(e.Key)) == 1 {
+		return gui.KeyName(strings.ToUpper(e.Key))
+	}
+	return gui.KeyName(e.Key)
+}
+
+// handleEvent feeds a browser event into the window shown to the clients
+func (d *Driver) handleEvent(ev *inputEvent) {
+	w := d.window()
+	if w == nil {
+		return
+	}
+	c := w.Canvas()
+
+	switch ev.Type {
+	case "resize":
+		if ev.Width > 0 && ev.Height > 0 {
+			size := gui.NewSize(ev.Width, ev.Height)
+			w.Resize(size)
+			if d.Recorder != nil {
+				d.Recorder.Resize(size)
+			}
+		}
+	case "move":
+		d.mouseMoved(c, ev)
+	case "down":
+		d.mouseDown(c, ev)
+	case "up":
+		d.mouseUp(c, ev)
+	case "double":
+		pos := ev.position()
+		o, p, _ := driver.FindObjectAtPositionMatching(pos, func(o gui.CanvasObject) bool {
+			_, ok := o.(gui.DoubleTappable)
+			return ok
+		}, c.Overlays().Top(), c.Content())
+		if o != nil {
+			o.(gui.DoubleTappable).DoubleTapped(&gui.PointEvent{AbsolutePosition: pos, Position: p})
+		}
+		if d.Recorder != nil {
+			d.Recorder.DoubleTap(pos)
+		}
+	case "wheel":
+		// browsers report the distance the content moves up, the engine the distance it moves down
+		test.Scroll(c, ev.position(), -ev.DX, -ev.DY)
+		if d.Recorder != nil {
+			d.Recorder.Scroll(ev.position(), -ev.DX, -ev.DY)
+		}
+	case "key":
+		d.keyTyped(w, ev)
+	case "rune":
+		if d.Recorder != nil {
+			d.Recorder.Type(ev.Rune)
+		}
+		for _, r := range ev.Rune {
+			if f := c.Focused(); f != nil {
+				f.TypedRune(r)
+			} else if h := c.OnTypedRune(); h != nil {
+				h(r)
+			}
+		}
+	}
+}
+
+func (d *Driver) mouseMoved(c gui.Canvas, ev *inputEvent) {
+	pos := ev.position()
+	in := &d.input
+	if !in.pressed {
+		test.MoveMouse(c, pos)
+		in.lastPos = pos
+		return
+	}
+
+	if in.dragged != nil && in.button == desktop.MouseButtonPrimary {
+		in.dragging = true
+		_, p, _ := driver.FindObjectAtPositionMatching(in.pressPos, func(o gui.CanvasObject) bool {
+			return o == in.dragged.(gui.CanvasObject)
+		}, c.Overlays().Top(), c.Content())
+		in.dragged.Dragged(&gui.DragEvent{
+			PointEvent: gui.PointEvent{AbsolutePosition: pos, Position: p.Add(pos.Subtract(in.pressPos))},
+			Dragged:    gui.NewDelta(pos.X-in.lastPos.X, pos.Y-in.lastPos.Y),
+		})
+	}
+	in.lastPos = pos
+}
+
+func (d *Driver) mouseDown(c gui.Canvas, ev *inputEvent) {
+	pos := ev.position()
+	in := &d.input
+	*in = inputState{pressed: true, button: ev.mouseButton(), pressPos: pos, lastPos: pos}
+
+	o, p, _ := driver.FindObjectAtPositionMatching(pos, func(o gui.CanvasObject) bool {
+		_, ok := o.(desktop.Mouseable)
+		return ok
+	}, c.Overlays().Top(), c.Content())
+	if o != nil {
+		in.mouseable = o.(desktop.Mouseable)
+		in.mouseable.MouseDown(&desktop.MouseEvent{
+			PointEvent: gui.PointEvent{AbsolutePosition: pos, Position: p},
+			Button:     in.button,
+			Modifier:   ev.modifier(),
+		})
+	}
+
+	o, _, _ = driver.FindObjectAtPositionMatching(pos, func(o gui.CanvasObject) bool {
+		_, ok := o.(gui.Draggable)
+		return ok
+	}, c.Overlays().Top(), c.Content())
+	if o != nil {
+		in.dragged = o.(gui.Draggable)
+	}
+}
+
+func (d *Driver) mouseUp(c gui.Canvas, ev *inputEvent) {
+	pos := ev.position()
+	in := &d.input
+	if !in.pressed {
+		return
+	}
+	defer func() {
+		*in = inputState{lastPos: pos}
+	}()
+
+	if in.mouseable != nil {
+		_, p, _ := driver.FindObjectAtPositionMatching(pos, func(o gui.CanvasObject) bool {
+			return o == in.mouseable.(gui.CanvasObject)
+		}, c.Overlays().Top(), c.Content())
+		in.mouseable.MouseUp(&desktop.MouseEvent{
+			PointEvent: gui.PointEvent{AbsolutePosition: pos, Position: p},
+			Button:     in.button,
+			Modifier:   ev.modifier(),
+		})
+	}
+	if in.dragging {
+		in.dragged.DragEnd()
+		if d.Recorder != nil {
+			d.Recorder.Drag(in.pressPos, pos.X-in.pressPos.X, pos.Y-in.pressPos.Y)
+		}
+		return
+	}
+
+	switch in.button {
+	case desktop.MouseButtonPrimary:
+		test.TapCanvas(c, pos)
+		if d.Recorder != nil {
+			d.Recorder.Tap(pos)
+		}
+	case desktop.MouseButtonSecondary:
+		o, p, _ := driver.FindObjectAtPositionMatching(pos, func(o gui.CanvasObject) bool {
+			_, ok := o.(gui.SecondaryTappable)
+			return ok
+		}, c.Overlays().Top(), c.Content())
+		if o != nil {
+			o.(gui.SecondaryTappable).TappedSecondary(&gui.PointEvent{AbsolutePosition: pos, Position: p})
+		}
+		if d.Recorder != nil {
+			d.Recorder.TapSecondary(pos)
+		}
+	}
+}
+
+func (d *Driver) keyTyped(w gui.Window, ev *inputEvent) {
+	c := w.Canvas()
+	if ev.Ctrl || ev.Meta {
+		var sc gui.Shortcut
+		name := ""
+		switch strings.ToLower(ev.Key) {
+		case "c":
+			sc, name = &gui.ShortcutCopy{Clipboard: w.Clipboard()}, "copy"
+		case "v":
+			sc, name = &gui.ShortcutPaste{Clipboard: w.Clipboard()}, "paste"
+		case "x":
+			sc, name = &gui.ShortcutCut{Clipboard: w.Clipboard()}, "cut"
+		case "a":
+			sc, name = &gui.ShortcutSelectAll{}, "selectall"
+		case "z":
+			if ev.Shift {
+				sc, name = &gui.ShortcutRedo{}, "redo"
+			} else {
+				sc, name = &gui.ShortcutUndo{}, "undo"
+			}
+		default:
+			return
+		}
+		if s, ok := c.Focused().(gui.Shortcutable); ok {
+			s.TypedShortcut(sc)
+		}
+		if d.Recorder != nil {
+			d.Recorder.TypedShortcut(name)
+		}
+		return
+	}
+
+	key := &gui.KeyEvent{Name: ev.keyName()}
+	if d.Recorder != nil {
+		d.Recorder.TypedKey(key.Name)
+	}
+	if f := c.Focused(); f != nil {
+		f.TypedKey(key)
+	} else if h := c.OnTypedKey(); h != nil {
+		h(key)
+	}
+}
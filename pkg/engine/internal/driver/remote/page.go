@@ -0,0 +1,102 @@
+package remote
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// indexHTML is the page which connects to the driver and shows the application
+const indexHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Bhojpur GUI</title>
+<style>
+html, body { margin: 0; height: 100%; overflow: hidden; background: #333; }
+canvas { display: block; outline: none; }
+</style>
+</head>
+<body>
+<canvas id="screen" tabindex="0"></canvas>
+<script>
+(function() {
+	var screen = document.getElementById("screen");
+	var ctx = screen.getContext("2d");
+	var proto = location.protocol === "https:" ? "wss://" : "ws://";
+	var ws = new WebSocket(proto + location.host + "/ws");
+
+	function send(ev) {
+		if (ws.readyState === WebSocket.OPEN) {
+			ws.send(JSON.stringify(ev));
+		}
+	}
+	function resize() {
+		send({type: "resize", width: window.innerWidth, height: window.innerHeight});
+	}
+	function pos(type, e) {
+		var r = screen.getBoundingClientRect();
+		return {type: type, x: e.clientX - r.left, y: e.clientY - r.top, button: e.button,
+			shift: e.shiftKey, ctrl: e.ctrlKey, alt: e.altKey, meta: e.metaKey};
+	}
+
+	ws.onopen = resize;
+	ws.onmessage = function(msg) {
+		var frame = JSON.parse(msg.data);
+		if (frame.type !== "frame") {
+			return;
+		}
+		if (screen.width !== frame.width || screen.height !== frame.height) {
+			screen.width = frame.width;
+			screen.height = frame.height;
+		}
+		frame.rects.forEach(function(r) {
+			var img = new Image();
+			img.onload = function() { ctx.drawImage(img, r.x, r.y); };
+			img.src = "data:image/png;base64," + r.data;
+		});
+	};
+	ws.onclose = function() {
+		document.title = "Bhojpur GUI (disconnected)";
+	};
+
+	window.addEventListener("resize", resize);
+	screen.addEventListener("mousedown", function(e) { screen.focus(); send(pos("down", e)); e.preventDefault(); });
+	screen.addEventListener("mouseup", function(e) { send(pos("up", e)); });
+	screen.addEventListener("mousemove", function(e) { send(pos("move", e)); });
+	screen.addEventListener("dblclick", function(e) { send(pos("double", e)); });
+	screen.addEventListener("contextmenu", function(e) { e.preventDefault(); });
+	screen.addEventListener("wheel", function(e) {
+		var ev = pos("wheel", e);
+		ev.dx = e.deltaX;
+		ev.dy = e.deltaY;
+		send(ev);
+		e.preventDefault();
+	});
+	screen.addEventListener("keydown", function(e) {
+		send({type: "key", key: e.key, shift: e.shiftKey, ctrl: e.ctrlKey, alt: e.altKey, meta: e.metaKey});
+		if (e.key.length === 1 && !e.ctrlKey && !e.metaKey) {
+			send({type: "rune", rune: e.key});
+		}
+		e.preventDefault();
+	});
+	screen.focus();
+})();
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,226 @@
+package software
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"image"
+	"image/color"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/internal"
+	"github.com/bhojpur/gui/pkg/engine/internal/driver"
+	"github.com/bhojpur/gui/pkg/engine/internal/painter"
+)
+
+// frame is the result of the last paint of a canvas together with what its
+// objects looked like at that time
+type frame struct {
+	img   *image.NRGBA
+	scale float32
+	objs  *objectState
+
+	refreshed map[gui.CanvasObject]bool
+	invalid   bool
+	damage    []image.Rectangle
+}
+
+// objectState is what the visible objects of a canvas look like at a time
+type objectState struct {
+	// own is the area painted for each object itself, tree the area painted for
+	// the object including all its descendants
+	own, tree map[gui.CanvasObject]image.Rectangle
+	// looks is the appearance of each object apart from its geometry
+	looks map[gui.CanvasObject]interface{}
+}
+
+// damaged returns the areas which have to be repainted to show the objects in their
+// current state. These are the old and new areas of all objects which were refreshed,
+// changed their appearance or geometry, appeared or disappeared.
+func (f *frame) damaged(cur *objectState, refreshed map[gui.CanvasObject]bool) []image.Rectangle {
+	var res []image.Rectangle
+	for obj, r := range cur.own {
+		old, ok := f.objs.own[obj]
+		if !ok || old != r {
+			res = append(res, old, r)
+		} else if !sameLook(f.objs.looks[obj], cur.looks[obj]) {
+			res = append(res, r)
+		}
+	}
+	for obj, old := range f.objs.own {
+		if _, ok := cur.own[obj]; !ok {
+			res = append(res, old)
+		}
+	}
+	for obj := range refreshed {
+		res = append(res, f.objs.tree[obj], cur.tree[obj])
+	}
+	return res
+}
+
+// collectState walks the visible objects of a canvas and records their current state
+func collectState(c gui.Canvas) *objectState {
+	s := &objectState{
+		own:   make(map[gui.CanvasObject]image.Rectangle),
+		tree:  make(map[gui.CanvasObject]image.Rectangle),
+		looks: make(map[gui.CanvasObject]interface{}),
+	}
+
+	before := func(obj gui.CanvasObject, pos, clipPos gui.Position, clipSize gui.Size) bool {
+		r := objectArea(c, obj, pos).Intersect(clipRect(c, clipPos, clipSize))
+		s.own[obj] = r
+		s.tree[obj] = r
+		if l := lookOf(obj); l != nil {
+			s.looks[obj] = l
+		}
+		return false
+	}
+	after := func(obj, parent gui.CanvasObject) {
+		if parent != nil {
+			s.tree[parent] = s.tree[parent].Union(s.tree[obj])
+		}
+	}
+
+	driver.WalkVisibleObjectTree(c.Content(), before, after)
+	for _, o := range c.Overlays().List() {
+		driver.WalkVisibleObjectTree(o, before, after)
+	}
+	return s
+}
+
+type textLook struct {
+	text  string
+	color color.Color
+	size  float32
+	style gui.TextStyle
+	align gui.TextAlign
+}
+
+type shapeLook struct {
+	fill, stroke color.Color
+	width        float32
+}
+
+type imageLook struct {
+	file         string
+	res          gui.Resource
+	img          image.Image
+	translucency float64
+	fill         canvas.ImageFill
+	scale        canvas.ImageScale
+}
+
+type gradientLook struct {
+	start, end     color.Color
+	angle, cx, cy  float64
+	linear, radial bool
+}
+
+// lookOf returns a comparable description of everything that determines how an object
+// is painted apart from its geometry. Objects without a description, like rasters,
+// are only repainted if they are refreshed.
+func lookOf(obj gui.CanvasObject) interface{} {
+	switch o := obj.(type) {
+	case *canvas.Text:
+		return textLook{text: o.Text, color: o.Color, size: o.TextSize, style: o.TextStyle, align: o.Alignment}
+	case *canvas.Rectangle:
+		return shapeLook{fill: o.FillColor, stroke: o.StrokeColor, width: o.StrokeWidth}
+	case *canvas.Circle:
+		return shapeLook{fill: o.FillColor, stroke: o.StrokeColor, width: o.StrokeWidth}
+	case *canvas.Line:
+		return shapeLook{stroke: o.StrokeColor, width: o.StrokeWidth}
+	case *canvas.Image:
+		return imageLook{file: o.File, res: o.Resource, img: o.Image, translucency: o.Translucency, fill: o.FillMode, scale: o.ScaleMode}
+	case *canvas.LinearGradient:
+		return gradientLook{start: o.StartColor, end: o.EndColor, angle: o.Angle, linear: true}
+	case *canvas.RadialGradient:
+		return gradientLook{start: o.StartColor, end: o.EndColor, cx: o.CenterOffsetX, cy: o.CenterOffsetY, radial: true}
+	}
+	return nil
+}
+
+// sameLook compares two looks. Looks holding values which cannot be compared, e.g. an
+// image type which is not a pointer, are treated as changed.
+func sameLook(a, b interface{}) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+// objectArea returns the pixels an object may paint to when placed at pos
+func objectArea(c gui.Canvas, obj gui.CanvasObject, pos gui.Position) image.Rectangle {
+	size := obj.Size()
+	var pad float32
+	switch o := obj.(type) {
+	case *canvas.Circle, *canvas.Line, *canvas.Rectangle:
+		pad = painter.VectorPad(o)
+	case *canvas.Text:
+		// text is not cut to its size, it may overflow on all sides if aligned or centered
+		min := o.MinSize()
+		pad = gui.Max(min.Width-size.Width, min.Height-size.Height)
+		if pad < 0 {
+			pad = 0
+		}
+	}
+
+	// one extra pixel compensates rounding and anti-aliasing
+	return image.Rect(
+		internal.ScaleInt(c, pos.X-pad)-1,
+		internal.ScaleInt(c, pos.Y-pad)-1,
+		internal.ScaleInt(c, pos.X+size.Width+pad)+1,
+		internal.ScaleInt(c, pos.Y+size.Height+pad)+1,
+	)
+}
+
+// mergeRects limits rectangles to the bounds, drops empty ones and joins the
+// ones overlapping each other
+func mergeRects(rects []image.Rectangle, bounds image.Rectangle) []image.Rectangle {
+	res := make([]image.Rectangle, 0, len(rects))
+	for _, r := range rects {
+		r = r.Intersect(bounds)
+		if r.Empty() {
+			continue
+		}
+		// joining may create new overlaps, so start over until nothing overlaps
+		for i := 0; i < len(res); {
+			if res[i].Overlaps(r) {
+				r = r.Union(res[i])
+				res = append(res[:i], res[i+1:]...)
+				i = 0
+				continue
+			}
+			i++
+		}
+		res = append(res, r)
+	}
+	return res
+}
+
+func coverage(rects []image.Rectangle) float64 {
+	var area float64
+	for _, r := range rects {
+		area += float64(r.Dx() * r.Dy())
+	}
+	return area
+}
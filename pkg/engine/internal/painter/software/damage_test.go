@@ -0,0 +1,114 @@
+package software_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/internal/painter/software"
+	"github.com/bhojpur/gui/pkg/engine/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func newDamageCanvas(p *software.Painter) (test.WindowlessCanvas, *canvas.Rectangle, *canvas.Rectangle) {
+	left := canvas.NewRectangle(color.NRGBA{R: 255, A: 255})
+	left.Move(gui.NewPos(10, 10))
+	left.Resize(gui.NewSize(20, 20))
+	right := canvas.NewRectangle(color.NRGBA{B: 255, A: 255})
+	right.Move(gui.NewPos(150, 50))
+	right.Resize(gui.NewSize(20, 20))
+
+	c := test.NewCanvasWithPainter(p)
+	c.SetPadded(false)
+	c.SetContent(gui.NewContainerWithoutLayout(left, right))
+	c.Resize(gui.NewSize(200, 100))
+	return c, left, right
+}
+
+// assertFreshPaint checks that a painter without history paints the same image
+func assertFreshPaint(t *testing.T, c test.WindowlessCanvas, img image.Image) {
+	fresh := software.NewPainter().Paint(c)
+	assert.Equal(t, fresh.(*image.NRGBA).Pix, img.(*image.NRGBA).Pix)
+}
+
+func TestPainter_Damage(t *testing.T) {
+	p := software.NewPainter()
+	c, left, right := newDamageCanvas(p)
+
+	first := p.Paint(c)
+	assert.Equal(t, []image.Rectangle{image.Rect(0, 0, 200, 100)}, p.Damage(c))
+
+	second := p.Paint(c)
+	assert.Empty(t, p.Damage(c))
+	assert.Equal(t, first.(*image.NRGBA).Pix, second.(*image.NRGBA).Pix)
+	assert.NotSame(t, first, second)
+
+	left.FillColor = color.NRGBA{G: 255, A: 255}
+	img := p.Paint(c)
+	assert.Equal(t, []image.Rectangle{image.Rect(9, 9, 31, 31)}, p.Damage(c))
+	assertFreshPaint(t, c, img)
+
+	right.Move(gui.NewPos(120, 50))
+	img = p.Paint(c)
+	assert.ElementsMatch(t, []image.Rectangle{image.Rect(149, 49, 171, 71), image.Rect(119, 49, 141, 71)}, p.Damage(c))
+	assertFreshPaint(t, c, img)
+
+	right.Hide()
+	img = p.Paint(c)
+	assert.Equal(t, []image.Rectangle{image.Rect(119, 49, 141, 71)}, p.Damage(c))
+	assertFreshPaint(t, c, img)
+
+	c.Resize(gui.NewSize(100, 100))
+	p.Paint(c)
+	assert.Equal(t, []image.Rectangle{image.Rect(0, 0, 100, 100)}, p.Damage(c))
+}
+
+func TestPainter_Damage_refresh(t *testing.T) {
+	p := software.NewPainter()
+	fill := color.NRGBA{R: 255, A: 255}
+	raster := canvas.NewRasterWithPixels(func(_, _, _, _ int) color.Color { return fill })
+	raster.Move(gui.NewPos(10, 10))
+	raster.Resize(gui.NewSize(20, 20))
+	c := test.NewCanvasWithPainter(p)
+	c.SetPadded(false)
+	c.SetContent(gui.NewContainerWithoutLayout(raster))
+	c.Resize(gui.NewSize(100, 100))
+	p.Paint(c)
+
+	// rasters are only repainted when they are refreshed
+	fill = color.NRGBA{G: 255, A: 255}
+	img := p.Paint(c)
+	assert.Empty(t, p.Damage(c))
+	assert.Equal(t, color.NRGBA{R: 255, A: 255}, img.At(15, 15))
+
+	c.Refresh(raster)
+	img = p.Paint(c)
+	assert.Equal(t, []image.Rectangle{image.Rect(9, 9, 31, 31)}, p.Damage(c))
+	assert.Equal(t, color.NRGBA{G: 255, A: 255}, img.At(15, 15))
+
+	p.Invalidate(c)
+	p.Paint(c)
+	assert.Equal(t, []image.Rectangle{image.Rect(0, 0, 100, 100)}, p.Damage(c))
+}
@@ -22,6 +22,8 @@ package software
 
 import (
 	"image"
+	"image/draw"
+	"sync"
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
@@ -29,31 +31,139 @@ import (
 	"github.com/bhojpur/gui/pkg/engine/internal/driver"
 )
 
+// fullRepaintRatio is the share of the canvas above which damaged areas are not
+// repainted one by one but the canvas is repainted as a whole
+const fullRepaintRatio = 0.6
+
 // Painter is a simple software painter that can paint a canvas in memory.
+// It remembers the last frame of every canvas and only repaints the areas which
+// were damaged since. An area is damaged if an object in it was refreshed, changed
+// its appearance, moved, resized, appeared or disappeared.
 type Painter struct {
+	mu     sync.Mutex
+	frames map[gui.Canvas]*frame
 }
 
 // NewPainter creates a new Painter.
 func NewPainter() *Painter {
-	return &Painter{}
+	return &Painter{frames: make(map[gui.Canvas]*frame)}
 }
 
 // Paint is the main entry point for a simple software painter.
 // The canvas to be drawn is passed in as a parameter and the return is an
-// image containing the result of rendering.
-func (*Painter) Paint(c gui.Canvas) image.Image {
+// image containing the result of rendering. Every call returns a new image.
+func (p *Painter) Paint(c gui.Canvas) image.Image {
 	bounds := image.Rect(0, 0, internal.ScaleInt(c, c.Size().Width), internal.ScaleInt(c, c.Size().Height))
+
+	// objects refreshed while painting are kept for the next frame
+	p.mu.Lock()
+	if p.frames == nil {
+		p.frames = make(map[gui.Canvas]*frame)
+	}
+	f := p.frames[c]
+	var refreshed map[gui.CanvasObject]bool
+	full := f == nil || f.invalid
+	if f != nil {
+		refreshed = f.refreshed
+		f.refreshed = make(map[gui.CanvasObject]bool)
+	}
+	p.mu.Unlock()
+
+	objs := collectState(c)
+	var damage []image.Rectangle
+	full = full || f.scale != c.Scale() || f.img.Bounds() != bounds
+	if !full {
+		damage = mergeRects(f.damaged(objs, refreshed), bounds)
+		full = coverage(damage) > fullRepaintRatio*float64(bounds.Dx()*bounds.Dy())
+	}
+
 	base := image.NewNRGBA(bounds)
+	if full {
+		damage = []image.Rectangle{bounds}
+		paintArea(c, base, bounds)
+	} else {
+		copy(base.Pix, f.img.Pix)
+		for _, r := range damage {
+			draw.Draw(base, r, image.Transparent, image.Point{}, draw.Src)
+			paintArea(c, base, r)
+		}
+	}
+
+	next := &frame{
+		img:       base,
+		scale:     c.Scale(),
+		objs:      objs,
+		refreshed: make(map[gui.CanvasObject]bool),
+		damage:    damage,
+	}
+	p.mu.Lock()
+	if f != nil {
+		next.refreshed = f.refreshed
+	}
+	p.frames[c] = next
+	p.mu.Unlock()
+	return base
+}
+
+// Refresh marks the area of an object and its descendants as damaged so that it is
+// repainted by the next Paint of the canvas.
+func (p *Painter) Refresh(c gui.Canvas, obj gui.CanvasObject) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if f := p.frames[c]; f != nil {
+		f.refreshed[obj] = true
+	}
+}
+
+// Invalidate causes the next Paint of the canvas to repaint everything.
+func (p *Painter) Invalidate(c gui.Canvas) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if f := p.frames[c]; f != nil {
+		f.invalid = true
+	}
+}
+
+// Damage returns the areas which were repainted by the last Paint of the canvas.
+func (p *Painter) Damage(c gui.Canvas) []image.Rectangle {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	f := p.frames[c]
+	if f == nil {
+		return nil
+	}
+	return append([]image.Rectangle(nil), f.damage...)
+}
 
+// Forget releases the last frame of a canvas which is not painted any more.
+func (p *Painter) Forget(c gui.Canvas) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.frames, c)
+}
+
+func clipRect(c gui.Canvas, clipPos gui.Position, clipSize gui.Size) image.Rectangle {
+	w := gui.Min(clipPos.X+clipSize.Width, c.Size().Width)
+	h := gui.Min(clipPos.Y+clipSize.Height, c.Size().Height)
+	return image.Rect(
+		internal.ScaleInt(c, clipPos.X),
+		internal.ScaleInt(c, clipPos.Y),
+		internal.ScaleInt(c, w),
+		internal.ScaleInt(c, h),
+	)
+}
+
+// paintArea paints all objects of the canvas which overlap the area
+func paintArea(c gui.Canvas, base *image.NRGBA, area image.Rectangle) {
 	paint := func(obj gui.CanvasObject, pos, clipPos gui.Position, clipSize gui.Size) bool {
-		w := gui.Min(clipPos.X+clipSize.Width, c.Size().Width)
-		h := gui.Min(clipPos.Y+clipSize.Height, c.Size().Height)
-		clip := image.Rect(
-			internal.ScaleInt(c, clipPos.X),
-			internal.ScaleInt(c, clipPos.Y),
-			internal.ScaleInt(c, w),
-			internal.ScaleInt(c, h),
-		)
+		clip := clipRect(c, clipPos, clipSize).Intersect(area)
+		if clip.Empty() {
+			return false
+		}
 		switch o := obj.(type) {
 		case *canvas.Image:
 			drawImage(c, o, pos, base, clip)
@@ -78,6 +188,4 @@ func (*Painter) Paint(c gui.Canvas) image.Image {
 	for _, o := range c.Overlays().List() {
 		driver.WalkVisibleObjectTree(o, paint, nil)
 	}
-
-	return base
 }
@@ -28,13 +28,33 @@ import (
 // for the canvasObject
 type ShortcutHandler struct {
 	entry sync.Map // map[string]func(Shortcut)
+	keyed sync.Map // map[shortcutKeys]Shortcut of the other shortcuts added for the keys of undo and redo
 }
 
-// TypedShortcut handle the registered shortcut
+// shortcutKeys identifies a keyboard shortcut by its key and modifier
+type shortcutKeys struct {
+	key KeyName
+	mod KeyModifier
+}
+
+// TypedShortcut handle the registered shortcut.
+// If there is no handler for undo or redo, the handler of a keyboard shortcut added for the same keys is
+// called instead, so that handlers added for Ctrl+Z and Ctrl+Shift+Z before these shortcuts existed keep working.
 func (sh *ShortcutHandler) TypedShortcut(shortcut Shortcut) {
 	val, ok := sh.entry.Load(shortcut.ShortcutName())
 	if !ok {
-		return
+		if !isUndoOrRedo(shortcut) {
+			return
+		}
+		keyed := shortcut.(KeyboardShortcut)
+		legacy, found := sh.keyed.Load(shortcutKeys{keyed.Key(), keyed.Mod()})
+		if !found {
+			return
+		}
+		shortcut = legacy.(Shortcut)
+		if val, ok = sh.entry.Load(shortcut.ShortcutName()); !ok {
+			return
+		}
 	}
 
 	f := val.(func(Shortcut))
@@ -44,11 +64,37 @@ func (sh *ShortcutHandler) TypedShortcut(shortcut Shortcut) {
 // AddShortcut register a handler to be executed when the shortcut action is triggered
 func (sh *ShortcutHandler) AddShortcut(shortcut Shortcut, handler func(shortcut Shortcut)) {
 	sh.entry.Store(shortcut.ShortcutName(), handler)
+	if keys, ok := undoRedoKeys(shortcut); ok {
+		sh.keyed.Store(keys, shortcut)
+	}
 }
 
 // RemoveShortcut removes a registered shortcut
 func (sh *ShortcutHandler) RemoveShortcut(shortcut Shortcut) {
 	sh.entry.Delete(shortcut.ShortcutName())
+	if keys, ok := undoRedoKeys(shortcut); ok {
+		sh.keyed.Delete(keys)
+	}
+}
+
+// undoRedoKeys returns the keys of a keyboard shortcut, other than undo or redo, that uses the keys of undo or redo.
+func undoRedoKeys(shortcut Shortcut) (shortcutKeys, bool) {
+	keyed, ok := shortcut.(KeyboardShortcut)
+	if !ok || isUndoOrRedo(shortcut) {
+		return shortcutKeys{}, false
+	}
+
+	keys := shortcutKeys{keyed.Key(), keyed.Mod()}
+	undo, redo := &ShortcutUndo{}, &ShortcutRedo{}
+	return keys, keys == shortcutKeys{undo.Key(), undo.Mod()} || keys == shortcutKeys{redo.Key(), redo.Mod()}
+}
+
+func isUndoOrRedo(shortcut Shortcut) bool {
+	switch shortcut.(type) {
+	case *ShortcutUndo, *ShortcutRedo:
+		return true
+	}
+	return false
 }
 
 // Shortcut is the interface used to describe a shortcut action
@@ -164,3 +210,55 @@ func (se *ShortcutSelectAll) Mod() KeyModifier {
 func (se *ShortcutSelectAll) ShortcutName() string {
 	return "SelectAll"
 }
+
+// ShortcutUndo describes a shortcut undo action.
+//
+// Since: 2.3
+type ShortcutUndo struct{}
+
+var _ KeyboardShortcut = (*ShortcutUndo)(nil)
+
+// Key returns the KeyName for this shortcut.
+//
+// Implements: KeyboardShortcut
+func (se *ShortcutUndo) Key() KeyName {
+	return KeyZ
+}
+
+// Mod returns the KeyModifier for this shortcut.
+//
+// Implements: KeyboardShortcut
+func (se *ShortcutUndo) Mod() KeyModifier {
+	return KeyModifierShortcutDefault
+}
+
+// ShortcutName returns the shortcut name
+func (se *ShortcutUndo) ShortcutName() string {
+	return "Undo"
+}
+
+// ShortcutRedo describes a shortcut redo action.
+//
+// Since: 2.3
+type ShortcutRedo struct{}
+
+var _ KeyboardShortcut = (*ShortcutRedo)(nil)
+
+// Key returns the KeyName for this shortcut.
+//
+// Implements: KeyboardShortcut
+func (se *ShortcutRedo) Key() KeyName {
+	return KeyZ
+}
+
+// Mod returns the KeyModifier for this shortcut.
+//
+// Implements: KeyboardShortcut
+func (se *ShortcutRedo) Mod() KeyModifier {
+	return KeyModifierShortcutDefault | KeyModifierShift
+}
+
+// ShortcutName returns the shortcut name
+func (se *ShortcutRedo) ShortcutName() string {
+	return "Redo"
+}
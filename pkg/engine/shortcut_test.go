@@ -81,3 +81,37 @@ func TestShortcutHandler_HandleShortcut(t *testing.T) {
 	handle.TypedShortcut(&ShortcutPaste{})
 	assert.True(t, pasteCalled)
 }
+
+type keyShortcut struct {
+	key KeyName
+	mod KeyModifier
+}
+
+func (k *keyShortcut) Key() KeyName         { return k.key }
+func (k *keyShortcut) Mod() KeyModifier     { return k.mod }
+func (k *keyShortcut) ShortcutName() string { return "Key:" + string(k.key) }
+
+func TestShortcutHandler_UndoRedoFallback(t *testing.T) {
+	handle := &ShortcutHandler{}
+	undo := &keyShortcut{key: KeyZ, mod: KeyModifierShortcutDefault}
+	var typed []Shortcut
+	handle.AddShortcut(undo, func(shortcut Shortcut) {
+		typed = append(typed, shortcut)
+	})
+
+	handle.TypedShortcut(&ShortcutUndo{})
+	handle.TypedShortcut(&ShortcutRedo{})
+	assert.Equal(t, []Shortcut{undo}, typed)
+
+	handle.AddShortcut(&ShortcutUndo{}, func(shortcut Shortcut) {
+		typed = append(typed, shortcut)
+	})
+	handle.TypedShortcut(&ShortcutUndo{})
+	assert.Equal(t, []Shortcut{undo, &ShortcutUndo{}}, typed)
+
+	typed = nil
+	handle.RemoveShortcut(&ShortcutUndo{})
+	handle.RemoveShortcut(undo)
+	handle.TypedShortcut(&ShortcutUndo{})
+	assert.Empty(t, typed)
+}
@@ -0,0 +1,150 @@
+package syntax
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "strings"
+
+const (
+	goStateBlockComment State = iota + 1
+	goStateRawString
+)
+
+var goKeywords = map[string]bool{
+	"break": true, "case": true, "chan": true, "const": true, "continue": true, "default": true,
+	"defer": true, "else": true, "fallthrough": true, "for": true, "func": true, "go": true, "goto": true,
+	"if": true, "import": true, "interface": true, "map": true, "package": true, "range": true,
+	"return": true, "select": true, "struct": true, "switch": true, "type": true, "var": true,
+}
+
+var goBuiltins = map[string]bool{
+	"any": true, "bool": true, "byte": true, "comparable": true, "complex64": true, "complex128": true,
+	"error": true, "float32": true, "float64": true, "int": true, "int8": true, "int16": true,
+	"int32": true, "int64": true, "rune": true, "string": true, "uint": true, "uint8": true,
+	"uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"append": true, "cap": true, "close": true, "complex": true, "copy": true, "delete": true,
+	"imag": true, "len": true, "make": true, "new": true, "panic": true, "print": true,
+	"println": true, "real": true, "recover": true,
+}
+
+var goLiterals = map[string]bool{"true": true, "false": true, "nil": true, "iota": true}
+
+type goLexer struct{}
+
+// Go returns a lexer for the Go programming language.
+//
+// Since: 2.3
+func Go() Lexer {
+	return goLexer{}
+}
+
+func (goLexer) Tokenize(line string, state State) ([]Token, State) {
+	var t tokens
+	i := 0
+	switch state {
+	case goStateBlockComment:
+		end := strings.Index(line, "*/")
+		if end < 0 {
+			t.add(TokenComment, line)
+			return t, state
+		}
+		i = end + 2
+		t.add(TokenComment, line[:i])
+	case goStateRawString:
+		end := strings.IndexByte(line, '`')
+		if end < 0 {
+			t.add(TokenString, line)
+			return t, state
+		}
+		i = end + 1
+		t.add(TokenString, line[:i])
+	}
+
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case isSpace(c):
+			end := spaceEnd(line, i)
+			t.add(TokenText, line[i:end])
+			i = end
+		case strings.HasPrefix(line[i:], "//"):
+			t.add(TokenComment, line[i:])
+			return t, 0
+		case strings.HasPrefix(line[i:], "/*"):
+			end := strings.Index(line[i+2:], "*/")
+			if end < 0 {
+				t.add(TokenComment, line[i:])
+				return t, goStateBlockComment
+			}
+			end += i + 4
+			t.add(TokenComment, line[i:end])
+			i = end
+		case c == '"' || c == '\'':
+			end, _ := quotedEnd(line, i, true)
+			t.add(TokenString, line[i:end])
+			i = end
+		case c == '`':
+			end := strings.IndexByte(line[i+1:], '`')
+			if end < 0 {
+				t.add(TokenString, line[i:])
+				return t, goStateRawString
+			}
+			end += i + 2
+			t.add(TokenString, line[i:end])
+			i = end
+		case isDigit(c) || (c == '.' && i+1 < len(line) && isDigit(line[i+1])):
+			end := numberEnd(line, i)
+			t.add(TokenNumber, line[i:end])
+			i = end
+		case strings.ContainsRune("+-*/%&|^<>=!:~", rune(c)):
+			end := i + 1
+			for end < len(line) && strings.ContainsRune("+-*/%&|^<>=!:~", rune(line[end])) &&
+				!strings.HasPrefix(line[end:], "//") && !strings.HasPrefix(line[end:], "/*") {
+				end++
+			}
+			t.add(TokenOperator, line[i:end])
+			i = end
+		case strings.ContainsRune("()[]{},;.", rune(c)):
+			t.add(TokenPunctuation, line[i:i+1])
+			i++
+		default:
+			end := identEnd(line, i)
+			if end == i {
+				_, end = nextRune(line, i)
+				t.add(TokenText, line[i:end])
+				i = end
+				continue
+			}
+			word := line[i:end]
+			switch {
+			case goKeywords[word]:
+				t.add(TokenKeyword, word)
+			case goBuiltins[word]:
+				t.add(TokenBuiltin, word)
+			case goLiterals[word]:
+				t.add(TokenLiteral, word)
+			default:
+				t.add(TokenName, word)
+			}
+			i = end
+		}
+	}
+	return t, 0
+}
@@ -0,0 +1,71 @@
+package syntax
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+type jsonLexer struct{}
+
+// JSON returns a lexer for JSON documents. Object keys are reported as TokenKey.
+//
+// Since: 2.3
+func JSON() Lexer {
+	return jsonLexer{}
+}
+
+func (jsonLexer) Tokenize(line string, _ State) ([]Token, State) {
+	var t tokens
+	for i := 0; i < len(line); {
+		c := line[i]
+		switch {
+		case isSpace(c):
+			end := spaceEnd(line, i)
+			t.add(TokenText, line[i:end])
+			i = end
+		case c == '"':
+			end, _ := quotedEnd(line, i, true)
+			if next := spaceEnd(line, end); next < len(line) && line[next] == ':' {
+				t.add(TokenKey, line[i:end])
+			} else {
+				t.add(TokenString, line[i:end])
+			}
+			i = end
+		case c == '-' || isDigit(c):
+			end := numberEnd(line, i)
+			t.add(TokenNumber, line[i:end])
+			i = end
+		case c == '{' || c == '}' || c == '[' || c == ']' || c == ',' || c == ':':
+			t.add(TokenPunctuation, line[i:i+1])
+			i++
+		default:
+			end := identEnd(line, i)
+			if end == i {
+				_, end = nextRune(line, i)
+			}
+			switch word := line[i:end]; word {
+			case "true", "false", "null":
+				t.add(TokenLiteral, word)
+			default:
+				t.add(TokenText, word)
+			}
+			i = end
+		}
+	}
+	return t, 0
+}
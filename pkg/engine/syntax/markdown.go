@@ -0,0 +1,190 @@
+package syntax
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "strings"
+
+const (
+	markdownStateBacktickFence State = iota + 1
+	markdownStateTildeFence
+)
+
+type markdownLexer struct{}
+
+// Markdown returns a lexer for Markdown documents.
+//
+// Since: 2.3
+func Markdown() Lexer {
+	return markdownLexer{}
+}
+
+func (markdownLexer) Tokenize(line string, state State) ([]Token, State) {
+	var t tokens
+	indent := spaceEnd(line, 0)
+	rest := line[indent:]
+	if state != 0 {
+		fence := "```"
+		if state == markdownStateTildeFence {
+			fence = "~~~"
+		}
+		if strings.HasPrefix(rest, fence) {
+			t.add(TokenPunctuation, line)
+			return t, 0
+		}
+		t.add(TokenCode, line)
+		return t, state
+	}
+
+	switch {
+	case strings.HasPrefix(rest, "```"):
+		t.add(TokenPunctuation, line)
+		return t, markdownStateBacktickFence
+	case strings.HasPrefix(rest, "~~~"):
+		t.add(TokenPunctuation, line)
+		return t, markdownStateTildeFence
+	case indent >= 4:
+		t.add(TokenCode, line)
+		return t, 0
+	case markdownIsHeading(rest):
+		t.add(TokenHeading, line)
+		return t, 0
+	case markdownIsRule(rest):
+		t.add(TokenPunctuation, line)
+		return t, 0
+	}
+
+	t.add(TokenText, line[:indent])
+	i := indent
+	for i < len(line) {
+		end := markdownMarkerEnd(line, i)
+		if end == i {
+			break
+		}
+		t.add(TokenPunctuation, line[i:end])
+		next := spaceEnd(line, end)
+		t.add(TokenText, line[end:next])
+		i = next
+	}
+	markdownInline(&t, line[i:])
+	return t, 0
+}
+
+func markdownIsHeading(s string) bool {
+	level := 0
+	for level < len(s) && s[level] == '#' {
+		level++
+	}
+	return level >= 1 && level <= 6 && (level == len(s) || isSpace(s[level]))
+}
+
+func markdownIsRule(s string) bool {
+	s = strings.ReplaceAll(strings.TrimSpace(s), " ", "")
+	return len(s) >= 3 && (strings.Count(s, "-") == len(s) || strings.Count(s, "*") == len(s) ||
+		strings.Count(s, "_") == len(s))
+}
+
+// markdownMarkerEnd returns the end of the block quote or list marker at i, or i if there is none.
+func markdownMarkerEnd(line string, i int) int {
+	if i >= len(line) {
+		return i
+	}
+
+	followedBySpace := func(j int) bool {
+		return j == len(line) || isSpace(line[j])
+	}
+	switch c := line[i]; {
+	case c == '>':
+		return i + 1
+	case (c == '-' || c == '*' || c == '+') && followedBySpace(i+1):
+		return i + 1
+	case isDigit(c):
+		end := i
+		for end < len(line) && isDigit(line[end]) {
+			end++
+		}
+		if end < len(line) && (line[end] == '.' || line[end] == ')') && followedBySpace(end+1) {
+			return end + 1
+		}
+	}
+	return i
+}
+
+// markdownInline adds the tokens of inline text, with code spans, emphasis and links.
+func markdownInline(t *tokens, text string) {
+	for i := 0; i < len(text); {
+		switch c := text[i]; {
+		case c == '\\' && i+1 < len(text):
+			t.add(TokenText, text[i:i+2])
+			i += 2
+			continue
+		case c == '`':
+			ticks := i
+			for ticks < len(text) && text[ticks] == '`' {
+				ticks++
+			}
+			if end := strings.Index(text[ticks:], text[i:ticks]); end >= 0 {
+				end += ticks + (ticks - i)
+				t.add(TokenCode, text[i:end])
+				i = end
+				continue
+			}
+		case c == '*' || c == '_':
+			delim := text[i : i+1]
+			typ := TokenEmphasis
+			if strings.HasPrefix(text[i:], strings.Repeat(delim, 2)) {
+				delim += delim
+				typ = TokenStrong
+			}
+			start := i + len(delim)
+			if end := strings.Index(text[start:], delim); end > 0 {
+				end += start + len(delim)
+				t.add(typ, text[i:end])
+				i = end
+				continue
+			}
+		case c == '[' || (c == '!' && i+1 < len(text) && text[i+1] == '['):
+			if end := markdownLinkEnd(text, i); end > i {
+				t.add(TokenLink, text[i:end])
+				i = end
+				continue
+			}
+		}
+
+		_, end := nextRune(text, i)
+		t.add(TokenText, text[i:end])
+		i = end
+	}
+}
+
+// markdownLinkEnd returns the end of a link or image of the form [text](url) starting at i, or i if there is none.
+func markdownLinkEnd(text string, i int) int {
+	open := strings.IndexByte(text[i:], '[')
+	closing := strings.Index(text[i+open:], "](")
+	if closing < 0 {
+		return i
+	}
+	urlStart := i + open + closing + 2
+	end := strings.IndexByte(text[urlStart:], ')')
+	if end < 0 {
+		return i
+	}
+	return urlStart + end + 1
+}
@@ -0,0 +1,231 @@
+// Package syntax provides line based lexers that split source code into tokens for syntax highlighting.
+//
+// Since: 2.3
+package syntax
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"path/filepath"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TokenType describes the kind of text that a Token contains.
+//
+// Since: 2.3
+type TokenType int
+
+const (
+	// TokenText is text that has no special meaning, including white space.
+	TokenText TokenType = iota
+	// TokenKeyword is a reserved word of the language.
+	TokenKeyword
+	// TokenBuiltin is a predeclared type or function, or a tag.
+	TokenBuiltin
+	// TokenName is an identifier, or an anchor or alias in YAML.
+	TokenName
+	// TokenKey is the key of a mapping, such as a JSON object or YAML map.
+	TokenKey
+	// TokenString is a string or character literal, or a plain scalar value.
+	TokenString
+	// TokenNumber is a numeric literal.
+	TokenNumber
+	// TokenLiteral is a constant value such as true, false or null.
+	TokenLiteral
+	// TokenComment is a comment.
+	TokenComment
+	// TokenOperator is an operator.
+	TokenOperator
+	// TokenPunctuation is a delimiter or structural marker such as a bracket or list bullet.
+	TokenPunctuation
+	// TokenHeading is a document heading.
+	TokenHeading
+	// TokenEmphasis is emphasised text.
+	TokenEmphasis
+	// TokenStrong is strongly emphasised text.
+	TokenStrong
+	// TokenCode is inline or block code inside a document.
+	TokenCode
+	// TokenLink is a hyperlink or image reference.
+	TokenLink
+)
+
+// Token is a piece of a line of text with the type that it should be highlighted as.
+//
+// Since: 2.3
+type Token struct {
+	Type TokenType
+	Text string
+}
+
+// State carries lexer context from the end of one line to the start of the next, such as being
+// inside a multi-line comment. The state at the start of a document is 0.
+//
+// Since: 2.3
+type State int
+
+// Lexer splits source code into tokens one line at a time.
+//
+// Since: 2.3
+type Lexer interface {
+	// Tokenize splits a line, without its line ending, into tokens whose texts join to form the line.
+	// The state passed in is the one returned for the previous line, and the returned state is
+	// passed when tokenizing the line that follows.
+	Tokenize(line string, state State) ([]Token, State)
+}
+
+// ForFilename returns the lexer for the language of a file, chosen by its extension,
+// or nil if the language is not known.
+//
+// Since: 2.3
+func ForFilename(name string) Lexer {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".go":
+		return Go()
+	case ".json":
+		return JSON()
+	case ".yaml", ".yml":
+		return YAML()
+	case ".md", ".markdown":
+		return Markdown()
+	}
+	return nil
+}
+
+// ForLanguage returns the lexer for a language by name, such as the info string of a fenced code block
+// in markdown, or nil if the language is not known. Names are matched ignoring case and file extensions
+// are accepted as names.
+//
+// Since: 2.3
+func ForLanguage(name string) Lexer {
+	switch name = strings.ToLower(strings.TrimSpace(name)); name {
+	case "":
+		return nil
+	case "golang":
+		return Go()
+	}
+	return ForFilename("." + name)
+}
+
+// Tokenize splits a whole document into lines of tokens using the given lexer.
+//
+// Since: 2.3
+func Tokenize(l Lexer, text string) [][]Token {
+	lines := strings.Split(text, "\n")
+	tokens := make([][]Token, len(lines))
+	state := State(0)
+	for i, line := range lines {
+		tokens[i], state = l.Tokenize(line, state)
+	}
+	return tokens
+}
+
+// tokens builds the token list of a line, joining adjacent tokens of the same type.
+type tokens []Token
+
+func (t *tokens) add(typ TokenType, text string) {
+	if text == "" {
+		return
+	}
+	if n := len(*t); n > 0 && (*t)[n-1].Type == typ {
+		(*t)[n-1].Text += text
+		return
+	}
+	*t = append(*t, Token{Type: typ, Text: text})
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t'
+}
+
+// identEnd returns the end of the identifier starting at i, which is i if there is none.
+func identEnd(line string, i int) int {
+	start := i
+	for i < len(line) {
+		r, size := utf8.DecodeRuneInString(line[i:])
+		if !unicode.IsLetter(r) && r != '_' && (i == start || !unicode.IsDigit(r)) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+// numberEnd returns the end of the number starting at i, accepting the forms used by Go, JSON and YAML.
+func numberEnd(line string, i int) int {
+	if i < len(line) && (line[i] == '-' || line[i] == '+') {
+		i++
+	}
+	if i+1 < len(line) && line[i] == '0' && strings.ContainsRune("xXoObB", rune(line[i+1])) {
+		i += 2
+	}
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case isDigit(c), c == '.', c == '_', c >= 'a' && c <= 'f', c >= 'A' && c <= 'F':
+			if (c == 'e' || c == 'E') && i+1 < len(line) && (line[i+1] == '-' || line[i+1] == '+') {
+				i++
+			}
+			i++
+		case c == 'i' || c == 'p' || c == 'P':
+			i++
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// quotedEnd returns the end of the quoted string starting at i and whether it was closed on this line.
+func quotedEnd(line string, i int, escapes bool) (int, bool) {
+	quote := line[i]
+	for i++; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			if escapes {
+				i++
+			}
+		case quote:
+			return i + 1, true
+		}
+	}
+	return len(line), false
+}
+
+// nextRune returns the rune starting at i and the index after it.
+func nextRune(line string, i int) (rune, int) {
+	r, size := utf8.DecodeRuneInString(line[i:])
+	return r, i + size
+}
+
+// spaceEnd returns the end of the white space starting at i.
+func spaceEnd(line string, i int) int {
+	for i < len(line) && isSpace(line[i]) {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,128 @@
+package syntax_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bhojpur/gui/pkg/engine/syntax"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typed struct {
+	typ  syntax.TokenType
+	text string
+}
+
+func assertTokens(t *testing.T, l syntax.Lexer, source string, expected [][]typed) {
+	lines := syntax.Tokenize(l, source)
+	assert.Equal(t, len(expected), len(lines))
+	for i, line := range lines {
+		var joined strings.Builder
+		var got []typed
+		for _, tok := range line {
+			joined.WriteString(tok.Text)
+			if tok.Type != syntax.TokenText || strings.TrimSpace(tok.Text) != "" {
+				got = append(got, typed{tok.Type, tok.Text})
+			}
+		}
+		assert.Equal(t, strings.Split(source, "\n")[i], joined.String())
+		if i < len(expected) {
+			assert.Equal(t, expected[i], got, "line %d", i)
+		}
+	}
+}
+
+func TestForFilename(t *testing.T) {
+	assert.Equal(t, syntax.Go(), syntax.ForFilename("main.go"))
+	assert.Equal(t, syntax.JSON(), syntax.ForFilename("data.JSON"))
+	assert.Equal(t, syntax.YAML(), syntax.ForFilename("config.yml"))
+	assert.Equal(t, syntax.YAML(), syntax.ForFilename("/etc/app.yaml"))
+	assert.Equal(t, syntax.Markdown(), syntax.ForFilename("README.md"))
+	assert.Nil(t, syntax.ForFilename("image.png"))
+}
+
+func TestForLanguage(t *testing.T) {
+	assert.Equal(t, syntax.Go(), syntax.ForLanguage("go"))
+	assert.Equal(t, syntax.Go(), syntax.ForLanguage("Golang"))
+	assert.Equal(t, syntax.YAML(), syntax.ForLanguage("yml"))
+	assert.Equal(t, syntax.Markdown(), syntax.ForLanguage("markdown"))
+	assert.Nil(t, syntax.ForLanguage(""))
+	assert.Nil(t, syntax.ForLanguage("brainfuck"))
+}
+
+func TestGo(t *testing.T) {
+	assertTokens(t, syntax.Go(), "func main() { // run\n\tx := len(\"a\\\"b\") + 0x1F /* one\ntwo */ + `raw\nstring` != nil", [][]typed{
+		{{syntax.TokenKeyword, "func"}, {syntax.TokenName, "main"}, {syntax.TokenPunctuation, "()"},
+			{syntax.TokenPunctuation, "{"}, {syntax.TokenComment, "// run"}},
+		{{syntax.TokenName, "x"}, {syntax.TokenOperator, ":="}, {syntax.TokenBuiltin, "len"},
+			{syntax.TokenPunctuation, "("}, {syntax.TokenString, "\"a\\\"b\""}, {syntax.TokenPunctuation, ")"},
+			{syntax.TokenOperator, "+"}, {syntax.TokenNumber, "0x1F"}, {syntax.TokenComment, "/* one"}},
+		{{syntax.TokenComment, "two */"}, {syntax.TokenOperator, "+"}, {syntax.TokenString, "`raw"}},
+		{{syntax.TokenString, "string`"}, {syntax.TokenOperator, "!="}, {syntax.TokenLiteral, "nil"}},
+	})
+}
+
+func TestJSON(t *testing.T) {
+	assertTokens(t, syntax.JSON(), "{\"name\": \"gui\",\n \"size\" : -1.5e3, \"ok\": [true, null]}", [][]typed{
+		{{syntax.TokenPunctuation, "{"}, {syntax.TokenKey, "\"name\""}, {syntax.TokenPunctuation, ":"},
+			{syntax.TokenString, "\"gui\""}, {syntax.TokenPunctuation, ","}},
+		{{syntax.TokenKey, "\"size\""}, {syntax.TokenPunctuation, ":"}, {syntax.TokenNumber, "-1.5e3"},
+			{syntax.TokenPunctuation, ","}, {syntax.TokenKey, "\"ok\""}, {syntax.TokenPunctuation, ":"},
+			{syntax.TokenPunctuation, "["}, {syntax.TokenLiteral, "true"}, {syntax.TokenPunctuation, ","},
+			{syntax.TokenLiteral, "null"}, {syntax.TokenPunctuation, "]}"}},
+	})
+}
+
+func TestYAML(t *testing.T) {
+	source := "---\nserver: # main\n  port: 8080\n  hosts: [a, \"b\"]\n  - &x plain text\nscript: |\n  echo: hi\n\n  done\nlast: yes"
+	assertTokens(t, syntax.YAML(), source, [][]typed{
+		{{syntax.TokenPunctuation, "---"}},
+		{{syntax.TokenKey, "server"}, {syntax.TokenPunctuation, ":"}, {syntax.TokenComment, "# main"}},
+		{{syntax.TokenKey, "port"}, {syntax.TokenPunctuation, ":"}, {syntax.TokenNumber, "8080"}},
+		{{syntax.TokenKey, "hosts"}, {syntax.TokenPunctuation, ":"}, {syntax.TokenPunctuation, "["},
+			{syntax.TokenString, "a"}, {syntax.TokenPunctuation, ","}, {syntax.TokenString, "\"b\""},
+			{syntax.TokenPunctuation, "]"}},
+		{{syntax.TokenPunctuation, "-"}, {syntax.TokenName, "&x"}, {syntax.TokenString, "plain text"}},
+		{{syntax.TokenKey, "script"}, {syntax.TokenPunctuation, ":"}, {syntax.TokenPunctuation, "|"}},
+		{{syntax.TokenString, "echo: hi"}},
+		nil,
+		{{syntax.TokenString, "done"}},
+		{{syntax.TokenKey, "last"}, {syntax.TokenPunctuation, ":"}, {syntax.TokenLiteral, "yes"}},
+	})
+}
+
+func TestMarkdown(t *testing.T) {
+	source := "# Title\nSome *em* and **strong** `code` [link](http://x).\n- item\n```go\nx := 1\n```\n> quote"
+	assertTokens(t, syntax.Markdown(), source, [][]typed{
+		{{syntax.TokenHeading, "# Title"}},
+		{{syntax.TokenText, "Some "}, {syntax.TokenEmphasis, "*em*"}, {syntax.TokenText, " and "},
+			{syntax.TokenStrong, "**strong**"}, {syntax.TokenCode, "`code`"},
+			{syntax.TokenLink, "[link](http://x)"}, {syntax.TokenText, "."}},
+		{{syntax.TokenPunctuation, "-"}, {syntax.TokenText, " item"}},
+		{{syntax.TokenPunctuation, "```go"}},
+		{{syntax.TokenCode, "x := 1"}},
+		{{syntax.TokenPunctuation, "```"}},
+		{{syntax.TokenPunctuation, ">"}, {syntax.TokenText, " quote"}},
+	})
+}
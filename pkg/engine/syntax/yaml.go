@@ -0,0 +1,185 @@
+package syntax
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import "strings"
+
+type yamlLexer struct{}
+
+// YAML returns a lexer for YAML documents. Mapping keys are reported as TokenKey and
+// the lines of block scalars as TokenString.
+//
+// Since: 2.3
+func YAML() Lexer {
+	return yamlLexer{}
+}
+
+// Tokenize splits a line of YAML. Inside a block scalar the state is one more than the
+// indentation of the line that started it.
+func (yamlLexer) Tokenize(line string, state State) ([]Token, State) {
+	var t tokens
+	indent := spaceEnd(line, 0)
+	if state > 0 {
+		if indent == len(line) || indent > int(state)-1 {
+			t.add(TokenText, line[:indent])
+			t.add(TokenString, line[indent:])
+			return t, state
+		}
+		state = 0
+	}
+
+	t.add(TokenText, line[:indent])
+	i := indent
+	if rest := line[i:]; (strings.HasPrefix(rest, "---") || strings.HasPrefix(rest, "...")) &&
+		(len(rest) == 3 || isSpace(rest[3])) {
+		t.add(TokenPunctuation, rest[:3])
+		i += 3
+	}
+	for i < len(line) && line[i] == '-' && (i+1 == len(line) || isSpace(line[i+1])) {
+		end := spaceEnd(line, i+1)
+		t.add(TokenPunctuation, "-")
+		t.add(TokenText, line[i+1:end])
+		i = end
+	}
+	if end := yamlKeyEnd(line, i); end > i {
+		t.add(TokenKey, line[i:end])
+		t.add(TokenPunctuation, ":")
+		i = end + 1
+	}
+
+	return t, yamlValue(&t, line, i, indent)
+}
+
+// yamlKeyEnd returns the index of the colon ending a mapping key that starts at i, or i if there is no key.
+func yamlKeyEnd(line string, i int) int {
+	if i >= len(line) {
+		return i
+	}
+
+	isColon := func(j int) bool {
+		return j < len(line) && line[j] == ':' && (j+1 == len(line) || isSpace(line[j+1]))
+	}
+	switch line[i] {
+	case '"', '\'':
+		end, closed := quotedEnd(line, i, line[i] == '"')
+		if closed && isColon(end) {
+			return end
+		}
+		return i
+	case '#', '[', '{', '&', '*', '!', '|', '>':
+		return i
+	}
+
+	for j := i; j < len(line); j++ {
+		if line[j] == '#' && isSpace(line[j-1]) {
+			return i
+		}
+		if isColon(j) {
+			return j
+		}
+	}
+	return i
+}
+
+// yamlValue adds the tokens of the node starting at i and returns the state for the next line.
+func yamlValue(t *tokens, line string, i, indent int) State {
+	state := State(0)
+	flow := 0
+	for i < len(line) {
+		c := line[i]
+		switch {
+		case isSpace(c):
+			end := spaceEnd(line, i)
+			t.add(TokenText, line[i:end])
+			i = end
+		case c == '#' && (i == 0 || isSpace(line[i-1])):
+			t.add(TokenComment, line[i:])
+			return state
+		case c == '"' || c == '\'':
+			end, _ := quotedEnd(line, i, c == '"')
+			t.add(TokenString, line[i:end])
+			i = end
+		case (c == '|' || c == '>') && flow == 0:
+			end := i + 1
+			for end < len(line) && strings.ContainsRune("+-0123456789", rune(line[end])) {
+				end++
+			}
+			t.add(TokenPunctuation, line[i:end])
+			state = State(indent + 1)
+			i = end
+		case c == '&' || c == '*' || c == '!':
+			end := i + 1
+			for end < len(line) && !isSpace(line[end]) && !strings.ContainsRune(",[]{}", rune(line[end])) {
+				end++
+			}
+			if c == '!' {
+				t.add(TokenBuiltin, line[i:end])
+			} else {
+				t.add(TokenName, line[i:end])
+			}
+			i = end
+		case c == '[' || c == '{':
+			flow++
+			t.add(TokenPunctuation, line[i:i+1])
+			i++
+		case c == ']' || c == '}' || (c == ',' && flow > 0):
+			if c != ',' && flow > 0 {
+				flow--
+			}
+			t.add(TokenPunctuation, line[i:i+1])
+			i++
+		case c == ':' && (i+1 == len(line) || isSpace(line[i+1])):
+			t.add(TokenPunctuation, ":")
+			i++
+		default:
+			end := i
+			for end < len(line) {
+				if isSpace(line[end]) && end+1 < len(line) && line[end+1] == '#' {
+					break
+				}
+				if flow > 0 && strings.ContainsRune(",[]{}", rune(line[end])) ||
+					line[end] == ':' && (end+1 == len(line) || isSpace(line[end+1])) {
+					break
+				}
+				end++
+			}
+			scalar := strings.TrimRight(line[i:end], " \t")
+			if scalar == "" {
+				scalar = line[i : i+1]
+			}
+			t.add(yamlScalarType(scalar), scalar)
+			i += len(scalar)
+		}
+	}
+	return state
+}
+
+// yamlScalarType returns the token type of an unquoted value.
+func yamlScalarType(s string) TokenType {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no", "on", "off", "null", "~":
+		return TokenLiteral
+	}
+	if strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' }) >= 0 && numberEnd(s, 0) == len(s) {
+		return TokenNumber
+	}
+	return TokenString
+}
@@ -0,0 +1,70 @@
+package test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/accessibility"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AccessibilityTree returns the semantic tree of the accessible objects on the canvas.
+//
+// Since: 2.3
+func AccessibilityTree(c gui.Canvas) *accessibility.Node {
+	return accessibility.Tree(c)
+}
+
+// AssertAccessible asserts that the canvas contains an accessible object with the given role and name
+// and returns its node. An empty name matches any object of that role.
+//
+// Since: 2.3
+func AssertAccessible(t *testing.T, c gui.Canvas, role gui.AccessibleRole, name string, msgAndArgs ...interface{}) *accessibility.Node {
+	node := FindAccessible(c, role, name)
+	if node == nil {
+		assert.Fail(t, "no accessible "+string(role)+" named \""+name+"\" found", msgAndArgs...)
+	}
+	return node
+}
+
+// FindAccessible returns the first accessible object on the canvas with the given role and name.
+// An empty name matches any object of that role. If no object matches nil is returned.
+//
+// Since: 2.3
+func FindAccessible(c gui.Canvas, role gui.AccessibleRole, name string) *accessibility.Node {
+	return accessibility.Tree(c).Find(role, name)
+}
+
+// TapAccessible taps the centre of the first accessible object on the canvas with the given role and name.
+// It returns false if no such object exists.
+//
+// Since: 2.3
+func TapAccessible(c gui.Canvas, role gui.AccessibleRole, name string) bool {
+	node := FindAccessible(c, role, name)
+	if node == nil {
+		return false
+	}
+	TapCanvas(c, node.Position.Add(gui.NewPos(node.Size.Width/2, node.Size.Height/2)))
+	return true
+}
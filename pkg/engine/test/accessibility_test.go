@@ -0,0 +1,75 @@
+package test_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/container"
+	"github.com/bhojpur/gui/pkg/engine/test"
+	"github.com/bhojpur/gui/pkg/engine/widget"
+)
+
+func TestFindAccessible(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	entry := widget.NewEntry()
+	entry.SetPlaceHolder("Name")
+	w := test.NewWindow(container.NewVBox(widget.NewButton("Cancel", nil), entry))
+	defer w.Close()
+
+	node := test.FindAccessible(w.Canvas(), gui.AccessibleRoleTextField, "Name")
+	require.NotNil(t, node)
+	assert.Same(t, entry, node.Object)
+	assert.Nil(t, test.FindAccessible(w.Canvas(), gui.AccessibleRoleButton, "OK"))
+
+	tt := &testing.T{}
+	assert.Nil(t, test.AssertAccessible(tt, w.Canvas(), gui.AccessibleRoleButton, "OK"))
+	assert.True(t, tt.Failed())
+	assert.NotNil(t, test.AssertAccessible(t, w.Canvas(), gui.AccessibleRoleButton, "Cancel"))
+}
+
+func TestTapAccessible(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	tapped := ""
+	check := widget.NewCheck("Remember", nil)
+	w := test.NewWindow(container.NewVBox(
+		widget.NewButton("OK", func() { tapped = "OK" }),
+		widget.NewButton("Cancel", func() { tapped = "Cancel" }),
+		check,
+	))
+	defer w.Close()
+	w.Resize(gui.NewSize(200, 200))
+
+	assert.True(t, test.TapAccessible(w.Canvas(), gui.AccessibleRoleButton, "Cancel"))
+	assert.Equal(t, "Cancel", tapped)
+	assert.True(t, test.TapAccessible(w.Canvas(), gui.AccessibleRoleCheckBox, "Remember"))
+	assert.True(t, check.Checked)
+	assert.True(t, test.AccessibilityTree(w.Canvas()).Find(gui.AccessibleRoleCheckBox, "").State.Checked)
+	assert.False(t, test.TapAccessible(w.Canvas(), gui.AccessibleRoleButton, "Missing"))
+}
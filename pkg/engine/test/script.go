@@ -0,0 +1,478 @@
+package test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/internal/driver"
+)
+
+// Script is a recorded sequence of input events and checkpoints which can be played
+// back against a window. Its textual form has one step per line:
+//
+//	resize 320 240
+//	wait 150ms
+//	tap 10 20
+//	secondary 10 20
+//	doubletap 10 20
+//	drag 10 20 50 0
+//	scroll 10 20 0 -10
+//	move 10 20
+//	type "Hello\tWorld"
+//	key Return
+//	shortcut copy
+//	focus next
+//	markup dialog_open.xml
+//	image dialog_open.png
+//
+// Positions are absolute canvas positions, followed by the distance for drag and
+// scroll. Checkpoints assert the markup or image snapshot of the canvas against a
+// master file relative to the `testdata` directory of the test. Empty lines and lines
+// starting with '#' are ignored.
+//
+// Since: 2.3
+type Script struct {
+	Steps []ScriptStep
+}
+
+// ScriptStep is a single line of a Script.
+//
+// Since: 2.3
+type ScriptStep struct {
+	// Action is the first word of the line, e.g. "tap" or "markup"
+	Action string
+	// Args are the remaining words, the text of a "type" step is unquoted
+	Args []string
+}
+
+// scriptArgs is the number of arguments expected by each action
+var scriptArgs = map[string]int{
+	"resize":    2,
+	"wait":      1,
+	"tap":       2,
+	"secondary": 2,
+	"doubletap": 2,
+	"drag":      4,
+	"scroll":    4,
+	"move":      2,
+	"type":      1,
+	"key":       1,
+	"shortcut":  1,
+	"focus":     1,
+	"markup":    1,
+	"image":     1,
+}
+
+// LoadScript reads a script from a file.
+//
+// Since: 2.3
+func LoadScript(path string) (*Script, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseScript(f)
+}
+
+// ParseScript reads a script in its textual form.
+//
+// Since: 2.3
+func ParseScript(r io.Reader) (*Script, error) {
+	s := &Script{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		action, rest := text, ""
+		if i := strings.IndexAny(text, " \t"); i >= 0 {
+			action, rest = text[:i], strings.TrimSpace(text[i+1:])
+		}
+		var args []string
+		if action == "type" {
+			chars, err := strconv.Unquote(rest)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: type expects a quoted string: %v", line, err)
+			}
+			args = []string{chars}
+		} else {
+			args = strings.Fields(rest)
+		}
+
+		step := ScriptStep{Action: action, Args: args}
+		if err := step.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+		s.Steps = append(s.Steps, step)
+	}
+	return s, scanner.Err()
+}
+
+// String returns the textual form of the script.
+func (s *Script) String() string {
+	var b strings.Builder
+	for _, step := range s.Steps {
+		b.WriteString(step.String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Save writes the textual form of the script to a file.
+//
+// Since: 2.3
+func (s *Script) Save(path string) error {
+	return os.WriteFile(path, []byte(s.String()), 0644)
+}
+
+// WithoutTiming returns a copy of the script without its wait steps,
+// so that it can be played back as fast as possible.
+//
+// Since: 2.3
+func (s *Script) WithoutTiming() *Script {
+	res := &Script{}
+	for _, step := range s.Steps {
+		if step.Action != "wait" {
+			res.Steps = append(res.Steps, step)
+		}
+	}
+	return res
+}
+
+// String returns the line of the step.
+func (s ScriptStep) String() string {
+	if s.Action == "type" && len(s.Args) == 1 {
+		return "type " + strconv.Quote(s.Args[0])
+	}
+	return strings.Join(append([]string{s.Action}, s.Args...), " ")
+}
+
+func (s ScriptStep) validate() error {
+	n, ok := scriptArgs[s.Action]
+	if !ok {
+		return fmt.Errorf("unknown action %q", s.Action)
+	}
+	if len(s.Args) != n {
+		return fmt.Errorf("%s expects %d arguments, got %d", s.Action, n, len(s.Args))
+	}
+
+	switch s.Action {
+	case "wait":
+		_, err := time.ParseDuration(s.Args[0])
+		return err
+	case "type", "key", "shortcut", "markup", "image":
+		return nil
+	case "focus":
+		if s.Args[0] != "next" && s.Args[0] != "previous" {
+			return fmt.Errorf("focus expects next or previous, got %q", s.Args[0])
+		}
+		return nil
+	}
+	_, err := s.floats()
+	return err
+}
+
+func (s ScriptStep) floats() ([]float32, error) {
+	res := make([]float32, len(s.Args))
+	for i, arg := range s.Args {
+		f, err := strconv.ParseFloat(arg, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s expects numbers, got %q", s.Action, arg)
+		}
+		res[i] = float32(f)
+	}
+	return res, nil
+}
+
+// Play performs the steps of the script on the window, pausing for the wait steps.
+// The test `t` fails if a checkpoint does not match its master file, playback
+// continues nonetheless. It returns true if all checkpoints matched.
+//
+// Since: 2.3
+func (s *Script) Play(t *testing.T, w gui.Window) bool {
+	ok := true
+	for i, step := range s.Steps {
+		if err := step.validate(); err != nil {
+			t.Errorf("step %d (%s): %v", i+1, step, err)
+			return false
+		}
+		if !step.play(t, w) {
+			ok = false
+		}
+	}
+	return ok
+}
+
+func (s ScriptStep) play(t *testing.T, w gui.Window) bool {
+	c := w.Canvas()
+	switch s.Action {
+	case "wait":
+		d, _ := time.ParseDuration(s.Args[0])
+		time.Sleep(d)
+	case "resize":
+		f, _ := s.floats()
+		w.Resize(gui.NewSize(f[0], f[1]))
+	case "tap":
+		pos := s.position()
+		click(c, pos, desktop.MouseButtonPrimary)
+		TapCanvas(c, pos)
+	case "secondary":
+		pos := s.position()
+		click(c, pos, desktop.MouseButtonSecondary)
+		o, p := findObject(c, pos, func(o gui.CanvasObject) bool {
+			_, ok := o.(gui.SecondaryTappable)
+			return ok
+		})
+		if o != nil {
+			handleFocusOnTap(c, o)
+			o.(gui.SecondaryTappable).TappedSecondary(&gui.PointEvent{AbsolutePosition: pos, Position: p})
+		}
+	case "doubletap":
+		pos := s.position()
+		o, p := findObject(c, pos, func(o gui.CanvasObject) bool {
+			_, ok := o.(gui.DoubleTappable)
+			return ok
+		})
+		if o != nil {
+			handleFocusOnTap(c, o)
+			o.(gui.DoubleTappable).DoubleTapped(&gui.PointEvent{AbsolutePosition: pos, Position: p})
+		}
+	case "drag":
+		f, _ := s.floats()
+		Drag(c, gui.NewPos(f[0], f[1]), f[2], f[3])
+	case "scroll":
+		f, _ := s.floats()
+		Scroll(c, gui.NewPos(f[0], f[1]), f[2], f[3])
+	case "move":
+		MoveMouse(c, s.position())
+	case "type":
+		for _, r := range s.Args[0] {
+			if f := c.Focused(); f != nil {
+				f.TypedRune(r)
+			} else if h := c.OnTypedRune(); h != nil {
+				h(r)
+			}
+		}
+	case "key":
+		ev := &gui.KeyEvent{Name: gui.KeyName(s.Args[0])}
+		if f := c.Focused(); f != nil {
+			f.TypedKey(ev)
+		} else if h := c.OnTypedKey(); h != nil {
+			h(ev)
+		}
+	case "shortcut":
+		var sc gui.Shortcut
+		switch s.Args[0] {
+		case "copy":
+			sc = &gui.ShortcutCopy{Clipboard: w.Clipboard()}
+		case "cut":
+			sc = &gui.ShortcutCut{Clipboard: w.Clipboard()}
+		case "paste":
+			sc = &gui.ShortcutPaste{Clipboard: w.Clipboard()}
+		case "selectall":
+			sc = &gui.ShortcutSelectAll{}
+		case "undo":
+			sc = &gui.ShortcutUndo{}
+		case "redo":
+			sc = &gui.ShortcutRedo{}
+		default:
+			t.Errorf("unknown shortcut %q", s.Args[0])
+			return false
+		}
+		if f, ok := c.Focused().(gui.Shortcutable); ok {
+			f.TypedShortcut(sc)
+		}
+	case "focus":
+		if s.Args[0] == "next" {
+			FocusNext(c)
+		} else {
+			FocusPrevious(c)
+		}
+	case "markup":
+		return AssertRendersToMarkup(t, s.Args[0], c, "checkpoint %q", s.Args[0])
+	case "image":
+		return AssertImageMatches(t, s.Args[0], c.Capture(), "checkpoint %q", s.Args[0])
+	}
+	return true
+}
+
+func (s ScriptStep) position() gui.Position {
+	f, _ := s.floats()
+	return gui.NewPos(f[0], f[1])
+}
+
+// click sends the mouse down and up events which precede a tap on desktop
+func click(c gui.Canvas, pos gui.Position, button desktop.MouseButton) {
+	if gui.CurrentDevice().IsMobile() {
+		return
+	}
+	o, p := findObject(c, pos, func(o gui.CanvasObject) bool {
+		_, ok := o.(desktop.Mouseable)
+		return ok
+	})
+	if o == nil {
+		return
+	}
+	ev := &desktop.MouseEvent{PointEvent: gui.PointEvent{AbsolutePosition: pos, Position: p}, Button: button}
+	o.(desktop.Mouseable).MouseDown(ev)
+	o.(desktop.Mouseable).MouseUp(ev)
+}
+
+func findObject(c gui.Canvas, pos gui.Position, matches func(gui.CanvasObject) bool) (gui.CanvasObject, gui.Position) {
+	o, p, _ := driver.FindObjectAtPositionMatching(pos, matches, c.Overlays().Top(), c.Content())
+	return o, p
+}
+
+// Recorder collects input events into a Script. The time passed between two
+// events is recorded as a wait step. It is safe for concurrent use.
+//
+// Since: 2.3
+type Recorder struct {
+	mu     sync.Mutex
+	script Script
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRecorder creates a recorder with an empty script.
+//
+// Since: 2.3
+func NewRecorder() *Recorder {
+	return &Recorder{now: time.Now}
+}
+
+// Script returns a copy of the steps recorded so far.
+func (r *Recorder) Script() *Script {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return &Script{Steps: append([]ScriptStep(nil), r.script.Steps...)}
+}
+
+// Resize records the resizing of the window.
+func (r *Recorder) Resize(size gui.Size) {
+	r.record("resize", formatFloat(size.Width), formatFloat(size.Height))
+}
+
+// Tap records a primary tap at an absolute position.
+func (r *Recorder) Tap(pos gui.Position) {
+	r.recordAt("tap", pos)
+}
+
+// TapSecondary records a secondary tap at an absolute position.
+func (r *Recorder) TapSecondary(pos gui.Position) {
+	r.recordAt("secondary", pos)
+}
+
+// DoubleTap records a double tap at an absolute position.
+func (r *Recorder) DoubleTap(pos gui.Position) {
+	r.recordAt("doubletap", pos)
+}
+
+// Drag records dragging from an absolute position by the given distance.
+func (r *Recorder) Drag(pos gui.Position, deltaX, deltaY float32) {
+	r.record("drag", formatFloat(pos.X), formatFloat(pos.Y), formatFloat(deltaX), formatFloat(deltaY))
+}
+
+// Scroll records scrolling at an absolute position by the given distance.
+func (r *Recorder) Scroll(pos gui.Position, deltaX, deltaY float32) {
+	r.record("scroll", formatFloat(pos.X), formatFloat(pos.Y), formatFloat(deltaX), formatFloat(deltaY))
+}
+
+// MoveMouse records a mouse movement to an absolute position.
+func (r *Recorder) MoveMouse(pos gui.Position) {
+	r.recordAt("move", pos)
+}
+
+// Type records typed characters. Consecutive characters are joined into a single step.
+func (r *Recorder) Type(chars string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if n := len(r.script.Steps); n > 0 && r.script.Steps[n-1].Action == "type" {
+		r.script.Steps[n-1].Args[0] += chars
+		r.last = r.now()
+		return
+	}
+	r.add("type", chars)
+}
+
+// TypedKey records a key press.
+func (r *Recorder) TypedKey(name gui.KeyName) {
+	r.record("key", string(name))
+}
+
+// TypedShortcut records a shortcut, one of "copy", "cut", "paste" or "selectall".
+func (r *Recorder) TypedShortcut(name string) {
+	r.record("shortcut", name)
+}
+
+// Checkpoint records a markup snapshot if the file name ends with ".xml"
+// and an image snapshot otherwise.
+func (r *Recorder) Checkpoint(masterFilename string) {
+	if strings.HasSuffix(masterFilename, ".xml") {
+		r.record("markup", masterFilename)
+	} else {
+		r.record("image", masterFilename)
+	}
+}
+
+func (r *Recorder) recordAt(action string, pos gui.Position) {
+	r.record(action, formatFloat(pos.X), formatFloat(pos.Y))
+}
+
+func (r *Recorder) record(action string, args ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.add(action, args...)
+}
+
+func (r *Recorder) add(action string, args ...string) {
+	now := r.now()
+	if !r.last.IsZero() {
+		// millisecond precision keeps scripts readable
+		if wait := now.Sub(r.last).Round(time.Millisecond); wait > 0 {
+			r.script.Steps = append(r.script.Steps, ScriptStep{Action: "wait", Args: []string{wait.String()}})
+		}
+	}
+	r.last = now
+	r.script.Steps = append(r.script.Steps, ScriptStep{Action: action, Args: args})
+}
+
+func formatFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', -1, 32)
+}
@@ -0,0 +1,106 @@
+package test_test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/container"
+	"github.com/bhojpur/gui/pkg/engine/test"
+	"github.com/bhojpur/gui/pkg/engine/widget"
+)
+
+const entryScript = `# fill in the form
+resize 200 120
+tap 10 10
+tap 20 50
+type "Hello\tWorld"
+key BackSpace
+wait 1ms
+markup script_entry.xml
+`
+
+func TestParseScript(t *testing.T) {
+	s, err := test.ParseScript(strings.NewReader(entryScript))
+	require.NoError(t, err)
+	require.Len(t, s.Steps, 7)
+	assert.Equal(t, test.ScriptStep{Action: "type", Args: []string{"Hello\tWorld"}}, s.Steps[3])
+	assert.Equal(t, entryScript[strings.Index(entryScript, "\n")+1:], s.String())
+	assert.Len(t, s.WithoutTiming().Steps, 6)
+
+	for name, script := range map[string]string{
+		"unknown action": "fly 10 10",
+		"missing args":   "tap 10",
+		"not a number":   "drag 10 10 far 0",
+		"unquoted text":  "type hello",
+		"bad duration":   "wait soon",
+		"bad focus":      "focus up",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.ParseScript(strings.NewReader("resize 10 10\n" + script))
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "line 2")
+		})
+	}
+}
+
+func TestScript_Play(t *testing.T) {
+	tapped := 0
+	entry := widget.NewEntry()
+	w := test.NewWindow(container.NewVBox(widget.NewButton("Tap", func() { tapped++ }), entry))
+	defer w.Close()
+
+	s, err := test.ParseScript(strings.NewReader(entryScript))
+	require.NoError(t, err)
+	assert.True(t, s.Play(t, w))
+	assert.Equal(t, 1, tapped)
+	assert.Equal(t, "Hello\tWorl", entry.Text)
+}
+
+func TestRecorder(t *testing.T) {
+	r := test.NewRecorder()
+	r.Resize(gui.NewSize(200, 120))
+	r.Tap(gui.NewPos(10, 10))
+	r.Tap(gui.NewPos(20, 50))
+	r.Type("Hello")
+	r.Type("\tWorld")
+	time.Sleep(5 * time.Millisecond)
+	r.TypedKey(gui.KeyBackspace)
+	r.Checkpoint("script_entry.xml")
+
+	s := r.Script()
+	assert.Equal(t, "wait", s.Steps[len(s.Steps)-3].Action)
+	assert.Equal(t, entryScript[strings.Index(entryScript, "\n")+1:],
+		strings.Replace(s.WithoutTiming().String(), "markup", "wait 1ms\nmarkup", 1))
+
+	path := filepath.Join(t.TempDir(), "session.txt")
+	require.NoError(t, s.Save(path))
+	loaded, err := test.LoadScript(path)
+	require.NoError(t, err)
+	assert.Equal(t, s, loaded)
+}
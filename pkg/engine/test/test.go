@@ -120,6 +120,36 @@ func Drag(c gui.Canvas, pos gui.Position, deltaX, deltaY float32) {
 	o.(gui.Draggable).DragEnd()
 }
 
+// DragAndDrop simulates pressing the pointer at the absolute position from on the canvas, moving it
+// to the absolute position to and releasing it there. The Draggable under from receives the events
+// as it would from a driver, so drag sources can carry their payload to the drop target at to.
+//
+// Since: 2.3
+func DragAndDrop(c gui.Canvas, from, to gui.Position) {
+	matches := func(object gui.CanvasObject) bool {
+		_, ok := object.(gui.Draggable)
+		return ok
+	}
+	o, p, _ := driver.FindObjectAtPositionMatching(from, matches, c.Overlays().Top(), c.Content())
+	if o == nil {
+		return
+	}
+
+	// move in two steps so that the pointer passes the half way point like a real drag
+	half := gui.NewPos((to.X-from.X)/2, (to.Y-from.Y)/2)
+	steps := []gui.Position{from.Add(half), to}
+	last := from
+	for _, pos := range steps {
+		e := &gui.DragEvent{
+			PointEvent: gui.PointEvent{AbsolutePosition: pos, Position: p.Add(pos.Subtract(from))},
+			Dragged:    gui.NewDelta(pos.X-last.X, pos.Y-last.Y),
+		}
+		o.(gui.Draggable).Dragged(e)
+		last = pos
+	}
+	o.(gui.Draggable).DragEnd()
+}
+
 // FocusNext focuses the next focusable on the canvas.
 func FocusNext(c gui.Canvas) {
 	if tc, ok := c.(*testCanvas); ok {
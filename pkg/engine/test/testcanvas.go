@@ -165,7 +165,10 @@ func (c *testCanvas) PixelCoordinateForPosition(pos gui.Position) (int, int) {
 	return int(float32(pos.X) * c.scale), int(float32(pos.Y) * c.scale)
 }
 
-func (c *testCanvas) Refresh(gui.CanvasObject) {
+func (c *testCanvas) Refresh(obj gui.CanvasObject) {
+	if p, ok := c.painter.(refreshPainter); ok {
+		p.Refresh(c, obj)
+	}
 }
 
 func (c *testCanvas) Resize(size gui.Size) {
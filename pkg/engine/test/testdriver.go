@@ -37,6 +37,18 @@ type SoftwarePainter interface {
 	Paint(gui.Canvas) image.Image
 }
 
+// refreshPainter is a SoftwarePainter which only repaints the objects refreshed since its last Paint
+type refreshPainter interface {
+	SoftwarePainter
+	Refresh(gui.Canvas, gui.CanvasObject)
+}
+
+// forgetPainter is a SoftwarePainter which keeps state for each canvas until it is told to forget it
+type forgetPainter interface {
+	SoftwarePainter
+	Forget(gui.Canvas)
+}
+
 type testDriver struct {
 	device       *device
 	painter      SoftwarePainter
@@ -63,6 +63,9 @@ func (w *testWindow) Close() {
 	}
 	w.focused = false
 	w.driver.removeWindow(w)
+	if p, ok := w.canvas.painter.(forgetPainter); ok {
+		p.Forget(w.canvas) // the painter may be shared by the windows of a driver
+	}
 }
 
 func (w *testWindow) Content() gui.CanvasObject {
@@ -0,0 +1,52 @@
+package test
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+)
+
+type forgettingPainter struct {
+	forgotten []gui.Canvas
+}
+
+func (p *forgettingPainter) Forget(c gui.Canvas) {
+	p.forgotten = append(p.forgotten, c)
+}
+
+func (p *forgettingPainter) Paint(gui.Canvas) image.Image {
+	return image.NewNRGBA(image.Rect(0, 0, 1, 1))
+}
+
+func TestTestWindow_CloseForgetsCanvas(t *testing.T) {
+	p := &forgettingPainter{}
+	d := NewDriverWithPainter(p)
+	w := d.CreateWindow("Test")
+	w.Close()
+
+	assert.Equal(t, []gui.Canvas{w.Canvas()}, p.forgotten)
+	assert.Empty(t, d.AllWindows())
+}
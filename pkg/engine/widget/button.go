@@ -72,6 +72,7 @@ const (
 )
 
 var _ gui.Focusable = (*Button)(nil)
+var _ gui.Accessible = (*Button)(nil)
 
 // Button widget has a text label and triggers an event func when clicked
 type Button struct {
@@ -114,6 +115,19 @@ func NewButtonWithIcon(label string, icon gui.Resource, tapped func()) *Button {
 	return button
 }
 
+// AccessibilityInfo describes this button to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (b *Button) AccessibilityInfo() gui.AccessibilityInfo {
+	name := b.Text
+	if name == "" && b.Icon != nil {
+		name = b.Icon.Name()
+	}
+	return gui.AccessibilityInfo{Role: gui.AccessibleRoleButton, Name: name}
+}
+
 // CreateRenderer is a private method to Bhojpur GUI which links this widget to its renderer
 func (b *Button) CreateRenderer() gui.WidgetRenderer {
 	b.ExtendBaseWidget(b)
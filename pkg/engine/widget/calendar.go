@@ -0,0 +1,499 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/internal/widget"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+const calendarRows = 6 // enough weeks to show any month
+
+// Declare conformity with interfaces.
+var _ gui.Widget = (*Calendar)(nil)
+var _ gui.Focusable = (*Calendar)(nil)
+var _ gui.Accessible = (*Calendar)(nil)
+
+// Calendar displays the days of a month so that a date can be selected.
+// The arrow keys move between days, page up and page down change the month and
+// enter or space selects the highlighted day.
+//
+// Since: 2.3
+type Calendar struct {
+	BaseWidget
+
+	// FirstDayOfWeek is the weekday shown in the first column, NewCalendar sets this from the user locale.
+	FirstDayOfWeek time.Weekday
+	// Min and Max are the earliest and latest dates that can be selected, a zero time is not bounded.
+	Min, Max   time.Time
+	OnSelected func(time.Time)
+
+	selected time.Time
+	month    time.Time // the first day of the month being shown
+	cursor   time.Time // the day highlighted for keyboard navigation
+	focused  bool
+	binder   basicBinder
+}
+
+// NewCalendar creates a calendar showing the month of the selected date, or the current month if it is zero.
+// The callback is called when the user selects a date.
+//
+// Since: 2.3
+func NewCalendar(selected time.Time, onSelected func(time.Time)) *Calendar {
+	c := &Calendar{FirstDayOfWeek: localeFirstDayOfWeek(), OnSelected: onSelected}
+	c.ExtendBaseWidget(c)
+	c.setSelected(selected)
+	return c
+}
+
+// NewCalendarWithData returns a calendar connected to the specified data source.
+//
+// Since: 2.3
+func NewCalendarWithData(data binding.Time) *Calendar {
+	c := NewCalendar(time.Time{}, nil)
+	c.Bind(data)
+	return c
+}
+
+// AccessibilityInfo describes this calendar to assistive technology.
+//
+// Implements: gui.Accessible
+func (c *Calendar) AccessibilityInfo() gui.AccessibilityInfo {
+	info := gui.AccessibilityInfo{Role: gui.AccessibleRoleTable, Name: c.displayMonth().Format("January 2006")}
+	if !c.selected.IsZero() {
+		info.Value = c.selected.Format("2006-01-02")
+	}
+	return info
+}
+
+// Bind connects the specified data source to this Calendar.
+// The current value will be selected and any changes in the data will cause the widget to update.
+// User interactions with this Calendar will set the value into the data source.
+func (c *Calendar) Bind(data binding.Time) {
+	c.binder.SetCallback(c.updateFromData)
+	c.binder.Bind(data)
+
+	c.OnSelected = func(_ time.Time) {
+		c.binder.CallWithData(c.writeData)
+	}
+}
+
+// CreateRenderer is a private method to gui which links this widget to its renderer
+//
+// Implements: gui.Widget
+func (c *Calendar) CreateRenderer() gui.WidgetRenderer {
+	c.ExtendBaseWidget(c)
+	r := &calendarRenderer{
+		calendar: c,
+		cursor:   canvas.NewRectangle(theme.FocusColor()),
+		month:    NewLabelWithStyle("", gui.TextAlignCenter, gui.TextStyle{Bold: true}),
+		prev:     NewButtonWithIcon("", theme.NavigateBackIcon(), func() { c.ShowMonth(c.displayMonth().AddDate(0, -1, 0)) }),
+		next:     NewButtonWithIcon("", theme.NavigateNextIcon(), func() { c.ShowMonth(c.displayMonth().AddDate(0, 1, 0)) }),
+	}
+	r.prev.Importance = LowImportance
+	r.next.Importance = LowImportance
+
+	objects := []gui.CanvasObject{r.cursor, r.prev, r.month, r.next}
+	for i := range r.weekdays {
+		r.weekdays[i] = canvas.NewText("", theme.ForegroundColor())
+		r.weekdays[i].Alignment = gui.TextAlignCenter
+		r.weekdays[i].TextStyle.Bold = true
+		objects = append(objects, r.weekdays[i])
+	}
+	for i := range r.days {
+		i := i // capture
+		r.days[i] = NewButton("", func() {
+			day := r.dayAt(i)
+			c.cursor = day
+			focusCollection(c.super())
+			c.selectDay(day)
+		})
+		objects = append(objects, r.days[i])
+	}
+	r.SetObjects(objects)
+	r.Refresh()
+	return r
+}
+
+// FocusGained is called when the Calendar has been given focus.
+//
+// Implements: gui.Focusable
+func (c *Calendar) FocusGained() {
+	c.focused = true
+	c.Refresh()
+}
+
+// FocusLost is called when the Calendar has had focus removed.
+//
+// Implements: gui.Focusable
+func (c *Calendar) FocusLost() {
+	c.focused = false
+	c.Refresh()
+}
+
+// Selected returns the date that is currently selected, or a zero time if there is no selection.
+func (c *Calendar) Selected() time.Time {
+	return c.selected
+}
+
+// SetSelected selects the day of the specified time and shows its month.
+// A zero time clears the selection.
+func (c *Calendar) SetSelected(t time.Time) {
+	if c.setSelected(t) {
+		c.Refresh()
+		if c.OnSelected != nil {
+			c.OnSelected(c.selected)
+		}
+	}
+}
+
+// ShowMonth changes the month that is displayed without changing the selection.
+func (c *Calendar) ShowMonth(t time.Time) {
+	c.month = calendarMonth(t)
+	day := 1
+	if !c.cursor.IsZero() {
+		day = c.cursor.Day()
+		if last := c.month.AddDate(0, 1, -1).Day(); day > last {
+			day = last
+		}
+	}
+	c.cursor = c.clampDay(c.month.AddDate(0, 0, day-1))
+	c.Refresh()
+}
+
+// TypedKey is called if a key event happens while this Calendar is focused.
+//
+// Implements: gui.Focusable
+func (c *Calendar) TypedKey(key *gui.KeyEvent) {
+	cursor := c.cursor
+	if cursor.IsZero() {
+		cursor = c.displayMonth()
+	}
+	switch key.Name {
+	case gui.KeyLeft:
+		cursor = cursor.AddDate(0, 0, -1)
+	case gui.KeyRight:
+		cursor = cursor.AddDate(0, 0, 1)
+	case gui.KeyUp:
+		cursor = cursor.AddDate(0, 0, -7)
+	case gui.KeyDown:
+		cursor = cursor.AddDate(0, 0, 7)
+	case gui.KeyPageUp:
+		cursor = calendarAddMonths(cursor, -1)
+	case gui.KeyPageDown:
+		cursor = calendarAddMonths(cursor, 1)
+	case gui.KeyHome:
+		cursor = calendarMonth(cursor)
+	case gui.KeyEnd:
+		cursor = calendarMonth(cursor).AddDate(0, 1, -1)
+	case gui.KeyReturn, gui.KeyEnter, gui.KeySpace:
+		c.cursor = cursor
+		c.selectDay(cursor)
+		return
+	default:
+		return
+	}
+
+	c.cursor = c.clampDay(cursor)
+	c.month = calendarMonth(c.cursor)
+	c.Refresh()
+}
+
+// TypedRune is called if a text event happens while this Calendar is focused.
+//
+// Implements: gui.Focusable
+func (c *Calendar) TypedRune(rune) {
+	// no-op, the keyboard is handled by TypedKey
+}
+
+// Unbind disconnects any configured data source from this Calendar.
+// The current selection will remain at the last value of the data source.
+func (c *Calendar) Unbind() {
+	c.OnSelected = nil
+	c.binder.Unbind()
+}
+
+func (c *Calendar) clampDay(day time.Time) time.Time {
+	if !c.Min.IsZero() && day.Before(calendarDate(c.Min, day)) {
+		return calendarDate(c.Min, day)
+	}
+	if !c.Max.IsZero() && day.After(calendarDate(c.Max, day)) {
+		return calendarDate(c.Max, day)
+	}
+	return day
+}
+
+func (c *Calendar) displayMonth() time.Time {
+	if c.month.IsZero() {
+		c.month = calendarMonth(time.Now())
+	}
+	return c.month
+}
+
+func (c *Calendar) inBounds(day time.Time) bool {
+	return c.clampDay(day).Equal(day)
+}
+
+func (c *Calendar) selectDay(day time.Time) {
+	if !c.inBounds(day) {
+		return
+	}
+	c.SetSelected(day)
+}
+
+func (c *Calendar) setSelected(t time.Time) bool {
+	if t.IsZero() {
+		if c.selected.IsZero() {
+			return false
+		}
+		c.selected = time.Time{}
+		return true
+	}
+
+	day := calendarDate(t, t)
+	c.month = calendarMonth(day)
+	c.cursor = day
+	if day.Equal(c.selected) {
+		return false
+	}
+	c.selected = day
+	return true
+}
+
+func (c *Calendar) updateFromData(data binding.DataItem) {
+	if data == nil {
+		return
+	}
+	timeSource, ok := data.(binding.Time)
+	if !ok {
+		return
+	}
+
+	val, err := timeSource.Get()
+	if err != nil {
+		gui.LogError("Error getting current data value", err)
+		return
+	}
+	if c.setSelected(val) {
+		c.Refresh()
+	}
+}
+
+func (c *Calendar) writeData(data binding.DataItem) {
+	if data == nil {
+		return
+	}
+	timeTarget, ok := data.(binding.Time)
+	if !ok {
+		return
+	}
+	currentValue, err := timeTarget.Get()
+	if err != nil {
+		return
+	}
+	selected := c.selected
+	if !selected.IsZero() {
+		selected = calendarDateTime(selected, currentValue)
+	}
+	if !currentValue.Equal(selected) {
+		err := timeTarget.Set(selected)
+		if err != nil {
+			gui.LogError(fmt.Sprintf("Failed to set binding value to %s", selected), err)
+		}
+	}
+}
+
+// calendarAddMonths moves a date by a number of months, keeping within the end of the target month.
+func calendarAddMonths(day time.Time, months int) time.Time {
+	month := calendarMonth(day).AddDate(0, months, 0)
+	last := month.AddDate(0, 1, -1).Day()
+	if day.Day() < last {
+		last = day.Day()
+	}
+	return month.AddDate(0, 0, last-1)
+}
+
+// calendarDate returns midnight at the start of the day of t, in the location of loc.
+func calendarDate(t, loc time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc.Location())
+}
+
+// calendarDateTime returns the day of date at the time of day, and in the location, of clock.
+// A zero clock leaves date unchanged.
+func calendarDateTime(date, clock time.Time) time.Time {
+	if clock.IsZero() {
+		return date
+	}
+	y, m, d := date.Date()
+	hour, min, sec := clock.Clock()
+	return time.Date(y, m, d, hour, min, sec, clock.Nanosecond(), clock.Location())
+}
+
+func calendarMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// localeFirstDayOfWeek looks up the first day of the week for the region of the user locale.
+// Most regions start on Monday, the exceptions are from the Unicode CLDR week data.
+func localeFirstDayOfWeek() time.Weekday {
+	locale := ""
+	for _, env := range []string{"LC_ALL", "LC_TIME", "LANG"} {
+		if locale = os.Getenv(env); locale != "" {
+			break
+		}
+	}
+	if i := strings.IndexAny(locale, ".@"); i >= 0 {
+		locale = locale[:i]
+	}
+	region := ""
+	if i := strings.IndexAny(locale, "_-"); i >= 0 {
+		region = strings.ToUpper(locale[i+1:])
+	}
+
+	switch region {
+	case "", "AG", "AS", "BD", "BR", "BS", "BT", "BW", "BZ", "CA", "CO", "DM", "DO", "ET", "GT", "GU", "HK", "HN",
+		"ID", "IL", "IN", "JM", "JP", "KE", "KH", "KR", "LA", "MH", "MM", "MO", "MT", "MX", "MZ", "NI", "NP", "PA",
+		"PE", "PH", "PK", "PR", "PT", "PY", "SA", "SG", "SV", "TH", "TT", "TW", "UM", "US", "VE", "VI", "WS", "YE",
+		"ZA", "ZW":
+		return time.Sunday
+	case "AE", "AF", "BH", "DJ", "DZ", "EG", "IQ", "IR", "JO", "KW", "LY", "OM", "QA", "SD", "SY":
+		return time.Saturday
+	}
+	return time.Monday
+}
+
+type calendarRenderer struct {
+	widget.BaseRenderer
+	calendar   *Calendar
+	cursor     *canvas.Rectangle
+	month      *Label
+	prev, next *Button
+	weekdays   [7]*canvas.Text
+	days       [calendarRows * 7]*Button
+}
+
+func (r *calendarRenderer) Layout(size gui.Size) {
+	pad := theme.Padding()
+	header := r.headerHeight()
+	r.prev.Resize(gui.NewSize(r.prev.MinSize().Width, header))
+	r.prev.Move(gui.NewPos(0, 0))
+	r.next.Resize(gui.NewSize(r.next.MinSize().Width, header))
+	r.next.Move(gui.NewPos(size.Width-r.next.Size().Width, 0))
+	r.month.Move(gui.NewPos(r.prev.Size().Width, 0))
+	r.month.Resize(gui.NewSize(size.Width-r.prev.Size().Width-r.next.Size().Width, header))
+
+	colWidth := (size.Width - pad*6) / 7
+	y := header + pad
+	textHeight := r.weekdays[0].MinSize().Height
+	for i, day := range r.weekdays {
+		day.Move(gui.NewPos(float32(i)*(colWidth+pad), y))
+		day.Resize(gui.NewSize(colWidth, textHeight))
+	}
+
+	y += textHeight + pad
+	rowHeight := (size.Height - y - pad*(calendarRows-1)) / calendarRows
+	r.cursor.Hide()
+	for i, day := range r.days {
+		pos := gui.NewPos(float32(i%7)*(colWidth+pad), y+float32(i/7)*(rowHeight+pad))
+		day.Move(pos)
+		day.Resize(gui.NewSize(colWidth, rowHeight))
+		if r.calendar.focused && r.dayAt(i).Equal(r.calendar.cursor) {
+			r.cursor.Move(pos.Subtract(gui.NewPos(pad/2, pad/2)))
+			r.cursor.Resize(gui.NewSize(colWidth+pad, rowHeight+pad))
+			r.cursor.Show()
+		}
+	}
+}
+
+func (r *calendarRenderer) MinSize() gui.Size {
+	pad := theme.Padding()
+	cell := r.days[0].MinSize().Max(r.weekdays[0].MinSize())
+	width := gui.Max(cell.Width*7+pad*6,
+		r.prev.MinSize().Width+r.month.MinSize().Width+r.next.MinSize().Width)
+	height := r.headerHeight() + pad + r.weekdays[0].MinSize().Height + pad +
+		cell.Height*calendarRows + pad*(calendarRows-1)
+	return gui.NewSize(width, height)
+}
+
+func (r *calendarRenderer) Refresh() {
+	c := r.calendar
+	month := c.displayMonth()
+	r.month.SetText(month.Format("January 2006"))
+	r.setEnabled(r.prev, c.Min.IsZero() || !month.AddDate(0, 0, -1).Before(calendarDate(c.Min, month)))
+	r.setEnabled(r.next, c.Max.IsZero() || !month.AddDate(0, 1, 0).After(calendarDate(c.Max, month)))
+
+	for i, day := range r.weekdays {
+		name := (c.FirstDayOfWeek + time.Weekday(i)) % 7
+		day.Text = name.String()[:3]
+		day.Color = theme.ForegroundColor()
+		day.TextSize = theme.TextSize()
+		day.Refresh()
+	}
+
+	for i, button := range r.days {
+		day := r.dayAt(i)
+		if day.Month() != month.Month() {
+			button.Hide()
+			continue
+		}
+		button.SetText(strconv.Itoa(day.Day()))
+		button.Importance = LowImportance
+		if day.Equal(c.selected) {
+			button.Importance = HighImportance
+		}
+		r.setEnabled(button, c.inBounds(day))
+		button.Show()
+		button.Refresh()
+	}
+
+	r.cursor.FillColor = theme.FocusColor()
+	r.Layout(c.Size())
+	canvas.Refresh(c.super())
+}
+
+// dayAt returns the date displayed in the cell at the given index of the day grid.
+func (r *calendarRenderer) dayAt(i int) time.Time {
+	month := r.calendar.displayMonth()
+	offset := (int(month.Weekday()) - int(r.calendar.FirstDayOfWeek) + 7) % 7
+	return month.AddDate(0, 0, i-offset)
+}
+
+func (r *calendarRenderer) headerHeight() float32 {
+	return gui.Max(r.prev.MinSize().Height, r.month.MinSize().Height)
+}
+
+func (r *calendarRenderer) setEnabled(b *Button, enabled bool) {
+	if enabled {
+		b.Enable()
+	} else {
+		b.Disable()
+	}
+}
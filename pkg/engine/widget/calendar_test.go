@@ -0,0 +1,178 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func calendarDay(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.Local)
+}
+
+func TestCalendar_Days(t *testing.T) {
+	c := NewCalendar(time.Date(2022, time.March, 15, 10, 30, 0, 0, time.Local), nil)
+	c.FirstDayOfWeek = time.Monday
+	r := test.WidgetRenderer(c).(*calendarRenderer)
+	r.Refresh()
+
+	assert.Equal(t, calendarDay(2022, time.March, 15), c.Selected())
+	assert.Equal(t, "March 2022", r.month.Text)
+	assert.Equal(t, "Mon", r.weekdays[0].Text)
+	assert.Equal(t, "Sun", r.weekdays[6].Text)
+	assert.False(t, r.days[0].Visible()) // 1 March 2022 was a Tuesday
+	assert.True(t, r.days[1].Visible())
+	assert.Equal(t, "1", r.days[1].Text)
+	assert.Equal(t, HighImportance, r.days[15].Importance)
+	assert.Equal(t, LowImportance, r.days[16].Importance)
+	assert.False(t, r.days[32].Visible())
+
+	c.FirstDayOfWeek = time.Sunday
+	c.Refresh()
+	assert.Equal(t, "Sun", r.weekdays[0].Text)
+	assert.Equal(t, "1", r.days[2].Text)
+}
+
+func TestCalendar_Keyboard(t *testing.T) {
+	var selected time.Time
+	c := NewCalendar(calendarDay(2022, time.January, 31), func(d time.Time) {
+		selected = d
+	})
+	c.FocusGained()
+
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyRight})
+	assert.Equal(t, calendarDay(2022, time.February, 1), c.cursor)
+	assert.Equal(t, calendarDay(2022, time.February, 1), c.month)
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, calendarDay(2022, time.February, 8), c.cursor)
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyLeft})
+	assert.Equal(t, calendarDay(2022, time.January, 31), c.cursor)
+
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyPageDown})
+	assert.Equal(t, calendarDay(2022, time.February, 28), c.cursor)
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyHome})
+	assert.Equal(t, calendarDay(2022, time.February, 1), c.cursor)
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyEnd})
+	assert.Equal(t, calendarDay(2022, time.February, 28), c.cursor)
+	assert.True(t, selected.IsZero())
+
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyReturn})
+	assert.Equal(t, calendarDay(2022, time.February, 28), selected)
+	assert.Equal(t, selected, c.Selected())
+}
+
+func TestCalendar_MinMax(t *testing.T) {
+	c := NewCalendar(calendarDay(2022, time.June, 10), nil)
+	c.FirstDayOfWeek = time.Wednesday // 1 June 2022 is the first cell
+	c.Min = time.Date(2022, time.June, 5, 18, 0, 0, 0, time.Local)
+	c.Max = calendarDay(2022, time.June, 20)
+	r := test.WidgetRenderer(c).(*calendarRenderer)
+	r.Refresh()
+
+	assert.True(t, r.days[3].Disabled())
+	assert.False(t, r.days[4].Disabled())
+	assert.False(t, r.days[19].Disabled())
+	assert.True(t, r.days[20].Disabled())
+	assert.True(t, r.prev.Disabled())
+	assert.True(t, r.next.Disabled())
+
+	test.Tap(r.days[2])
+	assert.Equal(t, calendarDay(2022, time.June, 10), c.Selected())
+	test.Tap(r.days[4])
+	assert.Equal(t, calendarDay(2022, time.June, 5), c.Selected())
+
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyPageUp})
+	assert.Equal(t, calendarDay(2022, time.June, 5), c.cursor)
+	c.TypedKey(&gui.KeyEvent{Name: gui.KeyEnd})
+	assert.Equal(t, calendarDay(2022, time.June, 20), c.cursor)
+}
+
+func TestCalendar_ShowMonth(t *testing.T) {
+	c := NewCalendar(calendarDay(2022, time.January, 31), nil)
+	c.ShowMonth(calendarDay(2022, time.February, 10))
+
+	assert.Equal(t, calendarDay(2022, time.February, 1), c.month)
+	assert.Equal(t, calendarDay(2022, time.February, 28), c.cursor)
+	assert.Equal(t, calendarDay(2022, time.January, 31), c.Selected())
+}
+
+func TestNewCalendarWithData(t *testing.T) {
+	data := binding.NewTime()
+	err := data.Set(calendarDay(2021, time.December, 24))
+	assert.Nil(t, err)
+
+	c := NewCalendarWithData(data)
+	waitForBinding()
+	assert.Equal(t, calendarDay(2021, time.December, 24), c.Selected())
+
+	c.SetSelected(calendarDay(2021, time.December, 25))
+	v, err := data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, calendarDay(2021, time.December, 25), v)
+
+	waitForBinding()
+	c.Unbind()
+	err = data.Set(calendarDay(2022, time.January, 1))
+	assert.Nil(t, err)
+	waitForBinding()
+	assert.Equal(t, calendarDay(2021, time.December, 25), c.Selected())
+}
+
+func TestCalendar_BindKeepsTimeOfDay(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	data := binding.NewTime()
+	err := data.Set(time.Date(2021, time.December, 24, 14, 30, 0, 0, loc))
+	assert.Nil(t, err)
+
+	c := NewCalendarWithData(data)
+	waitForBinding()
+	v, err := data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2021, time.December, 24, 14, 30, 0, 0, loc), v)
+
+	c.SetSelected(calendarDay(2021, time.December, 25))
+	v, err = data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2021, time.December, 25, 14, 30, 0, 0, loc), v)
+}
+
+func TestCalendar_LocaleFirstDayOfWeek(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_TIME", "")
+
+	t.Setenv("LANG", "en_US.UTF-8")
+	assert.Equal(t, time.Sunday, localeFirstDayOfWeek())
+	t.Setenv("LANG", "en_GB.UTF-8")
+	assert.Equal(t, time.Monday, localeFirstDayOfWeek())
+	t.Setenv("LANG", "ar_EG")
+	assert.Equal(t, time.Saturday, localeFirstDayOfWeek())
+
+	t.Setenv("LC_TIME", "de_DE@euro")
+	assert.Equal(t, time.Monday, NewCalendar(time.Time{}, nil).FirstDayOfWeek)
+}
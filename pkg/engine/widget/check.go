@@ -128,6 +128,22 @@ type Check struct {
 	binder basicBinder
 }
 
+// AccessibilityInfo describes this check to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (c *Check) AccessibilityInfo() gui.AccessibilityInfo {
+	c.propertyLock.RLock()
+	defer c.propertyLock.RUnlock()
+
+	return gui.AccessibilityInfo{
+		Role:  gui.AccessibleRoleCheckBox,
+		Name:  c.Text,
+		State: gui.AccessibleState{Checked: c.Checked},
+	}
+}
+
 // Bind connects the specified data source to this Check.
 // The current value will be displayed and any changes in the data will cause the widget to update.
 // User interactions with this Check will set the value into the data source.
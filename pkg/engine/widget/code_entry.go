@@ -0,0 +1,1103 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/internal/painter"
+	"github.com/bhojpur/gui/pkg/engine/internal/widget"
+	"github.com/bhojpur/gui/pkg/engine/syntax"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+const codeEntryBrackets = "()[]{}"
+
+// Declare conformity with interfaces.
+var _ gui.Widget = (*CodeEntry)(nil)
+var _ gui.Focusable = (*CodeEntry)(nil)
+var _ gui.Shortcutable = (*CodeEntry)(nil)
+var _ desktop.Keyable = (*CodeEntry)(nil)
+var _ gui.Tappable = (*codeEntryContent)(nil)
+var _ gui.Draggable = (*codeEntryContent)(nil)
+
+// CodeEntry is a multi-line text editor for source code, with line numbers, syntax highlighting,
+// auto-indent, bracket matching, undo/redo and search and replace.
+// Only the lines that are visible are rendered, so large files can be edited.
+//
+// Since: 2.3
+type CodeEntry struct {
+	BaseWidget
+
+	// Indent is inserted by the Tab key and added to new lines after an opening bracket or colon.
+	// If it is empty a tab character is used.
+	Indent string
+	// Lexer tokenizes lines for syntax highlighting, if it is nil the text is not highlighted.
+	Lexer           syntax.Lexer
+	OnChanged       func(string)
+	ShowLineNumbers bool
+
+	lines          [][]rune
+	cursor, anchor codePosition // the selection runs between anchor and cursor, which are equal if it is empty
+	widest         int          // display columns of the widest line seen since SetText
+	states         []syntax.State
+	undos, redos   []codeEdit
+	group          int
+	typing         bool // the last edit was typed text that the next typed rune can be merged with
+	keys           selectionKeys
+	focused        bool
+	scroller       *widget.Scroll
+	content        *codeEntryContent
+}
+
+// codePosition is a location in the text of a CodeEntry as a line and a rune index within it.
+type codePosition struct {
+	row, col int
+}
+
+func (p codePosition) before(o codePosition) bool {
+	return p.row < o.row || (p.row == o.row && p.col < o.col)
+}
+
+// codeEdit records that the text removed at a position was replaced by the text inserted, for undo and redo.
+// Edits made by a single user action share a group.
+type codeEdit struct {
+	group             int
+	at                codePosition
+	removed, inserted string
+}
+
+// NewCodeEntry creates a new, empty code editor that shows line numbers.
+//
+// Since: 2.3
+func NewCodeEntry() *CodeEntry {
+	e := &CodeEntry{ShowLineNumbers: true, lines: [][]rune{{}}, states: []syntax.State{0}}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// NewCodeEntryWithLexer creates a new code editor for text that is highlighted using the given lexer.
+//
+// Since: 2.3
+func NewCodeEntryWithLexer(lexer syntax.Lexer) *CodeEntry {
+	e := NewCodeEntry()
+	e.Lexer = lexer
+	return e
+}
+
+// CreateRenderer is a private method to Bhojpur GUI which links this widget to its renderer
+func (e *CodeEntry) CreateRenderer() gui.WidgetRenderer {
+	e.ExtendBaseWidget(e)
+	e.content = &codeEntryContent{entry: e}
+	e.content.ExtendBaseWidget(e.content)
+	e.scroller = widget.NewScroll(e.content)
+	e.scroller.OnScrolled = func(gui.Position) {
+		e.content.Refresh()
+	}
+
+	bg := canvas.NewRectangle(theme.InputBackgroundColor())
+	return &codeEntryRenderer{BaseRenderer: widget.NewBaseRenderer([]gui.CanvasObject{bg, e.scroller}),
+		entry: e, background: bg}
+}
+
+// CursorPosition returns the line and column of the cursor, starting at 0.
+//
+// Since: 2.3
+func (e *CodeEntry) CursorPosition() (row, col int) {
+	return e.cursor.row, e.cursor.col
+}
+
+// Find selects the next occurrence of text after the cursor or selection, continuing from the start
+// if there is none before the end. It returns false if the text does not occur.
+//
+// Since: 2.3
+func (e *CodeEntry) Find(text string, matchCase bool) bool {
+	needle := []rune(text)
+	if len(needle) == 0 {
+		return false
+	}
+
+	content := []rune(e.Text())
+	start, end := e.selection()
+	from := e.offsetOf(end)
+	if start != end {
+		from = e.offsetOf(start) + 1
+	}
+	index := indexRunes(content, needle, from, matchCase)
+	if index < 0 {
+		index = indexRunes(content, needle, 0, matchCase)
+	}
+	if index < 0 {
+		return false
+	}
+
+	e.anchor = e.positionAt(index)
+	e.cursor = e.positionAt(index + len(needle))
+	e.typing = false
+	e.scrollToCursor()
+	e.refreshContent()
+	return true
+}
+
+// FocusGained is called when the CodeEntry has been given focus.
+//
+// Implements: gui.Focusable
+func (e *CodeEntry) FocusGained() {
+	e.focused = true
+	e.refreshContent()
+}
+
+// FocusLost is called when the CodeEntry has had focus removed.
+//
+// Implements: gui.Focusable
+func (e *CodeEntry) FocusLost() {
+	e.focused = false
+	e.keys.shift = false
+	e.refreshContent()
+}
+
+// KeyDown is called when a key is pressed and tracks the shift key for extending the selection.
+//
+// Implements: desktop.Keyable
+func (e *CodeEntry) KeyDown(key *gui.KeyEvent) {
+	e.keys.keyDown(key)
+}
+
+// KeyUp is called when a key is released.
+//
+// Implements: desktop.Keyable
+func (e *CodeEntry) KeyUp(key *gui.KeyEvent) {
+	e.keys.keyUp(key)
+}
+
+// MinSize returns the size that this widget should not shrink below.
+func (e *CodeEntry) MinSize() gui.Size {
+	e.ExtendBaseWidget(e)
+	return e.BaseWidget.MinSize()
+}
+
+// Redo applies the last change that was undone again. It returns false if there is nothing to redo.
+//
+// Since: 2.3
+func (e *CodeEntry) Redo() bool {
+	if len(e.redos) == 0 {
+		return false
+	}
+
+	group := e.redos[len(e.redos)-1].group
+	for len(e.redos) > 0 && e.redos[len(e.redos)-1].group == group {
+		edit := e.redos[len(e.redos)-1]
+		e.redos = e.redos[:len(e.redos)-1]
+		end := e.replace(edit.at, endOfText(edit.at, edit.removed), edit.inserted)
+		e.undos = append(e.undos, edit)
+		e.cursor, e.anchor = end, end
+	}
+	e.typing = false
+	e.changed()
+	return true
+}
+
+// Replace replaces the selection with replacement if it matches text, and then finds the next occurrence of text.
+// It returns true if a replacement was made.
+//
+// Since: 2.3
+func (e *CodeEntry) Replace(text, replacement string, matchCase bool) bool {
+	replaced := false
+	if selected := []rune(e.SelectedText()); len(selected) > 0 && indexRunes(selected, []rune(text), 0, matchCase) == 0 &&
+		len(selected) == len([]rune(text)) {
+		e.insert(replacement)
+		replaced = true
+	}
+	e.Find(text, matchCase)
+	return replaced
+}
+
+// ReplaceAll replaces every occurrence of text with replacement as a single change that can be undone,
+// returning the number of replacements made.
+//
+// Since: 2.3
+func (e *CodeEntry) ReplaceAll(text, replacement string, matchCase bool) int {
+	needle := []rune(text)
+	if len(needle) == 0 {
+		return 0
+	}
+
+	content := []rune(e.Text())
+	var matches []int
+	for i := indexRunes(content, needle, 0, matchCase); i >= 0; i = indexRunes(content, needle, i+len(needle), matchCase) {
+		matches = append(matches, i)
+	}
+	if len(matches) == 0 {
+		return 0
+	}
+
+	e.group++
+	for i := len(matches) - 1; i >= 0; i-- {
+		start := e.positionAt(matches[i])
+		e.record(start, e.positionAt(matches[i]+len(needle)), replacement)
+	}
+	e.cursor = e.positionAt(matches[0] + len([]rune(replacement)))
+	e.anchor = e.cursor
+	e.typing = false
+	e.changed()
+	return len(matches)
+}
+
+// SelectedText returns the text that is currently selected.
+//
+// Since: 2.3
+func (e *CodeEntry) SelectedText() string {
+	start, end := e.selection()
+	return e.textRange(start, end)
+}
+
+// SetCursorPosition moves the cursor to a line and column, clearing the selection.
+//
+// Since: 2.3
+func (e *CodeEntry) SetCursorPosition(row, col int) {
+	e.moveTo(e.clampPosition(codePosition{row: row, col: col}), false)
+}
+
+// SetText replaces the content of the editor, which also clears the undo history.
+//
+// Since: 2.3
+func (e *CodeEntry) SetText(text string) {
+	parts := strings.Split(text, "\n")
+	e.lines = make([][]rune, len(parts))
+	e.widest = 0
+	for i, part := range parts {
+		e.lines[i] = []rune(part)
+		e.updateWidest(e.lines[i])
+	}
+	e.states = []syntax.State{0}
+	e.undos, e.redos = nil, nil
+	e.cursor, e.anchor = codePosition{}, codePosition{}
+	e.typing = false
+	if e.scroller != nil {
+		e.scroller.Offset = gui.Position{}
+	}
+	e.changed()
+}
+
+// Tapped is called when the editor is tapped and requests focus.
+//
+// Implements: gui.Tappable
+func (e *CodeEntry) Tapped(*gui.PointEvent) {
+	focusCollection(e.super())
+}
+
+// Text returns the content of the editor, with lines separated by '\n'.
+//
+// Since: 2.3
+func (e *CodeEntry) Text() string {
+	var b strings.Builder
+	for i, line := range e.lines {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		b.WriteString(string(line))
+	}
+	return b.String()
+}
+
+// TypedKey is called when a key is pressed, to move the cursor or edit the text.
+//
+// Implements: gui.Focusable
+func (e *CodeEntry) TypedKey(key *gui.KeyEvent) {
+	extend := e.keys.shift
+	start, end := e.selection()
+	switch key.Name {
+	case gui.KeyLeft:
+		if start != end && !extend {
+			e.moveTo(start, false)
+		} else {
+			e.moveTo(e.step(e.cursor, -1), extend)
+		}
+	case gui.KeyRight:
+		if start != end && !extend {
+			e.moveTo(end, false)
+		} else {
+			e.moveTo(e.step(e.cursor, 1), extend)
+		}
+	case gui.KeyUp:
+		e.moveTo(e.verticalPosition(-1), extend)
+	case gui.KeyDown:
+		e.moveTo(e.verticalPosition(1), extend)
+	case gui.KeyPageUp:
+		e.moveTo(e.verticalPosition(-e.pageLength()), extend)
+	case gui.KeyPageDown:
+		e.moveTo(e.verticalPosition(e.pageLength()), extend)
+	case gui.KeyHome:
+		// toggle between the first non-blank character and the start of the line
+		col := len(leadingSpace(e.lines[e.cursor.row]))
+		if e.cursor.col == col {
+			col = 0
+		}
+		e.moveTo(codePosition{row: e.cursor.row, col: col}, extend)
+	case gui.KeyEnd:
+		e.moveTo(codePosition{row: e.cursor.row, col: len(e.lines[e.cursor.row])}, extend)
+	case gui.KeyBackspace:
+		if start == end {
+			start = e.step(e.cursor, -1)
+		}
+		e.remove(start, end)
+	case gui.KeyDelete:
+		if start == end {
+			end = e.step(e.cursor, 1)
+		}
+		e.remove(start, end)
+	case gui.KeyReturn, gui.KeyEnter:
+		e.newLine()
+	case gui.KeyTab:
+		e.insert(e.indent())
+	}
+}
+
+// TypedRune is called when text is input, replacing the selection.
+// A closing bracket typed at the start of a line removes one level of indentation.
+//
+// Implements: gui.Focusable
+func (e *CodeEntry) TypedRune(r rune) {
+	start, end := e.selection()
+	line := e.lines[start.row]
+	if strings.ContainsRune(")]}", r) && start == end && len(leadingSpace(line)) == len(line) && start.col == len(line) &&
+		strings.HasSuffix(string(line), e.indent()) {
+		start.col -= len([]rune(e.indent()))
+	}
+
+	merge := e.typing && start == end && !unicode.IsSpace(r)
+	if !merge {
+		e.group++
+	}
+	pos := e.record(start, end, string(r))
+	if merge && len(e.undos) > 1 {
+		last, prev := e.undos[len(e.undos)-1], &e.undos[len(e.undos)-2]
+		if prev.group == last.group && prev.removed == "" && last.removed == "" && endOfText(prev.at, prev.inserted) == last.at {
+			prev.inserted += last.inserted
+			e.undos = e.undos[:len(e.undos)-1]
+		}
+	}
+	e.cursor, e.anchor = pos, pos
+	e.typing = !unicode.IsSpace(r)
+	e.changed()
+}
+
+// TypedShortcut handles the clipboard, select all and undo/redo shortcuts.
+//
+// Implements: gui.Shortcutable
+func (e *CodeEntry) TypedShortcut(shortcut gui.Shortcut) {
+	switch s := shortcut.(type) {
+	case *gui.ShortcutCopy:
+		if text := e.SelectedText(); text != "" {
+			s.Clipboard.SetContent(text)
+		}
+	case *gui.ShortcutCut:
+		if text := e.SelectedText(); text != "" {
+			s.Clipboard.SetContent(text)
+			e.insert("")
+		}
+	case *gui.ShortcutPaste:
+		e.insert(strings.ReplaceAll(s.Clipboard.Content(), "\r\n", "\n"))
+	case *gui.ShortcutSelectAll:
+		e.anchor = codePosition{}
+		e.moveTo(codePosition{row: len(e.lines) - 1, col: len(e.lines[len(e.lines)-1])}, true)
+	case *gui.ShortcutUndo:
+		e.Undo()
+	case *gui.ShortcutRedo:
+		e.Redo()
+	case *desktop.CustomShortcut:
+		switch {
+		case s.KeyName == gui.KeyZ && s.Modifier == gui.KeyModifierShortcutDefault:
+			e.Undo()
+		case s.KeyName == gui.KeyZ && s.Modifier == gui.KeyModifierShortcutDefault|gui.KeyModifierShift,
+			s.KeyName == gui.KeyY && s.Modifier == gui.KeyModifierShortcutDefault:
+			e.Redo()
+		}
+	}
+}
+
+// Undo reverts the last change to the text. It returns false if there is nothing to undo.
+//
+// Since: 2.3
+func (e *CodeEntry) Undo() bool {
+	if len(e.undos) == 0 {
+		return false
+	}
+
+	group := e.undos[len(e.undos)-1].group
+	for len(e.undos) > 0 && e.undos[len(e.undos)-1].group == group {
+		edit := e.undos[len(e.undos)-1]
+		e.undos = e.undos[:len(e.undos)-1]
+		end := e.replace(edit.at, endOfText(edit.at, edit.inserted), edit.removed)
+		e.redos = append(e.redos, edit)
+		e.cursor, e.anchor = end, end
+	}
+	e.typing = false
+	e.changed()
+	return true
+}
+
+// changed updates the display after the text was edited and notifies OnChanged.
+func (e *CodeEntry) changed() {
+	if e.scroller != nil {
+		e.content.Refresh()
+		e.scroller.Refresh()
+		e.scrollToCursor()
+	}
+	if f := e.OnChanged; f != nil {
+		f(e.Text())
+	}
+}
+
+func (e *CodeEntry) clampPosition(p codePosition) codePosition {
+	p.row = clampIndex(p.row, len(e.lines))
+	if p.col < 0 {
+		p.col = 0
+	} else if p.col > len(e.lines[p.row]) {
+		p.col = len(e.lines[p.row])
+	}
+	return p
+}
+
+func (e *CodeEntry) indent() string {
+	if e.Indent == "" {
+		return "\t"
+	}
+	return e.Indent
+}
+
+// insert replaces the selection with text as a new change.
+func (e *CodeEntry) insert(text string) {
+	start, end := e.selection()
+	e.group++
+	pos := e.record(start, end, text)
+	e.cursor, e.anchor = pos, pos
+	e.typing = false
+	e.changed()
+}
+
+// matchingBrackets returns the bracket next to the cursor and the one that matches it, if there is one.
+func (e *CodeEntry) matchingBrackets() (codePosition, codePosition, bool) {
+	at := e.cursor
+	if at.col >= len(e.lines[at.row]) || !strings.ContainsRune(codeEntryBrackets, e.lines[at.row][at.col]) {
+		at.col--
+	}
+	if at.col < 0 || !strings.ContainsRune(codeEntryBrackets, e.lines[at.row][at.col]) {
+		return at, at, false
+	}
+
+	bracket := e.lines[at.row][at.col]
+	index := strings.IndexRune(codeEntryBrackets, bracket)
+	match := rune(codeEntryBrackets[index^1])
+	dir := 1
+	if index%2 == 1 {
+		dir = -1
+	}
+
+	depth := 0
+	for row, col := at.row, at.col; row >= 0 && row < len(e.lines); {
+		line := e.lines[row]
+		for ; col >= 0 && col < len(line); col += dir {
+			switch line[col] {
+			case bracket:
+				depth++
+			case match:
+				depth--
+				if depth == 0 {
+					return at, codePosition{row: row, col: col}, true
+				}
+			}
+		}
+		row += dir
+		if row >= 0 && row < len(e.lines) {
+			col = 0
+			if dir < 0 {
+				col = len(e.lines[row]) - 1
+			}
+		}
+	}
+	return at, at, false
+}
+
+// moveTo moves the cursor, extending the selection from the anchor or clearing it.
+func (e *CodeEntry) moveTo(p codePosition, extend bool) {
+	e.cursor = p
+	if !extend {
+		e.anchor = p
+	}
+	e.typing = false
+	e.scrollToCursor()
+	e.refreshContent()
+}
+
+// newLine breaks the line at the cursor, indenting the new line like the current one.
+// After an opening bracket or a colon the new line is indented one more level, and a closing
+// bracket following the cursor is moved to a line of its own.
+func (e *CodeEntry) newLine() {
+	start, end := e.selection()
+	line := e.lines[start.row]
+	indent := string(leadingSpace(line[:start.col]))
+	text := "\n" + indent
+
+	before := strings.TrimRight(string(line[:start.col]), " \t")
+	cursor := len([]rune(text))
+	if strings.HasSuffix(before, "{") || strings.HasSuffix(before, "[") || strings.HasSuffix(before, "(") ||
+		strings.HasSuffix(before, ":") {
+		text += e.indent()
+		cursor = len([]rune(text))
+		if end.col < len(e.lines[end.row]) && strings.ContainsRune(")]}", e.lines[end.row][end.col]) {
+			text += "\n" + indent
+		}
+	}
+
+	e.group++
+	e.record(start, end, text)
+	pos := endOfText(start, string([]rune(text)[:cursor]))
+	e.cursor, e.anchor = pos, pos
+	e.typing = false
+	e.changed()
+}
+
+func (e *CodeEntry) offsetOf(p codePosition) int {
+	offset := p.col
+	for row := 0; row < p.row; row++ {
+		offset += len(e.lines[row]) + 1
+	}
+	return offset
+}
+
+func (e *CodeEntry) pageLength() int {
+	if e.scroller == nil || e.content == nil {
+		return 1
+	}
+	if rows := int(e.scroller.Size().Height / e.content.lineHeight()); rows > 1 {
+		return rows
+	}
+	return 1
+}
+
+func (e *CodeEntry) positionAt(offset int) codePosition {
+	for row, line := range e.lines {
+		if offset <= len(line) {
+			return codePosition{row: row, col: offset}
+		}
+		offset -= len(line) + 1
+	}
+	last := len(e.lines) - 1
+	return codePosition{row: last, col: len(e.lines[last])}
+}
+
+// record replaces the text between start and end, adding the change to the current undo group.
+func (e *CodeEntry) record(start, end codePosition, text string) codePosition {
+	removed := e.textRange(start, end)
+	if removed == "" && text == "" {
+		return start
+	}
+
+	e.undos = append(e.undos, codeEdit{group: e.group, at: start, removed: removed, inserted: text})
+	e.redos = nil
+	return e.replace(start, end, text)
+}
+
+func (e *CodeEntry) refreshContent() {
+	if e.content != nil {
+		e.content.Refresh()
+	}
+}
+
+// remove deletes the text between start and end as a new change.
+func (e *CodeEntry) remove(start, end codePosition) {
+	if start == end {
+		return
+	}
+	e.anchor, e.cursor = start, end
+	e.insert("")
+}
+
+// replace replaces the text between start and end and returns the position at the end of the new text.
+func (e *CodeEntry) replace(start, end codePosition, text string) codePosition {
+	parts := strings.Split(text, "\n")
+	tail := append([]rune{}, e.lines[end.row][end.col:]...)
+
+	inserted := make([][]rune, len(parts))
+	for i, part := range parts {
+		inserted[i] = []rune(part)
+	}
+	last := len(inserted) - 1
+	endCol := len(inserted[last])
+	inserted[0] = append(append([]rune{}, e.lines[start.row][:start.col]...), inserted[0]...)
+	if last == 0 {
+		endCol += start.col
+	}
+	inserted[last] = append(inserted[last], tail...)
+
+	lines := make([][]rune, 0, len(e.lines)-(end.row-start.row)+last)
+	lines = append(lines, e.lines[:start.row]...)
+	lines = append(lines, inserted...)
+	e.lines = append(lines, e.lines[end.row+1:]...)
+
+	for _, line := range inserted {
+		e.updateWidest(line)
+	}
+	if len(e.states) > start.row+1 {
+		e.states = e.states[:start.row+1]
+	}
+	return codePosition{row: start.row + last, col: endCol}
+}
+
+func (e *CodeEntry) scrollToCursor() {
+	if e.scroller == nil || e.content == nil {
+		return
+	}
+
+	cell := e.content.cellSize()
+	x := e.content.textLeft() + float32(displayColumn(e.lines[e.cursor.row], e.cursor.col))*cell.Width
+	y := float32(e.cursor.row) * cell.Height
+	size := e.scroller.Size()
+	offset := e.scroller.Offset
+	if y < offset.Y {
+		offset.Y = y
+	} else if y+cell.Height > offset.Y+size.Height {
+		offset.Y = y + cell.Height - size.Height
+	}
+	if x < offset.X+e.content.textLeft() {
+		offset.X = gui.Max(0, x-e.content.textLeft())
+	} else if x+cell.Width > offset.X+size.Width {
+		offset.X = x + cell.Width - size.Width
+	}
+	if offset != e.scroller.Offset {
+		e.scroller.Offset = offset
+		e.scroller.Refresh()
+	}
+}
+
+// selection returns the start and end of the selected text in document order.
+func (e *CodeEntry) selection() (codePosition, codePosition) {
+	if e.cursor.before(e.anchor) {
+		return e.cursor, e.anchor
+	}
+	return e.anchor, e.cursor
+}
+
+// step moves a position by one character, continuing onto the next or previous line.
+func (e *CodeEntry) step(p codePosition, dir int) codePosition {
+	p.col += dir
+	if p.col < 0 {
+		if p.row == 0 {
+			return codePosition{}
+		}
+		p.row--
+		p.col = len(e.lines[p.row])
+	} else if p.col > len(e.lines[p.row]) {
+		if p.row == len(e.lines)-1 {
+			p.col--
+			return p
+		}
+		p.row++
+		p.col = 0
+	}
+	return p
+}
+
+func (e *CodeEntry) textRange(start, end codePosition) string {
+	if start.row == end.row {
+		return string(e.lines[start.row][start.col:end.col])
+	}
+
+	var b strings.Builder
+	b.WriteString(string(e.lines[start.row][start.col:]))
+	for row := start.row + 1; row < end.row; row++ {
+		b.WriteByte('\n')
+		b.WriteString(string(e.lines[row]))
+	}
+	b.WriteByte('\n')
+	b.WriteString(string(e.lines[end.row][:end.col]))
+	return b.String()
+}
+
+// tokens returns the highlighted tokens of a line, tokenizing the lines before it if their state is not known.
+func (e *CodeEntry) tokens(row int) []syntax.Token {
+	if e.Lexer == nil {
+		return []syntax.Token{{Type: syntax.TokenText, Text: string(e.lines[row])}}
+	}
+
+	for len(e.states) <= row {
+		prev := len(e.states) - 1
+		_, state := e.Lexer.Tokenize(string(e.lines[prev]), e.states[prev])
+		e.states = append(e.states, state)
+	}
+	tokens, _ := e.Lexer.Tokenize(string(e.lines[row]), e.states[row])
+	return tokens
+}
+
+func (e *CodeEntry) updateWidest(line []rune) {
+	if width := displayColumn(line, len(line)); width > e.widest {
+		e.widest = width
+	}
+}
+
+// verticalPosition returns the position rows above or below the cursor, keeping its display column.
+func (e *CodeEntry) verticalPosition(rows int) codePosition {
+	row := clampIndex(e.cursor.row+rows, len(e.lines))
+	column := displayColumn(e.lines[e.cursor.row], e.cursor.col)
+	return codePosition{row: row, col: columnAtDisplay(e.lines[row], float32(column))}
+}
+
+// codeTokenColor returns the color used to highlight a type of token.
+func codeTokenColor(t syntax.TokenType) color.Color {
+	switch t {
+	case syntax.TokenKeyword, syntax.TokenHeading, syntax.TokenKey, syntax.TokenLink:
+		return theme.PrimaryColor()
+	case syntax.TokenComment:
+		return theme.DisabledColor()
+	case syntax.TokenString, syntax.TokenCode:
+		return &color.NRGBA{R: 0x4c, G: 0xaf, B: 0x50, A: 0xff}
+	case syntax.TokenNumber, syntax.TokenLiteral:
+		return &color.NRGBA{R: 0xff, G: 0x98, B: 0x00, A: 0xff}
+	case syntax.TokenBuiltin:
+		return &color.NRGBA{R: 0xab, G: 0x47, B: 0xbc, A: 0xff}
+	}
+	return theme.ForegroundColor()
+}
+
+// columnAtDisplay returns the index of the rune nearest to a display column, which may be fractional.
+func columnAtDisplay(line []rune, column float32) int {
+	x := 0
+	for i, r := range line {
+		next := x + 1
+		if r == '\t' {
+			next = nextTab(x, painter.DefaultTabWidth)
+		}
+		if column < float32(x+next)/2 {
+			return i
+		}
+		x = next
+	}
+	return len(line)
+}
+
+// displayColumn returns the column on screen of a rune index, with tabs expanded.
+func displayColumn(line []rune, col int) int {
+	x := 0
+	for _, r := range line[:col] {
+		if r == '\t' {
+			x = nextTab(x, painter.DefaultTabWidth)
+		} else {
+			x++
+		}
+	}
+	return x
+}
+
+// endOfText returns the position after text if it were inserted at a position.
+func endOfText(at codePosition, text string) codePosition {
+	lines := strings.Split(text, "\n")
+	if len(lines) == 1 {
+		return codePosition{row: at.row, col: at.col + len([]rune(text))}
+	}
+	return codePosition{row: at.row + len(lines) - 1, col: len([]rune(lines[len(lines)-1]))}
+}
+
+// indexRunes returns the index of the first occurrence of needle in text at or after from, or -1.
+func indexRunes(text, needle []rune, from int, matchCase bool) int {
+	equal := func(a, b rune) bool {
+		return a == b || (!matchCase && unicode.ToLower(a) == unicode.ToLower(b))
+	}
+
+	if from < 0 {
+		from = 0
+	}
+	for i := from; i+len(needle) <= len(text); i++ {
+		found := true
+		for j, r := range needle {
+			if !equal(text[i+j], r) {
+				found = false
+				break
+			}
+		}
+		if found {
+			return i
+		}
+	}
+	return -1
+}
+
+func leadingSpace(line []rune) []rune {
+	for i, r := range line {
+		if r != ' ' && r != '\t' {
+			return line[:i]
+		}
+	}
+	return line
+}
+
+type codeEntryRenderer struct {
+	widget.BaseRenderer
+	entry      *CodeEntry
+	background *canvas.Rectangle
+}
+
+func (r *codeEntryRenderer) Layout(size gui.Size) {
+	r.background.Resize(size)
+	r.entry.scroller.Resize(size)
+	r.entry.content.Refresh()
+}
+
+func (r *codeEntryRenderer) MinSize() gui.Size {
+	return r.entry.scroller.MinSize()
+}
+
+func (r *codeEntryRenderer) Refresh() {
+	r.background.FillColor = theme.InputBackgroundColor()
+	r.background.Refresh()
+	r.entry.content.Refresh()
+	r.entry.scroller.Refresh()
+}
+
+// codeEntryContent draws the visible lines of a CodeEntry inside its scroller.
+type codeEntryContent struct {
+	BaseWidget
+	entry    *CodeEntry
+	dragging bool
+}
+
+func (c *codeEntryContent) CreateRenderer() gui.WidgetRenderer {
+	cursor := canvas.NewRectangle(theme.PrimaryColor())
+	return &codeEntryContentRenderer{content: c, cursor: cursor}
+}
+
+func (c *codeEntryContent) DragEnd() {
+	c.dragging = false
+	if gui.CurrentDevice().IsMobile() {
+		c.entry.scroller.DragEnd()
+	}
+}
+
+func (c *codeEntryContent) Dragged(ev *gui.DragEvent) {
+	if gui.CurrentDevice().IsMobile() {
+		c.entry.scroller.Dragged(ev)
+		return
+	}
+
+	if !c.dragging {
+		c.dragging = true
+		c.entry.anchor = c.positionAt(ev.Position.Subtract(ev.Dragged))
+		focusCollection(c.entry.super())
+	}
+	c.entry.moveTo(c.positionAt(ev.Position), true)
+}
+
+func (c *codeEntryContent) MinSize() gui.Size {
+	c.ExtendBaseWidget(c)
+	return c.BaseWidget.MinSize()
+}
+
+func (c *codeEntryContent) Tapped(ev *gui.PointEvent) {
+	focusCollection(c.entry.super())
+	c.entry.moveTo(c.positionAt(ev.Position), c.entry.keys.shift)
+}
+
+func (c *codeEntryContent) cellSize() gui.Size {
+	return gui.MeasureText("M", theme.TextSize(), gui.TextStyle{Monospace: true})
+}
+
+func (c *codeEntryContent) gutterWidth() float32 {
+	if !c.entry.ShowLineNumbers {
+		return 0
+	}
+	digits := len(strconv.Itoa(len(c.entry.lines)))
+	return float32(digits)*c.cellSize().Width + theme.Padding()*2
+}
+
+func (c *codeEntryContent) lineHeight() float32 {
+	return c.cellSize().Height
+}
+
+func (c *codeEntryContent) positionAt(pos gui.Position) codePosition {
+	cell := c.cellSize()
+	row := clampIndex(int(pos.Y/cell.Height), len(c.entry.lines))
+	column := (pos.X - c.textLeft()) / cell.Width
+	return codePosition{row: row, col: columnAtDisplay(c.entry.lines[row], column)}
+}
+
+func (c *codeEntryContent) textLeft() float32 {
+	return c.gutterWidth() + theme.Padding()
+}
+
+type codeEntryContentRenderer struct {
+	content *codeEntryContent
+	cursor  *canvas.Rectangle
+	texts   []*canvas.Text
+	rects   []*canvas.Rectangle
+	objects []gui.CanvasObject
+}
+
+func (r *codeEntryContentRenderer) Destroy() {
+}
+
+func (r *codeEntryContentRenderer) Layout(gui.Size) {
+	r.Refresh()
+}
+
+func (r *codeEntryContentRenderer) MinSize() gui.Size {
+	c := r.content
+	cell := c.cellSize()
+	return gui.NewSize(c.textLeft()+float32(c.entry.widest+1)*cell.Width+theme.Padding(),
+		float32(len(c.entry.lines))*cell.Height)
+}
+
+func (r *codeEntryContentRenderer) Objects() []gui.CanvasObject {
+	return r.objects
+}
+
+// Refresh rebuilds the objects for the lines that are visible in the scroller, reusing those already created.
+func (r *codeEntryContentRenderer) Refresh() {
+	e := r.content.entry
+	cell := r.content.cellSize()
+	texts, rects := 0, 0
+	r.objects = r.objects[:0]
+	nextRect := func(fill color.Color, pos gui.Position, size gui.Size) {
+		if rects == len(r.rects) {
+			r.rects = append(r.rects, canvas.NewRectangle(fill))
+		}
+		rect := r.rects[rects]
+		rects++
+		rect.FillColor = fill
+		rect.Move(pos)
+		rect.Resize(size)
+		rect.Refresh()
+		r.objects = append(r.objects, rect)
+	}
+	nextText := func(text string, fill color.Color, pos gui.Position, size gui.Size, align gui.TextAlign) {
+		if texts == len(r.texts) {
+			r.texts = append(r.texts, canvas.NewText("", fill))
+		}
+		t := r.texts[texts]
+		texts++
+		t.Text = text
+		t.Color = fill
+		t.Alignment = align
+		t.TextStyle = gui.TextStyle{Monospace: true}
+		t.TextSize = theme.TextSize()
+		t.Move(pos)
+		t.Resize(size)
+		t.Refresh()
+		r.objects = append(r.objects, t)
+	}
+
+	first, last := 0, -1
+	if e.scroller != nil && e.scroller.Size().Height > 0 {
+		first = int(e.scroller.Offset.Y / cell.Height)
+		last = int((e.scroller.Offset.Y + e.scroller.Size().Height) / cell.Height)
+		if last >= len(e.lines) {
+			last = len(e.lines) - 1
+		}
+	}
+
+	left := r.content.textLeft()
+	width := gui.Max(r.content.Size().Width, r.MinSize().Width)
+	start, end := e.selection()
+	for row := first; row <= last; row++ {
+		y := float32(row) * cell.Height
+		if row == e.cursor.row && start == end {
+			nextRect(theme.HoverColor(), gui.NewPos(0, y), gui.NewSize(width, cell.Height))
+		}
+		if start != end && row >= start.row && row <= end.row {
+			from, to := 0, displayColumn(e.lines[row], len(e.lines[row]))+1
+			if row == start.row {
+				from = displayColumn(e.lines[row], start.col)
+			}
+			if row == end.row {
+				to = displayColumn(e.lines[row], end.col)
+			}
+			nextRect(theme.SelectionColor(), gui.NewPos(left+float32(from)*cell.Width, y),
+				gui.NewSize(float32(to-from)*cell.Width, cell.Height))
+		}
+	}
+	if e.focused {
+		if at, match, ok := e.matchingBrackets(); ok {
+			for _, p := range []codePosition{at, match} {
+				if p.row >= first && p.row <= last {
+					x := left + float32(displayColumn(e.lines[p.row], p.col))*cell.Width
+					nextRect(theme.FocusColor(), gui.NewPos(x, float32(p.row)*cell.Height), cell)
+				}
+			}
+		}
+	}
+
+	for row := first; row <= last; row++ {
+		y := float32(row) * cell.Height
+		if e.ShowLineNumbers {
+			fill := theme.DisabledColor()
+			if row == e.cursor.row {
+				fill = theme.ForegroundColor()
+			}
+			nextText(strconv.Itoa(row+1), fill, gui.NewPos(0, y),
+				gui.NewSize(r.content.gutterWidth()-theme.Padding(), cell.Height), gui.TextAlignTrailing)
+		}
+
+		column := 0
+		for _, token := range e.tokens(row) {
+			text := expandTabs(token.Text, column)
+			if strings.TrimSpace(text) != "" {
+				nextText(text, codeTokenColor(token.Type), gui.NewPos(left+float32(column)*cell.Width, y),
+					gui.NewSize(float32(len([]rune(text)))*cell.Width, cell.Height), gui.TextAlignLeading)
+			}
+			column += len([]rune(text))
+		}
+	}
+
+	if e.focused && e.cursor.row >= first && e.cursor.row <= last {
+		x := left + float32(displayColumn(e.lines[e.cursor.row], e.cursor.col))*cell.Width
+		r.cursor.FillColor = theme.PrimaryColor()
+		r.cursor.Move(gui.NewPos(x, float32(e.cursor.row)*cell.Height))
+		r.cursor.Resize(gui.NewSize(theme.InputBorderSize(), cell.Height))
+		r.cursor.Refresh()
+		r.objects = append(r.objects, r.cursor)
+	}
+	canvas.Refresh(r.content)
+}
+
+// expandTabs replaces the tabs in text with spaces, for text that starts at a display column.
+func expandTabs(text string, column int) string {
+	if !strings.ContainsRune(text, '\t') {
+		return text
+	}
+
+	var b strings.Builder
+	for _, r := range text {
+		if r != '\t' {
+			b.WriteRune(r)
+			column++
+			continue
+		}
+		next := nextTab(column, painter.DefaultTabWidth)
+		b.WriteString(strings.Repeat(" ", next-column))
+		column = next
+	}
+	return b.String()
+}
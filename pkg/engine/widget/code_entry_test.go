@@ -0,0 +1,259 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/syntax"
+	"github.com/bhojpur/gui/pkg/engine/test"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestCodeEntry(text string) (*CodeEntry, gui.Window) {
+	e := NewCodeEntry()
+	e.SetText(text)
+	w := test.NewWindow(e)
+	w.Resize(gui.NewSize(300, 200))
+	w.Canvas().Focus(e)
+	return e, w
+}
+
+func typeCodeKeys(e *CodeEntry, keys ...gui.KeyName) {
+	for _, key := range keys {
+		e.TypedKey(&gui.KeyEvent{Name: key})
+	}
+}
+
+func TestCodeEntry_Typing(t *testing.T) {
+	e, w := newTestCodeEntry("one\ntwo")
+	defer w.Close()
+	changed := ""
+	e.OnChanged = func(s string) {
+		changed = s
+	}
+
+	typeCodeKeys(e, gui.KeyEnd)
+	test.Type(e, "!")
+	typeCodeKeys(e, gui.KeyDown, gui.KeyBackspace, gui.KeyHome, gui.KeyDelete)
+	assert.Equal(t, "one!\nw", e.Text())
+	assert.Equal(t, "one!\nw", changed)
+
+	typeCodeKeys(e, gui.KeyLeft, gui.KeyBackspace)
+	assert.Equal(t, "one\nw", e.Text())
+	row, col := e.CursorPosition()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 3, col)
+
+	typeCodeKeys(e, gui.KeyDelete)
+	assert.Equal(t, "onew", e.Text())
+}
+
+func TestCodeEntry_Selection(t *testing.T) {
+	e, w := newTestCodeEntry("hello\nworld")
+	defer w.Close()
+	clipboard := test.NewClipboard()
+
+	e.SetCursorPosition(0, 3)
+	e.KeyDown(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+	typeCodeKeys(e, gui.KeyDown, gui.KeyRight)
+	e.KeyUp(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+	assert.Equal(t, "lo\nworl", e.SelectedText())
+
+	e.TypedShortcut(&gui.ShortcutCut{Clipboard: clipboard})
+	assert.Equal(t, "held", e.Text())
+	assert.Equal(t, "lo\nworl", clipboard.Content())
+
+	e.TypedShortcut(&gui.ShortcutSelectAll{})
+	assert.Equal(t, "held", e.SelectedText())
+	e.TypedShortcut(&gui.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "lo\nworl", e.Text())
+
+	typeCodeKeys(e, gui.KeyHome)
+	e.KeyDown(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+	typeCodeKeys(e, gui.KeyEnd)
+	e.KeyUp(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+	e.TypedShortcut(&gui.ShortcutCopy{Clipboard: clipboard})
+	assert.Equal(t, "worl", clipboard.Content())
+}
+
+func TestCodeEntry_AutoIndent(t *testing.T) {
+	e, w := newTestCodeEntry("func f() {}")
+	defer w.Close()
+
+	e.SetCursorPosition(0, 10)
+	typeCodeKeys(e, gui.KeyReturn)
+	assert.Equal(t, "func f() {\n\t\n}", e.Text())
+	row, col := e.CursorPosition()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 1, col)
+
+	test.Type(e, "x")
+	typeCodeKeys(e, gui.KeyReturn)
+	test.Type(e, "}")
+	assert.Equal(t, "func f() {\n\tx\n}\n}", e.Text())
+
+	yaml, w2 := newTestCodeEntry("server:")
+	defer w2.Close()
+	yaml.Indent = "  "
+	typeCodeKeys(yaml, gui.KeyEnd, gui.KeyReturn)
+	test.Type(yaml, "port: 1")
+	typeCodeKeys(yaml, gui.KeyReturn, gui.KeyTab)
+	assert.Equal(t, "server:\n  port: 1\n    ", yaml.Text())
+}
+
+func TestCodeEntry_UndoRedo(t *testing.T) {
+	e, w := newTestCodeEntry("")
+	defer w.Close()
+
+	test.Type(e, "abc def")
+	typeCodeKeys(e, gui.KeyBackspace)
+	assert.Equal(t, "abc de", e.Text())
+
+	assert.True(t, e.Undo())
+	assert.Equal(t, "abc def", e.Text())
+	assert.True(t, e.Undo())
+	assert.Equal(t, "abc ", e.Text())
+	e.TypedShortcut(&desktop.CustomShortcut{KeyName: gui.KeyZ, Modifier: gui.KeyModifierShortcutDefault})
+	assert.Equal(t, "abc", e.Text())
+	assert.True(t, e.Undo())
+	assert.Equal(t, "", e.Text())
+	assert.False(t, e.Undo())
+
+	assert.True(t, e.Redo())
+	assert.Equal(t, "abc", e.Text())
+	e.TypedShortcut(&desktop.CustomShortcut{KeyName: gui.KeyY, Modifier: gui.KeyModifierShortcutDefault})
+	assert.Equal(t, "abc ", e.Text())
+	e.TypedShortcut(&gui.ShortcutUndo{})
+	assert.Equal(t, "abc", e.Text())
+	e.TypedShortcut(&gui.ShortcutRedo{})
+	assert.Equal(t, "abc ", e.Text())
+
+	test.Type(e, "x")
+	assert.False(t, e.Redo())
+	assert.Equal(t, "abc x", e.Text())
+}
+
+func TestCodeEntry_MatchingBrackets(t *testing.T) {
+	e, w := newTestCodeEntry("f(a[0],\n  {b})")
+	defer w.Close()
+
+	e.SetCursorPosition(0, 1)
+	at, match, ok := e.matchingBrackets()
+	assert.True(t, ok)
+	assert.Equal(t, codePosition{0, 1}, at)
+	assert.Equal(t, codePosition{1, 5}, match)
+
+	e.SetCursorPosition(1, 6)
+	at, match, ok = e.matchingBrackets()
+	assert.True(t, ok)
+	assert.Equal(t, codePosition{1, 5}, at)
+	assert.Equal(t, codePosition{0, 1}, match)
+
+	e.SetCursorPosition(1, 4)
+	at, match, ok = e.matchingBrackets()
+	assert.True(t, ok)
+	assert.Equal(t, codePosition{1, 4}, at)
+	assert.Equal(t, codePosition{1, 2}, match)
+
+	e.SetCursorPosition(0, 0)
+	_, _, ok = e.matchingBrackets()
+	assert.False(t, ok)
+}
+
+func TestCodeEntry_FindReplace(t *testing.T) {
+	e, w := newTestCodeEntry("Cat cat\ncAT dog")
+	defer w.Close()
+
+	assert.True(t, e.Find("cat", true))
+	assert.Equal(t, "cat", e.SelectedText())
+	row, col := e.CursorPosition()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 7, col)
+
+	assert.True(t, e.Find("cat", false))
+	assert.Equal(t, "cAT", e.SelectedText())
+	assert.True(t, e.Find("cat", false))
+	assert.Equal(t, "Cat", e.SelectedText())
+	assert.False(t, e.Find("bird", false))
+
+	assert.True(t, e.Replace("cat", "lion", false))
+	assert.Equal(t, "lion cat\ncAT dog", e.Text())
+	assert.Equal(t, "cat", e.SelectedText())
+
+	assert.Equal(t, 2, e.ReplaceAll("CAT", "tiger", false))
+	assert.Equal(t, "lion tiger\ntiger dog", e.Text())
+	assert.True(t, e.Undo())
+	assert.Equal(t, "lion cat\ncAT dog", e.Text())
+}
+
+func TestCodeEntry_Highlighting(t *testing.T) {
+	e, w := newTestCodeEntry("func main() {\n\treturn // done\n}")
+	defer w.Close()
+	e.Lexer = syntax.Go()
+	e.Refresh()
+
+	texts := map[string]*canvas.Text{}
+	for _, o := range test.WidgetRenderer(e.content).Objects() {
+		if text, ok := o.(*canvas.Text); ok {
+			texts[text.Text] = text
+		}
+	}
+	assert.Equal(t, theme.PrimaryColor(), texts["func"].Color)
+	assert.Equal(t, theme.PrimaryColor(), texts["return"].Color)
+	assert.Equal(t, theme.DisabledColor(), texts["// done"].Color)
+	assert.Equal(t, theme.DisabledColor(), texts["3"].Color)
+	assert.Equal(t, theme.ForegroundColor(), texts["1"].Color)
+
+	cell := e.content.cellSize()
+	assert.Equal(t, e.content.textLeft()+cell.Width*4, texts["return"].Position().X)
+}
+
+func TestCodeEntry_LargeFile(t *testing.T) {
+	lines := make([]string, 50000)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("key%d: value", i)
+	}
+	e, w := newTestCodeEntry(strings.Join(lines, "\n"))
+	defer w.Close()
+	e.Lexer = syntax.YAML()
+	e.Refresh()
+
+	objects := test.WidgetRenderer(e.content).Objects()
+	assert.Less(t, len(objects), 100)
+
+	e.SetCursorPosition(49999, 0)
+	found := false
+	for _, o := range test.WidgetRenderer(e.content).Objects() {
+		if text, ok := o.(*canvas.Text); ok && text.Text == "50000" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+	assert.Less(t, len(test.WidgetRenderer(e.content).Objects()), 100)
+}
@@ -0,0 +1,282 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+const defaultDateFormat = "2006-01-02"
+
+// Declare conformity with interfaces.
+var _ gui.Widget = (*DateEntry)(nil)
+var _ gui.Focusable = (*DateEntry)(nil)
+
+// DateEntry is an input field for a date that can be typed or picked from a Calendar.
+// Pressing the down key opens the calendar.
+//
+// Since: 2.3
+type DateEntry struct {
+	Entry
+
+	// Format is the layout used to show and parse dates, see time.Parse. The default is "2006-01-02".
+	Format string
+	// Min and Max are the earliest and latest dates that are valid, a zero time is not bounded.
+	Min, Max time.Time
+	// OnChanged is called when a valid date is entered, or with a zero time when the text is cleared.
+	// A new date keeps the time of day and location of the previous one.
+	OnChanged func(time.Time)
+
+	date       time.Time
+	popUp      *PopUp
+	dateBinder basicBinder
+}
+
+// NewDateEntry creates an entry for a date.
+//
+// Since: 2.3
+func NewDateEntry() *DateEntry {
+	e := &DateEntry{}
+	e.ExtendBaseWidget(e)
+	e.Entry.OnChanged = e.textChanged
+	e.Validator = e.validate
+	e.ActionItem = e.setupCalendarButton()
+	return e
+}
+
+// NewDateEntryWithData returns a date entry connected to the specified data source.
+//
+// Since: 2.3
+func NewDateEntryWithData(data binding.Time) *DateEntry {
+	e := NewDateEntry()
+	e.Bind(data)
+	return e
+}
+
+// Bind connects the specified data source to this DateEntry.
+// The current value will be displayed and any changes in the data will cause the widget to update.
+// Dates entered by the user will be set into the data source, at midnight at the start of the day.
+func (e *DateEntry) Bind(data binding.Time) {
+	e.dateBinder.SetCallback(e.updateFromData)
+	e.dateBinder.Bind(data)
+
+	e.OnChanged = func(_ time.Time) {
+		e.dateBinder.CallWithData(e.writeData)
+	}
+}
+
+// CreateRenderer returns a new renderer for this date entry.
+//
+// Implements: gui.Widget
+func (e *DateEntry) CreateRenderer() gui.WidgetRenderer {
+	e.ExtendBaseWidget(e)
+	return e.Entry.CreateRenderer()
+}
+
+// Date returns the date that has been entered, or a zero time if there is none.
+func (e *DateEntry) Date() time.Time {
+	return e.date
+}
+
+// Disable this widget so that it cannot be interacted with, updating any style appropriately.
+//
+// Implements: gui.DisableableWidget
+func (e *DateEntry) Disable() {
+	e.ActionItem.(gui.Disableable).Disable()
+	e.Entry.Disable()
+}
+
+// Enable this widget, updating any style or features appropriately.
+//
+// Implements: gui.DisableableWidget
+func (e *DateEntry) Enable() {
+	e.ActionItem.(gui.Disableable).Enable()
+	e.Entry.Enable()
+}
+
+// MinSize returns the minimal size of the date entry.
+//
+// Implements: gui.Widget
+func (e *DateEntry) MinSize() gui.Size {
+	e.ExtendBaseWidget(e)
+	return e.Entry.MinSize()
+}
+
+// Move changes the relative position of the date entry.
+//
+// Implements: gui.Widget
+func (e *DateEntry) Move(pos gui.Position) {
+	e.Entry.Move(pos)
+	if e.popUp != nil {
+		e.popUp.Move(e.popUpPos())
+	}
+}
+
+// SetDate updates the date shown in this entry, a zero time clears the text.
+func (e *DateEntry) SetDate(date time.Time) {
+	e.SetText(e.formatDate(date))
+}
+
+// TypedKey receives key input events when the DateEntry widget is focused.
+//
+// Implements: gui.Focusable
+func (e *DateEntry) TypedKey(key *gui.KeyEvent) {
+	if key.Name == gui.KeyDown && !e.Disabled() {
+		e.showCalendar()
+		return
+	}
+	e.Entry.TypedKey(key)
+}
+
+// Unbind disconnects any configured data source from this DateEntry.
+// The current value will remain at the last value of the data source.
+func (e *DateEntry) Unbind() {
+	e.OnChanged = nil
+	e.dateBinder.Unbind()
+}
+
+func (e *DateEntry) format() string {
+	if e.Format == "" {
+		return defaultDateFormat
+	}
+	return e.Format
+}
+
+func (e *DateEntry) formatDate(date time.Time) string {
+	if date.IsZero() {
+		return ""
+	}
+	return date.Format(e.format())
+}
+
+func (e *DateEntry) parse(text string) (time.Time, error) {
+	if text == "" {
+		return time.Time{}, nil
+	}
+	date, err := time.ParseInLocation(e.format(), text, time.Local)
+	if err != nil {
+		return date, err
+	}
+	if !e.Min.IsZero() && date.Before(calendarDate(e.Min, date)) {
+		return date, errors.New("date is before " + e.formatDate(e.Min))
+	}
+	if !e.Max.IsZero() && date.After(calendarDate(e.Max, date)) {
+		return date, errors.New("date is after " + e.formatDate(e.Max))
+	}
+	return date, nil
+}
+
+func (e *DateEntry) popUpPos() gui.Position {
+	entryPos := gui.CurrentApp().Driver().AbsolutePositionForObject(e.super())
+	return entryPos.Add(gui.NewPos(0, e.Size().Height-theme.InputBorderSize()))
+}
+
+func (e *DateEntry) setupCalendarButton() *Button {
+	button := NewButtonWithIcon("", theme.MenuDropDownIcon(), e.showCalendar)
+	button.Importance = LowImportance
+	return button
+}
+
+func (e *DateEntry) showCalendar() {
+	c := gui.CurrentApp().Driver().CanvasForObject(e.super())
+	if c == nil {
+		return
+	}
+
+	cal := NewCalendar(e.date, nil)
+	cal.Min, cal.Max = e.Min, e.Max
+	cal.OnSelected = func(date time.Time) {
+		e.popUp.Hide()
+		e.popUp = nil
+		e.SetDate(date)
+		c.Focus(e.super().(gui.Focusable))
+	}
+	e.popUp = NewPopUp(cal, c)
+	e.popUp.ShowAtPosition(e.popUpPos())
+	c.Focus(cal)
+}
+
+func (e *DateEntry) textChanged(text string) {
+	if text == e.formatDate(e.date) {
+		return
+	}
+	date, err := e.parse(text)
+	if err != nil {
+		return
+	}
+	if !date.IsZero() {
+		date = calendarDateTime(date, e.date)
+	}
+
+	e.date = date
+	if e.OnChanged != nil {
+		e.OnChanged(date)
+	}
+}
+
+func (e *DateEntry) updateFromData(data binding.DataItem) {
+	if data == nil {
+		return
+	}
+	timeSource, ok := data.(binding.Time)
+	if !ok {
+		return
+	}
+
+	val, err := timeSource.Get()
+	if err != nil {
+		gui.LogError("Error getting current data value", err)
+		return
+	}
+	e.date = val
+	e.SetDate(val)
+}
+
+func (e *DateEntry) validate(text string) error {
+	_, err := e.parse(text)
+	return err
+}
+
+func (e *DateEntry) writeData(data binding.DataItem) {
+	if data == nil {
+		return
+	}
+	timeTarget, ok := data.(binding.Time)
+	if !ok {
+		return
+	}
+	currentValue, err := timeTarget.Get()
+	if err != nil {
+		return
+	}
+	if !currentValue.Equal(e.date) {
+		err := timeTarget.Set(e.date)
+		if err != nil {
+			gui.LogError(fmt.Sprintf("Failed to set binding value to %s", e.date), err)
+		}
+	}
+}
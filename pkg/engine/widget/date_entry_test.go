@@ -0,0 +1,132 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateEntry_Calendar(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	e := NewDateEntry()
+	e.SetDate(calendarDay(2022, time.March, 4))
+	w := test.NewWindow(e)
+	defer w.Close()
+	w.Resize(gui.NewSize(400, 400))
+	c := w.Canvas()
+
+	test.Tap(e.ActionItem.(gui.Tappable))
+	pop, ok := c.Overlays().Top().(*PopUp)
+	if !ok {
+		t.Fatal("calendar should be shown in a pop up")
+	}
+	cal := pop.Content.(*Calendar)
+	assert.Equal(t, cal, c.Focused())
+	assert.Equal(t, calendarDay(2022, time.March, 4), cal.Selected())
+
+	cal.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	cal.TypedKey(&gui.KeyEvent{Name: gui.KeyReturn})
+	assert.Nil(t, c.Overlays().Top())
+	assert.Equal(t, "2022-03-11", e.Text)
+	assert.Equal(t, calendarDay(2022, time.March, 11), e.Date())
+
+	c.Focus(e)
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.NotNil(t, c.Overlays().Top())
+}
+
+func TestDateEntry_Typed(t *testing.T) {
+	var changed []time.Time
+	e := NewDateEntry()
+	e.Format = "02/01/2006"
+	e.Min = calendarDay(2022, time.January, 1)
+	e.OnChanged = func(d time.Time) {
+		changed = append(changed, d)
+	}
+
+	test.Type(e, "25/12/2021")
+	assert.True(t, e.Date().IsZero())
+	assert.Error(t, e.Validate())
+	assert.Empty(t, changed)
+
+	e.SetText("")
+	test.Type(e, "25/12/2022")
+	assert.NoError(t, e.Validate())
+	assert.Equal(t, []time.Time{calendarDay(2022, time.December, 25)}, changed)
+	assert.Equal(t, calendarDay(2022, time.December, 25), e.Date())
+
+	e.SetText("not a date")
+	assert.Error(t, e.Validate())
+	assert.Equal(t, calendarDay(2022, time.December, 25), e.Date())
+
+	e.SetText("")
+	assert.NoError(t, e.Validate())
+	assert.True(t, e.Date().IsZero())
+	assert.Equal(t, 2, len(changed))
+}
+
+func TestNewDateEntryWithData(t *testing.T) {
+	data := binding.NewTime()
+	err := data.Set(calendarDay(2022, time.March, 4))
+	assert.Nil(t, err)
+
+	e := NewDateEntryWithData(data)
+	waitForBinding()
+	assert.Equal(t, "2022-03-04", e.Text)
+
+	e.SetText("2022-04-01")
+	v, err := data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, calendarDay(2022, time.April, 1), v)
+
+	err = data.Set(time.Time{})
+	assert.Nil(t, err)
+	waitForBinding()
+	assert.Equal(t, "", e.Text)
+}
+
+func TestDateEntry_BindKeepsTimeOfDay(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	data := binding.NewTime()
+	err := data.Set(time.Date(2022, time.March, 4, 14, 30, 0, 0, loc))
+	assert.Nil(t, err)
+
+	e := NewDateEntryWithData(data)
+	waitForBinding()
+	assert.Equal(t, "2022-03-04", e.Text)
+	v, err := data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2022, time.March, 4, 14, 30, 0, 0, loc), v)
+
+	e.SetText("2022-04-01")
+	v, err = data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2022, time.April, 1, 14, 30, 0, 0, loc), v)
+}
@@ -25,6 +25,7 @@ import (
 	"math"
 	"strings"
 	"unicode"
+	"unicode/utf8"
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
@@ -49,6 +50,7 @@ var _ gui.Tappable = (*Entry)(nil)
 var _ gui.Widget = (*Entry)(nil)
 var _ desktop.Mouseable = (*Entry)(nil)
 var _ desktop.Keyable = (*Entry)(nil)
+var _ gui.Accessible = (*Entry)(nil)
 var _ mobile.Keyboardable = (*Entry)(nil)
 var _ mobile.Touchable = (*Entry)(nil)
 var _ gui.Tabbable = (*Entry)(nil)
@@ -151,6 +153,23 @@ func (e *Entry) AcceptsTab() bool {
 	return e.MultiLine
 }
 
+// AccessibilityInfo describes this entry to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (e *Entry) AccessibilityInfo() gui.AccessibilityInfo {
+	e.propertyLock.RLock()
+	defer e.propertyLock.RUnlock()
+
+	info := gui.AccessibilityInfo{Role: gui.AccessibleRoleTextField, Name: e.PlaceHolder, Value: e.Text}
+	if e.Password {
+		info.Value = strings.Repeat(passwordChar, utf8.RuneCountInString(e.Text))
+		info.State.Protected = true
+	}
+	return info
+}
+
 // Bind connects the specified data source to this Entry.
 // The current value will be displayed and any changes in the data will cause the widget to update.
 // User interactions with this Entry will set the value into the data source.
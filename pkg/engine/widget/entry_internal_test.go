@@ -99,6 +99,27 @@ func TestEntry_DoubleTapped_AfterCol(t *testing.T) {
 	assert.Equal(t, "", entry.SelectedText())
 }
 
+func TestEntry_FocusedUndoCustomShortcut(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	e := NewEntry()
+	w := test.NewWindow(e)
+	defer w.Close()
+	var typed []gui.Shortcut
+	undo := &desktop.CustomShortcut{KeyName: gui.KeyZ, Modifier: gui.KeyModifierShortcutDefault}
+	redo := &desktop.CustomShortcut{KeyName: gui.KeyZ, Modifier: gui.KeyModifierShortcutDefault | gui.KeyModifierShift}
+	e.shortcut.AddShortcut(undo, func(s gui.Shortcut) { typed = append(typed, s) })
+	e.shortcut.AddShortcut(redo, func(s gui.Shortcut) { typed = append(typed, s) })
+
+	// the driver sends Ctrl+Z and Ctrl+Shift+Z to the focused widget as undo and redo
+	w.Canvas().Focus(e)
+	focused := w.Canvas().Focused().(gui.Shortcutable)
+	focused.TypedShortcut(&gui.ShortcutUndo{})
+	focused.TypedShortcut(&gui.ShortcutRedo{})
+	assert.Equal(t, []gui.Shortcut{undo, redo}, typed)
+}
+
 func TestEntry_DragSelect(t *testing.T) {
 	entry := NewEntry()
 	entry.Wrapping = gui.TextWrapOff
@@ -0,0 +1,156 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	gui "github.com/bhojpur/gui/pkg/engine"
+)
+
+// heightIndex locates the rows of a table or the items of a list, which are separated by a divider.
+// If all rows use the default height positions are calculated directly, otherwise a Fenwick tree of the
+// row heights is kept so that the position of a row, the row at a position and changing the height of
+// a single row all take O(log n).
+type heightIndex struct {
+	count             int
+	height, separator float32
+	sums              []float64 // Fenwick tree of the row heights including dividers, nil if all rows have the default height
+}
+
+// newHeightIndex creates an index for count rows of the given default height.
+// The heightOf callback returns the height of a row, or zero to use the default; it may be nil.
+func newHeightIndex(count int, height, separator float32, heightOf func(int) float32) *heightIndex {
+	h := &heightIndex{count: count, height: height, separator: separator}
+	if heightOf == nil || count <= 0 {
+		return h
+	}
+
+	h.build(heightOf)
+	return h
+}
+
+// custom returns true if the rows do not all have the default height.
+func (h *heightIndex) custom() bool {
+	return h.sums != nil
+}
+
+// indexAt returns the row at the vertical position y, a divider belongs to the row above it.
+// The result is count if y is beyond the last row.
+func (h *heightIndex) indexAt(y float32) int {
+	if y < 0 {
+		return 0
+	}
+	if h.sums == nil {
+		if h.height+h.separator <= 0 {
+			return 0
+		}
+		return int(gui.Min(float32(int(y/(h.height+h.separator))), float32(h.count)))
+	}
+
+	// find the number of rows that end at or before y
+	row, remaining := 0, float64(y)
+	for step := highestBit(h.count); step > 0; step >>= 1 {
+		if next := row + step; next <= h.count && h.sums[next] <= remaining {
+			row = next
+			remaining -= h.sums[next]
+		}
+	}
+	return row
+}
+
+// length returns the height of all rows including the dividers between them.
+func (h *heightIndex) length() float32 {
+	if h.count <= 0 {
+		return 0
+	}
+	if h.sums == nil {
+		return float32(h.count)*(h.height+h.separator) - h.separator
+	}
+	return float32(h.offset(h.count)) - h.separator
+}
+
+// position returns the top and the height of a row.
+func (h *heightIndex) position(i int) (y, height float32) {
+	if h.sums == nil || i < 0 || i >= h.count {
+		return float32(i) * (h.height + h.separator), h.height
+	}
+
+	top := h.offset(i)
+	return float32(top), float32(h.offset(i+1)-top) - h.separator
+}
+
+// setHeight changes the height of a single row, zero restores the default height.
+func (h *heightIndex) setHeight(i int, height float32) {
+	if i < 0 || i >= h.count {
+		return
+	}
+	if height <= 0 {
+		height = h.height
+	}
+	if h.sums == nil {
+		if height == h.height {
+			return
+		}
+		h.build(func(int) float32 { return 0 })
+	}
+
+	_, current := h.position(i)
+	delta := float64(height - current)
+	for j := i + 1; j <= h.count; j += j & -j {
+		h.sums[j] += delta
+	}
+}
+
+// build fills the tree with the heights of all rows in O(n).
+func (h *heightIndex) build(heightOf func(int) float32) {
+	h.sums = make([]float64, h.count+1)
+	for i := 1; i <= h.count; i++ {
+		rowHeight := heightOf(i - 1)
+		if rowHeight <= 0 {
+			rowHeight = h.height
+		}
+		h.sums[i] += float64(rowHeight + h.separator)
+		if parent := i + i&-i; parent <= h.count {
+			h.sums[parent] += h.sums[i]
+		}
+	}
+}
+
+// offset returns the top of row i, which is the sum of the heights of the rows before it.
+func (h *heightIndex) offset(i int) float64 {
+	sum := 0.0
+	for ; i > 0; i -= i & -i {
+		sum += h.sums[i]
+	}
+	return sum
+}
+
+// highestBit returns the largest power of two that is not more than n, or zero if n is not positive.
+func highestBit(n int) int {
+	if n <= 0 {
+		return 0
+	}
+
+	bit := 1
+	for bit<<1 <= n {
+		bit <<= 1
+	}
+	return bit
+}
@@ -0,0 +1,102 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeightIndex_Default(t *testing.T) {
+	i := newHeightIndex(5, 10, 1, nil)
+	assert.False(t, i.custom())
+	assert.Equal(t, float32(54), i.length())
+
+	y, h := i.position(2)
+	assert.Equal(t, float32(22), y)
+	assert.Equal(t, float32(10), h)
+
+	assert.Equal(t, 0, i.indexAt(-5))
+	assert.Equal(t, 0, i.indexAt(10))
+	assert.Equal(t, 1, i.indexAt(11))
+	assert.Equal(t, 4, i.indexAt(53))
+	assert.Equal(t, 5, i.indexAt(500))
+	assert.Equal(t, float32(0), newHeightIndex(0, 10, 1, nil).length())
+}
+
+func TestHeightIndex_Custom(t *testing.T) {
+	heights := map[int]float32{1: 30, 3: 5}
+	i := newHeightIndex(5, 10, 1, func(row int) float32 {
+		return heights[row]
+	})
+	assert.Equal(t, float32(10+30+10+5+10+4), i.length())
+
+	for row, expected := range []struct{ y, h float32 }{{0, 10}, {11, 30}, {42, 10}, {53, 5}, {59, 10}} {
+		y, h := i.position(row)
+		assert.Equal(t, expected.y, y, "row %d", row)
+		assert.Equal(t, expected.h, h, "row %d", row)
+	}
+
+	assert.Equal(t, 0, i.indexAt(0))
+	assert.Equal(t, 0, i.indexAt(10.5)) // the divider belongs to the row above
+	assert.Equal(t, 1, i.indexAt(11))
+	assert.Equal(t, 1, i.indexAt(41))
+	assert.Equal(t, 2, i.indexAt(42))
+	assert.Equal(t, 3, i.indexAt(58))
+	assert.Equal(t, 4, i.indexAt(59))
+	assert.Equal(t, 5, i.indexAt(100))
+}
+
+func TestHeightIndex_Large(t *testing.T) {
+	i := newHeightIndex(100000, 20, 1, func(row int) float32 {
+		if row%2 == 0 {
+			return 40
+		}
+		return 0
+	})
+	assert.Equal(t, float32(50000*41+50000*21-1), i.length())
+
+	y, h := i.position(99999)
+	assert.Equal(t, float32(50000*41+49999*21), y)
+	assert.Equal(t, float32(20), h)
+	assert.Equal(t, 99999, i.indexAt(y))
+	assert.Equal(t, 99998, i.indexAt(y-1))
+}
+
+func TestHeightIndex_SetHeight(t *testing.T) {
+	i := newHeightIndex(5, 10, 1, nil)
+	i.setHeight(1, 30)
+	assert.True(t, i.custom())
+	i.setHeight(3, 5)
+	i.setHeight(7, 50) // out of range
+	assert.Equal(t, newHeightIndex(5, 10, 1, func(row int) float32 {
+		return map[int]float32{1: 30, 3: 5}[row]
+	}), i)
+
+	i.setHeight(1, 0)
+	y, h := i.position(1)
+	assert.Equal(t, float32(11), y)
+	assert.Equal(t, float32(10), h)
+	y, _ = i.position(4)
+	assert.Equal(t, float32(11+11+11+6), y)
+	assert.Equal(t, 3, i.indexAt(38))
+}
@@ -28,6 +28,7 @@ import (
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
 	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/dnd"
 	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
 	"github.com/bhojpur/gui/pkg/engine/internal/widget"
 	"github.com/bhojpur/gui/pkg/engine/theme"
@@ -36,12 +37,19 @@ import (
 // ListItemID uniquely identifies an item within a list.
 type ListItemID = int
 
+// listItemDragType is the DragData type of the items of a list that is being reordered.
+const listItemDragType = "widget.ListItem"
+
 // Declare conformity with Widget interface.
 var _ gui.Widget = (*List)(nil)
+var _ gui.Accessible = (*List)(nil)
+var _ gui.Focusable = (*List)(nil)
+var _ desktop.Keyable = (*List)(nil)
+var _ gui.DropTarget = (*List)(nil)
 
 // List is a widget that pools list items for performance and
 // lays the items out in a vertical direction inside of a scroller.
-// List requires that all items are the same size.
+// Items have the size of the template returned by CreateItem unless ItemHeight is set.
 //
 // Since: 1.4
 type List struct {
@@ -52,10 +60,37 @@ type List struct {
 	UpdateItem   func(id ListItemID, item gui.CanvasObject)
 	OnSelected   func(id ListItemID)
 	OnUnselected func(id ListItemID)
+	// ItemHeight returns the height of the item with the given ID, or zero for the height of the template.
+	// The heights are read again when the length of the list changes, call SetItemHeight when the height
+	// of a single item changes.
+	//
+	// Since: 2.3
+	ItemHeight func(id ListItemID) float32
+	// SelectionMode sets whether one or many items can be selected, the default is SelectionSingle.
+	//
+	// Since: 2.3
+	SelectionMode SelectionMode
+	// OnSelectionChanged is called with the IDs of all selected items whenever the selection changes.
+	//
+	// Since: 2.3
+	OnSelectionChanged func(selected []ListItemID)
+	// OnReordered is called when an item has been dragged to a new position. The item with ID from
+	// should be moved so that it has ID to, the list is refreshed afterwards.
+	// Setting it allows the items to be reordered by dragging them.
+	//
+	// Since: 2.3
+	OnReordered func(from, to ListItemID)
 
 	scroller      *widget.Scroll
+	dropIndicator *canvas.Rectangle
+	dropIndex     ListItemID // where a dragged item would be inserted, -1 if not dragging
 	selected      []ListItemID
+	anchor        ListItemID // the item a range selection extends from
+	cursor        ListItemID // the item that keyboard navigation moves from
+	keys          selectionKeys
 	itemMin       gui.Size
+	itemHeights   map[ListItemID]float32
+	itemIndex     *heightIndex
 	offsetY       float32
 	offsetUpdated func(gui.Position)
 }
@@ -65,7 +100,7 @@ type List struct {
 //
 // Since: 1.4
 func NewList(length func() int, createItem func() gui.CanvasObject, updateItem func(ListItemID, gui.CanvasObject)) *List {
-	list := &List{BaseWidget: BaseWidget{}, Length: length, CreateItem: createItem, UpdateItem: updateItem}
+	list := &List{BaseWidget: BaseWidget{}, Length: length, CreateItem: createItem, UpdateItem: updateItem, dropIndex: -1}
 	list.ExtendBaseWidget(list)
 	return list
 }
@@ -90,6 +125,15 @@ func NewListWithData(data binding.DataList, createItem func() gui.CanvasObject,
 	return l
 }
 
+// AccessibilityInfo describes this list to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (l *List) AccessibilityInfo() gui.AccessibilityInfo {
+	return gui.AccessibilityInfo{Role: gui.AccessibleRoleList}
+}
+
 // CreateRenderer is a private method to Bhojpur GUI which links this widget to its renderer.
 func (l *List) CreateRenderer() gui.WidgetRenderer {
 	l.ExtendBaseWidget(l)
@@ -103,7 +147,9 @@ func (l *List) CreateRenderer() gui.WidgetRenderer {
 	l.scroller = widget.NewVScroll(layout)
 	layout.Layout = newListLayout(l)
 	layout.Resize(layout.MinSize())
-	objects := []gui.CanvasObject{l.scroller}
+	l.dropIndicator = canvas.NewRectangle(theme.PrimaryColor())
+	l.dropIndicator.Hide()
+	objects := []gui.CanvasObject{l.scroller, l.dropIndicator}
 	lr := newListRenderer(objects, l, l.scroller, layout)
 	return lr
 }
@@ -119,44 +165,207 @@ func (l *List) scrollTo(id ListItemID) {
 	if l.scroller == nil {
 		return
 	}
-	y := (float32(id) * l.itemMin.Height) + (float32(id) * theme.SeparatorThicknessSize())
+	y, height := l.itemPositions().position(id)
 	if y < l.scroller.Offset.Y {
 		l.scroller.Offset.Y = y
-	} else if y+l.itemMin.Height > l.scroller.Offset.Y+l.scroller.Size().Height {
-		l.scroller.Offset.Y = y + l.itemMin.Height - l.scroller.Size().Height
+	} else if y+height > l.scroller.Offset.Y+l.scroller.Size().Height {
+		l.scroller.Offset.Y = y + height - l.scroller.Size().Height
 	}
 	l.offsetUpdated(l.scroller.Offset)
 }
 
+// itemPositions returns the index of the item positions, it is rebuilt when the number of items, the template
+// or the ItemHeight callback changed.
+func (l *List) itemPositions() *heightIndex {
+	length := 0
+	if f := l.Length; f != nil {
+		length = f()
+	}
+
+	separatorThickness := theme.SeparatorThicknessSize()
+	custom := l.ItemHeight != nil || len(l.itemHeights) > 0
+	if i := l.itemIndex; i != nil && i.count == length && i.height == l.itemMin.Height && i.separator == separatorThickness &&
+		i.custom() == custom {
+		return i
+	}
+
+	var heightOf func(int) float32
+	if custom {
+		heightOf = l.itemHeight
+	}
+	l.itemIndex = newHeightIndex(length, l.itemMin.Height, separatorThickness, heightOf)
+	return l.itemIndex
+}
+
+// itemHeight returns the height of an item, or zero for the height of the template.
+func (l *List) itemHeight(id ListItemID) float32 {
+	if height, ok := l.itemHeights[id]; ok {
+		return height
+	}
+	if f := l.ItemHeight; f != nil {
+		return f(id)
+	}
+	return 0
+}
+
 // Resize is called when this list should change size. We refresh to ensure invisible items are drawn.
 func (l *List) Resize(s gui.Size) {
 	l.BaseWidget.Resize(s)
 	l.scroller.Content.(*gui.Container).Layout.(*listLayout).updateList(true)
 }
 
-// Select add the item identified by the given ID to the selection.
-func (l *List) Select(id ListItemID) {
-	if len(l.selected) > 0 && id == l.selected[0] {
+// DragLeave is called when a dragged item leaves this List.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (l *List) DragLeave() {
+	l.dropIndex = -1
+	l.moveDropIndicator()
+}
+
+// DragOver is called when an item is dragged over this List.
+// Only the items of this list are accepted and only if OnReordered is set.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (l *List) DragOver(data *gui.DragData, pos gui.Position) bool {
+	if item, ok := data.Source.(*listItem); !ok || item.list != l || l.OnReordered == nil {
+		return false
+	}
+
+	l.dropIndex = l.dropIndexAt(pos.Y)
+	l.moveDropIndicator()
+	return true
+}
+
+// Drop is called when an item of this List is dropped on it and moves the item to the drop position.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (l *List) Drop(data *gui.DragData, pos gui.Position) {
+	l.dropIndex = -1
+	l.moveDropIndicator()
+
+	from, ok := data.Value.(ListItemID)
+	if !ok || l.OnReordered == nil {
 		return
 	}
-	length := 0
-	if f := l.Length; f != nil {
-		length = f()
+	to := l.dropIndexAt(pos.Y)
+	if to > from {
+		to-- // the item is removed before being inserted
 	}
-	if id < 0 || id >= length {
+	if to == from {
 		return
 	}
-	old := l.selected
-	l.selected = []ListItemID{id}
-	defer func() {
-		if f := l.OnUnselected; f != nil && len(old) > 0 {
-			f(old[0])
+
+	for i, id := range l.selected {
+		l.selected[i] = movedIndex(id, from, to)
+	}
+	l.anchor = movedIndex(l.anchor, from, to)
+	l.cursor = movedIndex(l.cursor, from, to)
+	if len(l.itemHeights) > 0 {
+		heights := make(map[ListItemID]float32, len(l.itemHeights))
+		for id, height := range l.itemHeights {
+			heights[movedIndex(id, from, to)] = height
+		}
+		l.itemHeights = heights
+	}
+
+	l.OnReordered(from, to)
+	if l.itemIndex != nil {
+		first, last := from, to
+		if first > last {
+			first, last = last, first
 		}
-		if f := l.OnSelected; f != nil {
-			f(id)
+		for id := first; id <= last; id++ {
+			l.itemIndex.setHeight(id, l.itemHeight(id))
 		}
-	}()
+	}
+	l.Refresh()
+}
+
+// FocusGained is called after this List has gained focus.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (l *List) FocusGained() {
+}
+
+// FocusLost is called after this List has lost focus.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (l *List) FocusLost() {
+	l.keys.shift = false
+}
+
+// KeyDown is called when a key is pressed while this List is focused.
+//
+// Implements: desktop.Keyable
+//
+// Since: 2.3
+func (l *List) KeyDown(key *gui.KeyEvent) {
+	l.keys.keyDown(key)
+}
+
+// KeyUp is called when a key is released while this List is focused.
+//
+// Implements: desktop.Keyable
+//
+// Since: 2.3
+func (l *List) KeyUp(key *gui.KeyEvent) {
+	l.keys.keyUp(key)
+}
+
+// Select add the item identified by the given ID to the selection.
+// Unless SelectionMode is SelectionMultiple any previously selected item is unselected.
+func (l *List) Select(id ListItemID) {
+	if id < 0 || id >= l.length() {
+		return
+	}
+	l.anchor, l.cursor = id, id
+	if l.SelectionMode == SelectionMultiple {
+		if l.isSelected(id) {
+			return
+		}
+		l.scrollTo(id)
+		l.setSelection(append(l.Selected(), id))
+		return
+	}
+
+	if len(l.selected) == 1 && l.selected[0] == id {
+		return
+	}
 	l.scrollTo(id)
+	l.setSelection([]ListItemID{id})
+}
+
+// Selected returns the IDs of all selected items in the order they were selected.
+// The items of a range selection are in the order they are shown.
+//
+// Since: 2.3
+func (l *List) Selected() []ListItemID {
+	return append([]ListItemID(nil), l.selected...)
+}
+
+// SetItemHeight supports changing the height of the specified list item. Items normally take the height of the template
+// returned from the CreateItem callback, or the height returned by ItemHeight. The height parameter uses the same units
+// as a gui.Size type and refers to the internal content height not including the divider size.
+//
+// Since: 2.3
+func (l *List) SetItemHeight(id ListItemID, height float32) {
+	if l.itemHeights == nil {
+		l.itemHeights = make(map[ListItemID]float32)
+	}
+	l.itemHeights[id] = height
+	if l.itemIndex != nil {
+		l.itemIndex.setHeight(id, height)
+	}
 	l.Refresh()
 }
 
@@ -198,17 +407,77 @@ func (l *List) ScrollToTop() {
 	l.Refresh()
 }
 
+// TypedKey is called if a key event happens while this List is focused.
+// The arrow, Home, End, PageUp and PageDown keys move the selection and holding Shift
+// extends it when SelectionMode is SelectionMultiple. Space toggles the current item.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (l *List) TypedKey(key *gui.KeyEvent) {
+	length := l.length()
+	if length == 0 {
+		return
+	}
+
+	target := l.cursor
+	switch key.Name {
+	case gui.KeyUp:
+		if len(l.selected) > 0 {
+			target--
+		}
+	case gui.KeyDown:
+		if len(l.selected) > 0 {
+			target++
+		}
+	case gui.KeyHome:
+		target = 0
+	case gui.KeyEnd:
+		target = length - 1
+	case gui.KeyPageUp:
+		target -= l.pageLength()
+	case gui.KeyPageDown:
+		target += l.pageLength()
+	case gui.KeySpace:
+		if l.SelectionMode == SelectionMultiple && l.isSelected(l.cursor) {
+			l.Unselect(l.cursor)
+		} else {
+			l.Select(l.cursor)
+		}
+		return
+	default:
+		return
+	}
+
+	target = clampIndex(target, length)
+	if l.SelectionMode == SelectionMultiple && l.keys.shift {
+		l.selectRange(target)
+	} else {
+		l.selectOnly(target)
+	}
+}
+
+// TypedRune is called if a text event happens while this List is focused.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (l *List) TypedRune(_ rune) {
+}
+
 // Unselect removes the item identified by the given ID from the selection.
 func (l *List) Unselect(id ListItemID) {
-	if len(l.selected) == 0 || l.selected[0] != id {
+	if !l.isSelected(id) {
 		return
 	}
 
-	l.selected = nil
-	l.Refresh()
-	if f := l.OnUnselected; f != nil {
-		f(id)
+	var selected []ListItemID
+	for _, s := range l.selected {
+		if s != id {
+			selected = append(selected, s)
+		}
 	}
+	l.setSelection(selected)
 }
 
 // UnselectAll removes all items from the selection.
@@ -219,14 +488,173 @@ func (l *List) UnselectAll() {
 		return
 	}
 
-	selected := l.selected
-	l.selected = nil
+	l.setSelection(nil)
+}
+
+func (l *List) isSelected(id ListItemID) bool {
+	for _, s := range l.selected {
+		if s == id {
+			return true
+		}
+	}
+	return false
+}
+
+// itemTapped updates the selection for a tap on an item, honouring the modifiers held at the time.
+func (l *List) itemTapped(id ListItemID) {
+	focusCollection(l.super())
+	mods := l.keys.tapModifiers()
+	if l.SelectionMode != SelectionMultiple {
+		l.Select(id)
+		return
+	}
+
+	switch {
+	case mods&gui.KeyModifierShift != 0:
+		l.selectRange(id)
+	case mods&gui.KeyModifierShortcutDefault != 0:
+		if l.isSelected(id) {
+			l.anchor, l.cursor = id, id
+			l.Unselect(id)
+		} else {
+			l.Select(id)
+		}
+	default:
+		l.selectOnly(id)
+	}
+}
+
+// dropIndexAt returns the ID that an item dropped at y, relative to the list, would be inserted before.
+func (l *List) dropIndexAt(y float32) ListItemID {
+	y += l.offsetY
+	length := l.length()
+	index := l.itemPositions()
+	id := index.indexAt(y)
+	if id >= length {
+		return length
+	}
+	itemY, itemHeight := index.position(id)
+	if y > itemY+itemHeight/2 {
+		id++
+	}
+	return id
+}
+
+func (l *List) length() int {
+	if f := l.Length; f != nil {
+		return f()
+	}
+	return 0
+}
+
+// moveDropIndicator shows the line at which a dragged item would be inserted.
+func (l *List) moveDropIndicator() {
+	if l.dropIndicator == nil {
+		return
+	}
+	if l.dropIndex < 0 {
+		l.dropIndicator.Hide()
+		return
+	}
+
+	y := l.itemPositions().length() + theme.SeparatorThicknessSize()
+	if l.dropIndex < l.length() {
+		y, _ = l.itemPositions().position(l.dropIndex)
+	}
+	thickness := theme.SeparatorThicknessSize() * 2
+	top := gui.Max(0, gui.Min(y-l.offsetY-thickness, l.Size().Height-thickness))
+	l.dropIndicator.FillColor = theme.PrimaryColor()
+	l.dropIndicator.Move(gui.NewPos(0, top))
+	l.dropIndicator.Resize(gui.NewSize(l.Size().Width, thickness))
+	l.dropIndicator.Show()
+	l.dropIndicator.Refresh()
+}
+
+// pageLength returns the number of items that fit in the visible area at the current scroll position.
+func (l *List) pageLength() int {
+	if l.scroller == nil {
+		return 1
+	}
+	index := l.itemPositions()
+	items := index.indexAt(l.offsetY+l.scroller.Size().Height) - index.indexAt(l.offsetY)
+	if items < 1 {
+		return 1
+	}
+	return items
+}
+
+// selectOnly replaces the selection with the item identified by id.
+func (l *List) selectOnly(id ListItemID) {
+	l.anchor, l.cursor = id, id
+	if len(l.selected) == 1 && l.selected[0] == id {
+		l.scrollTo(id)
+		l.Refresh()
+		return
+	}
+	l.scrollTo(id)
+	l.setSelection([]ListItemID{id})
+}
+
+// selectRange replaces the selection with all items between the anchor and id.
+func (l *List) selectRange(id ListItemID) {
+	l.cursor = id
+	from, to := l.anchor, id
+	if from > to {
+		from, to = to, from
+	}
+	selected := make([]ListItemID, 0, to-from+1)
+	for i := from; i <= to; i++ {
+		selected = append(selected, i)
+	}
+	l.scrollTo(id)
+	l.setSelection(selected)
+}
+
+// setSelection stores the new selection, refreshes and then notifies the callbacks of any changes.
+func (l *List) setSelection(selected []ListItemID) {
+	old := l.selected
+	l.selected = selected
 	l.Refresh()
+
 	if f := l.OnUnselected; f != nil {
+		for _, id := range old {
+			if !l.isSelected(id) {
+				f(id)
+			}
+		}
+	}
+	if f := l.OnSelected; f != nil {
 		for _, id := range selected {
-			f(id)
+			if !containsListItem(old, id) {
+				f(id)
+			}
 		}
 	}
+	if f := l.OnSelectionChanged; f != nil {
+		f(l.Selected())
+	}
+}
+
+// movedIndex returns the new index of the item at index once the item at from has moved to to.
+func movedIndex(index, from, to int) int {
+	switch {
+	case index == from:
+		return to
+	case from < index && index <= to:
+		return index - 1
+	case to <= index && index < from:
+		return index + 1
+	}
+	return index
+}
+
+func containsListItem(items []ListItemID, id ListItemID) bool {
+	for _, i := range items {
+		if i == id {
+			return true
+		}
+	}
+	return false
 }
 
 // Declare conformity with WidgetRenderer interface.
@@ -268,14 +696,24 @@ func (l *listRenderer) Refresh() {
 var _ gui.Widget = (*listItem)(nil)
 var _ gui.Tappable = (*listItem)(nil)
 var _ desktop.Hoverable = (*listItem)(nil)
+var _ desktop.Mouseable = (*listItem)(nil)
+var _ gui.Draggable = (*listItem)(nil)
+var _ gui.DragSource = (*listItem)(nil)
+var _ gui.DragPreviewer = (*listItem)(nil)
+var _ gui.Accessible = (*listItem)(nil)
 
 type listItem struct {
 	BaseWidget
 
 	onTapped          func()
+	onMouseDown       func(*desktop.MouseEvent)
 	background        *canvas.Rectangle
 	child             gui.CanvasObject
 	hovered, selected bool
+
+	id      ListItemID
+	list    *List
+	tracker dnd.Tracker
 }
 
 func newListItem(child gui.CanvasObject, tapped func()) *listItem {
@@ -288,6 +726,13 @@ func newListItem(child gui.CanvasObject, tapped func()) *listItem {
 	return li
 }
 
+// AccessibilityInfo describes this item to assistive technology.
+//
+// Implements: gui.Accessible
+func (li *listItem) AccessibilityInfo() gui.AccessibilityInfo {
+	return gui.AccessibilityInfo{Role: gui.AccessibleRoleListItem, State: gui.AccessibleState{Selected: li.selected}}
+}
+
 // CreateRenderer is a private method to Bhojpur GUI which links this widget to its renderer.
 func (li *listItem) CreateRenderer() gui.WidgetRenderer {
 	li.ExtendBaseWidget(li)
@@ -300,6 +745,62 @@ func (li *listItem) CreateRenderer() gui.WidgetRenderer {
 	return &listItemRenderer{widget.NewBaseRenderer(objects), li}
 }
 
+// DragData returns the ID of this item as the payload when the list can be reordered.
+// Items that do not currently show an item of the list cannot be dragged.
+//
+// Implements: gui.DragSource
+func (li *listItem) DragData(gui.Position) *gui.DragData {
+	if li.list == nil || li.list.OnReordered == nil || li.id < 0 || li.id >= li.list.length() {
+		return nil
+	}
+	return &gui.DragData{Type: listItemDragType, Value: li.id}
+}
+
+// DragEnd is called when a drag of this item ends.
+func (li *listItem) DragEnd() {
+	if li.tracker.Active() || (li.list != nil && li.list.OnReordered != nil) {
+		li.tracker.DragEnd() // also resets a drag that could not start
+	} else if li.list != nil && li.list.scroller != nil {
+		li.list.scroller.DragEnd()
+	}
+}
+
+// DragFinished is called once a drag and drop of this item is complete.
+//
+// Implements: gui.DragSource
+func (li *listItem) DragFinished(*gui.DragData, bool) {
+}
+
+// DragPreview returns a highlighted copy of this item to show while it is dragged.
+//
+// Implements: gui.DragPreviewer
+func (li *listItem) DragPreview(*gui.DragData) gui.CanvasObject {
+	if li.list == nil || li.list.CreateItem == nil {
+		return nil
+	}
+
+	preview := newListItem(li.list.CreateItem(), nil)
+	if f := li.list.UpdateItem; f != nil {
+		f(li.id, preview.child)
+	}
+	preview.selected = true
+	preview.Refresh()
+	return preview
+}
+
+// Dragged is called when this item is dragged. It starts reordering if the list allows it,
+// otherwise the list is scrolled.
+func (li *listItem) Dragged(ev *gui.DragEvent) {
+	if li.list == nil {
+		return
+	}
+	if li.list.OnReordered != nil {
+		li.tracker.Dragged(li.super(), ev)
+	} else if li.list.scroller != nil {
+		li.list.scroller.Dragged(ev)
+	}
+}
+
 // MinSize returns the size that this widget should not shrink below.
 func (li *listItem) MinSize() gui.Size {
 	li.ExtendBaseWidget(li)
@@ -312,6 +813,13 @@ func (li *listItem) MouseIn(*desktop.MouseEvent) {
 	li.Refresh()
 }
 
+// MouseDown is called when a desktop pointer is pressed over the widget.
+func (li *listItem) MouseDown(ev *desktop.MouseEvent) {
+	if li.onMouseDown != nil {
+		li.onMouseDown(ev)
+	}
+}
+
 // MouseMoved is called when a desktop pointer hovers over the widget.
 func (li *listItem) MouseMoved(*desktop.MouseEvent) {
 }
@@ -322,6 +830,10 @@ func (li *listItem) MouseOut() {
 	li.Refresh()
 }
 
+// MouseUp is called when a desktop pointer is released over the widget.
+func (li *listItem) MouseUp(*desktop.MouseEvent) {
+}
+
 // Tapped is called when a pointer tapped event is captured and triggers any tap handler.
 func (li *listItem) Tapped(*gui.PointEvent) {
 	if li.onTapped != nil {
@@ -391,9 +903,7 @@ func (l *listLayout) Layout([]gui.CanvasObject, gui.Size) {
 
 func (l *listLayout) MinSize([]gui.CanvasObject) gui.Size {
 	if f := l.list.Length; f != nil {
-		separatorThickness := theme.SeparatorThicknessSize()
-		return gui.NewSize(l.list.itemMin.Width,
-			(l.list.itemMin.Height+separatorThickness)*float32(f())-separatorThickness)
+		return gui.NewSize(l.list.itemMin.Width, l.list.itemPositions().length())
 	}
 	return gui.NewSize(0, 0)
 }
@@ -431,8 +941,11 @@ func (l *listLayout) setupListItem(li *listItem, id ListItemID) {
 		f(id, li.child)
 	}
 	li.onTapped = func() {
-		l.list.Select(id)
+		l.list.itemTapped(id)
 	}
+	li.onMouseDown = l.list.keys.mouseDown
+	li.id = id
+	li.list = l.list
 }
 
 func (l *listLayout) updateList(refresh bool) {
@@ -444,10 +957,14 @@ func (l *listLayout) updateList(refresh bool) {
 	if f := l.list.Length; f != nil {
 		length = f()
 	}
-	visibleItemCount := int(math.Ceil(float64(l.list.scroller.Size().Height)/float64(l.list.itemMin.Height+theme.SeparatorThicknessSize()))) + 1
-	offY := l.list.offsetY - float32(math.Mod(float64(l.list.offsetY), float64(l.list.itemMin.Height+separatorThickness)))
-	minRow := ListItemID(offY / (l.list.itemMin.Height + separatorThickness))
-	maxRow := ListItemID(gui.Min(float32(minRow+visibleItemCount), float32(length)))
+	visibleItemCount := int(math.Ceil(float64(l.list.scroller.Size().Height)/float64(l.list.itemMin.Height+separatorThickness))) + 1
+	itemIndex := l.list.itemPositions()
+	minRow := itemIndex.indexAt(l.list.offsetY)
+	maxRow := itemIndex.indexAt(l.list.offsetY+l.list.scroller.Size().Height) + 1
+	if rows := minRow + visibleItemCount; rows > maxRow {
+		maxRow = rows
+	}
+	maxRow = ListItemID(gui.Min(float32(maxRow), float32(length)))
 
 	if l.list.UpdateItem == nil {
 		gui.LogError("Missing UpdateCell callback required for List", nil)
@@ -456,9 +973,9 @@ func (l *listLayout) updateList(refresh bool) {
 	wasVisible := l.visible
 	l.visible = make(map[ListItemID]*listItem)
 	var cells []gui.CanvasObject
-	y := offY
-	size := gui.NewSize(width, l.list.itemMin.Height)
 	for row := minRow; row < maxRow; row++ {
+		y, height := itemIndex.position(row)
+		size := gui.NewSize(width, height)
 		c, ok := wasVisible[row]
 		if !ok {
 			c = l.getItem()
@@ -477,7 +994,6 @@ func (l *listLayout) updateList(refresh bool) {
 			}
 		}
 
-		y += l.list.itemMin.Height + separatorThickness
 		l.visible[row] = c
 		cells = append(cells, c)
 	}
@@ -104,6 +104,59 @@ func TestList_OffsetChange(t *testing.T) {
 	test.AssertRendersToMarkup(t, "list/offset_changed.xml", w.Canvas())
 }
 
+func TestList_ItemHeight(t *testing.T) {
+	list := createList(1000)
+	list.ItemHeight = func(id ListItemID) float32 {
+		if id%10 == 0 {
+			return 100
+		}
+		return 0
+	}
+	list.Refresh()
+
+	separatorThickness := theme.SeparatorThicknessSize()
+	itemHeight := list.itemMin.Height
+	assert.Equal(t, 100*(100+separatorThickness)+900*(itemHeight+separatorThickness)-separatorThickness, list.scroller.Content.MinSize().Height)
+
+	layout := list.scroller.Content.(*gui.Container).Layout.(*listLayout)
+	assert.Equal(t, float32(100), layout.visible[0].Size().Height)
+	assert.Equal(t, itemHeight, layout.visible[1].Size().Height)
+	assert.Equal(t, float32(100)+separatorThickness, layout.visible[1].Position().Y)
+	assert.Equal(t, 100+9*itemHeight+10*separatorThickness, layout.visible[10].Position().Y)
+
+	list.ScrollTo(500)
+	y, height := list.itemPositions().position(500)
+	assert.Equal(t, y+height-list.scroller.Size().Height, list.offsetY)
+	assert.Equal(t, y, layout.visible[500].Position().Y)
+	assert.Equal(t, float32(100), layout.visible[500].Size().Height)
+	assert.NotContains(t, layout.visible, 0)
+
+	list.ScrollToBottom()
+	y, height = list.itemPositions().position(999)
+	assert.Equal(t, y+height-list.scroller.Size().Height, list.offsetY)
+	assert.Contains(t, layout.visible, 999)
+
+	// a page holds the items that are fully visible, whatever their height
+	list.ScrollToTop()
+	page := list.pageLength()
+	y, height = list.itemPositions().position(page - 1)
+	assert.LessOrEqual(t, y+height, list.scroller.Size().Height)
+	y, height = list.itemPositions().position(page)
+	assert.Greater(t, y+height, list.scroller.Size().Height)
+
+	list.ItemHeight = nil
+	list.Refresh()
+	assert.Equal(t, 1000*(itemHeight+separatorThickness)-separatorThickness, list.scroller.Content.MinSize().Height)
+
+	index := list.itemPositions()
+	list.SetItemHeight(2, 50)
+	assert.Same(t, index, list.itemPositions())
+	assert.Equal(t, 50+999*itemHeight+999*separatorThickness, list.scroller.Content.MinSize().Height)
+	y, height = list.itemPositions().position(3)
+	assert.Equal(t, 50+2*itemHeight+3*separatorThickness, y)
+	assert.Equal(t, itemHeight, height)
+}
+
 func TestList_Hover(t *testing.T) {
 	list := createList(1000)
 	children := list.scroller.Content.(*gui.Container).Layout.(*listLayout).children
@@ -226,6 +279,198 @@ func TestList_Select(t *testing.T) {
 	assert.True(t, visible[6].background.Visible())
 }
 
+func TestList_SelectMultiple(t *testing.T) {
+	list := createList(1000)
+	list.SelectionMode = SelectionMultiple
+	var changed []ListItemID
+	list.OnSelectionChanged = func(selected []ListItemID) {
+		changed = selected
+	}
+	children := list.scroller.Content.(*gui.Container).Layout.(*listLayout).children
+	tapWith := func(id ListItemID, mod gui.KeyModifier) {
+		item := children[id].(*listItem)
+		item.MouseDown(&desktop.MouseEvent{Modifier: mod})
+		item.Tapped(&gui.PointEvent{})
+	}
+
+	tapWith(2, 0)
+	assert.Equal(t, []ListItemID{2}, changed)
+	tapWith(4, gui.KeyModifierShortcutDefault)
+	assert.Equal(t, []ListItemID{2, 4}, changed)
+	assert.True(t, children[2].(*listItem).background.Visible())
+	assert.True(t, children[4].(*listItem).background.Visible())
+
+	tapWith(2, gui.KeyModifierShortcutDefault)
+	assert.Equal(t, []ListItemID{4}, changed)
+	assert.False(t, children[2].(*listItem).background.Visible())
+
+	tapWith(7, gui.KeyModifierShift)
+	assert.Equal(t, []ListItemID{2, 3, 4, 5, 6, 7}, changed)
+	tapWith(1, gui.KeyModifierShift)
+	assert.Equal(t, []ListItemID{1, 2}, list.Selected())
+
+	tapWith(5, 0)
+	assert.Equal(t, []ListItemID{5}, changed)
+
+	list.Select(8)
+	list.Select(6)
+	assert.Equal(t, []ListItemID{5, 8, 6}, list.Selected(), "items are in the order they were selected")
+	list.Unselect(5)
+	assert.Equal(t, []ListItemID{8, 6}, changed)
+}
+
+func TestList_SelectionKeys(t *testing.T) {
+	list := createList(1000)
+	list.SelectionMode = SelectionMultiple
+	var selected, unselected []ListItemID
+	list.OnSelected = func(id ListItemID) {
+		selected = append(selected, id)
+	}
+	list.OnUnselected = func(id ListItemID) {
+		unselected = append(unselected, id)
+	}
+
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []ListItemID{0}, list.Selected())
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []ListItemID{1}, list.Selected())
+	assert.Equal(t, []ListItemID{0, 1}, selected)
+	assert.Equal(t, []ListItemID{0}, unselected)
+
+	list.KeyDown(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []ListItemID{1, 2, 3}, list.Selected())
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeyHome})
+	assert.Equal(t, []ListItemID{0, 1}, list.Selected())
+	list.KeyUp(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeyEnd})
+	assert.Equal(t, []ListItemID{999}, list.Selected())
+	assert.Equal(t, 1000*list.itemMin.Height+999*theme.SeparatorThicknessSize()-list.scroller.Size().Height, list.offsetY)
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeyPageUp})
+	assert.Equal(t, []ListItemID{999 - list.pageLength()}, list.Selected())
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeySpace})
+	assert.Nil(t, list.Selected())
+
+	list.SelectionMode = SelectionSingle
+	list.Select(10)
+	list.KeyDown(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+	list.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	assert.Equal(t, []ListItemID{9}, list.Selected())
+}
+
+func TestList_Reorder(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	data := []string{"a", "b", "c", "d", "e"}
+	list := NewList(
+		func() int {
+			return len(data)
+		},
+		func() gui.CanvasObject {
+			return NewLabel("Template")
+		},
+		func(id ListItemID, item gui.CanvasObject) {
+			item.(*Label).SetText(data[id])
+		},
+	)
+	w := test.NewWindow(list)
+	defer w.Close()
+	w.SetPadded(false)
+	w.Resize(gui.NewSize(100, 300))
+	step := list.itemMin.Height + theme.SeparatorThicknessSize()
+	itemAt := func(id ListItemID, fraction float32) gui.Position {
+		return gui.NewPos(10, float32(id)*step+list.itemMin.Height*fraction)
+	}
+
+	test.DragAndDrop(w.Canvas(), itemAt(0, .5), itemAt(2, .75))
+	assert.Equal(t, []string{"a", "b", "c", "d", "e"}, data, "items can only be reordered with OnReordered set")
+
+	list.OnReordered = func(from, to ListItemID) {
+		item := data[from]
+		data = append(data[:from], data[from+1:]...)
+		data = append(data[:to], append([]string{item}, data[to:]...)...)
+	}
+	list.Select(0)
+	test.DragAndDrop(w.Canvas(), itemAt(0, .5), itemAt(2, .75))
+	assert.Equal(t, []string{"b", "c", "a", "d", "e"}, data)
+	assert.Equal(t, []ListItemID{2}, list.Selected())
+	assert.Equal(t, "a", test.WidgetRenderer(list).(*listRenderer).layout.Layout.(*listLayout).visible[2].child.(*Label).Text)
+	assert.False(t, list.dropIndicator.Visible())
+	assert.Empty(t, w.Canvas().Overlays().List())
+
+	test.DragAndDrop(w.Canvas(), itemAt(4, .5), itemAt(0, .25))
+	assert.Equal(t, []string{"e", "b", "c", "a", "d"}, data)
+	assert.Equal(t, []ListItemID{3}, list.Selected())
+
+	test.DragAndDrop(w.Canvas(), itemAt(1, .5), itemAt(1, .75))
+	assert.Equal(t, []string{"e", "b", "c", "a", "d"}, data)
+
+	other := NewList(func() int { return 1 }, func() gui.CanvasObject { return NewLabel("") }, func(ListItemID, gui.CanvasObject) {})
+	other.OnReordered = list.OnReordered
+	otherItem := newListItem(NewLabel(""), nil)
+	otherItem.list = other
+	assert.False(t, list.DragOver(&gui.DragData{Type: listItemDragType, Value: 0, Source: otherItem}, itemAt(1, .5)))
+	item := test.WidgetRenderer(list).(*listRenderer).layout.Layout.(*listLayout).visible[1]
+	assert.True(t, list.DragOver(&gui.DragData{Type: listItemDragType, Value: 1, Source: item}, itemAt(3, .75)))
+	assert.True(t, list.dropIndicator.Visible())
+	assert.Equal(t, float32(4)*step-2*theme.SeparatorThicknessSize(), list.dropIndicator.Position().Y)
+	list.DragLeave()
+	assert.False(t, list.dropIndicator.Visible())
+}
+
+func TestList_ReorderItemHeight(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+	list := NewList(
+		func() int { return len(data) },
+		func() gui.CanvasObject { return NewLabel("Template") },
+		func(id ListItemID, item gui.CanvasObject) { item.(*Label).SetText(data[id]) },
+	)
+	list.OnReordered = func(from, to ListItemID) {
+		item := data[from]
+		data = append(data[:from], data[from+1:]...)
+		data = append(data[:to], append([]string{item}, data[to:]...)...)
+	}
+	list.Resize(gui.NewSize(100, 400))
+	list.SetItemHeight(0, 80)
+	itemHeight := list.itemMin.Height
+
+	y, height := list.itemPositions().position(2)
+	list.Drop(&gui.DragData{Type: listItemDragType, Value: 0}, gui.NewPos(10, y+height*.75))
+	assert.Equal(t, []string{"b", "c", "a", "d"}, data)
+
+	for id, expected := range []float32{itemHeight, itemHeight, 80, itemHeight} {
+		_, height := list.itemPositions().position(id)
+		assert.Equal(t, expected, height, "item %d", id)
+	}
+}
+
+func TestList_DragAfterFailedStart(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	length := 0
+	list := NewList(func() int { return length }, func() gui.CanvasObject { return NewLabel("") }, func(ListItemID, gui.CanvasObject) {})
+	list.OnReordered = func(ListItemID, ListItemID) {}
+	item := newListItem(NewLabel("a"), nil)
+	item.list = list
+	w := test.NewWindow(item)
+	defer w.Close()
+	drag := &gui.DragEvent{PointEvent: gui.PointEvent{AbsolutePosition: gui.NewPos(5, 5)}, Dragged: gui.NewDelta(2, 2)}
+
+	item.Dragged(drag) // the item is not part of the list, so the drag cannot start
+	assert.False(t, item.tracker.Active())
+	item.DragEnd()
+
+	length = 1
+	item.Dragged(drag)
+	assert.True(t, item.tracker.Active())
+	item.DragEnd()
+	assert.False(t, item.tracker.Active())
+}
+
 func TestList_Unselect(t *testing.T) {
 	list := createList(1000)
 	var unselected ListItemID
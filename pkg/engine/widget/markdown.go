@@ -23,16 +23,27 @@ package widget
 import (
 	"io"
 	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
 
 	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/storage"
+	"github.com/bhojpur/gui/pkg/engine/syntax"
+	"github.com/bhojpur/gui/pkg/engine/theme"
 )
 
 // NewRichTextFromMarkdown configures a RichText widget by parsing the provided markdown content.
+// GitHub flavoured markdown tables, task lists, strikethrough and images are supported.
 //
 // Since: 2.1
 func NewRichTextFromMarkdown(content string) *RichText {
@@ -42,16 +53,39 @@ func NewRichTextFromMarkdown(content string) *RichText {
 // ParseMarkdown allows setting the content of this RichText widget from a markdown string.
 // It will replace the content of this widget similarly to SetText, but with the appropriate formatting.
 func (t *RichText) ParseMarkdown(content string) {
-	t.Segments = parseMarkdown(content)
+	t.Segments = (&markdownRenderer{highlight: t.HighlightCode}).parse(content)
 	t.Refresh()
 }
 
+// ToMarkdown returns the content of this RichText widget formatted as markdown.
+// Content that was loaded using ParseMarkdown will produce the same segments when parsed again.
+//
+// Since: 2.3
+func (t *RichText) ToMarkdown() string {
+	return SegmentsToMarkdown(t.Segments)
+}
+
+// SegmentsToMarkdown returns a markdown document that represents the rich text segments passed in.
+// Styles that markdown cannot express, such as colours or custom sizes, are not included in the output.
+//
+// Since: 2.3
+func SegmentsToMarkdown(segments []RichTextSegment) string {
+	w := &markdownWriter{}
+	w.writeSegments(segments)
+	w.endParagraph()
+	return strings.Join(w.blocks, "\n\n")
+}
+
 type markdownRenderer struct {
 	blockquote  bool
 	heading     bool
+	highlight   bool
 	nextSeg     RichTextSegment
 	parentStack [][]RichTextSegment
 	segs        []RichTextSegment
+
+	table *TableSegment
+	row   []RichTextSegment
 }
 
 func (m *markdownRenderer) AddOptions(...renderer.Option) {}
@@ -122,27 +156,56 @@ func (m *markdownRenderer) Render(_ io.Writer, source []byte, n ast.Node) error
 			if data[len(data)-1] == '\n' {
 				data = data[:len(data)-1]
 			}
+			if fenced, ok := n.(*ast.FencedCodeBlock); ok && m.highlight {
+				if lexer := syntax.ForLanguage(string(fenced.Language(source))); lexer != nil {
+					m.segs = append(m.segs, highlightMarkdownCode(string(data), lexer)...)
+					return ast.WalkContinue, nil
+				}
+			}
 			m.segs = append(m.segs, &TextSegment{
 				Style: RichTextStyleCodeBlock,
 				Text:  string(data),
 			})
 		case "Emph", "Emphasis":
+			seg := &TextSegment{}
 			switch n.(*ast.Emphasis).Level {
 			case 2:
-				m.nextSeg = &TextSegment{
-					Style: RichTextStyleStrong,
-				}
+				seg.Style = RichTextStyleStrong
 			default:
-				m.nextSeg = &TextSegment{
-					Style: RichTextStyleEmphasis,
-				}
+				seg.Style = RichTextStyleEmphasis
 			}
+			inheritMarkdownStyle(n, &seg.Style) // "***text***" is emphasis nested in strong
+			m.nextSeg = seg
 		case "Strong":
 			m.nextSeg = &TextSegment{
 				Style: RichTextStyleStrong,
 			}
+		case "Strikethrough":
+			seg := &TextSegment{Style: RichTextStyleInline}
+			seg.Style.Strikethrough = true
+			inheritMarkdownStyle(n, &seg.Style)
+			m.nextSeg = seg
+		case "TaskCheckBox":
+			m.segs = append(m.segs, &CheckBoxSegment{Checked: n.(*east.TaskCheckBox).IsChecked})
+		case "Image":
+			u := markdownImageURI(string(n.(*ast.Image).Destination))
+			m.segs = append(m.segs, &ImageSegment{Source: u, Title: string(n.Text(source))})
+			return ast.WalkSkipChildren, nil // the text is the title of the image
+		case "Table":
+			m.table = &TableSegment{}
+		case "TableHeader", "TableRow":
+			m.row = nil
+		case "TableCell":
+			m.parentStack = append(m.parentStack, m.segs)
+			m.segs = nil
+			m.nextSeg = &TextSegment{
+				Style: RichTextStyleInline,
+			}
 		case "Text":
 			trimmed := string(n.Text(source))
+			if !n.(*ast.Text).IsRaw() {
+				trimmed = string(util.UnescapePunctuations([]byte(trimmed)))
+			}
 			trimmed = strings.ReplaceAll(trimmed, "\n", " ") // newline inside paragraph is not newline
 			if trimmed == "" {
 				return ast.WalkContinue, nil
@@ -176,8 +239,48 @@ func (m *markdownRenderer) Render(_ io.Writer, source []byte, n ast.Node) error
 }
 
 func (m *markdownRenderer) handleExitNode(n ast.Node) error {
+	switch n.Kind().String() {
+	case "Table":
+		m.segs = append(m.segs, m.table)
+		m.table = nil
+		return nil
+	case "TableHeader":
+		m.table.Header = m.row
+		return nil
+	case "TableRow":
+		m.table.Rows = append(m.table.Rows, m.row)
+		return nil
+	case "TableCell":
+		cellSegs := m.segs
+		m.segs = m.parentStack[len(m.parentStack)-1]
+		m.parentStack = m.parentStack[:len(m.parentStack)-1]
+		align := gui.TextAlignLeading
+		switch n.(*east.TableCell).Alignment {
+		case east.AlignCenter:
+			align = gui.TextAlignCenter
+		case east.AlignRight:
+			align = gui.TextAlignTrailing
+		}
+		for _, seg := range cellSegs {
+			switch s := seg.(type) {
+			case *TextSegment:
+				s.Style.Alignment = align
+			case *HyperlinkSegment:
+				s.Alignment = align
+			}
+		}
+		m.row = append(m.row, &ParagraphSegment{Texts: cellSegs})
+		m.nextSeg = &TextSegment{
+			Style: RichTextStyleInline,
+		}
+		return nil
+	}
+
 	if n.Kind().String() == "Blockquote" {
 		m.blockquote = false
+		m.nextSeg = &TextSegment{
+			Style: RichTextStyleInline,
+		}
 	} else if n.Kind().String() == "List" {
 		listSegs := m.segs
 		m.segs = m.parentStack[len(m.parentStack)-1]
@@ -202,16 +305,497 @@ func (m *markdownRenderer) handleExitNode(n ast.Node) error {
 	return nil
 }
 
-func parseMarkdown(content string) []RichTextSegment {
-	r := &markdownRenderer{}
+func (m *markdownRenderer) parse(content string) []RichTextSegment {
 	if content == "" {
-		return r.segs
+		return m.segs
 	}
 
-	md := goldmark.New(goldmark.WithRenderer(r))
+	md := goldmark.New(goldmark.WithRenderer(m),
+		goldmark.WithExtensions(extension.Table, extension.Strikethrough, extension.TaskList))
 	err := md.Convert([]byte(content), nil)
 	if err != nil {
 		gui.LogError("Failed to parse markdown", err)
 	}
-	return r.segs
+	return m.segs
+}
+
+// inheritMarkdownStyle applies the emphasis and strikethrough of any inline nodes that contain n.
+func inheritMarkdownStyle(n ast.Node, style *RichTextStyle) {
+	for parent := n.Parent(); parent != nil; parent = parent.Parent() {
+		switch p := parent.(type) {
+		case *ast.Emphasis:
+			if p.Level == 2 {
+				style.TextStyle.Bold = true
+			} else {
+				style.TextStyle.Italic = true
+			}
+		case *east.Strikethrough:
+			style.Strikethrough = true
+		default:
+			return
+		}
+	}
+}
+
+func parseMarkdown(content string) []RichTextSegment {
+	return (&markdownRenderer{}).parse(content)
+}
+
+// highlightMarkdownCode returns the segments that display a block of code coloured using a lexer.
+// Each token is an inline segment except the last, which ends the block.
+func highlightMarkdownCode(code string, lexer syntax.Lexer) []RichTextSegment {
+	var segs []RichTextSegment
+	for i, line := range syntax.Tokenize(lexer, code) {
+		if len(line) == 0 {
+			line = []syntax.Token{{Type: syntax.TokenText}}
+		}
+		for j, token := range line {
+			style := RichTextStyleCodeInline
+			style.ColorName = markdownTokenColorName(token.Type)
+			text := token.Text
+			if i > 0 && j == 0 {
+				text = "\n" + text
+			}
+			segs = append(segs, &TextSegment{Style: style, Text: text})
+		}
+	}
+	segs[len(segs)-1].(*TextSegment).Style.Inline = false
+	return segs
+}
+
+func markdownTokenColorName(t syntax.TokenType) gui.ThemeColorName {
+	switch t {
+	case syntax.TokenKeyword, syntax.TokenBuiltin, syntax.TokenHeading, syntax.TokenKey, syntax.TokenLink:
+		return theme.ColorNamePrimary
+	case syntax.TokenString, syntax.TokenCode, syntax.TokenNumber, syntax.TokenLiteral:
+		return theme.ColorNameError
+	case syntax.TokenComment:
+		return theme.ColorNameDisabled
+	}
+	return theme.ColorNameForeground
+}
+
+// markdownWriter builds a markdown document from rich text segments.
+// Inline content is collected until a block ends, each completed block is stored separately.
+type markdownWriter struct {
+	blocks []string
+	inline strings.Builder
+
+	lastList byte // the marker used by a list that was the previous block, or 0
+	prev     RichTextSegment
+
+	// a run of inline code segments that may be continued by a code block, as made by highlighting
+	codeRun   strings.Builder
+	codeStart int
+	inCode    bool
+}
+
+func (w *markdownWriter) addBlock(block string) {
+	w.blocks = append(w.blocks, block)
+	w.lastList = 0
+}
+
+func (w *markdownWriter) endParagraph() {
+	if w.inline.Len() == 0 {
+		return
+	}
+
+	text := strings.TrimRightFunc(w.inline.String(), unicode.IsSpace)
+	w.inline.Reset()
+	if text == "" {
+		return
+	}
+	w.addBlock(escapeMarkdownLineStart(text))
+}
+
+func (w *markdownWriter) writeBlockText(seg *TextSegment) {
+	style := seg.Style
+	switch {
+	case style.TextStyle.Monospace:
+		code := seg.Text
+		if w.inCode {
+			code = w.codeRun.String() + code
+			text := w.inline.String()[:w.codeStart]
+			w.inline.Reset()
+			w.inline.WriteString(text)
+			w.endCodeRun()
+		}
+		w.endParagraph()
+		w.addBlock(markdownCodeBlock(code))
+	case style == RichTextStyleBlockquote:
+		w.endParagraph()
+		lines := strings.Split(seg.Text, "\n")
+		for i, line := range lines {
+			lines[i] = "> " + escapeMarkdownLineStart(escapeMarkdown(line))
+		}
+		w.addBlock(strings.Join(lines, "\n"))
+	case style.SizeName == theme.SizeNameHeadingText:
+		w.writeHeading("# ", seg.Text)
+	case style.SizeName == theme.SizeNameSubHeadingText:
+		w.writeHeading("## ", seg.Text)
+	case style.TextStyle.Bold && !style.TextStyle.Italic && w.inline.Len() == 0:
+		w.writeHeading("### ", seg.Text)
+	default:
+		w.inline.WriteString(markdownInline(seg.Text, style))
+		w.endParagraph()
+	}
+}
+
+func (w *markdownWriter) endCodeRun() {
+	w.inCode = false
+	w.codeRun.Reset()
+}
+
+func (w *markdownWriter) writeHeading(prefix, text string) {
+	w.endParagraph()
+	text = strings.TrimSpace(strings.ReplaceAll(text, "\n", " "))
+	if text == "" {
+		return
+	}
+	w.addBlock(prefix + escapeMarkdown(text))
+}
+
+func (w *markdownWriter) writeList(list *ListSegment, indent string) string {
+	marker := byte('-')
+	if list.Ordered {
+		marker = '.'
+	}
+	if indent == "" && w.lastList == marker { // adjacent lists with the same marker would be joined
+		if list.Ordered {
+			marker = ')'
+		} else {
+			marker = '*'
+		}
+	}
+
+	lines := make([]string, 0, len(list.Items))
+	for i, item := range list.Items {
+		prefix := string(marker) + " "
+		if list.Ordered {
+			prefix = strconv.Itoa(i+1) + string(marker) + " "
+		}
+
+		texts := []RichTextSegment{item}
+		if para, ok := item.(*ParagraphSegment); ok {
+			texts = para.Texts
+		}
+		// the first block follows the marker, later blocks are indented to continue the item
+		continued := indent + strings.Repeat(" ", len(prefix))
+		line := indent + prefix
+		started := false
+		content := &markdownWriter{}
+		addBlocks := func() {
+			content.endParagraph()
+			for _, block := range content.blocks {
+				if started {
+					line += "\n\n" + indentMarkdown(block, continued)
+				} else {
+					line += block
+				}
+				started = true
+			}
+			content.blocks = nil
+		}
+		for _, seg := range texts {
+			if sub, ok := seg.(*ListSegment); ok {
+				addBlocks()
+				line += "\n" + content.writeList(sub, continued)
+				started = true
+				continue
+			}
+			content.writeSegments([]RichTextSegment{seg})
+		}
+		addBlocks()
+
+		lines = append(lines, line)
+	}
+
+	if indent == "" {
+		w.lastList = marker
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (w *markdownWriter) writeSegments(segments []RichTextSegment) {
+	for _, seg := range segments {
+		if seg == w.prev { // the same segment can be listed twice where text continues across lines
+			continue
+		}
+		w.prev = seg
+
+		text, isText := seg.(*TextSegment)
+		if !isText || !text.Style.TextStyle.Monospace {
+			w.endCodeRun()
+		} else if text.Style.Inline && !w.inCode {
+			w.inCode = true
+			w.codeStart = w.inline.Len()
+		}
+
+		switch s := seg.(type) {
+		case *TextSegment:
+			if s.Style.Inline {
+				if w.inCode {
+					w.codeRun.WriteString(s.Text)
+				}
+				w.inline.WriteString(markdownInline(s.Text, s.Style))
+			} else {
+				w.writeBlockText(s)
+			}
+		case *CheckBoxSegment:
+			w.inline.WriteString(s.Textual())
+		case *ImageSegment:
+			w.endParagraph()
+			w.addBlock(markdownImage(s))
+		case *TableSegment:
+			w.endParagraph()
+			if table := markdownTable(s); table != "" {
+				w.addBlock(table)
+			}
+		case *HyperlinkSegment:
+			w.inline.WriteString(markdownLink(s))
+		case *ListSegment:
+			w.endParagraph()
+			list := w.writeList(s, "")
+			marker := w.lastList
+			w.addBlock(list)
+			w.lastList = marker
+		case *ParagraphSegment:
+			w.endParagraph()
+			w.writeSegments(s.Texts)
+			w.endParagraph()
+		case *SeparatorSegment:
+			w.endParagraph()
+			w.addBlock("---")
+		default:
+			if seg.Inline() {
+				w.inline.WriteString(escapeMarkdown(seg.Textual()))
+				continue
+			}
+			w.endParagraph()
+			if text := seg.Textual(); text != "" {
+				w.inline.WriteString(escapeMarkdown(text))
+				w.endParagraph()
+			}
+		}
+	}
+}
+
+func escapeMarkdown(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch r {
+		case '\\', '*', '_', '`', '[', ']', '<', '~':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// escapeMarkdownLineStart escapes characters that would start a different block type if found at the start of a line.
+func escapeMarkdownLineStart(line string) string {
+	if line == "" {
+		return line
+	}
+	switch line[0] {
+	case '#', '>', '-', '+', '=', '|':
+		return "\\" + line
+	}
+	if strings.HasPrefix(line, "~~~") { // a code fence, "~~" alone is strikethrough
+		return "\\" + line
+	}
+
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < len(line) && (line[digits] == '.' || line[digits] == ')') {
+		return line[:digits] + "\\" + line[digits:]
+	}
+	return line
+}
+
+// indentMarkdown adds the indent to the start of each line of block that is not blank.
+func indentMarkdown(block, indent string) string {
+	lines := strings.Split(block, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func markdownCodeBlock(code string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + "\n" + code + "\n" + fence
+}
+
+func markdownInline(text string, richStyle RichTextStyle) string {
+	if text == "" {
+		return ""
+	}
+	style := richStyle.TextStyle
+	if style.Monospace {
+		fence := "`"
+		for strings.Contains(text, fence) {
+			fence += "`"
+		}
+		if text[0] == '`' || text[len(text)-1] == '`' || (text[0] == ' ' && text[len(text)-1] == ' ') {
+			text = " " + text + " "
+		}
+		return fence + text + fence
+	}
+
+	content := strings.TrimSpace(text)
+	if content == "" || (!style.Bold && !style.Italic && !richStyle.Strikethrough) {
+		return escapeMarkdown(text)
+	}
+	mark := ""
+	if style.Italic {
+		mark = "*"
+	}
+	if style.Bold {
+		mark = "**"
+		if style.Italic {
+			mark = "***"
+		}
+	}
+
+	content = mark + escapeMarkdown(content) + mark
+	if richStyle.Strikethrough {
+		content = "~~" + content + "~~"
+	}
+
+	start := strings.Index(text, strings.TrimSpace(text)) // emphasis markers must touch the text they wrap
+	return text[:start] + content + text[start+len(strings.TrimSpace(text)):]
+}
+
+func markdownDestination(dest string) string {
+	if strings.ContainsAny(dest, " ()<>") {
+		dest = "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(dest) + ">"
+	}
+	return dest
+}
+
+func markdownImage(img *ImageSegment) string {
+	if img.Source == nil {
+		return escapeMarkdownLineStart(escapeMarkdown(img.Title))
+	}
+	dest := img.Source.String()
+	if img.Source.Scheme() == "file" && !path.IsAbs(img.Source.Path()) && !filepath.IsAbs(img.Source.Path()) {
+		dest = img.Source.Path() // a relative file path, as parsed by markdownImageURI
+	}
+	return "![" + escapeMarkdown(img.Title) + "](" + markdownDestination(dest) + ")"
+}
+
+// markdownImageURI returns the URI of an image destination.
+// A destination without a scheme, or with a single letter drive name as its scheme, is a file path.
+func markdownImageURI(dest string) gui.URI {
+	if parsed, err := url.Parse(dest); err == nil && len(parsed.Scheme) > 1 {
+		if u, err := storage.ParseURI(dest); err == nil {
+			return u
+		}
+	} else if err == nil && parsed.Scheme == "" && parsed.Path != "" {
+		dest = parsed.Path // remove any percent encoding
+	}
+	return storage.NewFileURI(dest)
+}
+
+// markdownTable returns a GitHub flavoured markdown table, the alignment of each column is taken from its first cell.
+func markdownTable(table *TableSegment) string {
+	cols := len(table.Header)
+	for _, row := range table.Rows {
+		if len(row) > cols {
+			cols = len(row)
+		}
+	}
+	if cols == 0 {
+		return ""
+	}
+
+	align := make([]string, cols)
+	for i := range align {
+		align[i] = "---"
+	}
+	first := table.Header
+	if len(first) == 0 && len(table.Rows) > 0 {
+		first = table.Rows[0]
+	}
+	for i, cell := range first {
+		switch markdownCellAlignment(cell) {
+		case gui.TextAlignCenter:
+			align[i] = ":---:"
+		case gui.TextAlignTrailing:
+			align[i] = "---:"
+		}
+	}
+
+	lines := []string{markdownTableRow(table.Header, cols), "| " + strings.Join(align, " | ") + " |"}
+	for _, row := range table.Rows {
+		lines = append(lines, markdownTableRow(row, cols))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func markdownCellAlignment(cell RichTextSegment) gui.TextAlign {
+	if para, ok := cell.(*ParagraphSegment); ok && len(para.Texts) > 0 {
+		cell = para.Texts[0]
+	}
+	switch s := cell.(type) {
+	case *TextSegment:
+		return s.Style.Alignment
+	case *HyperlinkSegment:
+		return s.Alignment
+	}
+	return gui.TextAlignLeading
+}
+
+func markdownTableRow(cells []RichTextSegment, cols int) string {
+	var b strings.Builder
+	b.WriteString("|")
+	for i := 0; i < cols; i++ {
+		text := ""
+		if i < len(cells) {
+			content := &markdownWriter{}
+			content.writeSegments(markdownCellSegments(cells[i]))
+			content.endParagraph()
+			text = strings.Join(content.blocks, " ")
+			text = strings.ReplaceAll(strings.ReplaceAll(text, "\n", " "), "|", "\\|")
+		}
+		b.WriteString(" " + text + " |")
+	}
+	return b.String()
+}
+
+// markdownCellSegments returns the content of a table cell as inline segments, a cell cannot contain blocks.
+func markdownCellSegments(cell RichTextSegment) []RichTextSegment {
+	switch s := cell.(type) {
+	case *ParagraphSegment:
+		var segs []RichTextSegment
+		for _, seg := range s.Texts {
+			segs = append(segs, markdownCellSegments(seg)...)
+		}
+		return segs
+	case *TextSegment:
+		if !s.Style.Inline {
+			inline := *s
+			inline.Style.Inline = true
+			inline.Text = strings.ReplaceAll(s.Text, "\n", " ")
+			return []RichTextSegment{&inline, &TextSegment{Style: RichTextStyleInline, Text: " "}}
+		}
+	}
+	return []RichTextSegment{cell}
+}
+
+func markdownLink(link *HyperlinkSegment) string {
+	text := escapeMarkdown(link.Text)
+	if link.URL == nil {
+		return text
+	}
+
+	return "[" + text + "](" + markdownDestination(link.URL.String()) + ")"
 }
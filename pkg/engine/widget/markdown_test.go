@@ -21,9 +21,14 @@ package widget
 // THE SOFTWARE.
 
 import (
+	"net/url"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/storage"
+	"github.com/bhojpur/gui/pkg/engine/theme"
 )
 
 func TestRichTextMarkdown_Blockquote(t *testing.T) {
@@ -59,6 +64,37 @@ func TestRichTextMarkdown_Code(t *testing.T) {
 	}
 }
 
+func TestRichTextMarkdown_Code_Highlight(t *testing.T) {
+	r := NewRichText()
+	r.HighlightCode = true
+	r.ParseMarkdown("``` go\nfunc a() {\n\treturn // done\n}\n```")
+
+	assert.Greater(t, len(r.Segments), 1)
+	code := ""
+	for i, seg := range r.Segments {
+		text := seg.(*TextSegment)
+		assert.True(t, text.Style.TextStyle.Monospace)
+		assert.Equal(t, i < len(r.Segments)-1, text.Style.Inline)
+		code += text.Text
+	}
+	assert.Equal(t, "func a() {\n\treturn // done\n}", code)
+
+	first := r.Segments[0].(*TextSegment)
+	assert.Equal(t, "func", first.Text)
+	assert.Equal(t, theme.ColorNamePrimary, first.Style.ColorName)
+	found := false
+	for _, seg := range r.Segments {
+		if text := seg.(*TextSegment); text.Text == "// done" {
+			found = true
+			assert.Equal(t, theme.ColorNameDisabled, text.Style.ColorName)
+		}
+	}
+	assert.True(t, found)
+
+	r.ParseMarkdown("``` unknown\ncode\n```")
+	assert.Equal(t, []RichTextSegment{&TextSegment{Text: "code", Style: RichTextStyleCodeBlock}}, r.Segments)
+}
+
 func TestRichTextMarkdown_Code_Incomplete(t *testing.T) {
 	r := NewRichTextFromMarkdown("` ")
 
@@ -158,6 +194,28 @@ func TestRichTextMarkdown_Hyperlink(t *testing.T) {
 	}
 }
 
+func TestRichTextMarkdown_Image(t *testing.T) {
+	r := NewRichTextFromMarkdown("![A picture](testdata/simple_renderer.png)")
+
+	assert.Equal(t, 1, len(r.Segments))
+	if img, ok := r.Segments[0].(*ImageSegment); ok {
+		assert.Equal(t, "A picture", img.Title)
+		assert.Equal(t, storage.NewFileURI("testdata/simple_renderer.png"), img.Source)
+	} else {
+		t.Error("Segment should be Image")
+	}
+
+	r.ParseMarkdown("![web](https://example.com/a.png)")
+	assert.Equal(t, "https://example.com/a.png", r.Segments[0].(*ImageSegment).Source.String())
+
+	r.ParseMarkdown("![spaced](images/my%20logo.png)")
+	assert.Equal(t, storage.NewFileURI("images/my logo.png"), r.Segments[0].(*ImageSegment).Source)
+	assert.Equal(t, "![spaced](<images/my logo.png>)", r.ToMarkdown())
+
+	r.ParseMarkdown("![drive](C:/images/logo.png)")
+	assert.Equal(t, storage.NewFileURI("C:/images/logo.png"), r.Segments[0].(*ImageSegment).Source)
+}
+
 func TestRichTextMarkdown_Lines(t *testing.T) {
 	r := NewRichTextFromMarkdown("line1\nline2\n") // a single newline is not a new paragraph
 
@@ -215,6 +273,61 @@ func TestRichTextMarkdown_List(t *testing.T) {
 	}
 }
 
+func TestRichTextMarkdown_Strikethrough(t *testing.T) {
+	r := NewRichTextFromMarkdown("a ~~gone~~ word")
+
+	assert.Equal(t, 3, len(r.Segments))
+	if text, ok := r.Segments[1].(*TextSegment); ok {
+		assert.Equal(t, "gone", text.Text)
+		assert.True(t, text.Style.Strikethrough)
+		assert.True(t, text.Style.Inline)
+	} else {
+		t.Error("Segment should be Text")
+	}
+	assert.False(t, r.Segments[2].(*TextSegment).Style.Strikethrough)
+}
+
+func TestRichTextMarkdown_Table(t *testing.T) {
+	r := NewRichTextFromMarkdown("before\n\n| Name | Count |\n| --- | ---: |\n| *a* | 1 |\n| b | 2 |\n\nafter")
+
+	assert.Equal(t, 3, len(r.Segments))
+	table, ok := r.Segments[1].(*TableSegment)
+	if !ok {
+		t.Fatal("Segment should be Table")
+	}
+	assert.Equal(t, 2, len(table.Header))
+	assert.Equal(t, 2, len(table.Rows))
+	assert.Equal(t, "Name\tCount\na\t1\nb\t2", table.Textual())
+
+	count := table.Header[1].(*ParagraphSegment).Texts[0].(*TextSegment)
+	assert.Equal(t, "Count", count.Text)
+	assert.Equal(t, gui.TextAlignTrailing, count.Style.Alignment)
+	a := table.Rows[0][0].(*ParagraphSegment).Texts[0].(*TextSegment)
+	assert.Equal(t, "a", a.Text)
+	assert.True(t, a.Style.TextStyle.Italic)
+	assert.Equal(t, "after", r.Segments[2].(*TextSegment).Text)
+}
+
+func TestRichTextMarkdown_TaskList(t *testing.T) {
+	r := NewRichTextFromMarkdown("- [x] done\n- [ ] todo")
+
+	assert.Equal(t, 1, len(r.Segments))
+	list, ok := r.Segments[0].(*ListSegment)
+	if !ok {
+		t.Fatal("Segment should be List")
+	}
+	assert.Equal(t, 2, len(list.Items))
+	done := list.Items[0].(*ParagraphSegment).Texts
+	assert.Equal(t, &CheckBoxSegment{Checked: true}, done[0])
+	assert.Equal(t, "done", done[1].(*TextSegment).Text)
+	todo := list.Items[1].(*ParagraphSegment).Texts
+	assert.Equal(t, &CheckBoxSegment{Checked: false}, todo[0])
+
+	items := list.Segments()
+	assert.Equal(t, 2, len(items))
+	assert.Equal(t, list.Items[0], items[0]) // no bullet is shown before a checkbox
+}
+
 func TestRichTextMarkdown_Separator(t *testing.T) {
 	r := NewRichTextFromMarkdown("---\n")
 
@@ -223,3 +336,215 @@ func TestRichTextMarkdown_Separator(t *testing.T) {
 		t.Error("Segment should be a separator")
 	}
 }
+
+func TestRichTextMarkdown_Escaped(t *testing.T) {
+	r := NewRichTextFromMarkdown("\\*not emphasis\\* `co\\de` end")
+
+	assert.Equal(t, 3, len(r.Segments))
+	assert.Equal(t, "*not emphasis* ", r.Segments[0].(*TextSegment).Text)
+	assert.Equal(t, "co\\de", r.Segments[1].(*TextSegment).Text)
+}
+
+func TestRichTextMarkdown_NestedEmphasis(t *testing.T) {
+	r := NewRichTextFromMarkdown("***both*** done")
+
+	text := r.Segments[0].(*TextSegment)
+	assert.Equal(t, "both", text.Text)
+	assert.True(t, text.Style.TextStyle.Bold)
+	assert.True(t, text.Style.TextStyle.Italic)
+}
+
+func TestRichText_ToMarkdown(t *testing.T) {
+	for name, tt := range map[string]struct {
+		segments []RichTextSegment
+		want     string
+	}{
+		"paragraphs": {
+			segments: []RichTextSegment{
+				&TextSegment{Text: "One", Style: RichTextStyleParagraph},
+				&TextSegment{Text: "Two", Style: RichTextStyleParagraph},
+			},
+			want: "One\n\nTwo",
+		},
+		"inline": {
+			segments: []RichTextSegment{
+				&TextSegment{Text: "A ", Style: RichTextStyleInline},
+				&TextSegment{Text: "bold ", Style: RichTextStyleStrong},
+				&TextSegment{Text: "and", Style: RichTextStyleEmphasis},
+				&TextSegment{Text: " ", Style: RichTextStyleInline},
+				&TextSegment{Text: "code", Style: RichTextStyleCodeInline},
+				&TextSegment{Text: " text", Style: RichTextStyleParagraph},
+			},
+			want: "A **bold** *and* `code` text",
+		},
+		"escaped": {
+			segments: []RichTextSegment{
+				&TextSegment{Text: "# not *a* heading", Style: RichTextStyleParagraph},
+				&TextSegment{Text: "1. not a list", Style: RichTextStyleParagraph},
+			},
+			want: "\\# not \\*a\\* heading\n\n1\\. not a list",
+		},
+		"headings": {
+			segments: []RichTextSegment{
+				&TextSegment{Text: "Title", Style: RichTextStyleHeading},
+				&TextSegment{Text: "Sub", Style: RichTextStyleSubHeading},
+				&SeparatorSegment{},
+			},
+			want: "# Title\n\n## Sub\n\n---",
+		},
+		"link": {
+			segments: []RichTextSegment{
+				&TextSegment{Text: "See ", Style: RichTextStyleInline},
+				&HyperlinkSegment{Text: "here", URL: &url.URL{Scheme: "https", Host: "example.com"}},
+			},
+			want: "See [here](https://example.com)",
+		},
+		"code": {
+			segments: []RichTextSegment{&TextSegment{Text: "a := 1\n```", Style: RichTextStyleCodeBlock}},
+			want:     "````\na := 1\n```\n````",
+		},
+		"lists": {
+			segments: []RichTextSegment{
+				&ListSegment{Items: []RichTextSegment{
+					&ParagraphSegment{Texts: []RichTextSegment{
+						&TextSegment{Text: "one", Style: RichTextStyleInline},
+						&ListSegment{Ordered: true, Items: []RichTextSegment{
+							&ParagraphSegment{Texts: []RichTextSegment{&TextSegment{Text: "nested"}}},
+						}},
+					}},
+					&ParagraphSegment{Texts: []RichTextSegment{&TextSegment{Text: "two"}}},
+				}},
+				&ListSegment{Items: []RichTextSegment{
+					&ParagraphSegment{Texts: []RichTextSegment{&TextSegment{Text: "other"}}},
+				}},
+			},
+			want: "- one\n  1. nested\n- two\n\n* other",
+		},
+		"strikethrough": {
+			segments: []RichTextSegment{
+				&TextSegment{Text: "not ", Style: RichTextStyleInline},
+				&TextSegment{Text: "this ~", Style: RichTextStyle{Inline: true, Strikethrough: true}},
+				&TextSegment{Text: "one", Style: RichTextStyle{Inline: true, Strikethrough: true,
+					TextStyle: gui.TextStyle{Bold: true}}},
+			},
+			want: "not ~~this \\~~~~~**one**~~",
+		},
+		"tasks": {
+			segments: []RichTextSegment{
+				&ListSegment{Items: []RichTextSegment{
+					&ParagraphSegment{Texts: []RichTextSegment{&CheckBoxSegment{Checked: true}, &TextSegment{Text: "done"}}},
+					&ParagraphSegment{Texts: []RichTextSegment{&CheckBoxSegment{}, &TextSegment{Text: "todo"}}},
+				}},
+			},
+			want: "- [x] done\n- [ ] todo",
+		},
+		"image": {
+			segments: []RichTextSegment{
+				&ImageSegment{Title: "Logo", Source: storage.NewFileURI("/tmp/my logo.png")},
+			},
+			want: "![Logo](<file:///tmp/my logo.png>)",
+		},
+		"table": {
+			segments: []RichTextSegment{
+				&TableSegment{
+					Header: []RichTextSegment{
+						&TextSegment{Text: "Key", Style: RichTextStyleInline},
+						&TextSegment{Text: "Value", Style: RichTextStyle{Inline: true, Alignment: gui.TextAlignCenter}},
+					},
+					Rows: [][]RichTextSegment{
+						{&TextSegment{Text: "a|b", Style: RichTextStyleInline}, &TextSegment{Text: "1", Style: RichTextStyleStrong}},
+						{&TextSegment{Text: "c", Style: RichTextStyleInline}},
+					},
+				},
+			},
+			want: "| Key | Value |\n| --- | :---: |\n| a\\|b | **1** |\n| c |  |",
+		},
+		"table blocks": {
+			segments: []RichTextSegment{
+				&TableSegment{
+					Header: []RichTextSegment{&TextSegment{Text: "Cell", Style: RichTextStyleInline}},
+					Rows: [][]RichTextSegment{{&ParagraphSegment{Texts: []RichTextSegment{
+						&TextSegment{Text: "text", Style: RichTextStyleParagraph},
+						&TextSegment{Text: "a := 1\nb := 2", Style: RichTextStyleCodeBlock},
+					}}}},
+				},
+			},
+			want: "| Cell |\n| --- |\n| text `a := 1 b := 2` |",
+		},
+		"highlighted code": {
+			segments: []RichTextSegment{
+				&TextSegment{Text: "Code:", Style: RichTextStyleInline},
+				&TextSegment{Text: "func", Style: RichTextStyleCodeInline},
+				&TextSegment{Text: " a()", Style: RichTextStyleCodeInline},
+				&TextSegment{Text: "\n}", Style: RichTextStyleCodeBlock},
+			},
+			want: "Code:\n\n```\nfunc a()\n}\n```",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewRichText(tt.segments...).ToMarkdown())
+		})
+	}
+}
+
+func TestSegmentsToMarkdown_RoundTrip(t *testing.T) {
+	for _, content := range []string{
+		"# Heading\n\nSome *emphasis* and **strong** text.",
+		"## Sub\n\n### Third\n\n---\n\nAfter ***both*** styles",
+		"- one\n- two *x*\n  1. nested\n  2. again\n- three",
+		"1. first\n2. second\n\n- other",
+		"```\ncode\n  block\n```\n\n> quote\n\n- after",
+		"A [link](https://example.com/a?b=c) and `code` with \\*escapes\\* and a\\_b.",
+		"\\# hash\n\n1\\. number\n\n`` a`b ``",
+		"Some ~~struck~~ and ~~*both*~~ here\n\n- [x] done\n- [ ] not *yet*",
+		"| A | B | C |\n| :-: | --: | --- |\n| 1 | *2* | [3](https://example.com) |\n| x\\|y |  | z |",
+		"![An image](testdata/simple_renderer.png)\n\nAnd text",
+		"![Relative](../widget/testdata/simple_renderer.png)",
+		"1. a\n\n   ```\n   code\n   ```\n2. b",
+		"- a\n\n  second paragraph\n  - nested\n\n  after\n- b",
+		"~~struck~~ at the start\n\n~~~\nfenced\n~~~",
+	} {
+		segs := parseMarkdown(content)
+		md := SegmentsToMarkdown(segs)
+		assert.Equal(t, normalizeSegments(segs), normalizeSegments(parseMarkdown(md)), "content %q became %q", content, md)
+	}
+}
+
+// normalizeSegments removes differences in how the parser splits text that have no effect on how it renders.
+func normalizeSegments(segs []RichTextSegment) []RichTextSegment {
+	var out []RichTextSegment
+	for i, seg := range segs {
+		if i > 0 && seg == segs[i-1] {
+			continue
+		}
+		switch s := seg.(type) {
+		case *TextSegment:
+			if len(out) > 0 {
+				if prev, ok := out[len(out)-1].(*TextSegment); ok && prev.Style.Inline {
+					style := prev.Style
+					style.Inline = s.Style.Inline
+					if style == s.Style {
+						prev.Text += s.Text
+						prev.Style.Inline = s.Style.Inline
+						continue
+					}
+				}
+			}
+			copied := *s
+			out = append(out, &copied)
+		case *ListSegment:
+			out = append(out, &ListSegment{Ordered: s.Ordered, Items: normalizeSegments(s.Items)})
+		case *ParagraphSegment:
+			out = append(out, &ParagraphSegment{Texts: normalizeSegments(s.Texts)})
+		case *TableSegment:
+			table := &TableSegment{Header: normalizeSegments(s.Header)}
+			for _, row := range s.Rows {
+				table.Rows = append(table.Rows, normalizeSegments(row))
+			}
+			out = append(out, table)
+		default:
+			out = append(out, seg)
+		}
+	}
+	return out
+}
@@ -0,0 +1,462 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+// numberScanPrecision matches the width and precision of a format verb, which fmt.Sscanf does not accept.
+var numberScanPrecision = regexp.MustCompile(`%([-+# 0]*)[0-9]*(\.[0-9]*)?([a-zA-Z])`)
+
+// Declare conformity with interfaces.
+var _ gui.Widget = (*NumberEntry)(nil)
+var _ gui.Focusable = (*NumberEntry)(nil)
+var _ gui.Scrollable = (*NumberEntry)(nil)
+var _ gui.Tappable = (*numberStepper)(nil)
+
+// NumberEntry is an input field for a number between Min and Max, also known as a spin box.
+// The value can be typed or changed by Step using the arrow keys, the mouse wheel or the buttons
+// at the end of the entry. Page up and page down change the value by ten steps.
+//
+// Since: 2.3
+type NumberEntry struct {
+	Entry
+
+	// Format is the fmt layout used to display the value, like "%.2f". If it is empty the shortest
+	// representation of the number is shown.
+	Format string
+	// Min and Max limit the value if Max is greater than Min.
+	Min, Max float64
+	// Step is the change made by the arrow keys, the mouse wheel and the buttons, the default is 1.
+	Step      float64
+	OnChanged func(float64)
+
+	value       float64
+	integer     bool // the value is bound to an Int and must be whole
+	numBinder   basicBinder
+	stepButtons *numberStepper
+}
+
+// NewNumberEntry creates an entry for a number between min and max that changes by step.
+//
+// Since: 2.3
+func NewNumberEntry(min, max, step float64) *NumberEntry {
+	e := &NumberEntry{Min: min, Max: max, Step: step}
+	e.ExtendBaseWidget(e)
+	e.Entry.OnChanged = e.textChanged
+	e.Validator = e.validate
+	e.stepButtons = newNumberStepper(e)
+	e.ActionItem = e.stepButtons
+	e.SetText(e.formatValue(e.clamp(0)))
+	return e
+}
+
+// NewNumberEntryWithData returns a number entry connected to the specified Float data source.
+//
+// Since: 2.3
+func NewNumberEntryWithData(min, max, step float64, data binding.Float) *NumberEntry {
+	e := NewNumberEntry(min, max, step)
+	e.Bind(data)
+	return e
+}
+
+// NewNumberEntryWithIntData returns a number entry connected to the specified Int data source.
+// The value will always be a whole number.
+//
+// Since: 2.3
+func NewNumberEntryWithIntData(min, max, step int, data binding.Int) *NumberEntry {
+	e := NewNumberEntry(float64(min), float64(max), float64(step))
+	e.BindInt(data)
+	return e
+}
+
+// Bind connects the specified Float data source to this NumberEntry.
+// The current value will be displayed and any changes in the data will cause the widget to update.
+// A data value outside of Min and Max is displayed as invalid, it is not changed until the user edits it.
+// Values entered by the user will be set into the data source.
+func (e *NumberEntry) Bind(data binding.Float) {
+	e.integer = false
+	e.bind(data)
+}
+
+// BindInt connects the specified Int data source to this NumberEntry.
+// The current value will be displayed and any changes in the data will cause the widget to update.
+// A data value outside of Min and Max is displayed as invalid, it is not changed until the user edits it.
+// Values entered by the user will be rounded to a whole number and set into the data source.
+func (e *NumberEntry) BindInt(data binding.Int) {
+	e.integer = true
+	e.bind(data)
+}
+
+// CreateRenderer returns a new renderer for this number entry.
+//
+// Implements: gui.Widget
+func (e *NumberEntry) CreateRenderer() gui.WidgetRenderer {
+	e.ExtendBaseWidget(e)
+	return e.Entry.CreateRenderer()
+}
+
+// Disable this widget so that it cannot be interacted with, updating any style appropriately.
+//
+// Implements: gui.DisableableWidget
+func (e *NumberEntry) Disable() {
+	e.stepButtons.Disable()
+	e.Entry.Disable()
+}
+
+// Enable this widget, updating any style or features appropriately.
+//
+// Implements: gui.DisableableWidget
+func (e *NumberEntry) Enable() {
+	e.stepButtons.Enable()
+	e.Entry.Enable()
+}
+
+// FocusLost is called when the NumberEntry has had focus removed.
+// Valid text is replaced by the formatted value.
+//
+// Implements: gui.Focusable
+func (e *NumberEntry) FocusLost() {
+	e.Entry.FocusLost()
+	if e.validate(e.Text) == nil {
+		e.SetText(e.formatValue(e.value))
+	}
+}
+
+// MinSize returns the minimal size of the number entry.
+//
+// Implements: gui.Widget
+func (e *NumberEntry) MinSize() gui.Size {
+	e.ExtendBaseWidget(e)
+	return e.Entry.MinSize()
+}
+
+// Scrolled is called when the mouse wheel is used over this NumberEntry, changing the value by one step.
+//
+// Implements: gui.Scrollable
+func (e *NumberEntry) Scrolled(ev *gui.ScrollEvent) {
+	if e.Disabled() {
+		return
+	}
+	if ev.Scrolled.DY > 0 {
+		e.stepValue(1)
+	} else if ev.Scrolled.DY < 0 {
+		e.stepValue(-1)
+	}
+}
+
+// SetValue updates the value of this entry, it is limited to between Min and Max.
+func (e *NumberEntry) SetValue(value float64) {
+	value = e.clamp(value)
+	e.SetText(e.formatValue(value))
+	e.setValue(value)
+}
+
+// TypedKey receives key input events when the NumberEntry widget is focused.
+//
+// Implements: gui.Focusable
+func (e *NumberEntry) TypedKey(key *gui.KeyEvent) {
+	if e.Disabled() {
+		return
+	}
+	switch key.Name {
+	case gui.KeyUp:
+		e.stepValue(1)
+	case gui.KeyDown:
+		e.stepValue(-1)
+	case gui.KeyPageUp:
+		e.stepValue(10)
+	case gui.KeyPageDown:
+		e.stepValue(-10)
+	default:
+		e.Entry.TypedKey(key)
+	}
+}
+
+// TypedRune receives text input events when the NumberEntry widget is focused.
+// Only characters that can be part of a number are accepted.
+//
+// Implements: gui.Focusable
+func (e *NumberEntry) TypedRune(r rune) {
+	if (r < '0' || r > '9') && !strings.ContainsRune("+-.,eE", r) && e.Format == "" {
+		return
+	}
+	e.Entry.TypedRune(r)
+}
+
+// Unbind disconnects any configured data source from this NumberEntry.
+// The current value will remain at the last value of the data source.
+func (e *NumberEntry) Unbind() {
+	e.OnChanged = nil
+	e.numBinder.Unbind()
+}
+
+// Value returns the current value of this entry.
+func (e *NumberEntry) Value() float64 {
+	return e.value
+}
+
+func (e *NumberEntry) bind(data binding.DataItem) {
+	e.numBinder.SetCallback(e.updateFromData)
+	e.numBinder.Bind(data)
+
+	e.OnChanged = func(_ float64) {
+		e.numBinder.CallWithData(e.writeData)
+	}
+}
+
+func (e *NumberEntry) clamp(value float64) float64 {
+	if e.integer {
+		value = math.Round(value)
+	}
+	if e.Max <= e.Min {
+		return value
+	}
+	return math.Max(e.Min, math.Min(e.Max, value))
+}
+
+func (e *NumberEntry) formatValue(value float64) string {
+	if e.Format == "" {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	if strings.ContainsAny(numberScanPrecision.FindString(e.Format), "dxXob") {
+		return fmt.Sprintf(e.Format, int(math.Round(value)))
+	}
+	return fmt.Sprintf(e.Format, value)
+}
+
+func (e *NumberEntry) parse(text string) (float64, error) {
+	text = strings.TrimSpace(text)
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil && e.Format != "" {
+		value, err = e.scan(text)
+	}
+	if err != nil {
+		return 0, errors.New("not a valid number")
+	}
+
+	if e.integer && value != math.Round(value) {
+		return value, errors.New("must be a whole number")
+	}
+	if e.Max > e.Min && value < e.Min {
+		return value, errors.New("must be at least " + e.formatValue(e.Min))
+	}
+	if e.Max > e.Min && value > e.Max {
+		return value, errors.New("must be at most " + e.formatValue(e.Max))
+	}
+	return value, nil
+}
+
+// scan reads a value from text that was written using the Format.
+func (e *NumberEntry) scan(text string) (float64, error) {
+	format := numberScanPrecision.ReplaceAllString(e.Format, "%$3")
+	if strings.ContainsAny(numberScanPrecision.FindString(e.Format), "dxXob") {
+		var i int
+		_, err := fmt.Sscanf(text, format, &i)
+		return float64(i), err
+	}
+
+	var f float64
+	_, err := fmt.Sscanf(text, format, &f)
+	return f, err
+}
+
+func (e *NumberEntry) setValue(value float64) {
+	if value == e.value {
+		return
+	}
+
+	e.value = value
+	if e.OnChanged != nil {
+		e.OnChanged(value)
+	}
+}
+
+// stepValue changes the value by a number of steps, keeping to a multiple of the step from Min.
+func (e *NumberEntry) stepValue(steps int) {
+	step := e.Step
+	if step <= 0 {
+		step = 1
+	}
+
+	steps64 := math.Round((e.value-e.Min)/step) + float64(steps)
+	value := e.Min + steps64*step
+	if decimals := numberDecimals(step) + numberDecimals(e.Min); decimals > 0 { // remove rounding errors
+		value, _ = strconv.ParseFloat(strconv.FormatFloat(value, 'f', decimals, 64), 64)
+	}
+	e.SetValue(value)
+}
+
+func (e *NumberEntry) textChanged(text string) {
+	if text == e.formatValue(e.value) {
+		return
+	}
+	value, err := e.parse(text)
+	if err != nil {
+		return
+	}
+	e.setValue(value)
+}
+
+func (e *NumberEntry) updateFromData(data binding.DataItem) {
+	var value float64
+	var err error
+	switch source := data.(type) {
+	case binding.Float:
+		value, err = source.Get()
+	case binding.Int:
+		var i int
+		i, err = source.Get()
+		value = float64(i)
+	default:
+		return
+	}
+
+	if err != nil {
+		gui.LogError("Error getting current data value", err)
+		return
+	}
+	// a value out of range is shown as invalid rather than clamped and written back to the data
+	e.value = value
+	e.SetText(e.formatValue(value))
+}
+
+func (e *NumberEntry) validate(text string) error {
+	_, err := e.parse(text)
+	return err
+}
+
+func (e *NumberEntry) writeData(data binding.DataItem) {
+	switch target := data.(type) {
+	case binding.Float:
+		currentValue, err := target.Get()
+		if err != nil || currentValue == e.value {
+			return
+		}
+		if err := target.Set(e.value); err != nil {
+			gui.LogError(fmt.Sprintf("Failed to set binding value to %f", e.value), err)
+		}
+	case binding.Int:
+		value := int(math.Round(e.value))
+		currentValue, err := target.Get()
+		if err != nil || currentValue == value {
+			return
+		}
+		if err := target.Set(value); err != nil {
+			gui.LogError(fmt.Sprintf("Failed to set binding value to %d", value), err)
+		}
+	}
+}
+
+// numberDecimals returns the number of digits after the decimal point needed to show a number exactly.
+func numberDecimals(f float64) int {
+	text := strconv.FormatFloat(f, 'f', -1, 64)
+	if i := strings.IndexByte(text, '.'); i >= 0 {
+		return len(text) - i - 1
+	}
+	return 0
+}
+
+// numberStepper shows increment and decrement arrows, the top half of it increases the value.
+type numberStepper struct {
+	DisableableWidget
+	up, down *canvas.Image
+	entry    *NumberEntry
+}
+
+func newNumberStepper(e *NumberEntry) *numberStepper {
+	s := &numberStepper{
+		up:    canvas.NewImageFromResource(theme.MenuDropUpIcon()),
+		down:  canvas.NewImageFromResource(theme.MenuDropDownIcon()),
+		entry: e,
+	}
+	s.up.FillMode = canvas.ImageFillContain
+	s.down.FillMode = canvas.ImageFillContain
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *numberStepper) CreateRenderer() gui.WidgetRenderer {
+	return &numberStepperRenderer{
+		WidgetRenderer: NewSimpleRenderer(&gui.Container{Objects: []gui.CanvasObject{s.up, s.down}}),
+		stepper:        s,
+	}
+}
+
+func (s *numberStepper) Cursor() desktop.Cursor {
+	return desktop.DefaultCursor
+}
+
+func (s *numberStepper) Tapped(ev *gui.PointEvent) {
+	if s.Disabled() {
+		return
+	}
+	if ev.Position.Y < s.Size().Height/2 {
+		s.entry.stepValue(1)
+	} else {
+		s.entry.stepValue(-1)
+	}
+	if c := gui.CurrentApp().Driver().CanvasForObject(s); c != nil {
+		c.Focus(s.entry.super().(gui.Focusable))
+	}
+}
+
+var _ gui.WidgetRenderer = (*numberStepperRenderer)(nil)
+
+type numberStepperRenderer struct {
+	gui.WidgetRenderer
+	stepper *numberStepper
+}
+
+func (r *numberStepperRenderer) Layout(size gui.Size) {
+	half := gui.NewSize(size.Width, size.Height/2)
+	r.stepper.up.Resize(half)
+	r.stepper.up.Move(gui.NewPos(0, 0))
+	r.stepper.down.Resize(half)
+	r.stepper.down.Move(gui.NewPos(0, half.Height))
+}
+
+func (r *numberStepperRenderer) MinSize() gui.Size {
+	return gui.NewSize(theme.IconInlineSize(), theme.IconInlineSize())
+}
+
+func (r *numberStepperRenderer) Refresh() {
+	up, down := theme.MenuDropUpIcon(), theme.MenuDropDownIcon()
+	if r.stepper.Disabled() {
+		up, down = theme.NewDisabledResource(up), theme.NewDisabledResource(down)
+	}
+	r.stepper.up.Resource = up
+	r.stepper.down.Resource = down
+	r.Layout(r.stepper.Size())
+	canvas.Refresh(r.stepper)
+}
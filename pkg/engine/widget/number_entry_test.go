@@ -0,0 +1,190 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberEntry_Format(t *testing.T) {
+	e := NewNumberEntry(0, 100, 0.25)
+	e.Format = "%.2f kg"
+	e.SetValue(3)
+	assert.Equal(t, "3.00 kg", e.Text)
+
+	e.SetText("4.5 kg")
+	assert.NoError(t, e.Validate())
+	assert.Equal(t, 4.5, e.Value())
+	e.SetText("7")
+	assert.Equal(t, 7.0, e.Value())
+
+	e.FocusLost()
+	assert.Equal(t, "7.00 kg", e.Text)
+
+	e.Format = "%03d"
+	e.SetValue(5)
+	assert.Equal(t, "005", e.Text)
+}
+
+func TestNumberEntry_Range(t *testing.T) {
+	var changed []float64
+	e := NewNumberEntry(-5, 5, 1)
+	e.OnChanged = func(v float64) {
+		changed = append(changed, v)
+	}
+	assert.Equal(t, "0", e.Text)
+
+	e.SetText("9")
+	assert.Error(t, e.Validate())
+	assert.Equal(t, 0.0, e.Value())
+	e.SetText("abc")
+	assert.Error(t, e.Validate())
+	e.SetText("-2.5")
+	assert.NoError(t, e.Validate())
+	assert.Equal(t, -2.5, e.Value())
+	assert.Equal(t, []float64{-2.5}, changed)
+
+	e.SetValue(12)
+	assert.Equal(t, 5.0, e.Value())
+	assert.Equal(t, "5", e.Text)
+
+	e = NewNumberEntry(3, 10, 1)
+	assert.Equal(t, 3.0, e.Value())
+}
+
+func TestNumberEntry_Step(t *testing.T) {
+	e := NewNumberEntry(0, 1, 0.1)
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	assert.Equal(t, "0.3", e.Text)
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, 0.2, e.Value())
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyPageUp})
+	assert.Equal(t, 1.0, e.Value())
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyPageDown})
+	assert.Equal(t, 0.0, e.Value())
+
+	e.SetText("0.46")
+	e.Scrolled(&gui.ScrollEvent{Scrolled: gui.NewDelta(0, 10)})
+	assert.Equal(t, 0.6, e.Value())
+	e.Scrolled(&gui.ScrollEvent{Scrolled: gui.NewDelta(0, -10)})
+	assert.Equal(t, 0.5, e.Value())
+
+	e.Disable()
+	e.Scrolled(&gui.ScrollEvent{Scrolled: gui.NewDelta(0, 10)})
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	assert.Equal(t, 0.5, e.Value())
+}
+
+func TestNumberEntry_StepButtons(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	e := NewNumberEntry(0, 10, 2)
+	w := test.NewWindow(e)
+	defer w.Close()
+	buttons := e.ActionItem.(*numberStepper)
+	buttons.Resize(gui.NewSize(20, 20))
+
+	buttons.Tapped(&gui.PointEvent{Position: gui.NewPos(10, 5)})
+	buttons.Tapped(&gui.PointEvent{Position: gui.NewPos(10, 5)})
+	assert.Equal(t, 4.0, e.Value())
+	assert.Equal(t, e, w.Canvas().Focused())
+	buttons.Tapped(&gui.PointEvent{Position: gui.NewPos(10, 15)})
+	assert.Equal(t, 2.0, e.Value())
+
+	e.Disable()
+	assert.True(t, buttons.Disabled())
+	buttons.Tapped(&gui.PointEvent{Position: gui.NewPos(10, 5)})
+	assert.Equal(t, 2.0, e.Value())
+}
+
+func TestNumberEntry_TypedRune(t *testing.T) {
+	e := NewNumberEntry(0, 0, 1)
+	e.SetText("")
+	test.Type(e, "1a2.5b")
+	assert.Equal(t, "12.5", e.Text)
+	assert.Equal(t, 12.5, e.Value())
+}
+
+func TestNewNumberEntryWithData(t *testing.T) {
+	data := binding.NewFloat()
+	err := data.Set(1.5)
+	assert.Nil(t, err)
+
+	e := NewNumberEntryWithData(0, 10, 0.5, data)
+	waitForBinding()
+	assert.Equal(t, "1.5", e.Text)
+
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	v, err := data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, v)
+
+	err = data.Set(20)
+	assert.Nil(t, err)
+	waitForBinding()
+	assert.Equal(t, "20", e.Text) // out of range data is shown as invalid, not clamped
+	assert.Equal(t, 20.0, e.Value())
+	assert.Error(t, e.Validate())
+	v, err = data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 20.0, v)
+
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	v, err = data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 10.0, v)
+}
+
+func TestNewNumberEntryWithIntData(t *testing.T) {
+	data := binding.NewInt()
+	err := data.Set(3)
+	assert.Nil(t, err)
+
+	e := NewNumberEntryWithIntData(0, 10, 1, data)
+	waitForBinding()
+	assert.Equal(t, "3", e.Text)
+
+	e.SetText("4.5")
+	assert.Error(t, e.Validate())
+	e.SetText("7")
+	v, err := data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 7, v)
+
+	e.SetValue(2.6)
+	assert.Equal(t, "3", e.Text)
+
+	waitForBinding()
+	e.Unbind()
+	err = data.Set(1)
+	assert.Nil(t, err)
+	waitForBinding()
+	assert.Equal(t, 3.0, e.Value())
+}
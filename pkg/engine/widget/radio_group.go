@@ -42,6 +42,7 @@ type RadioGroup struct {
 }
 
 var _ gui.Widget = (*RadioGroup)(nil)
+var _ gui.Accessible = (*RadioGroup)(nil)
 
 // NewRadioGroup creates a new radio group widget with the set options and change handler
 //
@@ -57,6 +58,15 @@ func NewRadioGroup(options []string, changed func(string)) *RadioGroup {
 	return r
 }
 
+// AccessibilityInfo describes this radio group to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (r *RadioGroup) AccessibilityInfo() gui.AccessibilityInfo {
+	return gui.AccessibilityInfo{Role: gui.AccessibleRoleRadioGroup, Value: r.Selected}
+}
+
 // Append adds a new option to the end of a RadioGroup widget.
 func (r *RadioGroup) Append(option string) {
 	r.Options = append(r.Options, option)
@@ -32,6 +32,7 @@ var _ gui.Widget = (*radioItem)(nil)
 var _ desktop.Hoverable = (*radioItem)(nil)
 var _ gui.Tappable = (*radioItem)(nil)
 var _ gui.Focusable = (*radioItem)(nil)
+var _ gui.Accessible = (*radioItem)(nil)
 
 func newRadioItem(label string, onTap func(*radioItem)) *radioItem {
 	i := &radioItem{Label: label, onTap: onTap}
@@ -51,6 +52,19 @@ type radioItem struct {
 	onTap   func(item *radioItem)
 }
 
+// AccessibilityInfo describes this item to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (i *radioItem) AccessibilityInfo() gui.AccessibilityInfo {
+	return gui.AccessibilityInfo{
+		Role:  gui.AccessibleRoleRadioButton,
+		Name:  i.Label,
+		State: gui.AccessibleState{Checked: i.Selected},
+	}
+}
+
 // CreateRenderer is a private method to Bhojpur GUI which links this widget to its renderer.
 //
 // Implements: gui.Widget
@@ -47,6 +47,11 @@ type RichText struct {
 	Wrapping gui.TextWrap
 	Scroll   widget.ScrollDirection
 
+	// HighlightCode sets whether fenced code blocks with a known language should be coloured by ParseMarkdown.
+	//
+	// Since: 2.3
+	HighlightCode bool
+
 	inset     gui.Size      // this varies due to how the widget works (entry with scroller vs others with padding)
 	rowBounds []rowBoundary // cache for boundaries
 	scr       *widget.Scroll
@@ -208,6 +213,11 @@ func (t *RichText) cachedSegmentVisual(seg RichTextSegment, offset int) gui.Canv
 	}
 
 	vis := seg.Visual()
+	if box, ok := vis.(*gui.Container); ok {
+		if img, ok := box.Layout.(*richImageLayout); ok {
+			img.setLoaded(t.Refresh) // images load in the background and change the layout when ready
+		}
+	}
 	if offset < len(t.visualCache[seg]) {
 		t.visualCache[seg][offset] = vis
 	} else {
@@ -447,6 +457,14 @@ type RichTextBlock interface {
 type textRenderer struct {
 	widget.BaseRenderer
 	obj *RichText
+
+	strikes []textStrike
+}
+
+// textStrike is a line drawn through the text of a segment with the Strikethrough style.
+type textStrike struct {
+	text *canvas.Text
+	line *canvas.Line
 }
 
 func (r *textRenderer) Layout(size gui.Size) {
@@ -509,6 +527,14 @@ func (r *textRenderer) Layout(size gui.Size) {
 			yPos += theme.Padding()
 		}
 	}
+
+	for _, strike := range r.strikes {
+		size := gui.MeasureText(strike.text.Text, strike.text.TextSize, strike.text.TextStyle)
+		pos := strike.text.Position().Add(gui.NewPos(0, size.Height/2))
+		strike.line.Position1 = pos
+		strike.line.Position2 = pos.Add(gui.NewPos(size.Width, 0))
+		strike.line.StrokeColor = strike.text.Color
+	}
 }
 
 // MinSize calculates the minimum size of a rich text widget.
@@ -586,6 +612,7 @@ func (r *textRenderer) Refresh() {
 	r.obj.propertyLock.RUnlock()
 
 	var objs []gui.CanvasObject
+	var strikes []textStrike
 	for _, bound := range bounds {
 		for i, seg := range bound.segments {
 			if _, ok := seg.(*TextSegment); !ok {
@@ -613,12 +640,19 @@ func (r *textRenderer) Refresh() {
 			if concealed(seg) {
 				txt.Text = strings.Repeat(passwordChar, len(runes))
 			}
+			if textSeg.Style.Strikethrough {
+				strikes = append(strikes, textStrike{text: txt, line: canvas.NewLine(txt.Color)})
+			}
 
 			objs = append(objs, txt)
 		}
 	}
+	for _, strike := range strikes { // the lines follow the segment visuals so that objects still match the segments
+		objs = append(objs, strike.line)
+	}
 
 	r.obj.propertyLock.Lock()
+	r.strikes = strikes
 	if r.obj.scr != nil {
 		r.obj.scr.Content = &gui.Container{Layout: layout.NewMaxLayout(), Objects: []gui.CanvasObject{
 			r.obj.prop, &gui.Container{Objects: objs}}}
@@ -802,7 +836,7 @@ func lineBounds(seg *TextSegment, wrap gui.TextWrap, firstWidth, maxWidth float3
 			reuse++
 		case gui.TextWrapBreak:
 			for low < high {
-				if measurer(text[low:high]) <= measureWidth {
+				if measurer(text[low:high]) <= measureWidth || high-low == 1 { // always fit one character per row
 					bounds = append(bounds, rowBoundary{[]RichTextSegment{seg}, reuse, low, high})
 					reuse++
 					low = high
@@ -810,12 +844,15 @@ func lineBounds(seg *TextSegment, wrap gui.TextWrap, firstWidth, maxWidth float3
 					measureWidth = maxWidth
 				} else {
 					high = binarySearch(checker, low, high)
+					if high <= low {
+						high = low + 1
+					}
 				}
 			}
 		case gui.TextWrapWord:
 			for low < high {
 				sub := text[low:high]
-				if measurer(sub) <= measureWidth {
+				if measurer(sub) <= measureWidth || len(sub) == 1 { // always fit one character per row
 					bounds = append(bounds, rowBoundary{[]RichTextSegment{seg}, reuse, low, high})
 					reuse++
 					low = high
@@ -836,6 +873,9 @@ func lineBounds(seg *TextSegment, wrap gui.TextWrap, firstWidth, maxWidth float3
 						measureWidth = maxWidth
 						continue
 					}
+					if high <= low {
+						high = low + 1
+					}
 				}
 			}
 		}
@@ -0,0 +1,1347 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"net/url"
+	"strings"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/internal/cache"
+	"github.com/bhojpur/gui/pkg/engine/internal/widget"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+// Declare conformity with interfaces.
+var _ gui.Widget = (*RichTextEntry)(nil)
+var _ gui.Focusable = (*RichTextEntry)(nil)
+var _ gui.Shortcutable = (*RichTextEntry)(nil)
+var _ desktop.Keyable = (*RichTextEntry)(nil)
+var _ gui.Tappable = (*richTextEntryContent)(nil)
+var _ gui.Draggable = (*richTextEntryContent)(nil)
+
+// RichTextEntry is an editor for formatted text that is displayed using RichText and can be loaded from
+// and saved as markdown. Formatting is applied to the selection, or to the text typed next if nothing
+// is selected, using the Toggle methods or these shortcuts with the platform shortcut modifier:
+//
+//	B for bold, I for italic and K for a link
+//	1, 2 and 3 for headings and 0 for normal text
+//	L for a bulleted list and Shift+L for a numbered list
+//
+// Since: 2.3
+type RichTextEntry struct {
+	BaseWidget
+
+	// OnChanged is called with the content formatted as markdown each time it is edited.
+	OnChanged func(string)
+
+	blocks         []*richBlock
+	cursor, anchor richPosition // the selection runs between anchor and cursor, which are equal if it is empty
+	pending        *richSpan    // the style for the next typed text, set when a style is toggled with nothing selected
+	keys           selectionKeys
+	focused        bool
+
+	text     *RichText
+	leaves   map[RichTextSegment]richPosition // where the text of each displayed segment starts
+	content  *richTextEntryContent
+	scroller *widget.Scroll
+}
+
+type richBlockKind int
+
+const (
+	richParagraph richBlockKind = iota
+	richHeading
+	richQuote
+	richCode
+	richSeparator
+	richListItem
+)
+
+// richBlock is a paragraph, or another block level element, in a RichTextEntry.
+type richBlock struct {
+	kind    richBlockKind
+	level   int  // the heading level, or the nesting depth of a list item starting at 0
+	ordered bool // a list item is numbered
+	spans   []richSpan
+}
+
+// richSpan is a run of text within a block that has the same style.
+type richSpan struct {
+	text               []rune
+	bold, italic, code bool
+	link               *url.URL
+}
+
+// richPosition is a location in a RichTextEntry as a block index and a rune offset within that block.
+type richPosition struct {
+	block, offset int
+}
+
+func (p richPosition) before(o richPosition) bool {
+	return p.block < o.block || (p.block == o.block && p.offset < o.offset)
+}
+
+// NewRichTextEntry creates a new, empty rich text editor.
+//
+// Since: 2.3
+func NewRichTextEntry() *RichTextEntry {
+	e := &RichTextEntry{blocks: []*richBlock{{}}}
+	e.ExtendBaseWidget(e)
+	return e
+}
+
+// NewRichTextEntryFromMarkdown creates a new rich text editor with content parsed from the markdown provided.
+//
+// Since: 2.3
+func NewRichTextEntryFromMarkdown(content string) *RichTextEntry {
+	e := NewRichTextEntry()
+	e.blocks = richBlocksFromSegments(parseMarkdown(content))
+	return e
+}
+
+// CreateRenderer is a private method to Bhojpur GUI which links this widget to its renderer
+func (e *RichTextEntry) CreateRenderer() gui.WidgetRenderer {
+	e.ExtendBaseWidget(e)
+	e.text = NewRichText()
+	e.text.Wrapping = gui.TextWrapWord
+	e.content = &richTextEntryContent{entry: e}
+	e.content.ExtendBaseWidget(e.content)
+	e.scroller = widget.NewVScroll(e.content)
+	e.refreshText()
+
+	bg := canvas.NewRectangle(theme.InputBackgroundColor())
+	return &richTextEntryRenderer{BaseRenderer: widget.NewBaseRenderer([]gui.CanvasObject{bg, e.scroller}),
+		entry: e, background: bg}
+}
+
+// FocusGained is called when the RichTextEntry has been given focus.
+//
+// Implements: gui.Focusable
+func (e *RichTextEntry) FocusGained() {
+	e.focused = true
+	e.refreshContent()
+}
+
+// FocusLost is called when the RichTextEntry has had focus removed.
+//
+// Implements: gui.Focusable
+func (e *RichTextEntry) FocusLost() {
+	e.focused = false
+	e.keys.shift = false
+	e.refreshContent()
+}
+
+// KeyDown is called when a key is pressed and tracks the shift key for extending the selection.
+//
+// Implements: desktop.Keyable
+func (e *RichTextEntry) KeyDown(key *gui.KeyEvent) {
+	e.keys.keyDown(key)
+}
+
+// KeyUp is called when a key is released.
+//
+// Implements: desktop.Keyable
+func (e *RichTextEntry) KeyUp(key *gui.KeyEvent) {
+	e.keys.keyUp(key)
+}
+
+// Markdown returns the content of the editor formatted as markdown.
+//
+// Since: 2.3
+func (e *RichTextEntry) Markdown() string {
+	segs, _ := richBlocksToSegments(e.blocks, false)
+	return SegmentsToMarkdown(segs)
+}
+
+// MinSize returns the size that this widget should not shrink below.
+func (e *RichTextEntry) MinSize() gui.Size {
+	e.ExtendBaseWidget(e)
+	return e.BaseWidget.MinSize()
+}
+
+// SelectedText returns the text that is currently selected, without formatting.
+//
+// Since: 2.3
+func (e *RichTextEntry) SelectedText() string {
+	start, end := e.selection()
+	var b strings.Builder
+	for i := start.block; i <= end.block; i++ {
+		text := e.blocks[i].runes()
+		from, to := 0, len(text)
+		if i == start.block {
+			from = start.offset
+		} else {
+			b.WriteByte('\n')
+		}
+		if i == end.block {
+			to = end.offset
+		}
+		b.WriteString(string(text[from:to]))
+	}
+	return b.String()
+}
+
+// SetMarkdown replaces the content of the editor with the result of parsing the markdown provided.
+//
+// Since: 2.3
+func (e *RichTextEntry) SetMarkdown(content string) {
+	e.blocks = richBlocksFromSegments(parseMarkdown(content))
+	e.cursor, e.anchor = richPosition{}, richPosition{}
+	e.pending = nil
+	if e.scroller != nil {
+		e.scroller.Offset = gui.Position{}
+	}
+	e.changed()
+}
+
+// Tapped is called when the editor is tapped and requests focus.
+//
+// Implements: gui.Tappable
+func (e *RichTextEntry) Tapped(*gui.PointEvent) {
+	focusCollection(e.super())
+}
+
+// ToggleBold makes the selected text bold, or normal weight if it is all bold already.
+//
+// Since: 2.3
+func (e *RichTextEntry) ToggleBold() {
+	e.toggleStyle(func(s *richSpan) *bool {
+		return &s.bold
+	})
+}
+
+// ToggleHeading makes the selected paragraphs headings of the given level, from 1 to 3.
+// If they are all headings of that level already, or the level is 0, they become normal paragraphs.
+//
+// Since: 2.3
+func (e *RichTextEntry) ToggleHeading(level int) {
+	if level > 3 {
+		level = 3
+	}
+	blocks := e.selectedBlocks()
+	all := level > 0
+	for _, b := range blocks {
+		if b.kind != richHeading || b.level != level {
+			all = false
+		}
+	}
+	for _, b := range blocks {
+		b.kind, b.level, b.ordered = richHeading, level, false
+		if all || level <= 0 {
+			b.kind, b.level = richParagraph, 0
+		}
+	}
+	e.changed()
+}
+
+// ToggleItalic makes the selected text italic, or upright if it is all italic already.
+//
+// Since: 2.3
+func (e *RichTextEntry) ToggleItalic() {
+	e.toggleStyle(func(s *richSpan) *bool {
+		return &s.italic
+	})
+}
+
+// ToggleLink removes any links from the selected text, or links it to the URL provided.
+// If the URL is nil the selected text is used as the link destination if it is an absolute URL.
+// With nothing selected the link at the cursor, if there is one, is removed.
+//
+// Since: 2.3
+func (e *RichTextEntry) ToggleLink(u *url.URL) {
+	start, end := e.selection()
+	if start == end {
+		e.removeLinkAt(start)
+		return
+	}
+
+	linked := false
+	e.eachSelectedSpan(func(s *richSpan) {
+		linked = linked || s.link != nil
+	})
+	if !linked && u == nil {
+		parsed, err := url.Parse(strings.TrimSpace(e.SelectedText()))
+		if err != nil || !parsed.IsAbs() {
+			return
+		}
+		u = parsed
+	}
+	e.eachSelectedSpan(func(s *richSpan) {
+		if linked {
+			s.link = nil
+		} else {
+			s.link = u
+		}
+	})
+	e.changed()
+}
+
+// ToggleList makes the selected paragraphs into list items, numbered if ordered is true.
+// If they are all items of that type of list already they become normal paragraphs.
+//
+// Since: 2.3
+func (e *RichTextEntry) ToggleList(ordered bool) {
+	blocks := e.selectedBlocks()
+	all := true
+	for _, b := range blocks {
+		if b.kind != richListItem || b.ordered != ordered {
+			all = false
+		}
+	}
+	for _, b := range blocks {
+		if all {
+			b.kind, b.level, b.ordered = richParagraph, 0, false
+			continue
+		}
+		if b.kind != richListItem {
+			b.level = 0
+		}
+		b.kind, b.ordered = richListItem, ordered
+	}
+	e.changed()
+}
+
+// TypedKey is called when a key is pressed, to move the cursor or edit the text.
+// Return starts a new paragraph or list item, and Tab changes the nesting of a list item.
+//
+// Implements: gui.Focusable
+func (e *RichTextEntry) TypedKey(key *gui.KeyEvent) {
+	extend := e.keys.shift
+	start, end := e.selection()
+	switch key.Name {
+	case gui.KeyLeft:
+		if start != end && !extend {
+			e.moveTo(start, false)
+		} else {
+			e.moveTo(e.step(e.cursor, -1), extend)
+		}
+	case gui.KeyRight:
+		if start != end && !extend {
+			e.moveTo(end, false)
+		} else {
+			e.moveTo(e.step(e.cursor, 1), extend)
+		}
+	case gui.KeyUp:
+		e.moveTo(e.verticalPosition(-1), extend)
+	case gui.KeyDown:
+		e.moveTo(e.verticalPosition(1), extend)
+	case gui.KeyHome:
+		e.moveTo(e.rowEdge(false), extend)
+	case gui.KeyEnd:
+		e.moveTo(e.rowEdge(true), extend)
+	case gui.KeyBackspace:
+		if start == end {
+			if start.offset == 0 && e.outdent(start.block) {
+				e.changed()
+				return
+			}
+			start = e.step(e.cursor, -1)
+		}
+		e.remove(start, end)
+	case gui.KeyDelete:
+		if start == end {
+			end = e.step(e.cursor, 1)
+		}
+		e.remove(start, end)
+	case gui.KeyReturn, gui.KeyEnter:
+		e.newBlock()
+	case gui.KeyTab:
+		b := e.blocks[e.cursor.block]
+		if b.kind != richListItem {
+			e.insert("\t")
+			return
+		}
+		if extend {
+			e.outdent(e.cursor.block)
+		} else if prev := e.cursor.block - 1; prev >= 0 && e.blocks[prev].kind == richListItem && e.blocks[prev].level >= b.level {
+			b.level++
+		}
+		e.changed()
+	}
+}
+
+// TypedRune is called when text is input, replacing the selection.
+//
+// Implements: gui.Focusable
+func (e *RichTextEntry) TypedRune(r rune) {
+	e.insert(string(r))
+}
+
+// TypedShortcut handles the clipboard and select all shortcuts as well as the formatting shortcuts.
+//
+// Implements: gui.Shortcutable
+func (e *RichTextEntry) TypedShortcut(shortcut gui.Shortcut) {
+	switch s := shortcut.(type) {
+	case *gui.ShortcutCopy:
+		if text := e.SelectedText(); text != "" {
+			s.Clipboard.SetContent(text)
+		}
+	case *gui.ShortcutCut:
+		if text := e.SelectedText(); text != "" {
+			s.Clipboard.SetContent(text)
+			e.insert("")
+		}
+	case *gui.ShortcutPaste:
+		e.insert(strings.ReplaceAll(s.Clipboard.Content(), "\r\n", "\n"))
+	case *gui.ShortcutSelectAll:
+		e.anchor = richPosition{}
+		last := len(e.blocks) - 1
+		e.moveTo(richPosition{block: last, offset: e.blocks[last].length()}, true)
+	case *desktop.CustomShortcut:
+		if s.Modifier == gui.KeyModifierShortcutDefault|gui.KeyModifierShift && s.KeyName == gui.KeyL {
+			e.ToggleList(true)
+			return
+		}
+		if s.Modifier != gui.KeyModifierShortcutDefault {
+			return
+		}
+		switch s.KeyName {
+		case gui.KeyB:
+			e.ToggleBold()
+		case gui.KeyI:
+			e.ToggleItalic()
+		case gui.KeyK:
+			e.ToggleLink(nil)
+		case gui.KeyL:
+			e.ToggleList(false)
+		case gui.Key0, gui.Key1, gui.Key2, gui.Key3:
+			e.ToggleHeading(int(s.KeyName[0] - '0'))
+		}
+	}
+}
+
+// changed updates the display after the content was edited and notifies OnChanged.
+func (e *RichTextEntry) changed() {
+	e.refreshText()
+	if f := e.OnChanged; f != nil {
+		f(e.Markdown())
+	}
+}
+
+// deleteRange removes the content between two positions, joining the blocks they are in.
+func (e *RichTextEntry) deleteRange(start, end richPosition) {
+	first, last := e.blocks[start.block], e.blocks[end.block]
+	head, _ := first.split(start.offset)
+	_, tail := last.split(end.offset)
+	if first.kind == richSeparator {
+		first.kind, first.level, first.ordered = last.kind, last.level, last.ordered
+	}
+	first.spans = mergeRichSpans(append(head, tail...))
+	e.blocks = append(e.blocks[:start.block+1], e.blocks[end.block+1:]...)
+}
+
+// eachSelectedSpan calls f for each span of text in the selection, splitting spans at its edges.
+func (e *RichTextEntry) eachSelectedSpan(f func(*richSpan)) {
+	start, end := e.selection()
+	for i := start.block; i <= end.block; i++ {
+		b := e.blocks[i]
+		from, to := 0, b.length()
+		if i == start.block {
+			from = start.offset
+		}
+		if i == end.block {
+			to = end.offset
+		}
+		before, rest := b.split(from)
+		b.spans = append(before, rest...)
+		before, rest = b.split(to)
+		b.spans = append(before, rest...)
+
+		offset := 0
+		for j := range b.spans {
+			length := len(b.spans[j].text)
+			if offset >= from && offset+length <= to && length > 0 {
+				f(&b.spans[j])
+			}
+			offset += length
+		}
+		b.spans = mergeRichSpans(b.spans)
+	}
+}
+
+func (e *RichTextEntry) insert(text string) {
+	start, end := e.selection()
+	style := e.styleAt(start)
+	if e.pending != nil {
+		style = *e.pending
+	}
+	if start != end {
+		e.deleteRange(start, end)
+	}
+
+	pos := start
+	if e.blocks[pos.block].kind == richSeparator {
+		pos = e.splitBlock(richPosition{block: pos.block})
+	}
+	lines := strings.Split(text, "\n")
+	if e.blocks[pos.block].kind == richCode {
+		lines = []string{text}
+	}
+	for i, line := range lines {
+		if i > 0 {
+			pos = e.splitBlock(pos)
+		}
+		pos = e.insertAt(pos, style, line)
+	}
+	e.cursor, e.anchor = pos, pos
+	e.pending = nil
+	e.changed()
+}
+
+// insertAt adds text with the given style at a position in a block and returns the position after it.
+func (e *RichTextEntry) insertAt(pos richPosition, style richSpan, text string) richPosition {
+	if text == "" {
+		return pos
+	}
+	b := e.blocks[pos.block]
+	before, after := b.split(pos.offset)
+	style.text = []rune(text)
+	b.spans = mergeRichSpans(append(append(before, style), after...))
+	pos.offset += len(style.text)
+	return pos
+}
+
+// moveTo moves the cursor, extending the selection from the anchor or clearing it.
+func (e *RichTextEntry) moveTo(p richPosition, extend bool) {
+	e.cursor = p
+	if !extend {
+		e.anchor = p
+	}
+	e.pending = nil
+	e.scrollToCursor()
+	e.refreshContent()
+}
+
+// newBlock handles the return key, which splits the current block in two.
+// In an empty list item or quote it returns to a normal paragraph instead, and in code it adds a line.
+func (e *RichTextEntry) newBlock() {
+	start, end := e.selection()
+	if start != end {
+		e.deleteRange(start, end)
+	}
+
+	pos := start
+	b := e.blocks[pos.block]
+	text := b.runes()
+	switch {
+	case len(text) == 0 && (b.kind == richListItem || b.kind == richQuote):
+		e.outdent(pos.block)
+	case b.kind == richCode && pos.offset == len(text) && len(text) > 0 && text[len(text)-1] == '\n':
+		b.spans, _ = b.split(len(text) - 1)
+		pos = e.splitBlock(richPosition{block: pos.block, offset: len(text) - 1})
+		e.blocks[pos.block].kind = richParagraph
+	case b.kind == richCode:
+		pos = e.insertAt(pos, richSpan{}, "\n")
+	default:
+		pos = e.splitBlock(pos)
+	}
+	e.cursor, e.anchor = pos, pos
+	e.pending = nil
+	e.changed()
+}
+
+// outdent reduces the nesting of a list item, or turns other formatted blocks into a paragraph.
+// It returns false if the block was a paragraph already.
+func (e *RichTextEntry) outdent(block int) bool {
+	b := e.blocks[block]
+	switch {
+	case b.kind == richParagraph || b.kind == richSeparator:
+		return false
+	case b.kind == richListItem && b.level > 0:
+		b.level--
+	default:
+		b.kind, b.level, b.ordered = richParagraph, 0, false
+	}
+	return true
+}
+
+func (e *RichTextEntry) refreshContent() {
+	if e.content != nil {
+		e.content.Refresh()
+	}
+}
+
+// refreshText rebuilds the segments displayed for the content of the editor.
+func (e *RichTextEntry) refreshText() {
+	if e.text == nil {
+		return
+	}
+
+	segs, leaves := richBlocksToSegments(e.blocks, true)
+	e.leaves = leaves
+	e.text.cacheLock.Lock()
+	e.text.visualCache = nil // the segments are new each time so cached visuals would never be used again
+	e.text.cacheLock.Unlock()
+	e.text.Segments = segs
+	e.text.Refresh()
+
+	e.scroller.Refresh()
+	e.content.Refresh()
+	e.scrollToCursor()
+}
+
+func (e *RichTextEntry) remove(start, end richPosition) {
+	if start == end {
+		return
+	}
+	e.deleteRange(start, end)
+	e.cursor, e.anchor = start, start
+	e.pending = nil
+	e.changed()
+}
+
+func (e *RichTextEntry) removeLinkAt(p richPosition) {
+	b := e.blocks[p.block]
+	offset := 0
+	for i, s := range b.spans {
+		length := len(s.text)
+		if s.link != nil && p.offset >= offset && p.offset <= offset+length {
+			b.spans[i].link = nil
+			b.spans = mergeRichSpans(b.spans)
+			e.changed()
+			return
+		}
+		offset += length
+	}
+}
+
+func (e *RichTextEntry) scrollToCursor() {
+	if e.scroller == nil {
+		return
+	}
+
+	pos, height, ok := e.caret(e.fragments(), e.cursor)
+	if !ok {
+		return
+	}
+	offset := e.scroller.Offset
+	if pos.Y < offset.Y {
+		offset.Y = pos.Y
+	} else if pos.Y+height > offset.Y+e.scroller.Size().Height {
+		offset.Y = pos.Y + height - e.scroller.Size().Height
+	}
+	if offset != e.scroller.Offset {
+		e.scroller.Offset = offset
+		e.scroller.Refresh()
+	}
+}
+
+// selectedBlocks returns the blocks that the selection touches, other than separators.
+func (e *RichTextEntry) selectedBlocks() []*richBlock {
+	start, end := e.selection()
+	var blocks []*richBlock
+	for _, b := range e.blocks[start.block : end.block+1] {
+		if b.kind != richSeparator {
+			blocks = append(blocks, b)
+		}
+	}
+	return blocks
+}
+
+// selection returns the start and end of the selected content in document order.
+func (e *RichTextEntry) selection() (richPosition, richPosition) {
+	if e.cursor.before(e.anchor) {
+		return e.cursor, e.anchor
+	}
+	return e.anchor, e.cursor
+}
+
+// splitBlock breaks a block in two at a position and returns the start of the new block.
+// The new block continues a list or quote, but text after a heading or separator is a paragraph.
+func (e *RichTextEntry) splitBlock(pos richPosition) richPosition {
+	b := e.blocks[pos.block]
+	before, after := b.split(pos.offset)
+	next := &richBlock{kind: b.kind, level: b.level, ordered: b.ordered, spans: after}
+	if b.kind == richSeparator || (b.kind == richHeading && len(after) == 0) {
+		next.kind, next.level = richParagraph, 0
+	}
+	b.spans = before
+
+	e.blocks = append(e.blocks, nil)
+	copy(e.blocks[pos.block+2:], e.blocks[pos.block+1:])
+	e.blocks[pos.block+1] = next
+	return richPosition{block: pos.block + 1}
+}
+
+// step returns the position one character before or after p, moving between blocks at their ends.
+func (e *RichTextEntry) step(p richPosition, dir int) richPosition {
+	p.offset += dir
+	if p.offset < 0 {
+		if p.block == 0 {
+			return richPosition{}
+		}
+		p.block--
+		p.offset = e.blocks[p.block].length()
+	} else if p.offset > e.blocks[p.block].length() {
+		if p.block == len(e.blocks)-1 {
+			p.offset--
+			return p
+		}
+		p.block++
+		p.offset = 0
+	}
+	return p
+}
+
+// styleAt returns the style for text inserted at a position, which continues the text before it.
+func (e *RichTextEntry) styleAt(p richPosition) richSpan {
+	offset := 0
+	for _, s := range e.blocks[p.block].spans {
+		length := len(s.text)
+		if p.offset == 0 || p.offset <= offset+length {
+			style := s
+			style.text = nil
+			if p.offset == offset+length {
+				style.link = nil // typing after a link does not extend it
+			}
+			return style
+		}
+		offset += length
+	}
+	return richSpan{}
+}
+
+func (e *RichTextEntry) toggleStyle(field func(*richSpan) *bool) {
+	start, end := e.selection()
+	if start == end {
+		style := e.styleAt(start)
+		if e.pending != nil {
+			style = *e.pending
+		}
+		*field(&style) = !*field(&style)
+		e.pending = &style
+		return
+	}
+
+	all := true
+	e.eachSelectedSpan(func(s *richSpan) {
+		all = all && *field(s)
+	})
+	e.eachSelectedSpan(func(s *richSpan) {
+		*field(s) = !all
+	})
+	e.changed()
+}
+
+// verticalPosition returns the position in the row of text above (dir < 0) or below the cursor.
+func (e *RichTextEntry) verticalPosition(dir int) richPosition {
+	frags := e.fragments()
+	at, height, ok := e.caret(frags, e.cursor)
+	if !ok {
+		return e.cursor
+	}
+
+	var target *richFragment
+	for i := range frags {
+		f := &frags[i]
+		top, bottom := f.obj.Position().Y, f.obj.Position().Y+f.obj.Size().Height
+		if dir < 0 && bottom <= at.Y+0.5 && (target == nil || bottom > target.obj.Position().Y+target.obj.Size().Height) {
+			target = f
+		} else if dir > 0 && top >= at.Y+height-0.5 && (target == nil || top < target.obj.Position().Y) {
+			target = f
+		}
+	}
+	if target == nil {
+		if dir < 0 {
+			return richPosition{}
+		}
+		last := len(e.blocks) - 1
+		return richPosition{block: last, offset: e.blocks[last].length()}
+	}
+	return e.positionIn(frags, gui.NewPos(at.X, target.obj.Position().Y+target.obj.Size().Height/2))
+}
+
+// rowEdge returns the position at the start or end of the displayed row that contains the cursor.
+func (e *RichTextEntry) rowEdge(end bool) richPosition {
+	frags := e.fragments()
+	at, height, ok := e.caret(frags, e.cursor)
+	if !ok {
+		return e.cursor
+	}
+	x := float32(-1)
+	if end {
+		x = e.text.Size().Width + 1
+	}
+	return e.positionIn(frags, gui.NewPos(x, at.Y+height/2))
+}
+
+// richFragment is a displayed object that shows the text of a block between two offsets.
+type richFragment struct {
+	obj        gui.CanvasObject
+	block      int
+	start, end int
+	text       []rune
+	size       float32
+	style      gui.TextStyle
+}
+
+// offsetAt returns the offset in the block of the character boundary closest to a horizontal position.
+func (f *richFragment) offsetAt(x float32) int {
+	x -= f.obj.Position().X
+	prev := float32(0)
+	for i := 1; i <= len(f.text); i++ {
+		width := gui.MeasureText(string(f.text[:i]), f.size, f.style).Width
+		if width >= x {
+			if x-prev < width-x {
+				return f.start + i - 1
+			}
+			return f.start + i
+		}
+		prev = width
+	}
+	return f.end
+}
+
+func (f *richFragment) x(offset int) float32 {
+	return f.obj.Position().X + gui.MeasureText(string(f.text[:offset-f.start]), f.size, f.style).Width
+}
+
+// caret returns the top left position and the height of the cursor when it is at p.
+func (e *RichTextEntry) caret(frags []richFragment, p richPosition) (gui.Position, float32, bool) {
+	var found *richFragment
+	for i := range frags {
+		f := &frags[i]
+		if f.block != p.block || p.offset < f.start || p.offset > f.end {
+			continue
+		}
+		found = f
+		if p.offset < f.end { // at a wrapped line end prefer the start of the next row
+			break
+		}
+	}
+	if found == nil {
+		return gui.Position{}, 0, false
+	}
+	return gui.NewPos(found.x(p.offset), found.obj.Position().Y), found.obj.Size().Height, true
+}
+
+// fragments matches the objects that display the text to the blocks that they show.
+func (e *RichTextEntry) fragments() []richFragment {
+	if e.text == nil {
+		return nil
+	}
+	objs := cache.Renderer(e.text).Objects()
+	e.text.propertyLock.RLock()
+	bounds := e.text.rowBounds
+	e.text.propertyLock.RUnlock()
+
+	var frags []richFragment
+	i := 0
+	for _, bound := range bounds {
+		for segI, seg := range bound.segments {
+			if i >= len(objs) {
+				return frags
+			}
+			obj := objs[i]
+			i++
+			at, ok := e.leaves[seg]
+			if !ok {
+				continue // list bullets are not part of the content
+			}
+
+			f := richFragment{obj: obj, block: at.block, start: at.offset, end: at.offset}
+			if text, ok := seg.(*TextSegment); ok {
+				runes := []rune(text.Text)
+				from, to := 0, len(runes)
+				if segI == 0 {
+					from = bound.begin
+					if len(bound.segments) == 1 {
+						to = bound.end
+					}
+				} else if segI == len(bound.segments)-1 {
+					to = bound.end
+				}
+				if to > len(runes) {
+					to = len(runes)
+				}
+				if from > to {
+					from = to
+				}
+				f.text, f.start, f.end = runes[from:to], at.offset+from, at.offset+to
+				f.size, f.style = text.size(), text.Style.TextStyle
+			}
+			frags = append(frags, f)
+		}
+	}
+	return frags
+}
+
+// positionIn returns the content position closest to a point in the displayed text.
+func (e *RichTextEntry) positionIn(frags []richFragment, pos gui.Position) richPosition {
+	if len(frags) == 0 {
+		return e.cursor
+	}
+
+	distance := func(f *richFragment) float32 {
+		top := f.obj.Position().Y
+		bottom := top + f.obj.Size().Height
+		if pos.Y < top {
+			return top - pos.Y
+		} else if pos.Y >= bottom {
+			return pos.Y - bottom
+		}
+		return 0
+	}
+	row := &frags[0]
+	for i := range frags {
+		if distance(&frags[i]) < distance(row) {
+			row = &frags[i]
+		}
+	}
+
+	top, bottom := row.obj.Position().Y, row.obj.Position().Y+row.obj.Size().Height
+	var best *richFragment
+	for i := range frags {
+		f := &frags[i]
+		if f.obj.Position().Y >= bottom || f.obj.Position().Y+f.obj.Size().Height <= top {
+			continue
+		}
+		if best == nil || pos.X >= f.obj.Position().X {
+			best = f
+		}
+	}
+	return richPosition{block: best.block, offset: best.offsetAt(pos.X)}
+}
+
+func (b *richBlock) length() int {
+	length := 0
+	for _, s := range b.spans {
+		length += len(s.text)
+	}
+	return length
+}
+
+func (b *richBlock) runes() []rune {
+	var text []rune
+	for _, s := range b.spans {
+		text = append(text, s.text...)
+	}
+	return text
+}
+
+// split returns copies of the spans of a block before and after an offset.
+func (b *richBlock) split(offset int) (before, after []richSpan) {
+	for _, s := range b.spans {
+		length := len(s.text)
+		switch {
+		case offset <= 0:
+			after = append(after, s)
+		case offset >= length:
+			before = append(before, s)
+		default:
+			head, tail := s, s
+			head.text = append([]rune{}, s.text[:offset]...)
+			tail.text = append([]rune{}, s.text[offset:]...)
+			before = append(before, head)
+			after = append(after, tail)
+		}
+		offset -= length
+	}
+	return before, after
+}
+
+func (s richSpan) sameStyle(o richSpan) bool {
+	if s.bold != o.bold || s.italic != o.italic || s.code != o.code {
+		return false
+	}
+	if s.link == nil || o.link == nil {
+		return s.link == o.link
+	}
+	return s.link.String() == o.link.String()
+}
+
+// segment returns the segment that shows this span, links are displayed as text when editing.
+func (s richSpan) segment(editing bool) RichTextSegment {
+	if s.link != nil && !editing {
+		return &HyperlinkSegment{Text: string(s.text), URL: s.link}
+	}
+
+	style := RichTextStyleInline
+	if s.code {
+		style = RichTextStyleCodeInline
+	} else {
+		style.TextStyle.Bold, style.TextStyle.Italic = s.bold, s.italic
+	}
+	if s.link != nil {
+		style.ColorName = theme.ColorNamePrimary
+	}
+	return &TextSegment{Style: style, Text: string(s.text)}
+}
+
+// mergeRichSpans removes empty spans and joins neighbouring spans that have the same style.
+func mergeRichSpans(spans []richSpan) []richSpan {
+	var merged []richSpan
+	for _, s := range spans {
+		if len(s.text) == 0 {
+			continue
+		}
+		if last := len(merged) - 1; last >= 0 && merged[last].sameStyle(s) {
+			merged[last].text = append(append([]rune{}, merged[last].text...), s.text...)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
+
+// richBlocksToSegments creates the segments that display the blocks of a RichTextEntry,
+// along with where each segment that shows text is located in the blocks.
+// Every block ends with a segment, which may be empty, that the cursor can be placed after.
+func richBlocksToSegments(blocks []*richBlock, editing bool) ([]RichTextSegment, map[RichTextSegment]richPosition) {
+	leaves := make(map[RichTextSegment]richPosition)
+	inline := func(index int, b *richBlock) []RichTextSegment {
+		var segs []RichTextSegment
+		offset := 0
+		for _, s := range b.spans {
+			seg := s.segment(editing)
+			leaves[seg] = richPosition{block: index, offset: offset}
+			segs = append(segs, seg)
+			offset += len(s.text)
+		}
+		return segs
+	}
+	type openList struct {
+		list  *ListSegment
+		level int
+	}
+
+	var segs []RichTextSegment
+	var lists []openList
+	for i, b := range blocks {
+		if b.kind != richListItem {
+			lists = nil
+		}
+
+		var seg RichTextSegment
+		switch b.kind {
+		case richHeading, richQuote, richCode:
+			style := RichTextStyleBlockquote
+			switch {
+			case b.kind == richCode:
+				style = RichTextStyleCodeBlock
+			case b.kind == richHeading && b.level <= 1:
+				style = RichTextStyleHeading
+			case b.kind == richHeading && b.level == 2:
+				style = RichTextStyleSubHeading
+			case b.kind == richHeading:
+				style = RichTextStyleParagraph
+				style.TextStyle.Bold = true
+			}
+			seg = &TextSegment{Style: style, Text: string(b.runes())}
+			segs = append(segs, seg)
+		case richSeparator:
+			seg = &SeparatorSegment{}
+			segs = append(segs, seg)
+		case richListItem:
+			seg = &TextSegment{Style: RichTextStyleInline}
+			item := &ParagraphSegment{Texts: append(inline(i, b), seg)}
+			for len(lists) > 0 && lists[len(lists)-1].level > b.level {
+				lists = lists[:len(lists)-1]
+			}
+			if len(lists) > 0 && lists[len(lists)-1].level == b.level && lists[len(lists)-1].list.Ordered != b.ordered {
+				lists = lists[:len(lists)-1]
+			}
+			if len(lists) == 0 || lists[len(lists)-1].level < b.level {
+				list := &ListSegment{Ordered: b.ordered}
+				if len(lists) == 0 {
+					segs = append(segs, list)
+				} else {
+					parent := lists[len(lists)-1].list
+					item := parent.Items[len(parent.Items)-1].(*ParagraphSegment)
+					item.Texts = append(item.Texts, list)
+				}
+				lists = append(lists, openList{list: list, level: b.level})
+			}
+			top := lists[len(lists)-1].list
+			top.Items = append(top.Items, item)
+		default:
+			segs = append(segs, inline(i, b)...)
+			seg = &TextSegment{Style: RichTextStyleParagraph}
+			segs = append(segs, seg)
+		}
+		if _, ok := leaves[seg]; !ok {
+			offset := 0
+			if b.kind == richParagraph || b.kind == richListItem {
+				offset = b.length()
+			}
+			leaves[seg] = richPosition{block: i, offset: offset}
+		}
+	}
+	return segs, leaves
+}
+
+// richBlocksFromSegments converts rich text segments, such as those parsed from markdown, to editor blocks.
+func richBlocksFromSegments(segs []RichTextSegment) []*richBlock {
+	r := &richBlockReader{}
+	r.read(segs)
+	r.endParagraph()
+	if len(r.blocks) == 0 {
+		return []*richBlock{{}}
+	}
+	return r.blocks
+}
+
+// richBlockReader collects the blocks of content for a RichTextEntry from a list of segments.
+type richBlockReader struct {
+	blocks []*richBlock
+	spans  []richSpan
+	prev   RichTextSegment
+}
+
+func (r *richBlockReader) add(kind richBlockKind, level int, text string) {
+	r.endParagraph()
+	r.blocks = append(r.blocks, &richBlock{kind: kind, level: level, spans: mergeRichSpans([]richSpan{{text: []rune(text)}})})
+}
+
+func (r *richBlockReader) addText(text string, style gui.TextStyle) {
+	r.spans = append(r.spans, richSpan{text: []rune(text), bold: style.Bold, italic: style.Italic, code: style.Monospace})
+}
+
+func (r *richBlockReader) endParagraph() {
+	spans := mergeRichSpans(r.spans)
+	r.spans = nil
+	if len(spans) > 0 {
+		r.blocks = append(r.blocks, &richBlock{spans: spans})
+	}
+}
+
+func (r *richBlockReader) read(segs []RichTextSegment) {
+	for _, seg := range segs {
+		if seg == r.prev { // the same segment can be listed twice where text continues across lines
+			continue
+		}
+		r.prev = seg
+
+		switch s := seg.(type) {
+		case *TextSegment:
+			style := s.Style
+			switch {
+			case style.Inline:
+				r.addText(s.Text, style.TextStyle)
+			case style.TextStyle.Monospace:
+				r.add(richCode, 0, s.Text)
+			case style == RichTextStyleBlockquote:
+				r.add(richQuote, 0, s.Text)
+			case style.SizeName == theme.SizeNameHeadingText:
+				r.add(richHeading, 1, s.Text)
+			case style.SizeName == theme.SizeNameSubHeadingText:
+				r.add(richHeading, 2, s.Text)
+			case style.TextStyle.Bold && !style.TextStyle.Italic && len(r.spans) == 0:
+				r.add(richHeading, 3, s.Text)
+			default:
+				r.addText(s.Text, style.TextStyle)
+				r.endParagraph()
+			}
+		case *HyperlinkSegment:
+			r.spans = append(r.spans, richSpan{text: []rune(s.Text), link: s.URL})
+		case *SeparatorSegment:
+			r.endParagraph()
+			r.blocks = append(r.blocks, &richBlock{kind: richSeparator})
+		case *ListSegment:
+			r.endParagraph()
+			r.readList(s, 0)
+		case *ParagraphSegment:
+			r.endParagraph()
+			r.read(s.Texts)
+			r.endParagraph()
+		default:
+			if !seg.Inline() {
+				r.endParagraph()
+			}
+			r.addText(seg.Textual(), gui.TextStyle{})
+			if !seg.Inline() {
+				r.endParagraph()
+			}
+		}
+	}
+}
+
+func (r *richBlockReader) readList(list *ListSegment, level int) {
+	for _, item := range list.Items {
+		texts := []RichTextSegment{item}
+		if para, ok := item.(*ParagraphSegment); ok {
+			texts = para.Texts
+		}
+
+		content := &richBlockReader{}
+		var nested []*ListSegment
+		for _, seg := range texts {
+			if sub, ok := seg.(*ListSegment); ok {
+				nested = append(nested, sub)
+				continue
+			}
+			content.read([]RichTextSegment{seg})
+		}
+		content.endParagraph()
+
+		b := &richBlock{kind: richListItem, level: level, ordered: list.Ordered}
+		for _, block := range content.blocks {
+			b.spans = append(b.spans, block.spans...)
+		}
+		b.spans = mergeRichSpans(b.spans)
+		r.blocks = append(r.blocks, b)
+		for _, sub := range nested {
+			r.readList(sub, level+1)
+		}
+	}
+}
+
+type richTextEntryRenderer struct {
+	widget.BaseRenderer
+	entry      *RichTextEntry
+	background *canvas.Rectangle
+}
+
+func (r *richTextEntryRenderer) Layout(size gui.Size) {
+	r.background.Resize(size)
+	r.entry.scroller.Resize(size)
+}
+
+func (r *richTextEntryRenderer) MinSize() gui.Size {
+	return r.entry.scroller.MinSize()
+}
+
+func (r *richTextEntryRenderer) Refresh() {
+	r.background.FillColor = theme.InputBackgroundColor()
+	r.background.Refresh()
+	r.entry.refreshText()
+}
+
+// richTextEntryContent shows the text of a RichTextEntry with the selection and cursor inside its scroller.
+type richTextEntryContent struct {
+	BaseWidget
+	entry    *RichTextEntry
+	dragging bool
+}
+
+func (c *richTextEntryContent) CreateRenderer() gui.WidgetRenderer {
+	cursor := canvas.NewRectangle(theme.PrimaryColor())
+	return &richTextEntryContentRenderer{content: c, cursor: cursor}
+}
+
+func (c *richTextEntryContent) DragEnd() {
+	c.dragging = false
+	if gui.CurrentDevice().IsMobile() {
+		c.entry.scroller.DragEnd()
+	}
+}
+
+func (c *richTextEntryContent) Dragged(ev *gui.DragEvent) {
+	if gui.CurrentDevice().IsMobile() {
+		c.entry.scroller.Dragged(ev)
+		return
+	}
+
+	frags := c.entry.fragments()
+	if !c.dragging {
+		c.dragging = true
+		c.entry.anchor = c.entry.positionIn(frags, ev.Position.Subtract(ev.Dragged))
+		focusCollection(c.entry.super())
+	}
+	c.entry.moveTo(c.entry.positionIn(frags, ev.Position), true)
+}
+
+func (c *richTextEntryContent) MinSize() gui.Size {
+	c.ExtendBaseWidget(c)
+	return c.BaseWidget.MinSize()
+}
+
+func (c *richTextEntryContent) Tapped(ev *gui.PointEvent) {
+	focusCollection(c.entry.super())
+	c.entry.moveTo(c.entry.positionIn(c.entry.fragments(), ev.Position), c.entry.keys.shift)
+}
+
+type richTextEntryContentRenderer struct {
+	content *richTextEntryContent
+	cursor  *canvas.Rectangle
+	rects   []*canvas.Rectangle
+	objects []gui.CanvasObject
+}
+
+func (r *richTextEntryContentRenderer) Destroy() {
+}
+
+func (r *richTextEntryContentRenderer) Layout(size gui.Size) {
+	r.content.entry.text.Resize(size)
+	r.Refresh()
+}
+
+func (r *richTextEntryContentRenderer) MinSize() gui.Size {
+	return r.content.entry.text.MinSize()
+}
+
+func (r *richTextEntryContentRenderer) Objects() []gui.CanvasObject {
+	return r.objects
+}
+
+// Refresh positions the selection highlights and the cursor over the text.
+func (r *richTextEntryContentRenderer) Refresh() {
+	e := r.content.entry
+	frags := e.fragments()
+	start, end := e.selection()
+	rects := 0
+	r.objects = r.objects[:0]
+	for i := range frags {
+		f := &frags[i]
+		if start == end || f.block < start.block || f.block > end.block {
+			continue
+		}
+		from, to := f.start, f.end
+		if f.block == start.block && start.offset > from {
+			from = start.offset
+		}
+		if f.block == end.block && end.offset < to {
+			to = end.offset
+		}
+		if from >= to && (f.text != nil || f.block == start.block || f.block == end.block) {
+			continue
+		}
+
+		if rects == len(r.rects) {
+			r.rects = append(r.rects, canvas.NewRectangle(theme.SelectionColor()))
+		}
+		rect := r.rects[rects]
+		rects++
+		rect.FillColor = theme.SelectionColor()
+		if f.text == nil {
+			rect.Move(f.obj.Position())
+			rect.Resize(f.obj.Size())
+		} else {
+			rect.Move(gui.NewPos(f.x(from), f.obj.Position().Y))
+			rect.Resize(gui.NewSize(f.x(to)-f.x(from), f.obj.Size().Height))
+		}
+		rect.Refresh()
+		r.objects = append(r.objects, rect)
+	}
+	r.objects = append(r.objects, e.text)
+
+	if pos, height, ok := e.caret(frags, e.cursor); ok && e.focused {
+		r.cursor.FillColor = theme.PrimaryColor()
+		r.cursor.Move(pos)
+		r.cursor.Resize(gui.NewSize(theme.InputBorderSize(), height))
+		r.cursor.Refresh()
+		r.objects = append(r.objects, r.cursor)
+	}
+	canvas.Refresh(r.content)
+}
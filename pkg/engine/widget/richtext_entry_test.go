@@ -0,0 +1,213 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"net/url"
+	"testing"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRichTextEntry(content string) (*RichTextEntry, gui.Window) {
+	e := NewRichTextEntryFromMarkdown(content)
+	w := test.NewWindow(e)
+	w.Resize(gui.NewSize(300, 200))
+	w.Canvas().Focus(e)
+	return e, w
+}
+
+func typeRichTextKeys(e *RichTextEntry, keys ...gui.KeyName) {
+	for _, key := range keys {
+		e.TypedKey(&gui.KeyEvent{Name: key})
+	}
+}
+
+func selectRichText(e *RichTextEntry, keys ...gui.KeyName) {
+	e.KeyDown(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+	typeRichTextKeys(e, keys...)
+	e.KeyUp(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+}
+
+func typeRichTextShortcut(e *RichTextEntry, key gui.KeyName, mods gui.KeyModifier) {
+	e.TypedShortcut(&desktop.CustomShortcut{KeyName: key, Modifier: gui.KeyModifierShortcutDefault | mods})
+}
+
+func TestRichTextEntry_Typing(t *testing.T) {
+	e, w := newTestRichTextEntry("")
+	defer w.Close()
+	changed := ""
+	e.OnChanged = func(s string) {
+		changed = s
+	}
+
+	test.Type(e, "Hello")
+	typeRichTextKeys(e, gui.KeyReturn)
+	test.Type(e, "world")
+	assert.Equal(t, "Hello\n\nworld", e.Markdown())
+	assert.Equal(t, "Hello\n\nworld", changed)
+
+	typeRichTextKeys(e, gui.KeyHome, gui.KeyBackspace)
+	assert.Equal(t, "Helloworld", e.Markdown())
+	typeRichTextKeys(e, gui.KeyLeft, gui.KeyDelete, gui.KeyDelete)
+	assert.Equal(t, "Hellorld", e.Markdown())
+	assert.Equal(t, richPosition{offset: 4}, e.cursor)
+}
+
+func TestRichTextEntry_Bold(t *testing.T) {
+	e, w := newTestRichTextEntry("one two")
+	defer w.Close()
+
+	typeRichTextKeys(e, gui.KeyEnd)
+	selectRichText(e, gui.KeyLeft, gui.KeyLeft, gui.KeyLeft)
+	assert.Equal(t, "two", e.SelectedText())
+	typeRichTextShortcut(e, gui.KeyB, 0)
+	assert.Equal(t, "one **two**", e.Markdown())
+
+	// typing at the end continues the style
+	typeRichTextKeys(e, gui.KeyEnd)
+	test.Type(e, "s")
+	assert.Equal(t, "one **twos**", e.Markdown())
+
+	// toggling with nothing selected applies to the text typed next
+	typeRichTextShortcut(e, gui.KeyB, 0)
+	typeRichTextShortcut(e, gui.KeyI, 0)
+	test.Type(e, " three")
+	assert.Equal(t, "one **twos** *three*", e.Markdown())
+
+	e.TypedShortcut(&gui.ShortcutSelectAll{})
+	e.ToggleBold()
+	assert.Equal(t, "**one twos** ***three***", e.Markdown())
+	e.ToggleBold()
+	assert.Equal(t, "one twos *three*", e.Markdown())
+}
+
+func TestRichTextEntry_Blocks(t *testing.T) {
+	e, w := newTestRichTextEntry("Title\n\nitem")
+	defer w.Close()
+
+	typeRichTextShortcut(e, gui.Key1, 0)
+	assert.Equal(t, "# Title\n\nitem", e.Markdown())
+	typeRichTextShortcut(e, gui.Key2, 0)
+	assert.Equal(t, "## Title\n\nitem", e.Markdown())
+	typeRichTextShortcut(e, gui.Key2, 0)
+	assert.Equal(t, "Title\n\nitem", e.Markdown())
+	typeRichTextShortcut(e, gui.Key3, 0)
+
+	typeRichTextKeys(e, gui.KeyDown)
+	assert.Equal(t, 1, e.cursor.block)
+	typeRichTextShortcut(e, gui.KeyL, 0)
+	typeRichTextKeys(e, gui.KeyEnd, gui.KeyReturn)
+	test.Type(e, "next")
+	typeRichTextKeys(e, gui.KeyReturn, gui.KeyTab)
+	test.Type(e, "nested")
+	assert.Equal(t, "### Title\n\n- item\n- next\n  - nested", e.Markdown())
+
+	typeRichTextShortcut(e, gui.KeyL, gui.KeyModifierShift)
+	assert.Equal(t, "### Title\n\n- item\n- next\n  1. nested", e.Markdown())
+
+	// return in an empty item leaves the list
+	typeRichTextKeys(e, gui.KeyReturn, gui.KeyReturn, gui.KeyReturn)
+	test.Type(e, "after")
+	assert.Equal(t, "### Title\n\n- item\n- next\n  1. nested\n\nafter", e.Markdown())
+
+	// backspace at the start of a list item makes it a paragraph
+	typeRichTextKeys(e, gui.KeyUp, gui.KeyUp, gui.KeyHome, gui.KeyBackspace)
+	assert.Equal(t, "### Title\n\n- item\n\nnext\n\n1. nested\n\nafter", e.Markdown())
+}
+
+func TestRichTextEntry_Link(t *testing.T) {
+	e, w := newTestRichTextEntry("see https://example.com")
+	defer w.Close()
+
+	typeRichTextKeys(e, gui.KeyEnd)
+	selectRichText(e, gui.KeyHome)
+	typeRichTextShortcut(e, gui.KeyK, 0)
+	assert.Equal(t, "see https://example.com", e.Markdown(), "not a URL")
+
+	typeRichTextKeys(e, gui.KeyHome, gui.KeyRight, gui.KeyRight, gui.KeyRight, gui.KeyRight)
+	selectRichText(e, gui.KeyEnd)
+	typeRichTextShortcut(e, gui.KeyK, 0)
+	assert.Equal(t, "see [https://example.com](https://example.com)", e.Markdown())
+
+	typeRichTextKeys(e, gui.KeyEnd)
+	test.Type(e, "!")
+	assert.Equal(t, "see [https://example.com](https://example.com)!", e.Markdown())
+
+	typeRichTextKeys(e, gui.KeyLeft, gui.KeyLeft)
+	e.ToggleLink(nil)
+	assert.Equal(t, "see https://example.com!", e.Markdown())
+
+	u, _ := url.Parse("https://bhojpur.net")
+	typeRichTextKeys(e, gui.KeyHome)
+	selectRichText(e, gui.KeyRight, gui.KeyRight, gui.KeyRight)
+	e.ToggleLink(u)
+	assert.Equal(t, "[see](https://bhojpur.net) https://example.com!", e.Markdown())
+}
+
+func TestRichTextEntry_Markdown(t *testing.T) {
+	content := "# Heading\n\nSome *emphasis*, **strong** and `code`.\n\n---\n\n> quoted\n\n" +
+		"1. one\n2. two\n   - nested\n\n```\nfunc main() {}\n```"
+	e, w := newTestRichTextEntry(content)
+	defer w.Close()
+	assert.Equal(t, content, e.Markdown())
+
+	e.SetMarkdown("plain")
+	assert.Equal(t, "plain", e.Markdown())
+	assert.Equal(t, richPosition{}, e.cursor)
+}
+
+func TestRichTextEntry_Selection(t *testing.T) {
+	e, w := newTestRichTextEntry("abcde\n\nabcde")
+	defer w.Close()
+	clipboard := test.NewClipboard()
+
+	typeRichTextKeys(e, gui.KeyRight, gui.KeyRight)
+	selectRichText(e, gui.KeyDown)
+	assert.Equal(t, "cde\nab", e.SelectedText())
+	e.TypedShortcut(&gui.ShortcutCut{Clipboard: clipboard})
+	assert.Equal(t, "abcde", e.Markdown())
+
+	e.TypedShortcut(&gui.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "abcde\n\nabcde", e.Markdown())
+	assert.Equal(t, richPosition{block: 1, offset: 2}, e.cursor)
+
+	// tapping places the cursor
+	frags := e.fragments()
+	first := frags[0]
+	e.content.Tapped(&gui.PointEvent{Position: first.obj.Position().Add(gui.NewPos(first.x(first.start+3)-first.obj.Position().X, 2))})
+	assert.Equal(t, richPosition{offset: 3}, e.cursor)
+}
+
+func TestRichTextEntry_Separator(t *testing.T) {
+	e, w := newTestRichTextEntry("above\n\n---\n\nbelow")
+	defer w.Close()
+
+	typeRichTextKeys(e, gui.KeyDown, gui.KeyDown)
+	assert.Equal(t, richPosition{block: 2}, e.cursor)
+	typeRichTextKeys(e, gui.KeyBackspace)
+	assert.Equal(t, "above\n\nbelow", e.Markdown())
+	assert.Equal(t, richPosition{block: 1}, e.cursor)
+}
@@ -21,12 +21,18 @@ package widget
 // THE SOFTWARE.
 
 import (
+	"bytes"
+	"image"
 	"image/color"
+	"io"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/storage"
 	"github.com/bhojpur/gui/pkg/engine/theme"
 )
 
@@ -121,6 +127,59 @@ var (
 	}
 )
 
+// CheckBoxSegment represents the check box at the start of an item in a task list.
+//
+// Since: 2.3
+type CheckBoxSegment struct {
+	Checked bool
+}
+
+// Inline returns true as a check box is shown at the start of the text that follows it.
+func (c *CheckBoxSegment) Inline() bool {
+	return true
+}
+
+// Textual returns the markdown representation of the check box.
+func (c *CheckBoxSegment) Textual() string {
+	if c.Checked {
+		return "[x] "
+	}
+	return "[ ] "
+}
+
+// Visual returns the icon required to render this check box.
+func (c *CheckBoxSegment) Visual() gui.CanvasObject {
+	icon := canvas.NewImageFromResource(nil)
+	icon.FillMode = canvas.ImageFillContain
+	c.Update(icon)
+	return icon
+}
+
+// Update applies the checked state of this segment to an existing visual.
+func (c *CheckBoxSegment) Update(o gui.CanvasObject) {
+	icon := o.(*canvas.Image)
+	icon.Resource = theme.CheckButtonIcon()
+	if c.Checked {
+		icon.Resource = theme.CheckButtonCheckedIcon()
+	}
+	size := theme.TextSize()
+	icon.SetMinSize(gui.NewSize(size+theme.Padding(), size))
+	icon.Refresh()
+}
+
+// Select does nothing for a check box.
+func (c *CheckBoxSegment) Select(_, _ gui.Position) {
+}
+
+// SelectedText returns the empty string for this check box.
+func (c *CheckBoxSegment) SelectedText() string {
+	return ""
+}
+
+// Unselect does nothing for a check box.
+func (c *CheckBoxSegment) Unselect() {
+}
+
 // HyperlinkSegment represents a hyperlink within a rich text widget.
 //
 // Since: 2.1
@@ -172,6 +231,70 @@ func (h *HyperlinkSegment) Unselect() {
 	// no-op: this will be added when we progress to editor
 }
 
+// ImageSegment represents an image within a rich text widget, loaded from a storage URI.
+// The image is shown at its original size unless it is wider than the text.
+//
+// Since: 2.3
+type ImageSegment struct {
+	Alignment gui.TextAlign
+	Source    gui.URI
+	Title     string
+}
+
+// Inline returns false as an image is displayed as a block.
+func (i *ImageSegment) Inline() bool {
+	return false
+}
+
+// Textual returns the title of this image.
+func (i *ImageSegment) Textual() string {
+	return i.Title
+}
+
+// Visual returns the image element required to render this segment.
+func (i *ImageSegment) Visual() gui.CanvasObject {
+	box := &gui.Container{Layout: &richImageLayout{}}
+	i.Update(box)
+	return box
+}
+
+// Update starts loading the image source into an existing visual if it has changed, and applies the alignment.
+// The image is read in the background and the visual is refreshed once it arrives.
+func (i *ImageSegment) Update(o gui.CanvasObject) {
+	box := o.(*gui.Container)
+	l := box.Layout.(*richImageLayout)
+	l.lock.Lock()
+	l.align = i.Alignment
+	changed := len(box.Objects) == 0 || l.source != i.Source
+	if changed {
+		l.size = gui.Size{}
+		l.source = i.Source
+	}
+	l.lock.Unlock()
+
+	if changed {
+		img := &canvas.Image{FillMode: canvas.ImageFillContain}
+		box.Objects = []gui.CanvasObject{img}
+		if i.Source != nil {
+			go l.load(i.Source, img, box)
+		}
+	}
+	box.Refresh()
+}
+
+// Select does nothing for an image.
+func (i *ImageSegment) Select(_, _ gui.Position) {
+}
+
+// SelectedText returns the empty string for this image.
+func (i *ImageSegment) SelectedText() string {
+	return ""
+}
+
+// Unselect does nothing for an image.
+func (i *ImageSegment) Unselect() {
+}
+
 // ListSegment includes an itemised list with the content set using the Items field.
 //
 // Since: 2.1
@@ -195,6 +318,13 @@ func (l *ListSegment) Segments() []RichTextSegment {
 		}
 		bullet := &TextSegment{Text: txt + " ", Style: RichTextStyleStrong}
 		if para, ok := in.(*ParagraphSegment); ok {
+			if len(para.Texts) > 0 {
+				if _, ok := para.Texts[0].(*CheckBoxSegment); ok { // task items show a check box instead
+					out[i] = para
+					continue
+				}
+			}
+
 			seg := &ParagraphSegment{Texts: []RichTextSegment{bullet}}
 			seg.Texts = append(seg.Texts, para.Texts...)
 			out[i] = seg
@@ -320,6 +450,68 @@ func (s *SeparatorSegment) SelectedText() string {
 func (s *SeparatorSegment) Unselect() {
 }
 
+// TableSegment represents a table of rich text, with an optional heading row.
+// Each cell is a segment, usually a ParagraphSegment that holds the text of the cell.
+//
+// Since: 2.3
+type TableSegment struct {
+	Header []RichTextSegment
+	Rows   [][]RichTextSegment
+}
+
+// Inline returns false as a table is displayed as a block.
+func (t *TableSegment) Inline() bool {
+	return false
+}
+
+// Textual returns the content of the table with cells separated by tabs and rows by newlines.
+func (t *TableSegment) Textual() string {
+	var lines []string
+	for _, row := range append([][]RichTextSegment{t.Header}, t.Rows...) {
+		if len(row) == 0 {
+			continue
+		}
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = richTextSegmentText(cell)
+		}
+		lines = append(lines, strings.Join(cells, "\t"))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Visual returns the widget required to render this table.
+func (t *TableSegment) Visual() gui.CanvasObject {
+	table := &richTable{}
+	table.ExtendBaseWidget(table)
+	t.Update(table)
+	return table
+}
+
+// Update applies the content of this table to an existing visual.
+func (t *TableSegment) Update(o gui.CanvasObject) {
+	table := o.(*richTable)
+	table.header = richTableRow(richTableHeader(t.Header))
+	table.rows = make([][]*RichText, len(t.Rows))
+	for i, row := range t.Rows {
+		table.rows[i] = richTableRow(row)
+	}
+	table.Refresh()
+}
+
+// Select does nothing for a table.
+func (t *TableSegment) Select(_, _ gui.Position) {
+}
+
+// SelectedText returns the empty string for this table.
+func (t *TableSegment) SelectedText() string {
+	return ""
+}
+
+// Unselect does nothing for a table.
+func (t *TableSegment) Unselect() {
+}
+
 // RichTextStyle describes the details of a text object inside a RichText widget.
 //
 // Since: 2.1
@@ -329,6 +521,10 @@ type RichTextStyle struct {
 	Inline    bool
 	SizeName  gui.ThemeSizeName
 	TextStyle gui.TextStyle
+	// Strikethrough draws a line through the text.
+	//
+	// Since: 2.3
+	Strikethrough bool
 
 	// an internal detail where we obscure password fields
 	concealed bool
@@ -432,3 +628,235 @@ func (u *unpadTextWidgetLayout) MinSize(o []gui.CanvasObject) gui.Size {
 	pad4 := theme.Padding() * 4
 	return o[0].MinSize().Subtract(gui.NewSize(pad4, pad4))
 }
+
+// richImageLayout positions an image at its original size, scaling it down to fit the available width.
+type richImageLayout struct {
+	lock   sync.RWMutex
+	align  gui.TextAlign
+	loaded func() // called when an image has loaded so that the rich text can update its layout
+	size   gui.Size
+	source gui.URI
+}
+
+func (r *richImageLayout) Layout(o []gui.CanvasObject, s gui.Size) {
+	r.lock.RLock()
+	align, size := r.align, r.size
+	r.lock.RUnlock()
+	if size.Width > s.Width && size.Width > 0 {
+		size = gui.NewSize(s.Width, size.Height*s.Width/size.Width)
+	}
+
+	x := float32(0)
+	switch align {
+	case gui.TextAlignCenter:
+		x = (s.Width - size.Width) / 2
+	case gui.TextAlignTrailing:
+		x = s.Width - size.Width
+	}
+	for _, obj := range o {
+		obj.Move(gui.NewPos(x, 0))
+		obj.Resize(size)
+	}
+}
+
+func (r *richImageLayout) MinSize([]gui.CanvasObject) gui.Size {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.size
+}
+
+func (r *richImageLayout) load(uri gui.URI, img *canvas.Image, box *gui.Container) {
+	res, size := loadRichImage(uri)
+
+	r.lock.Lock()
+	if r.source != uri { // the source changed while this image was loading
+		r.lock.Unlock()
+		return
+	}
+	r.size = size
+	loaded := r.loaded
+	r.lock.Unlock()
+
+	img.Resource = res
+	box.Refresh()
+	if loaded != nil {
+		loaded()
+	}
+}
+
+func (r *richImageLayout) setLoaded(loaded func()) {
+	r.lock.Lock()
+	r.loaded = loaded
+	r.lock.Unlock()
+}
+
+// loadRichImage reads an image from a storage URI and returns it with the size of its pixel content.
+// Images that cannot be measured, such as SVG content, are given a default size.
+func loadRichImage(uri gui.URI) (gui.Resource, gui.Size) {
+	read, err := storage.Reader(uri)
+	if err != nil {
+		gui.LogError("Failed to open image URI", err)
+		return nil, gui.Size{}
+	}
+	defer read.Close()
+	data, err := io.ReadAll(read)
+	if err != nil {
+		gui.LogError("Failed to read image URI", err)
+		return nil, gui.Size{}
+	}
+
+	res := gui.NewStaticResource(uri.Name(), data)
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		size := theme.IconInlineSize() * 4
+		return res, gui.NewSize(size, size)
+	}
+	return res, gui.NewSize(float32(cfg.Width), float32(cfg.Height))
+}
+
+// richTable displays the cells of a TableSegment in a grid with lines between the rows.
+type richTable struct {
+	BaseWidget
+	header []*RichText
+	rows   [][]*RichText
+}
+
+func (t *richTable) CreateRenderer() gui.WidgetRenderer {
+	r := &richTableRenderer{table: t}
+	r.Refresh()
+	return r
+}
+
+// richTableHeader returns copies of the header cells with text emboldened, leaving the table content unchanged.
+func richTableHeader(cells []RichTextSegment) []RichTextSegment {
+	bold := make([]RichTextSegment, len(cells))
+	for i, cell := range cells {
+		switch s := cell.(type) {
+		case *TextSegment:
+			text := *s
+			text.Style.TextStyle.Bold = true
+			bold[i] = &text
+		case *ParagraphSegment:
+			bold[i] = &ParagraphSegment{Texts: richTableHeader(s.Texts)}
+		default:
+			bold[i] = cell
+		}
+	}
+	return bold
+}
+
+func richTableRow(cells []RichTextSegment) []*RichText {
+	row := make([]*RichText, len(cells))
+	for i, cell := range cells {
+		row[i] = NewRichText(cell)
+	}
+	return row
+}
+
+// richTextSegmentText returns the text content of a segment, including that of any segments it contains.
+func richTextSegmentText(seg RichTextSegment) string {
+	block, ok := seg.(RichTextBlock)
+	if !ok {
+		return seg.Textual()
+	}
+
+	var b strings.Builder
+	for _, child := range block.Segments() {
+		b.WriteString(richTextSegmentText(child))
+	}
+	return b.String()
+}
+
+type richTableRenderer struct {
+	table   *richTable
+	lines   []*canvas.Rectangle
+	objects []gui.CanvasObject
+}
+
+func (r *richTableRenderer) Destroy() {
+}
+
+func (r *richTableRenderer) Layout(gui.Size) {
+	widths, heights := r.cellSizes()
+	y := float32(0)
+	line := 0
+	for i, row := range r.allRows() {
+		x := float32(0)
+		for col, cell := range row {
+			cell.Move(gui.NewPos(x, y))
+			cell.Resize(gui.NewSize(widths[col], heights[i]))
+			x += widths[col]
+		}
+		y += heights[i]
+		if i < len(heights)-1 {
+			r.lines[line].Move(gui.NewPos(0, y))
+			r.lines[line].Resize(gui.NewSize(r.table.Size().Width, theme.SeparatorThicknessSize()))
+			line++
+		}
+	}
+}
+
+func (r *richTableRenderer) MinSize() gui.Size {
+	widths, heights := r.cellSizes()
+	min := gui.Size{}
+	for _, w := range widths {
+		min.Width += w
+	}
+	for _, h := range heights {
+		min.Height += h
+	}
+	return min
+}
+
+func (r *richTableRenderer) Objects() []gui.CanvasObject {
+	return r.objects
+}
+
+func (r *richTableRenderer) Refresh() {
+	rows := r.allRows()
+	r.objects = r.objects[:0]
+	for i := range rows {
+		if i == 0 {
+			continue
+		}
+		if len(r.lines) < i {
+			r.lines = append(r.lines, canvas.NewRectangle(theme.ShadowColor()))
+		}
+		line := r.lines[i-1]
+		line.FillColor = theme.ShadowColor()
+		line.Refresh()
+		r.objects = append(r.objects, line)
+	}
+	for _, row := range rows {
+		for _, cell := range row {
+			r.objects = append(r.objects, cell)
+		}
+	}
+
+	r.Layout(r.table.Size())
+	canvas.Refresh(r.table)
+}
+
+func (r *richTableRenderer) allRows() [][]*RichText {
+	if len(r.table.header) == 0 {
+		return r.table.rows
+	}
+	return append([][]*RichText{r.table.header}, r.table.rows...)
+}
+
+// cellSizes returns the width of each column and the height of each row.
+func (r *richTableRenderer) cellSizes() (widths, heights []float32) {
+	rows := r.allRows()
+	heights = make([]float32, len(rows))
+	for i, row := range rows {
+		for col, cell := range row {
+			min := cell.MinSize()
+			if col == len(widths) {
+				widths = append(widths, 0)
+			}
+			widths[col] = gui.Max(widths[col], min.Width)
+			heights[i] = gui.Max(heights[i], min.Height)
+		}
+	}
+	return widths, heights
+}
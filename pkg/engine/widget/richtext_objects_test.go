@@ -23,12 +23,81 @@ package widget
 import (
 	"strings"
 	"testing"
+	"time"
 
+	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/storage"
 	"github.com/bhojpur/gui/pkg/engine/test"
+	"github.com/bhojpur/gui/pkg/engine/theme"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestRichText_CheckBox(t *testing.T) {
+	text := NewRichText(&CheckBoxSegment{Checked: true}, &TextSegment{Text: "Done"})
+	objs := test.WidgetRenderer(text).Objects()
+	assert.Equal(t, theme.CheckButtonCheckedIcon(), objs[0].(*canvas.Image).Resource)
+	assert.Equal(t, "Done", objs[1].(*canvas.Text).Text)
+	assert.Equal(t, "[x] Done", text.String())
+
+	text.Segments[0].(*CheckBoxSegment).Checked = false
+	text.Refresh()
+	objs = test.WidgetRenderer(text).Objects()
+	assert.Equal(t, theme.CheckButtonIcon(), objs[0].(*canvas.Image).Resource)
+}
+
+func TestRichText_Image(t *testing.T) {
+	text := NewRichText(&ImageSegment{Source: storage.NewFileURI("testdata/simple_renderer.png"), Title: "Simple"})
+	box := test.WidgetRenderer(text).Objects()[0].(*gui.Container)
+	assert.Equal(t, gui.Size{}, box.MinSize()) // the image loads in the background
+	assert.Eventually(t, func() bool {
+		return box.MinSize() == gui.NewSize(23, 29)
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, "Simple", text.String())
+	assert.Equal(t, box.MinSize().Height+theme.Padding()*4, text.MinSize().Height) // relayout once loaded
+
+	text.Resize(gui.NewSize(text.MinSize().Width/2, 100)) // images scale down to fit narrow content
+	img := box.Objects[0].(*canvas.Image)
+	assert.Less(t, img.Size().Width, float32(23))
+	assert.Less(t, img.Size().Height, float32(29))
+}
+
+func TestRichText_Strikethrough(t *testing.T) {
+	style := RichTextStyleInline
+	style.Strikethrough = true
+	text := NewRichText(&TextSegment{Text: "Gone", Style: style}, &TextSegment{Text: "Here"})
+	text.Resize(text.MinSize())
+	objs := test.WidgetRenderer(text).Objects()
+	assert.Equal(t, 3, len(objs))
+	word := objs[0].(*canvas.Text)
+	line := objs[2].(*canvas.Line)
+	assert.Equal(t, word.Position().X, line.Position1.X)
+	assert.Greater(t, line.Position2.X, line.Position1.X)
+	assert.Greater(t, line.Position1.Y, word.Position().Y)
+	assert.Less(t, line.Position1.Y, word.Position().Y+word.Size().Height)
+}
+
+func TestRichText_Table(t *testing.T) {
+	table := &TableSegment{
+		Header: []RichTextSegment{&TextSegment{Text: "Key"}, &TextSegment{Text: "Value"}},
+		Rows: [][]RichTextSegment{
+			{&TextSegment{Text: "a"}, &TextSegment{Text: "a much longer value"}},
+			{&TextSegment{Text: "b"}},
+		},
+	}
+	text := NewRichText(table)
+	assert.Equal(t, "Key\tValue\na\ta much longer value\nb", text.String())
+
+	visual := test.WidgetRenderer(text).Objects()[0].(*richTable)
+	header := visual.header[0].Segments[0].(*TextSegment)
+	assert.True(t, header.Style.TextStyle.Bold)
+	assert.False(t, table.Header[0].(*TextSegment).Style.TextStyle.Bold)
+
+	long := NewRichTextWithText("a much longer value").MinSize()
+	assert.Greater(t, visual.MinSize().Width, long.Width)
+	assert.Equal(t, long.Height*3, visual.MinSize().Height)
+}
+
 func TestRichText_List(t *testing.T) {
 	seg := trailingBoldErrorSegment()
 	seg.Text = "Test"
@@ -51,6 +51,7 @@ type Select struct {
 }
 
 var _ gui.Widget = (*Select)(nil)
+var _ gui.Accessible = (*Select)(nil)
 var _ desktop.Hoverable = (*Select)(nil)
 var _ gui.Tappable = (*Select)(nil)
 var _ gui.Focusable = (*Select)(nil)
@@ -67,6 +68,18 @@ func NewSelect(options []string, changed func(string)) *Select {
 	return s
 }
 
+// AccessibilityInfo describes this select to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (s *Select) AccessibilityInfo() gui.AccessibilityInfo {
+	s.propertyLock.RLock()
+	defer s.propertyLock.RUnlock()
+
+	return gui.AccessibilityInfo{Role: gui.AccessibleRoleComboBox, Name: s.PlaceHolder, Value: s.Selected}
+}
+
 // ClearSelected clears the current option of the select widget.  After
 // clearing the current option, the Select widget's PlaceHolder will
 // be displayed.
@@ -0,0 +1,97 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+)
+
+// SelectionMode defines how many items of a collection widget can be selected at once.
+//
+// Since: 2.3
+type SelectionMode int
+
+const (
+	// SelectionSingle allows only one item to be selected, this is the default.
+	//
+	// Since: 2.3
+	SelectionSingle SelectionMode = iota
+	// SelectionMultiple allows many items to be selected.
+	// Tapping with the shortcut modifier toggles an item and holding Shift selects a range.
+	//
+	// Since: 2.3
+	SelectionMultiple
+)
+
+// selectionKeys tracks the modifier keys that change how a tap or key press updates a selection.
+type selectionKeys struct {
+	shift bool            // a shift key is currently held down
+	mouse gui.KeyModifier // modifiers of the last mouse press, consumed by the tap that follows
+}
+
+func (k *selectionKeys) keyDown(key *gui.KeyEvent) {
+	if key.Name == desktop.KeyShiftLeft || key.Name == desktop.KeyShiftRight {
+		k.shift = true
+	}
+}
+
+func (k *selectionKeys) keyUp(key *gui.KeyEvent) {
+	if key.Name == desktop.KeyShiftLeft || key.Name == desktop.KeyShiftRight {
+		k.shift = false
+	}
+}
+
+func (k *selectionKeys) mouseDown(ev *desktop.MouseEvent) {
+	k.mouse = ev.Modifier
+}
+
+// tapModifiers returns the modifiers held when the current tap started and resets them.
+func (k *selectionKeys) tapModifiers() gui.KeyModifier {
+	mods := k.mouse
+	k.mouse = 0
+	if k.shift {
+		mods |= gui.KeyModifierShift
+	}
+	return mods
+}
+
+// focusCollection requests keyboard focus for a collection widget so it can be navigated with keys.
+func focusCollection(w gui.CanvasObject) {
+	f, ok := w.(gui.Focusable)
+	if !ok {
+		return
+	}
+	if c := gui.CurrentApp().Driver().CanvasForObject(w); c != nil {
+		c.Focus(f)
+	}
+}
+
+// clampIndex limits i to the indexes of a collection with the given length.
+func clampIndex(i, length int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= length {
+		return length - 1
+	}
+	return i
+}
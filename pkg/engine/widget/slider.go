@@ -23,6 +23,7 @@ package widget
 import (
 	"fmt"
 	"math"
+	"strconv"
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
@@ -41,6 +42,7 @@ const (
 )
 
 var _ gui.Draggable = (*Slider)(nil)
+var _ gui.Accessible = (*Slider)(nil)
 
 // Slider is a widget that can slide between two fixed values.
 type Slider struct {
@@ -80,6 +82,15 @@ func NewSliderWithData(min, max float64, data binding.Float) *Slider {
 	return slider
 }
 
+// AccessibilityInfo describes this slider to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (s *Slider) AccessibilityInfo() gui.AccessibilityInfo {
+	return gui.AccessibilityInfo{Role: gui.AccessibleRoleSlider, Value: strconv.FormatFloat(s.Value, 'f', -1, 64)}
+}
+
 // Bind connects the specified data source to this Slider.
 // The current value will be displayed and any changes in the data will cause the widget to update.
 // User interactions with this Slider will set the value into the data source.
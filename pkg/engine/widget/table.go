@@ -22,6 +22,8 @@ package widget
 
 import (
 	"math"
+	"sort"
+	"strconv"
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
@@ -32,6 +34,9 @@ import (
 
 // Declare conformity with Widget interface.
 var _ gui.Widget = (*Table)(nil)
+var _ gui.Accessible = (*Table)(nil)
+var _ gui.Focusable = (*Table)(nil)
+var _ desktop.Keyable = (*Table)(nil)
 
 // TableCellID is a type that represents a cell's position in a table based on it's row and column location.
 type TableCellID struct {
@@ -43,6 +48,10 @@ type TableCellID struct {
 // It's performance is provided by caching cell templates created with CreateCell and re-using them with UpdateCell.
 // The size of the content rows/columns is returned by the Length callback.
 //
+// A header row and a header column can be shown which stay in place while the cells are scrolled.
+// Header cells are created with CreateHeader and updated with UpdateHeader, the cells of the header row
+// have a Row of -1 and the cells of the header column have a Col of -1.
+//
 // Since: 1.4
 type Table struct {
 	BaseWidget
@@ -53,9 +62,47 @@ type Table struct {
 	OnSelected   func(id TableCellID)
 	OnUnselected func(id TableCellID)
 
+	// ShowHeaderRow turns on the header row above the cells that shows a title for each column.
+	// Columns can be resized by dragging the dividers of the header row.
+	//
+	// Since: 2.3
+	ShowHeaderRow bool
+	// ShowHeaderColumn turns on the header column before the cells that shows a title for each row.
+	//
+	// Since: 2.3
+	ShowHeaderColumn bool
+	// CreateHeader returns a template object for header cells.
+	// If it is not set a bold label is used. Call Refresh after changing it so the header size is measured again.
+	//
+	// Since: 2.3
+	CreateHeader func() gui.CanvasObject
+	// UpdateHeader applies the header at the specified location to the passed template object.
+	// If it is not set the default labels show column letters and row numbers.
+	//
+	// Since: 2.3
+	UpdateHeader func(id TableCellID, template gui.CanvasObject)
+	// OnHeaderTapped is called when a cell of the header row or header column is tapped.
+	//
+	// Since: 2.3
+	OnHeaderTapped func(id TableCellID)
+	// SelectionMode sets whether one or many cells can be selected, the default is SelectionSingle.
+	//
+	// Since: 2.3
+	SelectionMode SelectionMode
+	// OnSelectionChanged is called with the IDs of all selected cells whenever the selection changes.
+	//
+	// Since: 2.3
+	OnSelectionChanged func(selected []TableCellID)
+
 	selectedCell, hoveredCell *TableCellID
+	selectedCells             []TableCellID // cells selected in addition to selectedCell
+	anchor, cursor            TableCellID   // where a range selection extends from and keyboard navigation moves from
+	keys                      selectionKeys
 	cells                     *tableCells
-	columnWidths              map[int]float32
+	headerRow, headerColumn   *tableHeader
+	headerSize                gui.Size // the size of the header template, zero until it is measured
+	columnWidths, rowHeights  map[int]float32
+	rowIndex                  *heightIndex
 	moveCallback              func()
 	offset                    gui.Position
 	scroll                    *widget.Scroll
@@ -73,6 +120,15 @@ func NewTable(length func() (int, int), create func() gui.CanvasObject, update f
 	return t
 }
 
+// AccessibilityInfo describes this table to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (t *Table) AccessibilityInfo() gui.AccessibilityInfo {
+	return gui.AccessibilityInfo{Role: gui.AccessibleRoleTable}
+}
+
 // CreateRenderer returns a new renderer for the table.
 //
 // Implements: gui.Widget
@@ -84,14 +140,17 @@ func (t *Table) CreateRenderer() gui.WidgetRenderer {
 	cellSize := t.templateSize()
 	t.cells = newTableCells(t, cellSize)
 	t.scroll = widget.NewScroll(t.cells)
+	t.headerRow = newTableHeader(t, false)
+	t.headerColumn = newTableHeader(t, true)
 
-	obj := []gui.CanvasObject{marker, hover, t.scroll}
+	obj := []gui.CanvasObject{marker, hover, t.scroll, t.headerRow, t.headerColumn}
 	r := &tableRenderer{t: t, scroll: t.scroll, marker: marker, hover: hover, cellSize: cellSize}
 	r.SetObjects(obj)
 	t.moveCallback = r.moveIndicators
 	t.scroll.OnScrolled = func(pos gui.Position) {
 		t.offset = pos
 		t.cells.Refresh()
+		t.refreshHeaders()
 		r.moveIndicators()
 	}
 
@@ -99,7 +158,43 @@ func (t *Table) CreateRenderer() gui.WidgetRenderer {
 	return r
 }
 
+// FocusGained is called after this Table has gained focus.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (t *Table) FocusGained() {
+}
+
+// FocusLost is called after this Table has lost focus.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (t *Table) FocusLost() {
+	t.keys.shift = false
+}
+
+// KeyDown is called when a key is pressed while this Table is focused.
+//
+// Implements: desktop.Keyable
+//
+// Since: 2.3
+func (t *Table) KeyDown(key *gui.KeyEvent) {
+	t.keys.keyDown(key)
+}
+
+// KeyUp is called when a key is released while this Table is focused.
+//
+// Implements: desktop.Keyable
+//
+// Since: 2.3
+func (t *Table) KeyUp(key *gui.KeyEvent) {
+	t.keys.keyUp(key)
+}
+
 // Select will mark the specified cell as selected.
+// Unless SelectionMode is SelectionMultiple any previously selected cell is unselected.
 func (t *Table) Select(id TableCellID) {
 	if t.Length == nil {
 		return
@@ -110,19 +205,33 @@ func (t *Table) Select(id TableCellID) {
 		return
 	}
 
-	if t.selectedCell != nil && *t.selectedCell == id {
+	t.anchor, t.cursor = id, id
+	if t.SelectionMode == SelectionMultiple {
+		if t.isSelected(id) {
+			return
+		}
+		t.ScrollTo(id)
+		t.setSelection(append(t.Selected(), id), &id)
 		return
 	}
-	if f := t.OnUnselected; f != nil && t.selectedCell != nil {
-		f(*t.selectedCell)
-	}
-	t.selectedCell = &id
 
+	if t.selectedCell != nil && *t.selectedCell == id && len(t.selectedCells) == 0 {
+		return
+	}
 	t.ScrollTo(id)
+	t.setSelection([]TableCellID{id}, &id)
+}
 
-	if f := t.OnSelected; f != nil {
-		f(id)
+// Selected returns the IDs of all selected cells ordered by row and then column.
+//
+// Since: 2.3
+func (t *Table) Selected() []TableCellID {
+	selected := append([]TableCellID(nil), t.selectedCells...)
+	if t.selectedCell != nil {
+		selected = append(selected, *t.selectedCell)
 	}
+	sortTableCells(selected)
+	return selected
 }
 
 // SetColumnWidth supports changing the width of the specified column. Columns normally take the width of the template
@@ -138,40 +247,122 @@ func (t *Table) SetColumnWidth(id int, width float32) {
 	t.Refresh()
 }
 
+// SetRowHeight supports changing the height of the specified row. Rows normally take the height of the template
+// cell returned from the CreateCell callback. The height parameter uses the same units as a gui.Size type and refers
+// to the internal content height not including the divider size.
+//
+// Since: 2.3
+func (t *Table) SetRowHeight(id int, height float32) {
+	if t.rowHeights == nil {
+		t.rowHeights = make(map[int]float32)
+	}
+	t.rowHeights[id] = height
+	if t.rowIndex != nil {
+		t.rowIndex.setHeight(id, height)
+	}
+	t.Refresh()
+}
+
+// TypedKey is called if a key event happens while this Table is focused.
+// The arrow keys move the selection between cells, Home and End move to the first and last column
+// and PageUp and PageDown move by the number of visible rows. Holding Shift extends the selection
+// when SelectionMode is SelectionMultiple and Space toggles the current cell.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (t *Table) TypedKey(key *gui.KeyEvent) {
+	if t.Length == nil {
+		return
+	}
+	rows, cols := t.Length()
+	if rows == 0 || cols == 0 {
+		return
+	}
+
+	target := t.cursor
+	moved := t.selectedCell != nil || len(t.selectedCells) > 0
+	switch key.Name {
+	case gui.KeyUp:
+		if moved {
+			target.Row--
+		}
+	case gui.KeyDown:
+		if moved {
+			target.Row++
+		}
+	case gui.KeyLeft:
+		if moved {
+			target.Col--
+		}
+	case gui.KeyRight:
+		if moved {
+			target.Col++
+		}
+	case gui.KeyHome:
+		target.Col = 0
+	case gui.KeyEnd:
+		target.Col = cols - 1
+	case gui.KeyPageUp:
+		target.Row -= t.pageLength()
+	case gui.KeyPageDown:
+		target.Row += t.pageLength()
+	case gui.KeySpace:
+		if t.SelectionMode == SelectionMultiple && t.isSelected(t.cursor) {
+			t.Unselect(t.cursor)
+		} else {
+			t.Select(t.cursor)
+		}
+		return
+	default:
+		return
+	}
+
+	target.Row = clampIndex(target.Row, rows)
+	target.Col = clampIndex(target.Col, cols)
+	if t.SelectionMode == SelectionMultiple && t.keys.shift {
+		t.selectRange(target)
+	} else {
+		t.selectOnly(target)
+	}
+}
+
+// TypedRune is called if a text event happens while this Table is focused.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (t *Table) TypedRune(_ rune) {
+}
+
 // Unselect will mark the cell provided by id as unselected.
 func (t *Table) Unselect(id TableCellID) {
-	if t.selectedCell == nil || id != *t.selectedCell {
+	if !t.isSelected(id) {
 		return
 	}
-	t.selectedCell = nil
 
-	if t.moveCallback != nil {
-		t.moveCallback()
+	var selected []TableCellID
+	for _, cell := range t.Selected() {
+		if cell != id {
+			selected = append(selected, cell)
+		}
 	}
-
-	if f := t.OnUnselected; f != nil {
-		f(id)
+	current := t.selectedCell
+	if current != nil && *current == id {
+		current = nil
 	}
+	t.setSelection(selected, current)
 }
 
 // UnselectAll will mark all cells as unselected.
 //
 // Since: 2.1
 func (t *Table) UnselectAll() {
-	if t.selectedCell == nil {
+	if t.selectedCell == nil && len(t.selectedCells) == 0 {
 		return
 	}
 
-	selected := *t.selectedCell
-	t.selectedCell = nil
-
-	if t.moveCallback != nil {
-		t.moveCallback()
-	}
-
-	if f := t.OnUnselected; f != nil {
-		f(selected)
-	}
+	t.setSelection(nil, nil)
 }
 
 // ScrollTo will scroll to the given cell without changing the selection.
@@ -278,6 +469,30 @@ func (t *Table) ScrollToTrailing() {
 	t.finishScroll()
 }
 
+// cellTapped updates the selection for a tap on a cell, honouring the modifiers held at the time.
+func (t *Table) cellTapped(id TableCellID) {
+	focusCollection(t.super())
+	mods := t.keys.tapModifiers()
+	if t.SelectionMode != SelectionMultiple {
+		t.Select(id)
+		return
+	}
+
+	switch {
+	case mods&gui.KeyModifierShift != 0:
+		t.selectRange(id)
+	case mods&gui.KeyModifierShortcutDefault != 0:
+		if t.isSelected(id) {
+			t.anchor, t.cursor = id, id
+			t.Unselect(id)
+		} else {
+			t.Select(id)
+		}
+	default:
+		t.selectOnly(id)
+	}
+}
+
 func (t *Table) findX(col int) (cellX float32, cellWidth float32) {
 	cellSize := t.templateSize()
 	for i := 0; i <= col; i++ {
@@ -295,10 +510,7 @@ func (t *Table) findX(col int) (cellX float32, cellWidth float32) {
 }
 
 func (t *Table) findY(row int) (cellY float32, cellHeight float32) {
-	cellSize := t.templateSize()
-	cellHeight = cellSize.Height
-	cellY = float32(row) * (cellHeight + theme.SeparatorThicknessSize())
-	return
+	return t.rowPositions(t.templateSize().Height).position(row)
 }
 
 func (t *Table) finishScroll() {
@@ -307,6 +519,169 @@ func (t *Table) finishScroll() {
 	}
 	t.scroll.Refresh()
 	t.cells.Refresh()
+	t.refreshHeaders()
+}
+
+func (t *Table) createHeader() gui.CanvasObject {
+	if f := t.CreateHeader; f != nil {
+		return f()
+	}
+
+	l := NewLabel("00")
+	l.Alignment = gui.TextAlignCenter
+	l.TextStyle.Bold = true
+	return l
+}
+
+func (t *Table) isSelected(id TableCellID) bool {
+	if t.selectedCell != nil && *t.selectedCell == id {
+		return true
+	}
+	for _, cell := range t.selectedCells {
+		if cell == id {
+			return true
+		}
+	}
+	return false
+}
+
+// headerOffset returns the position of the cells, moved by the size of the visible headers.
+func (t *Table) headerOffset() gui.Position {
+	if !t.ShowHeaderRow && !t.ShowHeaderColumn {
+		return gui.Position{}
+	}
+
+	headerSize := t.headerTemplateSize()
+	off := gui.Position{}
+	if t.ShowHeaderColumn {
+		off.X = headerSize.Width + theme.SeparatorThicknessSize()
+	}
+	if t.ShowHeaderRow {
+		off.Y = headerSize.Height + theme.SeparatorThicknessSize()
+	}
+	return off
+}
+
+// headerTemplateSize returns the size of the header template, it is measured again when the table is refreshed.
+func (t *Table) headerTemplateSize() gui.Size {
+	if t.headerSize.IsZero() {
+		t.headerSize = t.createHeader().MinSize()
+	}
+	return t.headerSize
+}
+
+// pageLength returns the number of rows of template height that fit in the visible area.
+func (t *Table) pageLength() int {
+	if t.scroll == nil {
+		return 1
+	}
+	rows := int(t.scroll.Size().Height / (t.templateSize().Height + theme.SeparatorThicknessSize()))
+	if rows < 1 {
+		return 1
+	}
+	return rows
+}
+
+// rowPositions returns the index of the row positions, it is rebuilt when the number of rows or the template changed.
+func (t *Table) rowPositions(cellHeight float32) *heightIndex {
+	rows := 0
+	if f := t.Length; f != nil {
+		rows, _ = t.Length()
+	}
+
+	separatorThickness := theme.SeparatorThicknessSize()
+	if i := t.rowIndex; i != nil && i.count == rows && i.height == cellHeight && i.separator == separatorThickness {
+		return i
+	}
+
+	var heightOf func(int) float32
+	if len(t.rowHeights) > 0 {
+		heightOf = func(row int) float32 {
+			return t.rowHeights[row]
+		}
+	}
+	t.rowIndex = newHeightIndex(rows, cellHeight, separatorThickness, heightOf)
+	return t.rowIndex
+}
+
+func (t *Table) refreshHeaders() {
+	if t.headerRow == nil {
+		return
+	}
+
+	if t.ShowHeaderRow {
+		t.headerRow.Refresh()
+	}
+	if t.ShowHeaderColumn {
+		t.headerColumn.Refresh()
+	}
+}
+
+// selectOnly replaces the selection with the cell identified by id.
+func (t *Table) selectOnly(id TableCellID) {
+	t.anchor, t.cursor = id, id
+	t.ScrollTo(id)
+	if t.selectedCell != nil && *t.selectedCell == id && len(t.selectedCells) == 0 {
+		return
+	}
+	t.setSelection([]TableCellID{id}, &id)
+}
+
+// selectRange replaces the selection with all cells in the rectangle between the anchor and id.
+func (t *Table) selectRange(id TableCellID) {
+	t.cursor = id
+	minRow, maxRow := t.anchor.Row, id.Row
+	if minRow > maxRow {
+		minRow, maxRow = maxRow, minRow
+	}
+	minCol, maxCol := t.anchor.Col, id.Col
+	if minCol > maxCol {
+		minCol, maxCol = maxCol, minCol
+	}
+
+	selected := make([]TableCellID, 0, (maxRow-minRow+1)*(maxCol-minCol+1))
+	for row := minRow; row <= maxRow; row++ {
+		for col := minCol; col <= maxCol; col++ {
+			selected = append(selected, TableCellID{row, col})
+		}
+	}
+	t.ScrollTo(id)
+	t.setSelection(selected, &id)
+}
+
+// setSelection stores the new selection with current as the focused cell, moves the markers
+// and then notifies the callbacks of any changes.
+func (t *Table) setSelection(selected []TableCellID, current *TableCellID) {
+	old := t.Selected()
+	t.selectedCell = current
+	t.selectedCells = nil
+	for _, cell := range selected {
+		if current == nil || cell != *current {
+			t.selectedCells = append(t.selectedCells, cell)
+		}
+	}
+
+	if t.moveCallback != nil {
+		t.moveCallback()
+	}
+
+	if f := t.OnUnselected; f != nil {
+		for _, cell := range old {
+			if !t.isSelected(cell) {
+				f(cell)
+			}
+		}
+	}
+	if f := t.OnSelected; f != nil {
+		for _, cell := range t.Selected() {
+			if !containsTableCell(old, cell) {
+				f(cell)
+			}
+		}
+	}
+	if f := t.OnSelectionChanged; f != nil {
+		f(t.Selected())
+	}
 }
 
 func (t *Table) templateSize() gui.Size {
@@ -319,6 +694,23 @@ func (t *Table) templateSize() gui.Size {
 	return gui.Size{}
 }
 
+func (t *Table) updateHeader(id TableCellID, template gui.CanvasObject) {
+	if f := t.UpdateHeader; f != nil {
+		f(id, template)
+		return
+	}
+
+	l, ok := template.(*Label)
+	if !ok {
+		return
+	}
+	if id.Col == -1 {
+		l.SetText(strconv.Itoa(id.Row + 1))
+	} else {
+		l.SetText(columnLetters(id.Col))
+	}
+}
+
 func (t *Table) visibleColumnWidths(colWidth float32, cols int) (visible map[int]float32, offX float32, minCol, maxCol int) {
 	maxCol = cols
 	colOffset := float32(0)
@@ -365,31 +757,56 @@ type tableRenderer struct {
 
 	scroll        *widget.Scroll
 	hover, marker *canvas.Rectangle
+	selection     []*canvas.Rectangle // markers for the cells in Table.selectedCells
 	dividers      []gui.CanvasObject
 
 	cellSize gui.Size
 }
 
 func (t *tableRenderer) Layout(s gui.Size) {
-	t.scroll.Resize(s)
+	off := t.t.headerOffset()
+	t.scroll.Move(off)
+	t.scroll.Resize(s.Subtract(off))
+
+	if t.t.ShowHeaderRow {
+		t.t.headerRow.Move(gui.NewPos(off.X, 0))
+		t.t.headerRow.Resize(gui.NewSize(s.Width-off.X, off.Y-theme.SeparatorThicknessSize()))
+		t.t.headerRow.Show()
+	} else {
+		t.t.headerRow.Hide()
+	}
+	if t.t.ShowHeaderColumn {
+		t.t.headerColumn.Move(gui.NewPos(0, off.Y))
+		t.t.headerColumn.Resize(gui.NewSize(off.X-theme.SeparatorThicknessSize(), s.Height-off.Y))
+		t.t.headerColumn.Show()
+	} else {
+		t.t.headerColumn.Hide()
+	}
 	t.moveIndicators()
 }
 
 func (t *tableRenderer) MinSize() gui.Size {
-	return t.t.scroll.MinSize().Max(t.cellSize)
+	off := t.t.headerOffset()
+	return t.t.scroll.MinSize().Max(t.cellSize).Add(off)
 }
 
 func (t *tableRenderer) Refresh() {
 	t.cellSize = t.t.templateSize()
-	t.moveIndicators()
+	t.t.headerSize = gui.Size{} // the header template or the theme may have changed
+	t.Layout(t.t.Size())
 
 	t.marker.FillColor = theme.SelectionColor()
 	t.marker.Refresh()
+	for _, m := range t.selection {
+		m.FillColor = theme.SelectionColor()
+		m.Refresh()
+	}
 
 	t.hover.FillColor = theme.HoverColor()
 	t.hover.Refresh()
 
 	t.t.cells.Refresh()
+	t.t.refreshHeaders()
 }
 
 func (t *tableRenderer) moveIndicators() {
@@ -411,38 +828,60 @@ func (t *tableRenderer) moveIndicators() {
 		t.moveMarker(t.hover, t.t.hoveredCell.Row, t.t.hoveredCell.Col, offX, minCol, visibleColWidths)
 	}
 
+	off := t.t.headerOffset()
+	rowIndex := t.t.rowPositions(t.cellSize.Height)
+	minRow := rowIndex.indexAt(t.scroll.Offset.Y)
+	maxRow := int(gui.Min(float32(rowIndex.indexAt(t.scroll.Offset.Y+t.scroll.Size().Height)), float32(rows-1)))
+	t.moveSelectionMarkers(minRow, maxRow, offX, minCol, maxCol, visibleColWidths)
 	colDivs := maxCol - minCol - 1
-	rowDivs := int(math.Ceil(float64(t.t.size.Height+separatorThickness) / float64(t.cellSize.Height+1)))
+	rowDivs := 0
+	if maxRow > minRow {
+		rowDivs = maxRow - minRow
+	}
+	headerDivs := 0
+	if t.t.ShowHeaderRow {
+		headerDivs++
+	}
+	if t.t.ShowHeaderColumn {
+		headerDivs++
+	}
 
-	if len(t.dividers) < colDivs+rowDivs {
-		for i := len(t.dividers); i < colDivs+rowDivs; i++ {
+	if len(t.dividers) < colDivs+rowDivs+headerDivs {
+		for i := len(t.dividers); i < colDivs+rowDivs+headerDivs; i++ {
 			t.dividers = append(t.dividers, NewSeparator())
 		}
 
-		obj := []gui.CanvasObject{t.marker, t.hover, t.scroll}
-		t.SetObjects(append(obj, t.dividers...))
+		t.updateObjects()
 	}
 
 	divs := 0
+	if t.t.ShowHeaderRow {
+		t.dividers[divs].Move(gui.NewPos(0, off.Y-separatorThickness))
+		t.dividers[divs].Resize(gui.NewSize(t.t.size.Width, separatorThickness))
+		t.dividers[divs].Show()
+		divs++
+	}
+	if t.t.ShowHeaderColumn {
+		t.dividers[divs].Move(gui.NewPos(off.X-separatorThickness, 0))
+		t.dividers[divs].Resize(gui.NewSize(separatorThickness, t.t.size.Height))
+		t.dividers[divs].Show()
+		divs++
+	}
+
 	i := minCol
 	for x := offX + visibleColWidths[i]; i < minCol+colDivs && divs < len(t.dividers); x += visibleColWidths[i] + separatorThickness {
 		i++
 
-		t.dividers[divs].Move(gui.NewPos(x-t.scroll.Offset.X, 0))
+		t.dividers[divs].Move(gui.NewPos(x-t.scroll.Offset.X+off.X, 0))
 		t.dividers[divs].Resize(gui.NewSize(separatorThickness, t.t.size.Height))
 		t.dividers[divs].Show()
 		divs++
 	}
 
-	i = 0
-	count := float32(math.Mod(float64(t.scroll.Offset.Y), float64(t.cellSize.Height+separatorThickness)))
-	for y := t.scroll.Offset.Y - count - separatorThickness; y < t.scroll.Offset.Y+t.t.size.Height && i < rows-1 && divs < len(t.dividers); y += t.cellSize.Height + separatorThickness {
-		if y < t.scroll.Offset.Y {
-			continue
-		}
-		i++
+	for row := minRow; row < maxRow && divs < len(t.dividers); row++ {
+		y, height := rowIndex.position(row)
 
-		t.dividers[divs].Move(gui.NewPos(0, y-t.scroll.Offset.Y))
+		t.dividers[divs].Move(gui.NewPos(0, y+height-t.scroll.Offset.Y+off.Y))
 		t.dividers[divs].Resize(gui.NewSize(t.t.size.Width, separatorThickness))
 		t.dividers[divs].Show()
 		divs++
@@ -454,6 +893,26 @@ func (t *tableRenderer) moveIndicators() {
 	canvas.Refresh(t.t)
 }
 
+// moveSelectionMarkers places a marker over each visible cell that is selected in addition to the current cell.
+func (t *tableRenderer) moveSelectionMarkers(minRow, maxRow int, offX float32, minCol, maxCol int, widths map[int]float32) {
+	count := 0
+	for _, cell := range t.t.selectedCells {
+		if cell.Row < minRow || cell.Row > maxRow || cell.Col < minCol || cell.Col >= maxCol {
+			continue
+		}
+		if count == len(t.selection) {
+			t.selection = append(t.selection, canvas.NewRectangle(theme.SelectionColor()))
+			t.updateObjects()
+		}
+		t.moveMarker(t.selection[count], cell.Row, cell.Col, offX, minCol, widths)
+		count++
+	}
+
+	for i := count; i < len(t.selection); i++ {
+		t.selection[i].Hide()
+	}
+}
+
 func (t *tableRenderer) moveMarker(marker gui.CanvasObject, row, col int, offX float32, minCol int, widths map[int]float32) {
 	if col == -1 || row == -1 {
 		marker.Hide()
@@ -470,18 +929,19 @@ func (t *tableRenderer) moveMarker(marker gui.CanvasObject, row, col int, offX f
 		}
 		xPos += theme.SeparatorThicknessSize()
 	}
-	x1 := xPos - t.scroll.Offset.X
+	off := t.t.headerOffset()
+	x1 := xPos - t.scroll.Offset.X + off.X
 	x2 := x1 + widths[col]
 
-	offY := float32(row)*(t.cellSize.Height+theme.SeparatorThicknessSize()) - t.scroll.Offset.Y
-	y1 := offY
-	y2 := y1 + t.cellSize.Height
+	rowY, rowHeight := t.t.rowPositions(t.cellSize.Height).position(row)
+	y1 := rowY - t.scroll.Offset.Y + off.Y
+	y2 := y1 + rowHeight
 
-	if x2 < 0 || x1 > t.t.size.Width || y2 < 0 || y1 > t.t.size.Height {
+	if x2 < off.X || x1 > t.t.size.Width || y2 < off.Y || y1 > t.t.size.Height {
 		marker.Hide()
 	} else {
-		left := gui.Max(0, x1)
-		top := gui.Max(0, y1)
+		left := gui.Max(off.X, x1)
+		top := gui.Max(off.Y, y1)
 		marker.Move(gui.NewPos(left, top))
 		marker.Resize(gui.NewSize(gui.Min(x2, t.t.size.Width)-left, gui.Min(y2, t.t.size.Height)-top))
 
@@ -490,9 +950,21 @@ func (t *tableRenderer) moveMarker(marker gui.CanvasObject, row, col int, offX f
 	marker.Refresh()
 }
 
+func (t *tableRenderer) updateObjects() {
+	obj := []gui.CanvasObject{t.marker}
+	for _, m := range t.selection {
+		obj = append(obj, m)
+	}
+	obj = append(obj, t.hover, t.scroll, t.t.headerRow, t.t.headerColumn)
+	t.SetObjects(append(obj, t.dividers...))
+}
+
 // Declare conformity with Hoverable interface.
 var _ desktop.Hoverable = (*tableCells)(nil)
 
+// Declare conformity with Mouseable interface.
+var _ desktop.Mouseable = (*tableCells)(nil)
+
 // Declare conformity with Tappable interface.
 var _ gui.Tappable = (*tableCells)(nil)
 
@@ -515,6 +987,10 @@ func (c *tableCells) CreateRenderer() gui.WidgetRenderer {
 	return &tableCellsRenderer{cells: c, pool: &syncPool{}, visible: make(map[TableCellID]gui.CanvasObject)}
 }
 
+func (c *tableCells) MouseDown(ev *desktop.MouseEvent) {
+	c.t.keys.mouseDown(ev)
+}
+
 func (c *tableCells) MouseIn(ev *desktop.MouseEvent) {
 	c.hoverAt(ev.Position)
 }
@@ -527,6 +1003,9 @@ func (c *tableCells) MouseOut() {
 	c.hoverOut()
 }
 
+func (c *tableCells) MouseUp(*desktop.MouseEvent) {
+}
+
 func (c *tableCells) Resize(s gui.Size) {
 	if s == c.size {
 		return
@@ -537,8 +1016,7 @@ func (c *tableCells) Resize(s gui.Size) {
 
 func (c *tableCells) Tapped(e *gui.PointEvent) {
 	if e.Position.X < 0 || e.Position.X >= c.Size().Width || e.Position.Y < 0 || e.Position.Y >= c.Size().Height {
-		c.t.selectedCell = nil
-		c.t.Refresh()
+		c.t.UnselectAll()
 		return
 	}
 
@@ -546,8 +1024,8 @@ func (c *tableCells) Tapped(e *gui.PointEvent) {
 	if col == -1 {
 		return // out of col range
 	}
-	row := int(e.Position.Y / (c.cellSize.Height + theme.SeparatorThicknessSize()))
-	c.t.Select(TableCellID{row, col})
+	row := c.t.rowPositions(c.cellSize.Height).indexAt(e.Position.Y)
+	c.t.cellTapped(TableCellID{row, col})
 }
 
 func (c *tableCells) columnAt(pos gui.Position) int {
@@ -575,7 +1053,7 @@ func (c *tableCells) hoverAt(pos gui.Position) {
 	}
 
 	col := c.columnAt(pos)
-	row := int(pos.Y / (c.cellSize.Height + theme.SeparatorThicknessSize()))
+	row := c.t.rowPositions(c.cellSize.Height).indexAt(pos.Y)
 	c.t.hoveredCell = &TableCellID{row, col}
 
 	rows, cols := 0, 0
@@ -616,9 +1094,9 @@ func (r *tableCellsRenderer) Layout(_ gui.Size) {
 }
 
 func (r *tableCellsRenderer) MinSize() gui.Size {
-	rows, cols := 0, 0
+	cols := 0
 	if f := r.cells.t.Length; f != nil {
-		rows, cols = r.cells.t.Length()
+		_, cols = r.cells.t.Length()
 	} else {
 		gui.LogError("Missing Length callback required for Table", nil)
 	}
@@ -635,7 +1113,7 @@ func (r *tableCellsRenderer) MinSize() gui.Size {
 	}
 
 	separatorSize := theme.SeparatorThicknessSize()
-	return gui.NewSize(width+float32(cols-1)*separatorSize, r.cells.cellSize.Height*float32(rows)+float32(rows-1)*separatorSize)
+	return gui.NewSize(width+float32(cols-1)*separatorSize, r.cells.t.rowPositions(r.cells.cellSize.Height).length())
 }
 
 func (r *tableCellsRenderer) Refresh() {
@@ -652,14 +1130,17 @@ func (r *tableCellsRenderer) Refresh() {
 	if f := r.cells.t.Length; f != nil {
 		dataRows, dataCols = r.cells.t.Length()
 	}
-	rows := r.visibleRows()
 	visibleColWidths, offX, minCol, maxCol := r.cells.t.visibleColumnWidths(r.cells.cellSize.Width, dataCols)
 	if len(visibleColWidths) == 0 { // we can't show anything until we have some dimensions
 		return
 	}
-	offY := r.cells.t.offset.Y - float32(int(r.cells.t.offset.Y)%int(r.cells.cellSize.Height+separatorThickness))
-	minRow := int(offY / (r.cells.cellSize.Height + separatorThickness))
-	maxRow := int(gui.Min(float32(minRow+rows), float32(dataRows)))
+	rowIndex := r.cells.t.rowPositions(r.cells.cellSize.Height)
+	minRow := rowIndex.indexAt(r.cells.t.offset.Y)
+	maxRow := rowIndex.indexAt(r.cells.t.offset.Y+r.cells.t.scroll.Size().Height) + 1
+	if rows := minRow + r.visibleRows(); rows > maxRow {
+		maxRow = rows
+	}
+	maxRow = int(gui.Min(float32(maxRow), float32(dataRows)))
 
 	updateCell := r.cells.t.UpdateCell
 	if updateCell == nil {
@@ -685,9 +1166,9 @@ func (r *tableCellsRenderer) Refresh() {
 				}
 			}
 
-			c.Move(gui.NewPos(cellOffset,
-				float32(row)*(r.cells.cellSize.Height+separatorThickness)))
-			c.Resize(gui.NewSize(colWidth, r.cells.cellSize.Height))
+			rowY, rowHeight := rowIndex.position(row)
+			c.Move(gui.NewPos(cellOffset, rowY))
+			c.Resize(gui.NewSize(colWidth, rowHeight))
 
 			if updateCell != nil {
 				updateCell(TableCellID{row, col}, c)
@@ -714,12 +1195,258 @@ func (r *tableCellsRenderer) returnAllToPool() {
 	r.SetObjects(nil)
 }
 
+// visibleRows returns how many rows of the default height fit into the table, plus one for partial rows.
 func (r *tableCellsRenderer) visibleRows() int {
-	rows := math.Ceil(float64(r.cells.t.Size().Height)/float64(r.cells.cellSize.Height+theme.SeparatorThicknessSize()) + 1)
+	return int(math.Ceil(float64(r.cells.t.Size().Height)/float64(r.cells.cellSize.Height+theme.SeparatorThicknessSize()) + 1))
+}
 
-	dataRows := 0
-	if f := r.cells.t.Length; f != nil {
-		dataRows, _ = r.cells.t.Length()
+// columnLetters returns the spreadsheet style name of a column, A to Z followed by AA, AB and so on.
+func columnLetters(col int) string {
+	name := ""
+	for col >= 0 {
+		name = string(rune('A'+col%26)) + name
+		col = col/26 - 1
+	}
+	return name
+}
+
+// Declare conformity with interfaces.
+var _ desktop.Cursorable = (*tableHeader)(nil)
+var _ desktop.Hoverable = (*tableHeader)(nil)
+var _ gui.Draggable = (*tableHeader)(nil)
+var _ gui.Scrollable = (*tableHeader)(nil)
+var _ gui.Tappable = (*tableHeader)(nil)
+var _ gui.Widget = (*tableHeader)(nil)
+
+// tableHeader shows the header row, or the header column, of a table in sync with the scrolled cells.
+type tableHeader struct {
+	BaseWidget
+	t      *Table
+	column bool
+
+	dragging, resizeHovered bool
+	resizeCol               int
+	resizeWidth             float32
+}
+
+func newTableHeader(t *Table, column bool) *tableHeader {
+	h := &tableHeader{t: t, column: column}
+	h.ExtendBaseWidget(h)
+	h.Hide()
+	return h
+}
+
+func (h *tableHeader) CreateRenderer() gui.WidgetRenderer {
+	return &tableHeaderRenderer{header: h, pool: &syncPool{}, visible: make(map[int]gui.CanvasObject)}
+}
+
+func (h *tableHeader) Cursor() desktop.Cursor {
+	if h.resizeHovered || (h.dragging && h.resizeCol >= 0) {
+		return desktop.HResizeCursor
+	}
+	return desktop.DefaultCursor
+}
+
+// Dragged resizes the column whose divider the drag started on.
+func (h *tableHeader) Dragged(e *gui.DragEvent) {
+	if h.column {
+		return
+	}
+
+	if !h.dragging {
+		h.dragging = true
+		h.resizeCol = h.dividerAt(e.Position.Subtract(e.Dragged).X)
+		if h.resizeCol >= 0 {
+			_, h.resizeWidth = h.t.findX(h.resizeCol)
+		}
+	}
+	if h.resizeCol < 0 {
+		return
+	}
+
+	h.resizeWidth += e.Dragged.DX
+	h.t.SetColumnWidth(h.resizeCol, gui.Max(h.resizeWidth, theme.IconInlineSize()))
+}
+
+func (h *tableHeader) DragEnd() {
+	h.dragging = false
+}
+
+func (h *tableHeader) MouseIn(ev *desktop.MouseEvent) {
+	h.MouseMoved(ev)
+}
+
+func (h *tableHeader) MouseMoved(ev *desktop.MouseEvent) {
+	h.resizeHovered = !h.column && h.dividerAt(ev.Position.X) >= 0
+}
+
+func (h *tableHeader) MouseOut() {
+	h.resizeHovered = false
+}
+
+func (h *tableHeader) Resize(s gui.Size) {
+	if s == h.size {
+		return
+	}
+	h.BaseWidget.Resize(s)
+	h.Refresh() // trigger a redraw
+}
+
+// Scrolled passes scroll events over the header on to the cells, so they scroll together.
+func (h *tableHeader) Scrolled(e *gui.ScrollEvent) {
+	h.t.scroll.Scrolled(e)
+}
+
+func (h *tableHeader) Tapped(e *gui.PointEvent) {
+	id, ok := h.cellAt(e.Position)
+	if !ok {
+		return
+	}
+
+	if f := h.t.OnHeaderTapped; f != nil {
+		f(id)
+	}
+}
+
+func (h *tableHeader) cellAt(pos gui.Position) (TableCellID, bool) {
+	rows, cols := 0, 0
+	if f := h.t.Length; f != nil {
+		rows, cols = h.t.Length()
+	}
+
+	if h.column {
+		row := h.t.rowPositions(h.t.templateSize().Height).indexAt(pos.Y + h.t.offset.Y)
+		return TableCellID{row, -1}, pos.Y >= 0 && row < rows
+	}
+
+	x := pos.X + h.t.offset.X
+	for col := 0; col < cols; col++ {
+		cellX, width := h.t.findX(col)
+		if x >= cellX && x < cellX+width {
+			return TableCellID{-1, col}, true
+		}
+	}
+	return TableCellID{}, false
+}
+
+// dividerAt returns the column whose trailing divider is near the position in the header row, or -1.
+func (h *tableHeader) dividerAt(x float32) int {
+	cols := 0
+	if f := h.t.Length; f != nil {
+		_, cols = h.t.Length()
+	}
+
+	x += h.t.offset.X
+	cellX := float32(0)
+	cellSize := h.t.templateSize()
+	for col := 0; col < cols; col++ {
+		width := cellSize.Width
+		if w, ok := h.t.columnWidths[col]; ok {
+			width = w
+		}
+
+		edge := cellX + width + theme.SeparatorThicknessSize()/2
+		if x >= edge-theme.Padding() && x <= edge+theme.Padding() {
+			return col
+		}
+		if edge > x+theme.Padding() {
+			break
+		}
+		cellX += width + theme.SeparatorThicknessSize()
 	}
-	return int(gui.Min(float32(rows), float32(dataRows)))
+	return -1
+}
+
+// Declare conformity with WidgetRenderer interface.
+var _ gui.WidgetRenderer = (*tableHeaderRenderer)(nil)
+
+type tableHeaderRenderer struct {
+	widget.BaseRenderer
+
+	header  *tableHeader
+	pool    pool
+	visible map[int]gui.CanvasObject
+}
+
+func (r *tableHeaderRenderer) Layout(_ gui.Size) {
+	// we deal with cached objects so just refresh instead
+}
+
+func (r *tableHeaderRenderer) MinSize() gui.Size {
+	return r.header.t.headerTemplateSize()
+}
+
+func (r *tableHeaderRenderer) Refresh() {
+	t := r.header.t
+	dataRows, dataCols := 0, 0
+	if f := t.Length; f != nil {
+		dataRows, dataCols = t.Length()
+	}
+	cellSize := t.templateSize()
+	headerSize := t.headerTemplateSize()
+	separatorThickness := theme.SeparatorThicknessSize()
+
+	wasVisible := r.visible
+	r.visible = make(map[int]gui.CanvasObject)
+	var cells []gui.CanvasObject
+	place := func(i int, id TableCellID, pos gui.Position, size gui.Size) {
+		c, ok := wasVisible[i]
+		if !ok {
+			c = r.pool.Obtain()
+			if c == nil {
+				c = t.createHeader()
+			}
+		}
+
+		c.Move(pos)
+		c.Resize(size)
+		t.updateHeader(id, c)
+		r.visible[i] = c
+		cells = append(cells, c)
+	}
+
+	if r.header.column {
+		rowIndex := t.rowPositions(cellSize.Height)
+		minRow := rowIndex.indexAt(t.offset.Y)
+		maxRow := int(gui.Min(float32(rowIndex.indexAt(t.offset.Y+r.header.Size().Height)+1), float32(dataRows)))
+		for row := minRow; row < maxRow; row++ {
+			rowY, rowHeight := rowIndex.position(row)
+			place(row, TableCellID{row, -1}, gui.NewPos(0, rowY-t.offset.Y), gui.NewSize(headerSize.Width, rowHeight))
+		}
+	} else {
+		visibleColWidths, offX, minCol, maxCol := t.visibleColumnWidths(cellSize.Width, dataCols)
+		if len(visibleColWidths) > 0 {
+			x := offX
+			for col := minCol; col < maxCol; col++ {
+				width := visibleColWidths[col]
+				place(col, TableCellID{-1, col}, gui.NewPos(x-t.offset.X, 0), gui.NewSize(width, headerSize.Height))
+				x += width + separatorThickness
+			}
+		}
+	}
+
+	for i, old := range wasVisible {
+		if _, ok := r.visible[i]; !ok {
+			r.pool.Release(old)
+		}
+	}
+	r.SetObjects(cells)
+}
+
+func containsTableCell(cells []TableCellID, id TableCellID) bool {
+	for _, cell := range cells {
+		if cell == id {
+			return true
+		}
+	}
+	return false
+}
+
+func sortTableCells(cells []TableCellID) {
+	sort.Slice(cells, func(i, j int) bool {
+		if cells[i].Row == cells[j].Row {
+			return cells[i].Col < cells[j].Col
+		}
+		return cells[i].Row < cells[j].Row
+	})
 }
@@ -27,6 +27,8 @@ import (
 
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/internal/painter"
 	"github.com/bhojpur/gui/pkg/engine/test"
 	"github.com/bhojpur/gui/pkg/engine/theme"
 
@@ -121,6 +123,173 @@ func TestTable_Filled(t *testing.T) {
 	test.AssertImageMatches(t, "table/filled.png", w.Canvas().Capture())
 }
 
+func TestTable_Headers(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+	test.ApplyTheme(t, theme.LightTheme())
+	painter.ClearFontCache() // the bold headers must not use fonts cached by earlier theme changes
+
+	table := NewTable(
+		func() (int, int) { return 30, 30 },
+		func() gui.CanvasObject {
+			return NewLabel("Cell 000, 000")
+		},
+		func(id TableCellID, c gui.CanvasObject) {
+			c.(*Label).SetText(fmt.Sprintf("Cell %d, %d", id.Row, id.Col))
+		})
+	table.ShowHeaderRow = true
+	table.ShowHeaderColumn = true
+
+	w := test.NewWindow(table)
+	defer w.Close()
+	w.Resize(gui.NewSize(300, 200))
+	test.AssertRendersToMarkup(t, "table/headers.xml", w.Canvas())
+
+	renderer := test.WidgetRenderer(table).(*tableRenderer)
+	off := table.headerOffset()
+	assert.Equal(t, off, renderer.scroll.Position())
+	assert.Equal(t, table.Size().Subtract(off), renderer.scroll.Size())
+	assert.True(t, table.headerRow.Visible())
+	assert.True(t, table.headerColumn.Visible())
+
+	rowHeaders := test.WidgetRenderer(table.headerRow).Objects()
+	assert.Equal(t, "A", rowHeaders[0].(*Label).Text)
+	assert.Equal(t, "B", rowHeaders[1].(*Label).Text)
+	colHeaders := test.WidgetRenderer(table.headerColumn).Objects()
+	assert.Equal(t, "1", colHeaders[0].(*Label).Text)
+	assert.Equal(t, float32(0), colHeaders[0].Position().Y)
+
+	table.ScrollTo(TableCellID{Row: 10, Col: 3})
+	rowHeaders = test.WidgetRenderer(table.headerRow).Objects()
+	assert.Equal(t, "B", rowHeaders[0].(*Label).Text)
+	cellX, _ := table.findX(1)
+	assert.Equal(t, cellX-table.offset.X, rowHeaders[0].Position().X)
+	colHeaders = test.WidgetRenderer(table.headerColumn).Objects()
+	_, cellHeight := table.findY(0)
+	firstRow := int(table.offset.Y / (cellHeight + theme.SeparatorThicknessSize()))
+	cellY, _ := table.findY(firstRow)
+	assert.Equal(t, fmt.Sprintf("%d", firstRow+1), colHeaders[0].(*Label).Text)
+	assert.Equal(t, cellY-table.offset.Y, colHeaders[0].Position().Y)
+
+	table.ShowHeaderColumn = false
+	table.Refresh()
+	assert.False(t, table.headerColumn.Visible())
+	assert.Equal(t, float32(0), renderer.scroll.Position().X)
+	assert.Equal(t, off.Y, renderer.scroll.Position().Y)
+}
+
+func TestTable_HeaderCustom(t *testing.T) {
+	table := NewTable(
+		func() (int, int) { return 3, 3 },
+		func() gui.CanvasObject { return NewLabel("Cell") },
+		func(TableCellID, gui.CanvasObject) {})
+	table.ShowHeaderRow = true
+	table.CreateHeader = func() gui.CanvasObject { return NewButton("Header", nil) }
+	table.UpdateHeader = func(id TableCellID, o gui.CanvasObject) {
+		o.(*Button).SetText(fmt.Sprintf("Column %d", id.Col))
+	}
+	var tapped []TableCellID
+	table.OnHeaderTapped = func(id TableCellID) {
+		tapped = append(tapped, id)
+	}
+	table.Resize(gui.NewSize(300, 200))
+
+	headers := test.WidgetRenderer(table.headerRow).Objects()
+	assert.Equal(t, "Column 0", headers[0].(*Button).Text)
+	assert.Equal(t, "Column 2", headers[2].(*Button).Text)
+	assert.Equal(t, NewButton("Header", nil).MinSize().Height, headers[0].Size().Height)
+
+	cellX, _ := table.findX(1)
+	test.TapAt(table.headerRow, gui.NewPos(cellX+5, 5))
+	test.TapAt(table.headerRow, gui.NewPos(290, 5))
+	assert.Equal(t, []TableCellID{{Row: -1, Col: 1}}, tapped)
+}
+
+func TestTable_HeaderTemplateSize(t *testing.T) {
+	table := NewTable(
+		func() (int, int) { return 30, 3 },
+		func() gui.CanvasObject { return NewLabel("Cell") },
+		func(TableCellID, gui.CanvasObject) {})
+	table.ShowHeaderRow = true
+	created := 0
+	table.CreateHeader = func() gui.CanvasObject {
+		created++
+		return NewLabel("Header")
+	}
+	table.Resize(gui.NewSize(300, 200))
+
+	count := created
+	table.headerOffset()
+	table.ScrollTo(TableCellID{Row: 20, Col: 0})
+	assert.Equal(t, count, created)
+	assert.Equal(t, NewLabel("Header").MinSize(), table.headerTemplateSize())
+
+	table.CreateHeader = func() gui.CanvasObject {
+		return NewButton("Header", nil)
+	}
+	table.Refresh()
+	assert.Equal(t, NewButton("Header", nil).MinSize(), table.headerTemplateSize())
+}
+
+func TestTable_HeaderColumnTapped(t *testing.T) {
+	table := NewTable(
+		func() (int, int) { return 3, 3 },
+		func() gui.CanvasObject { return NewLabel("Cell") },
+		func(TableCellID, gui.CanvasObject) {})
+	table.ShowHeaderColumn = true
+	var tapped []TableCellID
+	table.OnHeaderTapped = func(id TableCellID) {
+		tapped = append(tapped, id)
+	}
+	table.Resize(gui.NewSize(300, 200))
+
+	_, cellHeight := table.findY(0)
+	test.TapAt(table.headerColumn, gui.NewPos(5, cellHeight+theme.SeparatorThicknessSize()+5))
+	test.TapAt(table.headerColumn, gui.NewPos(5, 190))
+	assert.Equal(t, []TableCellID{{Row: 1, Col: -1}}, tapped)
+}
+
+func TestTable_HeaderResize(t *testing.T) {
+	table := NewTable(
+		func() (int, int) { return 3, 3 },
+		func() gui.CanvasObject { return NewLabel("Cell") },
+		func(TableCellID, gui.CanvasObject) {})
+	table.ShowHeaderRow = true
+	table.Resize(gui.NewSize(300, 200))
+
+	_, width := table.findX(0)
+	edge := width + theme.SeparatorThicknessSize()/2
+	table.headerRow.MouseIn(&desktop.MouseEvent{PointEvent: gui.PointEvent{Position: gui.NewPos(edge, 5)}})
+	assert.Equal(t, desktop.HResizeCursor, table.headerRow.Cursor())
+	table.headerRow.MouseMoved(&desktop.MouseEvent{PointEvent: gui.PointEvent{Position: gui.NewPos(edge/2, 5)}})
+	assert.Equal(t, desktop.DefaultCursor, table.headerRow.Cursor())
+
+	drag := &gui.DragEvent{Dragged: gui.NewDelta(10, 0)}
+	drag.Position = gui.NewPos(edge+10, 5)
+	table.headerRow.Dragged(drag)
+	drag.Position = gui.NewPos(edge+20, 5)
+	table.headerRow.Dragged(drag)
+	table.headerRow.DragEnd()
+	assert.Equal(t, width+20, table.columnWidths[0])
+
+	_, width1 := table.findX(1)
+	drag = &gui.DragEvent{Dragged: gui.NewDelta(-10, 0)}
+	drag.Position = gui.NewPos(edge/2-10, 5)
+	table.headerRow.Dragged(drag)
+	table.headerRow.DragEnd()
+	assert.Equal(t, width+20, table.columnWidths[0])
+	_, ok := table.columnWidths[1]
+	assert.False(t, ok)
+
+	drag = &gui.DragEvent{Dragged: gui.NewDelta(-1000, 0)}
+	drag.Position = gui.NewPos(edge+20-1000, 5)
+	table.headerRow.Dragged(drag)
+	table.headerRow.DragEnd()
+	assert.Equal(t, theme.IconInlineSize(), table.columnWidths[0])
+	_, w := table.findX(1)
+	assert.Equal(t, width1, w)
+}
+
 func TestTable_MinSize(t *testing.T) {
 	for name, tt := range map[string]struct {
 		cellSize        gui.Size
@@ -509,6 +678,100 @@ func TestTable_Select(t *testing.T) {
 	test.AssertRendersToMarkup(t, "table/selected_scrolled.xml", w.Canvas())
 }
 
+func TestTable_SelectMultiple(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	table := NewTable(
+		func() (int, int) { return 5, 5 },
+		func() gui.CanvasObject {
+			return NewLabel("placeholder")
+		},
+		func(id TableCellID, c gui.CanvasObject) {
+			text := fmt.Sprintf("Cell %d, %d", id.Row, id.Col)
+			c.(*Label).SetText(text)
+		})
+	table.SelectionMode = SelectionMultiple
+	var changed []TableCellID
+	table.OnSelectionChanged = func(selected []TableCellID) {
+		changed = selected
+	}
+
+	w := test.NewWindow(table)
+	defer w.Close()
+	w.Resize(gui.NewSize(180, 180))
+
+	tapWith := func(pos gui.Position, mod gui.KeyModifier) {
+		table.cells.MouseDown(&desktop.MouseEvent{Modifier: mod})
+		test.TapCanvas(w.Canvas(), pos)
+	}
+	tapWith(gui.NewPos(35, 58), 0)
+	assert.Equal(t, []TableCellID{{1, 0}}, changed)
+	assert.Equal(t, table, w.Canvas().Focused())
+	tapWith(gui.NewPos(35, 100), gui.KeyModifierShortcutDefault)
+	assert.Equal(t, []TableCellID{{1, 0}, {2, 0}}, changed)
+	tapWith(gui.NewPos(35, 58), gui.KeyModifierShortcutDefault)
+	assert.Equal(t, []TableCellID{{2, 0}}, changed)
+
+	tapWith(gui.NewPos(120, 100), gui.KeyModifierShift)
+	assert.Equal(t, []TableCellID{{1, 0}, {1, 1}, {2, 0}, {2, 1}}, table.Selected())
+	assert.Equal(t, TableCellID{2, 1}, *table.selectedCell)
+	test.AssertRendersToMarkup(t, "table/selected_multiple.xml", w.Canvas())
+
+	table.Unselect(TableCellID{2, 1})
+	assert.Nil(t, table.selectedCell)
+	assert.Equal(t, []TableCellID{{1, 0}, {1, 1}, {2, 0}}, changed)
+
+	table.UnselectAll()
+	assert.Empty(t, changed)
+}
+
+func TestTable_SelectionKeys(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	table := NewTable(
+		func() (int, int) { return 50, 5 },
+		func() gui.CanvasObject {
+			return NewLabel("placeholder")
+		},
+		func(id TableCellID, c gui.CanvasObject) {
+			text := fmt.Sprintf("Cell %d, %d", id.Row, id.Col)
+			c.(*Label).SetText(text)
+		})
+	table.SelectionMode = SelectionMultiple
+
+	w := test.NewWindow(table)
+	defer w.Close()
+	w.Resize(gui.NewSize(180, 180))
+
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []TableCellID{{0, 0}}, table.Selected())
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyRight})
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []TableCellID{{1, 1}}, table.Selected())
+
+	table.KeyDown(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyLeft})
+	assert.Equal(t, []TableCellID{{1, 0}, {1, 1}, {2, 0}, {2, 1}}, table.Selected())
+	table.KeyUp(&gui.KeyEvent{Name: desktop.KeyShiftLeft})
+
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyEnd})
+	assert.Equal(t, []TableCellID{{2, 4}}, table.Selected())
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyHome})
+	assert.Equal(t, []TableCellID{{2, 0}}, table.Selected())
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyPageDown})
+	assert.Equal(t, []TableCellID{{2 + table.pageLength(), 0}}, table.Selected())
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyPageUp})
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeyPageUp})
+	assert.Equal(t, []TableCellID{{0, 0}}, table.Selected())
+
+	table.TypedKey(&gui.KeyEvent{Name: gui.KeySpace})
+	assert.Empty(t, table.Selected())
+}
+
 func TestTable_SetColumnWidth(t *testing.T) {
 	test.NewApp()
 	defer test.NewApp()
@@ -549,6 +812,64 @@ func TestTable_SetColumnWidth(t *testing.T) {
 	test.AssertImageMatches(t, "table/col_size.png", w.Canvas().Capture())
 }
 
+func TestTable_SetRowHeight(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	table := NewTable(
+		func() (int, int) { return 50, 2 },
+		func() gui.CanvasObject {
+			return NewLabel("Cell 00, 0")
+		},
+		func(id TableCellID, c gui.CanvasObject) {
+			c.(*Label).SetText(fmt.Sprintf("Cell %d, %d", id.Row, id.Col))
+		})
+	w := test.NewWindow(table)
+	defer w.Close()
+	w.Resize(gui.NewSize(200, 200))
+
+	_, cellHeight := table.findY(0)
+	separatorThickness := theme.SeparatorThicknessSize()
+	contentHeight := test.WidgetRenderer(table.cells).MinSize().Height
+	index := table.rowPositions(cellHeight)
+	table.SetRowHeight(1, 80)
+	table.SetRowHeight(2, cellHeight/2)
+	assert.Same(t, index, table.rowPositions(cellHeight))
+	assert.Equal(t, contentHeight+80+cellHeight/2-2*cellHeight, test.WidgetRenderer(table.cells).MinSize().Height)
+
+	y, height := table.findY(1)
+	assert.Equal(t, cellHeight+separatorThickness, y)
+	assert.Equal(t, float32(80), height)
+	y, height = table.findY(3)
+	assert.Equal(t, cellHeight+80+cellHeight/2+3*separatorThickness, y)
+	assert.Equal(t, cellHeight, height)
+
+	cells := test.WidgetRenderer(table.cells).(*tableCellsRenderer)
+	assert.Equal(t, gui.NewPos(0, cellHeight+separatorThickness), cells.visible[TableCellID{1, 0}].Position())
+	assert.Equal(t, float32(80), cells.visible[TableCellID{1, 0}].Size().Height)
+	assert.Equal(t, cellHeight/2, cells.visible[TableCellID{2, 1}].Size().Height)
+
+	test.TapAt(table.cells, gui.NewPos(5, cellHeight+70))
+	assert.Equal(t, TableCellID{1, 0}, *table.selectedCell)
+	test.TapAt(table.cells, gui.NewPos(5, cellHeight+80+2*separatorThickness+cellHeight/2+1))
+	assert.Equal(t, TableCellID{3, 0}, *table.selectedCell)
+
+	table.SetRowHeight(40, 300)
+	table.ScrollTo(TableCellID{40, 0})
+	y, height = table.findY(40)
+	assert.Equal(t, y+height-table.scroll.Size().Height, table.offset.Y)
+	_, ok := cells.visible[TableCellID{40, 0}]
+	assert.True(t, ok)
+	_, ok = cells.visible[TableCellID{10, 0}]
+	assert.False(t, ok)
+
+	table.ScrollToBottom()
+	y, height = table.findY(49)
+	assert.Equal(t, y+height-table.scroll.Size().Height, table.offset.Y)
+	_, ok = cells.visible[TableCellID{49, 1}]
+	assert.True(t, ok)
+}
+
 func TestTable_ShowVisible(t *testing.T) {
 	table := NewTable(
 		func() (int, int) { return 50, 50 },
@@ -0,0 +1,444 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"image/color"
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/internal/cache"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+const (
+	terminalDefaultRows = 24
+	terminalDefaultCols = 80
+)
+
+// Declare conformity with interfaces.
+var _ gui.Widget = (*Terminal)(nil)
+var _ gui.Focusable = (*Terminal)(nil)
+var _ gui.Shortcutable = (*Terminal)(nil)
+var _ gui.Tappable = (*Terminal)(nil)
+var _ io.Writer = (*Terminal)(nil)
+
+// Terminal is a VT100/xterm compatible terminal emulator that displays its screen in a TextGrid.
+// Output of a program is fed to the terminal through Write or RunWithConnection and
+// keyboard input is encoded as escape sequences and sent to the connection.
+//
+// Since: 2.3
+type Terminal struct {
+	BaseWidget
+
+	// OnResized is called when the number of rows or columns of the screen changes,
+	// for example to update the window size of a connected PTY.
+	OnResized func(rows, cols int)
+	// OnTitleChanged is called when the program sets the window title.
+	OnTitleChanged func(title string)
+
+	content *TextGrid
+	conn    io.Writer
+	focused bool
+
+	lock       sync.Mutex
+	rows, cols int
+	screen     terminalScreen
+	parser     terminalParser
+	title      string
+	replies    []byte
+}
+
+// NewTerminal creates a new terminal widget with a screen of 24 rows and 80 columns.
+// The screen is resized to fill the widget once it is laid out.
+//
+// Since: 2.3
+func NewTerminal() *Terminal {
+	t := &Terminal{content: NewTextGrid()}
+	t.ExtendBaseWidget(t)
+	t.reset(terminalDefaultRows, terminalDefaultCols)
+	return t
+}
+
+// CreateRenderer is a private method to Bhojpur GUI which links this widget to its renderer
+func (t *Terminal) CreateRenderer() gui.WidgetRenderer {
+	t.ExtendBaseWidget(t)
+	cursor := canvas.NewRectangle(theme.FocusColor())
+	r := &terminalRenderer{terminal: t, cursor: cursor, objects: []gui.CanvasObject{t.content, cursor}}
+	r.moveCursor()
+	return r
+}
+
+// CursorPosition returns the row and column of the cursor on the screen, starting at 0.
+//
+// Since: 2.3
+func (t *Terminal) CursorPosition() (row, col int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.screen.row, t.screen.col
+}
+
+// FocusGained is called when the terminal has been given focus.
+//
+// Implements: gui.Focusable
+func (t *Terminal) FocusGained() {
+	t.lock.Lock()
+	t.focused = true
+	t.lock.Unlock()
+	t.Refresh()
+}
+
+// FocusLost is called when the terminal has had focus removed.
+//
+// Implements: gui.Focusable
+func (t *Terminal) FocusLost() {
+	t.lock.Lock()
+	t.focused = false
+	t.lock.Unlock()
+	t.Refresh()
+}
+
+// MinSize returns the size that this widget should not shrink below.
+func (t *Terminal) MinSize() gui.Size {
+	t.ExtendBaseWidget(t)
+	return t.BaseWidget.MinSize()
+}
+
+// RunWithConnection connects the terminal to conn, usually a PTY running a shell.
+// Output read from conn is displayed and keyboard input is written to it.
+// This call blocks until reading from conn fails, and returns nil if it reached the end of the stream.
+//
+// Since: 2.3
+func (t *Terminal) RunWithConnection(conn io.ReadWriter) error {
+	t.lock.Lock()
+	t.conn = conn
+	t.lock.Unlock()
+
+	buf := make([]byte, 4096)
+	for {
+		n, err := conn.Read(buf)
+		if n > 0 {
+			_, _ = t.Write(buf[:n])
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// GridSize returns the number of rows and columns of the terminal screen.
+//
+// Since: 2.3
+func (t *Terminal) GridSize() (rows, cols int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.rows, t.cols
+}
+
+// Tapped is called when a pointer tapped event is captured and requests focus for the terminal.
+//
+// Implements: gui.Tappable
+func (t *Terminal) Tapped(*gui.PointEvent) {
+	if c := gui.CurrentApp().Driver().CanvasForObject(t.super()); c != nil {
+		c.Focus(t.super().(gui.Focusable))
+	}
+}
+
+// Text returns the characters on the screen, with trailing spaces removed from each row.
+//
+// Since: 2.3
+func (t *Terminal) Text() string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	lines := make([]string, len(t.content.Rows))
+	for i, row := range t.content.Rows {
+		runes := make([]rune, len(row.Cells))
+		for j, cell := range row.Cells {
+			runes[j] = cell.Rune
+			if runes[j] == 0 {
+				runes[j] = ' '
+			}
+		}
+		lines[i] = strings.TrimRight(string(runes), " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Title returns the window title most recently set by the program running in the terminal.
+//
+// Since: 2.3
+func (t *Terminal) Title() string {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.title
+}
+
+// TypedKey is called when a key is pressed and sends the escape sequence for it to the connection.
+//
+// Implements: gui.Focusable
+func (t *Terminal) TypedKey(ev *gui.KeyEvent) {
+	t.lock.Lock()
+	appCursor := t.screen.appCursor
+	t.lock.Unlock()
+
+	if seq := terminalKeySequence(ev.Name, appCursor); seq != "" {
+		t.send([]byte(seq))
+	}
+}
+
+// TypedRune is called when text is input and sends it to the connection.
+//
+// Implements: gui.Focusable
+func (t *Terminal) TypedRune(r rune) {
+	buf := make([]byte, utf8.UTFMax)
+	t.send(buf[:utf8.EncodeRune(buf, r)])
+}
+
+// TypedShortcut handles shortcuts. Paste sends the clipboard content, bracketed if the program asked for it,
+// and control key combinations are sent as the matching control character.
+//
+// Implements: gui.Shortcutable
+func (t *Terminal) TypedShortcut(shortcut gui.Shortcut) {
+	if paste, ok := shortcut.(*gui.ShortcutPaste); ok {
+		t.paste(paste.Clipboard.Content())
+		return
+	}
+
+	// Control with a letter sends a control character, also when Shift is held. Shortcuts such as copy are
+	// reported with the shortcut modifier, which is Super on macOS, so that is treated as Control too.
+	key, ok := shortcut.(gui.KeyboardShortcut)
+	if !ok || key.Mod()&(gui.KeyModifierControl|gui.KeyModifierShortcutDefault) == 0 || len(key.Key()) != 1 {
+		return
+	}
+	if c := key.Key()[0]; c >= 'A' && c <= 'Z' {
+		t.send([]byte{c - 'A' + 1})
+	}
+}
+
+// Write processes the output of a program, updating the screen for the text and escape sequences it contains.
+//
+// Implements: io.Writer
+func (t *Terminal) Write(p []byte) (int, error) {
+	t.lock.Lock()
+	oldTitle := t.title
+	for _, b := range p {
+		t.parser.handle(t, b)
+	}
+	title := t.title
+	replies := t.replies
+	t.replies = nil
+	t.lock.Unlock()
+
+	if len(replies) > 0 {
+		t.send(replies)
+	}
+	if title != oldTitle && t.OnTitleChanged != nil {
+		t.OnTitleChanged(title)
+	}
+	t.Refresh()
+	return len(p), nil
+}
+
+func (t *Terminal) paste(text string) {
+	t.lock.Lock()
+	bracketed := t.screen.bracketedPaste
+	t.lock.Unlock()
+
+	text = strings.ReplaceAll(text, "\r\n", "\r")
+	text = strings.ReplaceAll(text, "\n", "\r")
+	if bracketed {
+		text = "\x1b[200~" + text + "\x1b[201~"
+	}
+	t.send([]byte(text))
+}
+
+func (t *Terminal) send(data []byte) {
+	t.lock.Lock()
+	conn := t.conn
+	t.lock.Unlock()
+
+	if conn != nil {
+		_, _ = conn.Write(data)
+	}
+}
+
+// setGridSize resizes the screen to fit rows and columns, returning true if it changed.
+func (t *Terminal) setGridSize(rows, cols int) bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if rows < 1 {
+		rows = 1
+	}
+	if cols < 1 {
+		cols = 1
+	}
+	if rows == t.rows && cols == t.cols {
+		return false
+	}
+
+	t.resize(rows, cols)
+	return true
+}
+
+// terminalKeySequence returns the bytes that xterm sends for the named key.
+func terminalKeySequence(key gui.KeyName, appCursor bool) string {
+	cursorPrefix := "\x1b["
+	if appCursor {
+		cursorPrefix = "\x1bO"
+	}
+
+	switch key {
+	case gui.KeyUp:
+		return cursorPrefix + "A"
+	case gui.KeyDown:
+		return cursorPrefix + "B"
+	case gui.KeyRight:
+		return cursorPrefix + "C"
+	case gui.KeyLeft:
+		return cursorPrefix + "D"
+	case gui.KeyHome:
+		return cursorPrefix + "H"
+	case gui.KeyEnd:
+		return cursorPrefix + "F"
+	case gui.KeyReturn, gui.KeyEnter:
+		return "\r"
+	case gui.KeyBackspace:
+		return "\x7f"
+	case gui.KeyTab:
+		return "\t"
+	case gui.KeyEscape:
+		return "\x1b"
+	case gui.KeyInsert:
+		return "\x1b[2~"
+	case gui.KeyDelete:
+		return "\x1b[3~"
+	case gui.KeyPageUp:
+		return "\x1b[5~"
+	case gui.KeyPageDown:
+		return "\x1b[6~"
+	case gui.KeyF1:
+		return "\x1bOP"
+	case gui.KeyF2:
+		return "\x1bOQ"
+	case gui.KeyF3:
+		return "\x1bOR"
+	case gui.KeyF4:
+		return "\x1bOS"
+	case gui.KeyF5:
+		return "\x1b[15~"
+	case gui.KeyF6:
+		return "\x1b[17~"
+	case gui.KeyF7:
+		return "\x1b[18~"
+	case gui.KeyF8:
+		return "\x1b[19~"
+	case gui.KeyF9:
+		return "\x1b[20~"
+	case gui.KeyF10:
+		return "\x1b[21~"
+	case gui.KeyF11:
+		return "\x1b[23~"
+	case gui.KeyF12:
+		return "\x1b[24~"
+	}
+	return ""
+}
+
+type terminalRenderer struct {
+	terminal *Terminal
+	cursor   *canvas.Rectangle
+	objects  []gui.CanvasObject
+}
+
+func (r *terminalRenderer) Destroy() {
+}
+
+func (r *terminalRenderer) Layout(size gui.Size) {
+	cell := r.cellSize()
+	resized := r.terminal.setGridSize(int(size.Height/cell.Height), int(size.Width/cell.Width))
+
+	r.terminal.lock.Lock()
+	r.terminal.content.Resize(size)
+	r.terminal.lock.Unlock()
+	r.moveCursor()
+
+	if f := r.terminal.OnResized; resized && f != nil {
+		f(r.terminal.GridSize())
+	}
+}
+
+func (r *terminalRenderer) MinSize() gui.Size {
+	cell := r.cellSize()
+	return gui.NewSize(cell.Width, cell.Height)
+}
+
+func (r *terminalRenderer) Objects() []gui.CanvasObject {
+	return r.objects
+}
+
+func (r *terminalRenderer) Refresh() {
+	r.terminal.lock.Lock()
+	r.terminal.content.Refresh()
+	r.terminal.lock.Unlock()
+	r.moveCursor()
+}
+
+func (r *terminalRenderer) cellSize() gui.Size {
+	return cache.Renderer(r.terminal.content).(*textGridRenderer).cellSize
+}
+
+func (r *terminalRenderer) moveCursor() {
+	r.terminal.lock.Lock()
+	row, col, hidden := r.terminal.screen.row, r.terminal.screen.col, r.terminal.screen.cursorHidden
+	focused := r.terminal.focused
+	r.terminal.lock.Unlock()
+
+	if hidden {
+		r.cursor.Hide()
+		return
+	}
+
+	cell := r.cellSize()
+	r.cursor.StrokeColor = theme.PrimaryColor()
+	r.cursor.StrokeWidth = 1
+	if focused {
+		r.cursor.FillColor = theme.FocusColor()
+	} else {
+		r.cursor.FillColor = color.Transparent
+	}
+	r.cursor.Move(gui.NewPos(float32(col)*cell.Width, float32(row)*cell.Height))
+	r.cursor.Resize(gui.NewSize(cell.Width, cell.Height))
+	r.cursor.Show()
+	r.cursor.Refresh()
+}
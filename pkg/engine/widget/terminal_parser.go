@@ -0,0 +1,716 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+type terminalParserState int
+
+const (
+	terminalStateGround terminalParserState = iota
+	terminalStateEscape
+	terminalStateCharset
+	terminalStateCSI
+	terminalStateOSC
+	terminalStateOSCEscape
+)
+
+// terminalParser splits the output of a program into text, control characters and escape sequences.
+type terminalParser struct {
+	state  terminalParserState
+	buf    []byte // parameters of the current sequence, or the bytes of an incomplete UTF-8 rune
+	inter  []byte // intermediate bytes of the current control sequence
+	active bool   // a rune is being decoded in buf
+}
+
+// terminalStyle is the graphic rendition that characters are written with.
+type terminalStyle struct {
+	fg, bg  color.Color
+	reverse bool
+}
+
+type terminalCursor struct {
+	row, col int
+	style    terminalStyle
+}
+
+// terminalScreen is the state of the emulated screen, apart from its content which is kept in the TextGrid.
+type terminalScreen struct {
+	row, col    int
+	wrapNext    bool // the last column has been written and the next character wraps
+	top, bottom int  // scrolling region
+	style       terminalStyle
+	cellStyle   TextGridStyle
+	saved       terminalCursor
+
+	appCursor, autoWrap, bracketedPaste, cursorHidden bool
+
+	alt        bool
+	mainRows   []TextGridRow
+	mainCursor terminalCursor
+}
+
+func (p *terminalParser) handle(t *Terminal, b byte) {
+	switch p.state {
+	case terminalStateGround:
+		p.ground(t, b)
+	case terminalStateEscape:
+		p.escape(t, b)
+	case terminalStateCharset:
+		p.state = terminalStateGround // character sets other than UTF-8 are not supported
+	case terminalStateCSI:
+		p.csi(t, b)
+	case terminalStateOSC:
+		switch b {
+		case 0x07:
+			t.osc(string(p.buf))
+			p.state = terminalStateGround
+		case 0x1b:
+			p.state = terminalStateOSCEscape
+		default:
+			p.buf = append(p.buf, b)
+		}
+	case terminalStateOSCEscape:
+		t.osc(string(p.buf))
+		p.state = terminalStateGround
+		if b != '\\' {
+			p.handle(t, b)
+		}
+	}
+}
+
+func (p *terminalParser) ground(t *Terminal, b byte) {
+	if p.active {
+		if b&0xc0 == 0x80 {
+			p.buf = append(p.buf, b)
+			if !utf8.FullRune(p.buf) {
+				return
+			}
+			r, _ := utf8.DecodeRune(p.buf)
+			p.active = false
+			t.put(r)
+			return
+		}
+		p.active = false
+		t.put(utf8.RuneError)
+	}
+
+	switch {
+	case b == 0x1b:
+		p.state = terminalStateEscape
+		p.buf = p.buf[:0]
+		p.inter = p.inter[:0]
+	case b < 0x20 || b == 0x7f:
+		t.control(b)
+	case b < 0x80:
+		t.put(rune(b))
+	default:
+		p.buf = append(p.buf[:0], b)
+		p.active = true
+	}
+}
+
+func (p *terminalParser) escape(t *Terminal, b byte) {
+	p.state = terminalStateGround
+	switch b {
+	case '[':
+		p.state = terminalStateCSI
+	case ']':
+		p.state = terminalStateOSC
+	case '(', ')', '*', '+':
+		p.state = terminalStateCharset
+	case '7':
+		t.saveCursor()
+	case '8':
+		t.restoreCursor()
+	case 'D':
+		t.index()
+	case 'E':
+		t.screen.col = 0
+		t.index()
+	case 'M':
+		t.reverseIndex()
+	case 'c':
+		t.reset(t.rows, t.cols)
+	default:
+		if b < 0x20 {
+			t.control(b)
+			p.state = terminalStateEscape
+		}
+	}
+}
+
+func (p *terminalParser) csi(t *Terminal, b byte) {
+	switch {
+	case b == 0x1b:
+		p.state = terminalStateEscape
+	case b < 0x20:
+		t.control(b)
+	case b < 0x30:
+		p.inter = append(p.inter, b)
+	case b < 0x40:
+		p.buf = append(p.buf, b)
+	default:
+		p.state = terminalStateGround
+		if len(p.inter) == 0 {
+			t.dispatchCSI(string(p.buf), b)
+		}
+		p.buf = p.buf[:0]
+		p.inter = p.inter[:0]
+	}
+}
+
+func (t *Terminal) control(b byte) {
+	s := &t.screen
+	switch b {
+	case '\b':
+		if s.col > 0 {
+			s.col--
+		}
+		s.wrapNext = false
+	case '\t':
+		col := nextTab(s.col, t.content.tabWidth())
+		if col >= t.cols {
+			col = t.cols - 1
+		}
+		s.col = col
+		s.wrapNext = false
+	case '\n', '\v', '\f':
+		t.index()
+	case '\r':
+		s.col = 0
+		s.wrapNext = false
+	}
+}
+
+func (t *Terminal) dispatchCSI(params string, final byte) {
+	private := strings.HasPrefix(params, "?")
+	args := terminalParams(strings.TrimLeft(params, "<=>?"))
+	arg := func(i, def int) int {
+		if i >= len(args) || args[i] == 0 {
+			return def
+		}
+		return args[i]
+	}
+
+	s := &t.screen
+	switch final {
+	case 'A':
+		t.moveCursor(s.row-arg(0, 1), s.col)
+	case 'B', 'e':
+		t.moveCursor(s.row+arg(0, 1), s.col)
+	case 'C', 'a':
+		t.moveCursor(s.row, s.col+arg(0, 1))
+	case 'D':
+		t.moveCursor(s.row, s.col-arg(0, 1))
+	case 'E':
+		t.moveCursor(s.row+arg(0, 1), 0)
+	case 'F':
+		t.moveCursor(s.row-arg(0, 1), 0)
+	case 'G', '`':
+		t.moveCursor(s.row, arg(0, 1)-1)
+	case 'H', 'f':
+		t.moveCursor(arg(0, 1)-1, arg(1, 1)-1)
+	case 'd':
+		t.moveCursor(arg(0, 1)-1, s.col)
+	case 'J':
+		t.eraseDisplay(arg(0, 0))
+	case 'K':
+		t.eraseLine(arg(0, 0))
+	case 'L':
+		t.insertLines(arg(0, 1))
+	case 'M':
+		t.deleteLines(arg(0, 1))
+	case '@':
+		t.insertChars(arg(0, 1))
+	case 'P':
+		t.deleteChars(arg(0, 1))
+	case 'X':
+		t.eraseChars(s.row, s.col, s.col+arg(0, 1))
+	case 'S':
+		t.scrollUp(s.top, s.bottom, arg(0, 1))
+	case 'T':
+		t.scrollDown(s.top, s.bottom, arg(0, 1))
+	case 'm':
+		if !private {
+			t.setGraphics(args)
+		}
+	case 'r':
+		if !private {
+			t.setScrollRegion(arg(0, 1)-1, arg(1, t.rows)-1)
+		}
+	case 's':
+		t.saveCursor()
+	case 'u':
+		t.restoreCursor()
+	case 'h', 'l':
+		if private {
+			for _, mode := range args {
+				t.setMode(mode, final == 'h')
+			}
+		}
+	case 'n':
+		switch arg(0, 0) {
+		case 5:
+			t.replies = append(t.replies, "\x1b[0n"...)
+		case 6:
+			t.replies = append(t.replies, fmt.Sprintf("\x1b[%d;%dR", s.row+1, s.col+1)...)
+		}
+	case 'c':
+		if !private && arg(0, 0) == 0 {
+			t.replies = append(t.replies, "\x1b[?1;2c"...)
+		}
+	}
+}
+
+func (t *Terminal) osc(command string) {
+	parts := strings.SplitN(command, ";", 2)
+	if len(parts) == 2 && (parts[0] == "0" || parts[0] == "2") {
+		t.title = parts[1]
+	}
+}
+
+func (t *Terminal) put(r rune) {
+	s := &t.screen
+	if s.wrapNext {
+		s.col = 0
+		t.index()
+	}
+
+	t.content.Rows[s.row].Cells[s.col] = TextGridCell{Rune: r, Style: s.cellStyle}
+	if s.col < t.cols-1 {
+		s.col++
+	} else {
+		s.wrapNext = s.autoWrap
+	}
+}
+
+func (t *Terminal) reset(rows, cols int) {
+	t.rows, t.cols = rows, cols
+	t.screen = terminalScreen{bottom: rows - 1, autoWrap: true}
+	t.content.Rows = make([]TextGridRow, rows)
+	for i := range t.content.Rows {
+		t.content.Rows[i] = t.blankRow()
+	}
+}
+
+func (t *Terminal) resize(rows, cols int) {
+	s := &t.screen
+	t.content.Rows = resizeTerminalRows(t.content.Rows, rows, cols, s.row)
+	if s.row >= rows {
+		s.row = rows - 1
+	}
+	if s.alt {
+		s.mainRows = resizeTerminalRows(s.mainRows, rows, cols, s.mainCursor.row)
+		if s.mainCursor.row >= rows {
+			s.mainCursor.row = rows - 1
+		}
+	}
+
+	t.rows, t.cols = rows, cols
+	s.top, s.bottom = 0, rows-1
+	t.moveCursor(s.row, s.col)
+}
+
+// resizeTerminalRows changes the size of a screen, dropping rows from the top to keep the cursor row visible.
+func resizeTerminalRows(content []TextGridRow, rows, cols, cursorRow int) []TextGridRow {
+	if cursorRow >= rows {
+		content = content[cursorRow-rows+1:]
+	}
+	if len(content) > rows {
+		content = content[:rows]
+	}
+
+	resized := make([]TextGridRow, rows)
+	for i := range resized {
+		resized[i].Cells = make([]TextGridCell, cols)
+		if i < len(content) {
+			copy(resized[i].Cells, content[i].Cells)
+		}
+	}
+	return resized
+}
+
+func (t *Terminal) blankRow() TextGridRow {
+	cells := make([]TextGridCell, t.cols)
+	for i := range cells {
+		cells[i] = t.blankCell()
+	}
+	return TextGridRow{Cells: cells}
+}
+
+// blankCell returns an erased cell, which keeps the current background color.
+func (t *Terminal) blankCell() TextGridCell {
+	if t.screen.style.bg == nil {
+		return TextGridCell{}
+	}
+	return TextGridCell{Style: &CustomTextGridStyle{BGColor: t.screen.style.bg}}
+}
+
+func (t *Terminal) moveCursor(row, col int) {
+	s := &t.screen
+	s.row = clampIndex(row, t.rows)
+	s.col = clampIndex(col, t.cols)
+	s.wrapNext = false
+}
+
+func (t *Terminal) index() {
+	s := &t.screen
+	s.wrapNext = false
+	if s.row == s.bottom {
+		t.scrollUp(s.top, s.bottom, 1)
+	} else if s.row < t.rows-1 {
+		s.row++
+	}
+}
+
+func (t *Terminal) reverseIndex() {
+	s := &t.screen
+	s.wrapNext = false
+	if s.row == s.top {
+		t.scrollDown(s.top, s.bottom, 1)
+	} else if s.row > 0 {
+		s.row--
+	}
+}
+
+// scrollUp moves the rows from top to bottom up by n, adding blank rows at the bottom.
+func (t *Terminal) scrollUp(top, bottom, n int) {
+	rows := t.content.Rows[top : bottom+1]
+	if n > len(rows) {
+		n = len(rows)
+	}
+	copy(rows, rows[n:])
+	for i := len(rows) - n; i < len(rows); i++ {
+		rows[i] = t.blankRow()
+	}
+}
+
+// scrollDown moves the rows from top to bottom down by n, adding blank rows at the top.
+func (t *Terminal) scrollDown(top, bottom, n int) {
+	rows := t.content.Rows[top : bottom+1]
+	if n > len(rows) {
+		n = len(rows)
+	}
+	copy(rows[n:], rows)
+	for i := 0; i < n; i++ {
+		rows[i] = t.blankRow()
+	}
+}
+
+func (t *Terminal) insertLines(n int) {
+	s := &t.screen
+	if s.row < s.top || s.row > s.bottom {
+		return
+	}
+	t.scrollDown(s.row, s.bottom, n)
+	s.col = 0
+	s.wrapNext = false
+}
+
+func (t *Terminal) deleteLines(n int) {
+	s := &t.screen
+	if s.row < s.top || s.row > s.bottom {
+		return
+	}
+	t.scrollUp(s.row, s.bottom, n)
+	s.col = 0
+	s.wrapNext = false
+}
+
+func (t *Terminal) insertChars(n int) {
+	s := &t.screen
+	cells := t.content.Rows[s.row].Cells[s.col:]
+	if n > len(cells) {
+		n = len(cells)
+	}
+	copy(cells[n:], cells)
+	for i := 0; i < n; i++ {
+		cells[i] = t.blankCell()
+	}
+	s.wrapNext = false
+}
+
+func (t *Terminal) deleteChars(n int) {
+	s := &t.screen
+	cells := t.content.Rows[s.row].Cells[s.col:]
+	if n > len(cells) {
+		n = len(cells)
+	}
+	copy(cells, cells[n:])
+	for i := len(cells) - n; i < len(cells); i++ {
+		cells[i] = t.blankCell()
+	}
+	s.wrapNext = false
+}
+
+// eraseChars blanks the cells of row from column start up to, but not including, end.
+func (t *Terminal) eraseChars(row, start, end int) {
+	if end > t.cols {
+		end = t.cols
+	}
+	cells := t.content.Rows[row].Cells
+	for i := start; i < end; i++ {
+		cells[i] = t.blankCell()
+	}
+	t.screen.wrapNext = false
+}
+
+func (t *Terminal) eraseDisplay(mode int) {
+	s := &t.screen
+	switch mode {
+	case 0:
+		t.eraseChars(s.row, s.col, t.cols)
+		for row := s.row + 1; row < t.rows; row++ {
+			t.content.Rows[row] = t.blankRow()
+		}
+	case 1:
+		for row := 0; row < s.row; row++ {
+			t.content.Rows[row] = t.blankRow()
+		}
+		t.eraseChars(s.row, 0, s.col+1)
+	case 2, 3:
+		for row := range t.content.Rows {
+			t.content.Rows[row] = t.blankRow()
+		}
+	}
+}
+
+func (t *Terminal) eraseLine(mode int) {
+	s := &t.screen
+	switch mode {
+	case 0:
+		t.eraseChars(s.row, s.col, t.cols)
+	case 1:
+		t.eraseChars(s.row, 0, s.col+1)
+	case 2:
+		t.eraseChars(s.row, 0, t.cols)
+	}
+}
+
+func (t *Terminal) saveCursor() {
+	s := &t.screen
+	s.saved = terminalCursor{row: s.row, col: s.col, style: s.style}
+}
+
+func (t *Terminal) restoreCursor() {
+	s := &t.screen
+	t.moveCursor(s.saved.row, s.saved.col)
+	s.style = s.saved.style
+	s.cellStyle = s.style.gridStyle()
+}
+
+func (t *Terminal) setScrollRegion(top, bottom int) {
+	if bottom >= t.rows {
+		bottom = t.rows - 1
+	}
+	if top < 0 || top >= bottom {
+		return
+	}
+
+	t.screen.top, t.screen.bottom = top, bottom
+	t.moveCursor(0, 0)
+}
+
+func (t *Terminal) setMode(mode int, on bool) {
+	s := &t.screen
+	switch mode {
+	case 1:
+		s.appCursor = on
+	case 7:
+		s.autoWrap = on
+	case 25:
+		s.cursorHidden = !on
+	case 47, 1047, 1049:
+		t.setAltScreen(on, mode == 1049)
+	case 2004:
+		s.bracketedPaste = on
+	}
+}
+
+// setAltScreen switches between the main screen and the alternate screen, which has no scrolling history
+// and is used by full screen programs. The main screen is restored as it was when the program exits.
+func (t *Terminal) setAltScreen(on, saveCursor bool) {
+	s := &t.screen
+	if on == s.alt {
+		return
+	}
+
+	if on {
+		s.mainRows = t.content.Rows
+		s.mainCursor = terminalCursor{row: s.row, col: s.col, style: s.style}
+		s.alt = true
+		t.content.Rows = make([]TextGridRow, t.rows)
+		for i := range t.content.Rows {
+			t.content.Rows[i] = t.blankRow()
+		}
+		if saveCursor {
+			t.moveCursor(0, 0)
+		}
+		return
+	}
+
+	t.content.Rows = s.mainRows
+	s.mainRows = nil
+	s.alt = false
+	if saveCursor {
+		t.moveCursor(s.mainCursor.row, s.mainCursor.col)
+		s.style = s.mainCursor.style
+		s.cellStyle = s.style.gridStyle()
+	}
+}
+
+// setGraphics applies a "select graphic rendition" sequence to the current style.
+func (t *Terminal) setGraphics(args []int) {
+	s := &t.screen
+	if len(args) == 0 {
+		args = []int{0}
+	}
+
+	for i := 0; i < len(args); i++ {
+		switch a := args[i]; {
+		case a == 0:
+			s.style = terminalStyle{}
+		case a == 7:
+			s.style.reverse = true
+		case a == 27:
+			s.style.reverse = false
+		case a >= 30 && a <= 37:
+			s.style.fg = terminalColor(a - 30)
+		case a == 38:
+			s.style.fg, i = terminalExtendedColor(args, i)
+		case a == 39:
+			s.style.fg = nil
+		case a >= 40 && a <= 47:
+			s.style.bg = terminalColor(a - 40)
+		case a == 48:
+			s.style.bg, i = terminalExtendedColor(args, i)
+		case a == 49:
+			s.style.bg = nil
+		case a >= 90 && a <= 97:
+			s.style.fg = terminalColor(a - 90 + 8)
+		case a >= 100 && a <= 107:
+			s.style.bg = terminalColor(a - 100 + 8)
+		}
+	}
+	s.cellStyle = s.style.gridStyle()
+}
+
+// gridStyle returns the TextGrid style for characters written in this style, or nil for the default style.
+func (s terminalStyle) gridStyle() TextGridStyle {
+	fg, bg := s.fg, s.bg
+	if s.reverse {
+		if fg == nil {
+			fg = theme.ForegroundColor()
+		}
+		if bg == nil {
+			bg = theme.BackgroundColor()
+		}
+		fg, bg = bg, fg
+	}
+	if fg == nil && bg == nil {
+		return nil
+	}
+	return &CustomTextGridStyle{FGColor: fg, BGColor: bg}
+}
+
+var terminalBasicColors = []color.Color{
+	&color.NRGBA{R: 0, G: 0, B: 0, A: 255},
+	&color.NRGBA{R: 205, G: 0, B: 0, A: 255},
+	&color.NRGBA{R: 0, G: 205, B: 0, A: 255},
+	&color.NRGBA{R: 205, G: 205, B: 0, A: 255},
+	&color.NRGBA{R: 0, G: 0, B: 238, A: 255},
+	&color.NRGBA{R: 205, G: 0, B: 205, A: 255},
+	&color.NRGBA{R: 0, G: 205, B: 205, A: 255},
+	&color.NRGBA{R: 229, G: 229, B: 229, A: 255},
+	&color.NRGBA{R: 127, G: 127, B: 127, A: 255},
+	&color.NRGBA{R: 255, G: 0, B: 0, A: 255},
+	&color.NRGBA{R: 0, G: 255, B: 0, A: 255},
+	&color.NRGBA{R: 255, G: 255, B: 0, A: 255},
+	&color.NRGBA{R: 92, G: 92, B: 255, A: 255},
+	&color.NRGBA{R: 255, G: 0, B: 255, A: 255},
+	&color.NRGBA{R: 0, G: 255, B: 255, A: 255},
+	&color.NRGBA{R: 255, G: 255, B: 255, A: 255},
+}
+
+// terminalColor returns the color at index of the xterm 256 color palette.
+func terminalColor(index int) color.Color {
+	switch {
+	case index < 0 || index > 255:
+		return nil
+	case index < 16:
+		return terminalBasicColors[index]
+	case index < 232:
+		index -= 16
+		level := func(i int) uint8 {
+			if i == 0 {
+				return 0
+			}
+			return uint8(55 + i*40)
+		}
+		return &color.NRGBA{R: level(index / 36), G: level(index / 6 % 6), B: level(index % 6), A: 255}
+	default:
+		gray := uint8(8 + (index-232)*10)
+		return &color.NRGBA{R: gray, G: gray, B: gray, A: 255}
+	}
+}
+
+// terminalExtendedColor parses the "5;n" or "2;r;g;b" color following args[i],
+// returning the color and the index of the last argument used.
+func terminalExtendedColor(args []int, i int) (color.Color, int) {
+	if i+1 >= len(args) {
+		return nil, i
+	}
+
+	switch args[i+1] {
+	case 5:
+		if i+2 < len(args) {
+			return terminalColor(args[i+2]), i + 2
+		}
+	case 2:
+		if i+4 < len(args) {
+			return &color.NRGBA{R: uint8(args[i+2]), G: uint8(args[i+3]), B: uint8(args[i+4]), A: 255}, i + 4
+		}
+	}
+	return nil, len(args)
+}
+
+// terminalParams parses the numeric parameters of a control sequence, using 0 for those that are missing.
+func terminalParams(params string) []int {
+	if params == "" {
+		return nil
+	}
+
+	fields := strings.Split(strings.ReplaceAll(params, ":", ";"), ";")
+	args := make([]int, len(fields))
+	for i, field := range fields {
+		args[i], _ = strconv.Atoi(field)
+	}
+	return args
+}
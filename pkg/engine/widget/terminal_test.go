@@ -0,0 +1,198 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bytes"
+	"image/color"
+	"io"
+	"strings"
+	"testing"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
+	"github.com/bhojpur/gui/pkg/engine/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type terminalConn struct {
+	io.Reader
+	bytes.Buffer
+}
+
+func (c *terminalConn) Read(p []byte) (int, error) {
+	return c.Reader.Read(p)
+}
+
+func newTestTerminal(rows, cols int) (*Terminal, *terminalConn) {
+	term := NewTerminal()
+	term.setGridSize(rows, cols)
+	conn := &terminalConn{Reader: strings.NewReader("")}
+	term.conn = conn
+	return term, conn
+}
+
+func TestTerminal_Write(t *testing.T) {
+	term, _ := newTestTerminal(3, 5)
+	_, err := term.Write([]byte("ab\r\ncdefgh\x08\x08X"))
+	assert.NoError(t, err)
+	assert.Equal(t, "ab\ncdefg\nX", term.Text())
+	row, col := term.CursorPosition()
+	assert.Equal(t, 2, row)
+	assert.Equal(t, 1, col)
+
+	term.Write([]byte("\n\xe2\x82"))
+	term.Write([]byte("\xac\t!"))
+	assert.Equal(t, "cdefg\nX\n €  !", term.Text())
+}
+
+func TestTerminal_CursorMovement(t *testing.T) {
+	term, _ := newTestTerminal(4, 6)
+	term.Write([]byte("\x1b[2;3Hx\x1b[Ay\x1b[2Bz\x1b[10D<\x1b[Ge"))
+	assert.Equal(t, "   y\n  x\ne   z\n", term.Text())
+
+	term.Write([]byte("\x1b[H123456\x1b[1;3H\x1b[K\x1b[3;2H\x1b[1K"))
+	assert.Equal(t, "12\n  x\n    z\n", term.Text())
+
+	term.Write([]byte("\x1b[2;4H\x1b[1J"))
+	assert.Equal(t, "\n\n    z\n", term.Text())
+	term.Write([]byte("\x1b[2J"))
+	assert.Equal(t, "\n\n\n", term.Text())
+
+	term.Write([]byte("\x1b[Habcdef\x1b[1;2H\x1b[2P\x1b[2@\x1b[1X"))
+	assert.Equal(t, "a  def\n\n\n", term.Text())
+}
+
+func TestTerminal_Colors(t *testing.T) {
+	term, _ := newTestTerminal(2, 10)
+	term.Write([]byte("a\x1b[31mb\x1b[38;5;21;48;2;1;2;3mc\x1b[0;7md\x1b[mx\x1b[101m\x1b[K"))
+
+	cells := term.content.Rows[0].Cells
+	assert.Nil(t, cells[0].Style)
+	assert.Equal(t, &CustomTextGridStyle{FGColor: terminalBasicColors[1]}, cells[1].Style)
+	assert.Equal(t, &CustomTextGridStyle{FGColor: &color.NRGBA{R: 0, G: 0, B: 255, A: 255},
+		BGColor: &color.NRGBA{R: 1, G: 2, B: 3, A: 255}}, cells[2].Style)
+	assert.NotNil(t, cells[3].Style.TextColor())
+	assert.NotNil(t, cells[3].Style.BackgroundColor())
+	assert.Nil(t, cells[4].Style)
+	assert.Equal(t, terminalBasicColors[9], cells[5].Style.BackgroundColor())
+	assert.Equal(t, terminalBasicColors[9], cells[9].Style.BackgroundColor())
+}
+
+func TestTerminal_ScrollRegion(t *testing.T) {
+	term, _ := newTestTerminal(4, 3)
+	term.Write([]byte("1\r\n2\r\n3\r\n4"))
+	term.Write([]byte("\x1b[2;3r\x1b[3;1H\n5"))
+	assert.Equal(t, "1\n3\n5\n4", term.Text())
+
+	term.Write([]byte("\x1b[2;1H\x1bM6"))
+	assert.Equal(t, "1\n6\n3\n4", term.Text())
+
+	term.Write([]byte("\x1b[L"))
+	assert.Equal(t, "1\n\n6\n4", term.Text())
+	term.Write([]byte("\x1b[M"))
+	assert.Equal(t, "1\n6\n\n4", term.Text())
+
+	term.Write([]byte("\x1b[r\x1b[4;1H\n7"))
+	assert.Equal(t, "6\n\n4\n7", term.Text())
+}
+
+func TestTerminal_AltScreen(t *testing.T) {
+	term, _ := newTestTerminal(2, 5)
+	term.Write([]byte("main"))
+	term.Write([]byte("\x1b[?1049h"))
+	assert.Equal(t, "\n", term.Text())
+	row, col := term.CursorPosition()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 0, col)
+
+	term.Write([]byte("\x1b[2;2Halt"))
+	assert.Equal(t, "\n alt", term.Text())
+
+	term.Write([]byte("\x1b[?1049l"))
+	assert.Equal(t, "main\n", term.Text())
+	row, col = term.CursorPosition()
+	assert.Equal(t, 0, row)
+	assert.Equal(t, 4, col)
+}
+
+func TestTerminal_Input(t *testing.T) {
+	term, conn := newTestTerminal(2, 5)
+
+	term.TypedRune('a')
+	term.TypedRune('é')
+	term.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	term.TypedKey(&gui.KeyEvent{Name: gui.KeyReturn})
+	term.TypedKey(&gui.KeyEvent{Name: gui.KeyF5})
+	term.TypedShortcut(&desktop.CustomShortcut{KeyName: gui.KeyD, Modifier: gui.KeyModifierControl})
+	assert.Equal(t, "aé\x1b[A\r\x1b[15~\x04", conn.String())
+
+	conn.Reset()
+	term.TypedShortcut(&desktop.CustomShortcut{KeyName: gui.KeyC, Modifier: gui.KeyModifierControl | gui.KeyModifierShift})
+	term.TypedShortcut(&gui.ShortcutCopy{}) // Ctrl+C, or Cmd+C on macOS
+	term.TypedShortcut(&desktop.CustomShortcut{KeyName: gui.KeyC, Modifier: gui.KeyModifierAlt})
+	assert.Equal(t, "\x03\x03", conn.String())
+
+	conn.Reset()
+	term.Write([]byte("\x1b[?1h\x1b[?2004h"))
+	term.TypedKey(&gui.KeyEvent{Name: gui.KeyLeft})
+	clipboard := test.NewClipboard()
+	clipboard.SetContent("one\ntwo")
+	term.TypedShortcut(&gui.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "\x1bOD\x1b[200~one\rtwo\x1b[201~", conn.String())
+}
+
+func TestTerminal_RunWithConnection(t *testing.T) {
+	term := NewTerminal()
+	title := ""
+	term.OnTitleChanged = func(t string) {
+		title = t
+	}
+	conn := &terminalConn{Reader: strings.NewReader("\x1b]0;shell\x07hi\x1b[6n")}
+
+	assert.NoError(t, term.RunWithConnection(conn))
+	assert.Equal(t, "hi", strings.TrimSpace(term.Text()))
+	assert.Equal(t, "shell", title)
+	assert.Equal(t, "shell", term.Title())
+	assert.Equal(t, "\x1b[1;3R", conn.String())
+}
+
+func TestTerminal_Resize(t *testing.T) {
+	term := NewTerminal()
+	rows, cols := term.GridSize()
+	assert.Equal(t, 24, rows)
+	assert.Equal(t, 80, cols)
+
+	resized := [2]int{}
+	term.OnResized = func(rows, cols int) {
+		resized = [2]int{rows, cols}
+	}
+	term.Write([]byte("one\r\ntwo\r\nthree"))
+	cell := test.WidgetRenderer(term.content).(*textGridRenderer).cellSize
+	term.Resize(gui.NewSize(cell.Width*4, cell.Height*2))
+
+	assert.Equal(t, [2]int{2, 4}, resized)
+	assert.Equal(t, "two\nthre", term.Text())
+	row, col := term.CursorPosition()
+	assert.Equal(t, 1, row)
+	assert.Equal(t, 3, col)
+}
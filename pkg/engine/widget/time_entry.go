@@ -0,0 +1,353 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/theme"
+)
+
+const (
+	defaultTimeFormat = "15:04"
+	defaultTimeStep   = 15 * time.Minute
+)
+
+// Declare conformity with interfaces.
+var _ gui.Widget = (*TimeEntry)(nil)
+var _ gui.Focusable = (*TimeEntry)(nil)
+
+// TimeEntry is an input field for a time of day that can be typed or picked from a menu.
+// The up and down keys change the time by one Step.
+//
+// Since: 2.3
+type TimeEntry struct {
+	Entry
+
+	// Format is the layout used to show and parse times, see time.Parse. The default is "15:04".
+	Format string
+	// Min and Max are the earliest and latest times that are valid, only their time of day is used.
+	// A zero time is not bounded.
+	Min, Max time.Time
+	// Step is the interval between the times in the picker menu and the change made by the arrow keys.
+	// The default is 15 minutes.
+	Step time.Duration
+	// OnChanged is called when a valid time is entered, or with a zero time when the text is cleared.
+	OnChanged func(time.Time)
+
+	day        time.Time // the date that entered times are on
+	value      time.Time
+	popUp      *PopUpMenu
+	timeBinder basicBinder
+}
+
+// NewTimeEntry creates an entry for a time of day.
+//
+// Since: 2.3
+func NewTimeEntry() *TimeEntry {
+	e := &TimeEntry{}
+	e.ExtendBaseWidget(e)
+	e.Entry.OnChanged = e.textChanged
+	e.Validator = e.validate
+	e.ActionItem = e.setupPickerButton()
+	return e
+}
+
+// NewTimeEntryWithData returns a time entry connected to the specified data source.
+//
+// Since: 2.3
+func NewTimeEntryWithData(data binding.Time) *TimeEntry {
+	e := NewTimeEntry()
+	e.Bind(data)
+	return e
+}
+
+// Bind connects the specified data source to this TimeEntry.
+// The current value will be displayed and any changes in the data will cause the widget to update.
+// Times entered by the user will be set into the data source, keeping the date of the data.
+func (e *TimeEntry) Bind(data binding.Time) {
+	e.timeBinder.SetCallback(e.updateFromData)
+	e.timeBinder.Bind(data)
+
+	e.OnChanged = func(_ time.Time) {
+		e.timeBinder.CallWithData(e.writeData)
+	}
+}
+
+// CreateRenderer returns a new renderer for this time entry.
+//
+// Implements: gui.Widget
+func (e *TimeEntry) CreateRenderer() gui.WidgetRenderer {
+	e.ExtendBaseWidget(e)
+	return e.Entry.CreateRenderer()
+}
+
+// Disable this widget so that it cannot be interacted with, updating any style appropriately.
+//
+// Implements: gui.DisableableWidget
+func (e *TimeEntry) Disable() {
+	e.ActionItem.(gui.Disableable).Disable()
+	e.Entry.Disable()
+}
+
+// Enable this widget, updating any style or features appropriately.
+//
+// Implements: gui.DisableableWidget
+func (e *TimeEntry) Enable() {
+	e.ActionItem.(gui.Disableable).Enable()
+	e.Entry.Enable()
+}
+
+// MinSize returns the minimal size of the time entry.
+//
+// Implements: gui.Widget
+func (e *TimeEntry) MinSize() gui.Size {
+	e.ExtendBaseWidget(e)
+	return e.Entry.MinSize()
+}
+
+// Move changes the relative position of the time entry.
+//
+// Implements: gui.Widget
+func (e *TimeEntry) Move(pos gui.Position) {
+	e.Entry.Move(pos)
+	if e.popUp != nil {
+		e.popUp.Move(e.popUpPos())
+	}
+}
+
+// SetTime updates the time shown in this entry, a zero time clears the text.
+// The date of the time is kept for values that are entered later.
+func (e *TimeEntry) SetTime(t time.Time) {
+	if !t.IsZero() {
+		e.day = t
+	}
+	e.setValue(t)
+	e.SetText(e.formatTime(t))
+}
+
+// Time returns the time that has been entered, or a zero time if there is none.
+func (e *TimeEntry) Time() time.Time {
+	return e.value
+}
+
+// TypedKey receives key input events when the TimeEntry widget is focused.
+//
+// Implements: gui.Focusable
+func (e *TimeEntry) TypedKey(key *gui.KeyEvent) {
+	if e.Disabled() {
+		return
+	}
+	switch key.Name {
+	case gui.KeyUp:
+		e.stepTime(1)
+	case gui.KeyDown:
+		e.stepTime(-1)
+	default:
+		e.Entry.TypedKey(key)
+	}
+}
+
+// Unbind disconnects any configured data source from this TimeEntry.
+// The current value will remain at the last value of the data source.
+func (e *TimeEntry) Unbind() {
+	e.OnChanged = nil
+	e.timeBinder.Unbind()
+}
+
+// bounds returns the earliest and latest valid times of day, as durations since midnight.
+func (e *TimeEntry) bounds() (min, max time.Duration) {
+	max = 24*time.Hour - time.Second
+	if !e.Min.IsZero() {
+		min = timeOfDay(e.Min)
+	}
+	if !e.Max.IsZero() {
+		max = timeOfDay(e.Max)
+	}
+	return min, max
+}
+
+func (e *TimeEntry) format() string {
+	if e.Format == "" {
+		return defaultTimeFormat
+	}
+	return e.Format
+}
+
+func (e *TimeEntry) formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(e.format())
+}
+
+// onDay returns the time at a duration after midnight on the date used by this entry.
+func (e *TimeEntry) onDay(clock time.Duration) time.Time {
+	day := e.day
+	if day.IsZero() {
+		day = time.Now()
+	}
+	return calendarDate(day, day).Add(clock)
+}
+
+func (e *TimeEntry) parse(text string) (time.Time, error) {
+	if text == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(e.format(), text)
+	if err != nil {
+		return t, err
+	}
+
+	clock := timeOfDay(t)
+	min, max := e.bounds()
+	if clock < min {
+		return t, errors.New("time is before " + e.formatTime(e.Min))
+	}
+	if clock > max {
+		return t, errors.New("time is after " + e.formatTime(e.Max))
+	}
+	return e.onDay(clock), nil
+}
+
+func (e *TimeEntry) popUpPos() gui.Position {
+	entryPos := gui.CurrentApp().Driver().AbsolutePositionForObject(e.super())
+	return entryPos.Add(gui.NewPos(0, e.Size().Height-theme.InputBorderSize()))
+}
+
+func (e *TimeEntry) setValue(t time.Time) {
+	if t.Equal(e.value) {
+		return
+	}
+
+	e.value = t
+	if e.OnChanged != nil {
+		e.OnChanged(t)
+	}
+}
+
+func (e *TimeEntry) setupPickerButton() *Button {
+	button := NewButtonWithIcon("", theme.MenuDropDownIcon(), e.showPicker)
+	button.Importance = LowImportance
+	return button
+}
+
+func (e *TimeEntry) showPicker() {
+	c := gui.CurrentApp().Driver().CanvasForObject(e.super())
+	if c == nil {
+		return
+	}
+
+	var items []*gui.MenuItem
+	min, max := e.bounds()
+	for clock := min; clock <= max; clock += e.step() {
+		t := e.onDay(clock)
+		items = append(items, gui.NewMenuItem(e.formatTime(t), func() { e.SetTime(t) }))
+	}
+	e.popUp = NewPopUpMenu(gui.NewMenu("", items...), c)
+	e.popUp.ShowAtPosition(e.popUpPos())
+	e.popUp.Resize(gui.NewSize(e.Size().Width, e.popUp.MinSize().Height))
+}
+
+func (e *TimeEntry) step() time.Duration {
+	if e.Step <= 0 {
+		return defaultTimeStep
+	}
+	return e.Step
+}
+
+// stepTime moves the time by a number of steps, staying within the bounds on the same day.
+func (e *TimeEntry) stepTime(steps int) {
+	min, max := e.bounds()
+	clock := min
+	if !e.value.IsZero() {
+		clock = timeOfDay(e.value) + time.Duration(steps)*e.step()
+	}
+	if clock < min {
+		clock = min
+	} else if clock > max {
+		clock = max
+	}
+	e.SetTime(e.onDay(clock))
+}
+
+func (e *TimeEntry) textChanged(text string) {
+	if text == e.formatTime(e.value) { // unchanged, the value may be more precise than the format
+		return
+	}
+	t, err := e.parse(text)
+	if err != nil {
+		return
+	}
+	e.setValue(t)
+}
+
+func (e *TimeEntry) updateFromData(data binding.DataItem) {
+	if data == nil {
+		return
+	}
+	timeSource, ok := data.(binding.Time)
+	if !ok {
+		return
+	}
+
+	val, err := timeSource.Get()
+	if err != nil {
+		gui.LogError("Error getting current data value", err)
+		return
+	}
+	e.SetTime(val)
+}
+
+func (e *TimeEntry) validate(text string) error {
+	_, err := e.parse(text)
+	return err
+}
+
+func (e *TimeEntry) writeData(data binding.DataItem) {
+	if data == nil {
+		return
+	}
+	timeTarget, ok := data.(binding.Time)
+	if !ok {
+		return
+	}
+	currentValue, err := timeTarget.Get()
+	if err != nil {
+		return
+	}
+	if !currentValue.Equal(e.value) {
+		err := timeTarget.Set(e.value)
+		if err != nil {
+			gui.LogError(fmt.Sprintf("Failed to set binding value to %s", e.value), err)
+		}
+	}
+}
+
+// timeOfDay returns the duration since midnight of a time.
+func timeOfDay(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+}
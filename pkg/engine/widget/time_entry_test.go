@@ -0,0 +1,113 @@
+package widget
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"testing"
+	"time"
+
+	gui "github.com/bhojpur/gui/pkg/engine"
+	"github.com/bhojpur/gui/pkg/engine/data/binding"
+	"github.com/bhojpur/gui/pkg/engine/test"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeEntry_Keys(t *testing.T) {
+	e := NewTimeEntry()
+	e.Min = time.Date(1, 1, 1, 9, 0, 0, 0, time.UTC)
+	e.Max = time.Date(1, 1, 1, 17, 0, 0, 0, time.UTC)
+	e.Step = 30 * time.Minute
+
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	assert.Equal(t, "09:00", e.Text)
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	assert.Equal(t, "09:30", e.Text)
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, "09:00", e.Text)
+
+	e.SetText("16:45")
+	e.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	assert.Equal(t, "17:00", e.Text)
+	assert.Equal(t, 17, e.Time().Hour())
+}
+
+func TestTimeEntry_Picker(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	e := NewTimeEntry()
+	e.Min = time.Date(1, 1, 1, 22, 0, 0, 0, time.UTC)
+	e.Step = time.Hour
+	w := test.NewWindow(e)
+	defer w.Close()
+	w.Resize(gui.NewSize(200, 400))
+
+	test.Tap(e.ActionItem.(gui.Tappable))
+	assert.True(t, e.popUp.Visible())
+	assert.Equal(t, 2, len(e.popUp.Items))
+	assert.Equal(t, "23:00", e.popUp.Items[1].(*menuItem).Item.Label)
+
+	test.Tap(e.popUp.Items[1].(*menuItem))
+	assert.Equal(t, "23:00", e.Text)
+	assert.Equal(t, 23, e.Time().Hour())
+}
+
+func TestTimeEntry_Typed(t *testing.T) {
+	day := time.Date(2022, time.March, 4, 8, 15, 30, 0, time.Local)
+	var changed []time.Time
+	e := NewTimeEntry()
+	e.Format = "3:04PM"
+	e.Max = time.Date(1, 1, 1, 18, 0, 0, 0, time.UTC)
+	e.SetTime(day)
+	e.OnChanged = func(t time.Time) {
+		changed = append(changed, t)
+	}
+	assert.Equal(t, "8:15AM", e.Text)
+	assert.Equal(t, day, e.Time())
+
+	e.SetText("7:30PM")
+	assert.Error(t, e.Validate())
+	assert.Empty(t, changed)
+
+	e.SetText("2:45PM")
+	assert.NoError(t, e.Validate())
+	assert.Equal(t, []time.Time{time.Date(2022, time.March, 4, 14, 45, 0, 0, time.Local)}, changed)
+
+	e.SetText("")
+	assert.True(t, e.Time().IsZero())
+}
+
+func TestNewTimeEntryWithData(t *testing.T) {
+	data := binding.NewTime()
+	err := data.Set(time.Date(2022, time.March, 4, 10, 0, 0, 0, time.Local))
+	assert.Nil(t, err)
+
+	e := NewTimeEntryWithData(data)
+	waitForBinding()
+	assert.Equal(t, "10:00", e.Text)
+
+	e.SetText("11:30")
+	v, err := data.Get()
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2022, time.March, 4, 11, 30, 0, 0, time.Local), v)
+}
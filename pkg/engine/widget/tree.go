@@ -21,18 +21,29 @@ package widget
 // THE SOFTWARE.
 
 import (
+	"image/color"
+
 	gui "github.com/bhojpur/gui/pkg/engine"
 	"github.com/bhojpur/gui/pkg/engine/canvas"
+	"github.com/bhojpur/gui/pkg/engine/dnd"
 	"github.com/bhojpur/gui/pkg/engine/driver/desktop"
 	"github.com/bhojpur/gui/pkg/engine/internal/cache"
 	"github.com/bhojpur/gui/pkg/engine/internal/widget"
+	"github.com/bhojpur/gui/pkg/engine/layout"
 	"github.com/bhojpur/gui/pkg/engine/theme"
 )
 
 // TreeNodeID represents the unique id of a tree node.
 type TreeNodeID = string
 
+// treeNodeDragType is the DragData type of the nodes of a tree that is being rearranged.
+const treeNodeDragType = "widget.TreeNode"
+
 var _ gui.Widget = (*Tree)(nil)
+var _ gui.Accessible = (*Tree)(nil)
+var _ gui.Focusable = (*Tree)(nil)
+var _ desktop.Keyable = (*Tree)(nil)
+var _ gui.DropTarget = (*Tree)(nil)
 
 // Tree widget displays hierarchical data.
 // Each node of the tree must be identified by a Unique TreeNodeID.
@@ -50,8 +61,26 @@ type Tree struct {
 	OnSelected     func(uid TreeNodeID)                                     // Called when the Node with the given TreeNodeID is selected.
 	OnUnselected   func(uid TreeNodeID)                                     // Called when the Node with the given TreeNodeID is unselected.
 	UpdateNode     func(uid TreeNodeID, branch bool, node gui.CanvasObject) // Called to update the given CanvasObject to represent the data at the given TreeNodeID
-
+	// SelectionMode sets whether one or many nodes can be selected, the default is SelectionSingle.
+	//
+	// Since: 2.3
+	SelectionMode SelectionMode
+	// OnSelectionChanged is called with the IDs of all selected nodes whenever the selection changes.
+	//
+	// Since: 2.3
+	OnSelectionChanged func(selected []TreeNodeID)
+	// OnMoved is called when a node has been dragged to a new place. The node with uid should be removed
+	// from its parent and inserted into the children of parent at index, the tree is refreshed afterwards.
+	// Setting it allows the nodes to be moved by dragging them.
+	//
+	// Since: 2.3
+	OnMoved func(uid, parent TreeNodeID, index int)
+
+	anchor        TreeNodeID // the node a range selection extends from
 	branchMinSize gui.Size
+	cursor        TreeNodeID // the node that keyboard navigation moves from
+	dropIndicator *canvas.Rectangle
+	keys          selectionKeys
 	leafMinSize   gui.Size
 	offset        gui.Position
 	open          map[TreeNodeID]bool
@@ -97,6 +126,15 @@ func NewTreeWithStrings(data map[string][]string) (t *Tree) {
 	return
 }
 
+// AccessibilityInfo describes this tree to assistive technology.
+//
+// Implements: gui.Accessible
+//
+// Since: 2.3
+func (t *Tree) AccessibilityInfo() gui.AccessibilityInfo {
+	return gui.AccessibilityInfo{Role: gui.AccessibleRoleTree}
+}
+
 // CloseAllBranches closes all branches in the tree.
 func (t *Tree) CloseAllBranches() {
 	t.propertyLock.Lock()
@@ -123,8 +161,10 @@ func (t *Tree) CreateRenderer() gui.WidgetRenderer {
 	c := newTreeContent(t)
 	s := widget.NewScroll(c)
 	t.scroller = s
+	t.dropIndicator = canvas.NewRectangle(color.Transparent)
+	t.dropIndicator.Hide()
 	r := &treeRenderer{
-		BaseRenderer: widget.NewBaseRenderer([]gui.CanvasObject{s}),
+		BaseRenderer: widget.NewBaseRenderer([]gui.CanvasObject{s, t.dropIndicator}),
 		tree:         t,
 		content:      c,
 		scroller:     s,
@@ -135,6 +175,72 @@ func (t *Tree) CreateRenderer() gui.WidgetRenderer {
 	return r
 }
 
+// DragLeave is called when a dragged node leaves this Tree.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (t *Tree) DragLeave() {
+	t.moveDropIndicator(treeDrop{}, false)
+}
+
+// DragOver is called when a node is dragged over this Tree.
+// Only the nodes of this tree are accepted and only if OnMoved is set.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (t *Tree) DragOver(data *gui.DragData, pos gui.Position) bool {
+	uid, ok := t.draggedNode(data)
+	if !ok {
+		return false
+	}
+
+	drop, ok := t.dropAt(uid, pos.Y)
+	t.moveDropIndicator(drop, ok)
+	return ok
+}
+
+// Drop is called when a node of this Tree is dropped on it and moves the node to the drop position.
+//
+// Implements: gui.DropTarget
+//
+// Since: 2.3
+func (t *Tree) Drop(data *gui.DragData, pos gui.Position) {
+	t.moveDropIndicator(treeDrop{}, false)
+	uid, ok := t.draggedNode(data)
+	if !ok {
+		return
+	}
+	drop, ok := t.dropAt(uid, pos.Y)
+	if !ok {
+		return
+	}
+	if parent, index, _ := t.findParent(uid); parent == drop.parent && index == drop.index {
+		return
+	}
+
+	t.OnMoved(uid, drop.parent, drop.index)
+	t.Refresh()
+}
+
+// FocusGained is called after this Tree has gained focus.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (t *Tree) FocusGained() {
+}
+
+// FocusLost is called after this Tree has lost focus.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (t *Tree) FocusLost() {
+	t.keys.shift = false
+}
+
 // IsBranchOpen returns true if the branch with the given TreeNodeID is expanded.
 func (t *Tree) IsBranchOpen(uid TreeNodeID) bool {
 	if uid == t.Root {
@@ -152,6 +258,24 @@ func (t *Tree) MinSize() gui.Size {
 	return t.BaseWidget.MinSize()
 }
 
+// KeyDown is called when a key is pressed while this Tree is focused.
+//
+// Implements: desktop.Keyable
+//
+// Since: 2.3
+func (t *Tree) KeyDown(key *gui.KeyEvent) {
+	t.keys.keyDown(key)
+}
+
+// KeyUp is called when a key is released while this Tree is focused.
+//
+// Implements: desktop.Keyable
+//
+// Since: 2.3
+func (t *Tree) KeyUp(key *gui.KeyEvent) {
+	t.keys.keyUp(key)
+}
+
 // OpenAllBranches opens all branches in the tree.
 func (t *Tree) OpenAllBranches() {
 	t.ensureOpenMap()
@@ -247,20 +371,31 @@ func (t *Tree) ScrollToTop() {
 }
 
 // Select marks the specified node to be selected.
+// Unless SelectionMode is SelectionMultiple any previously selected node is unselected.
 func (t *Tree) Select(uid TreeNodeID) {
-	if len(t.selected) > 0 {
-		if uid == t.selected[0] {
-			return // no change
-		}
-		if f := t.OnUnselected; f != nil {
-			f(t.selected[0])
+	t.anchor, t.cursor = uid, uid
+	if t.SelectionMode == SelectionMultiple {
+		if t.isSelected(uid) {
+			return
 		}
+		t.ScrollTo(uid)
+		t.setSelection(append(t.Selected(), uid))
+		return
 	}
-	t.selected = []TreeNodeID{uid}
-	t.ScrollTo(uid)
-	if f := t.OnSelected; f != nil {
-		f(uid)
+
+	if len(t.selected) == 1 && t.selected[0] == uid {
+		return // no change
 	}
+	t.ScrollTo(uid)
+	t.setSelection([]TreeNodeID{uid})
+}
+
+// Selected returns the IDs of all selected nodes in the order they were selected.
+// The nodes of a range selection are in the order they are shown.
+//
+// Since: 2.3
+func (t *Tree) Selected() []TreeNodeID {
+	return append([]TreeNodeID(nil), t.selected...)
 }
 
 // ToggleBranch flips the state of the branch with the given TreeNodeID.
@@ -272,17 +407,94 @@ func (t *Tree) ToggleBranch(uid string) {
 	}
 }
 
+// TypedKey is called if a key event happens while this Tree is focused.
+// The arrow, Home, End, PageUp and PageDown keys move the selection through the visible nodes and
+// holding Shift extends it when SelectionMode is SelectionMultiple.
+// Left and Right close and open the current branch and Space toggles the current node.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (t *Tree) TypedKey(key *gui.KeyEvent) {
+	nodes := t.visibleNodes()
+	if len(nodes) == 0 {
+		return
+	}
+
+	current := -1
+	for i, uid := range nodes {
+		if uid == t.cursor {
+			current = i
+			break
+		}
+	}
+	target := current
+	switch key.Name {
+	case gui.KeyUp:
+		target--
+	case gui.KeyDown:
+		target++
+	case gui.KeyHome:
+		target = 0
+	case gui.KeyEnd:
+		target = len(nodes) - 1
+	case gui.KeyPageUp:
+		target -= t.pageLength()
+	case gui.KeyPageDown:
+		target += t.pageLength()
+	case gui.KeyLeft:
+		if current >= 0 && t.IsBranch != nil && t.IsBranch(t.cursor) && t.IsBranchOpen(t.cursor) {
+			t.CloseBranch(t.cursor)
+		}
+		return
+	case gui.KeyRight:
+		if current >= 0 && t.IsBranch != nil && t.IsBranch(t.cursor) && !t.IsBranchOpen(t.cursor) {
+			t.OpenBranch(t.cursor)
+		}
+		return
+	case gui.KeySpace:
+		if current < 0 {
+			return
+		}
+		if t.SelectionMode == SelectionMultiple && t.isSelected(t.cursor) {
+			t.Unselect(t.cursor)
+		} else {
+			t.Select(t.cursor)
+		}
+		return
+	default:
+		return
+	}
+
+	target = clampIndex(target, len(nodes))
+	if t.SelectionMode == SelectionMultiple && t.keys.shift {
+		t.selectRange(nodes[target])
+	} else {
+		t.selectOnly(nodes[target])
+	}
+}
+
+// TypedRune is called if a text event happens while this Tree is focused.
+//
+// Implements: gui.Focusable
+//
+// Since: 2.3
+func (t *Tree) TypedRune(_ rune) {
+}
+
 // Unselect marks the specified node to be not selected.
 func (t *Tree) Unselect(uid TreeNodeID) {
-	if len(t.selected) == 0 || t.selected[0] != uid {
+	if !t.isSelected(uid) {
 		return
 	}
 
-	t.selected = nil
-	t.Refresh()
-	if f := t.OnUnselected; f != nil {
-		f(uid)
+	var selected []TreeNodeID
+	for _, s := range t.selected {
+		if s != uid {
+			selected = append(selected, s)
+		}
 	}
+	t.setSelection(selected)
 }
 
 // UnselectAll sets all nodes to be not selected.
@@ -293,14 +505,74 @@ func (t *Tree) UnselectAll() {
 		return
 	}
 
-	selected := t.selected
-	t.selected = nil
-	t.Refresh()
-	if f := t.OnUnselected; f != nil {
-		for _, uid := range selected {
-			f(uid)
+	t.setSelection(nil)
+}
+
+func (t *Tree) childUIDs(uid TreeNodeID) []TreeNodeID {
+	if f := t.ChildUIDs; f != nil {
+		return f(uid)
+	}
+	return nil
+}
+
+// draggedNode returns the ID of the node carried by data if it is a node of this tree that can be moved.
+func (t *Tree) draggedNode(data *gui.DragData) (TreeNodeID, bool) {
+	var node *treeNode
+	switch src := data.Source.(type) {
+	case *branch:
+		node = src.treeNode
+	case *leaf:
+		node = src.treeNode
+	}
+	if node == nil || node.tree != t || t.OnMoved == nil {
+		return "", false
+	}
+	uid, ok := data.Value.(TreeNodeID)
+	return uid, ok
+}
+
+// treeDrop describes where a dragged node would be moved to.
+type treeDrop struct {
+	parent TreeNodeID
+	index  int // the index among the children of parent once the node has been removed from its old place
+	target TreeNodeID
+	into   bool // the node is dropped into the target branch
+	after  bool // the node is dropped after the target, otherwise before it
+}
+
+// dropAt returns where the node uid would be moved to if it was dropped at y, relative to the tree.
+// It returns false if the node cannot be dropped there, like into itself or one of its descendants.
+func (t *Tree) dropAt(uid TreeNodeID, y float32) (drop treeDrop, ok bool) {
+	y += t.offset.Y
+	target, top, height, found := t.nodeAt(y)
+	if !found {
+		nodes := t.visibleNodes()
+		if len(nodes) == 0 {
+			return drop, false
 		}
+		// below the last node appends to the root
+		drop = treeDrop{parent: t.Root, index: len(t.childUIDs(t.Root)), target: nodes[len(nodes)-1], after: true}
+	} else {
+		if target == uid || t.isAncestor(uid, target) {
+			return drop, false
+		}
+
+		pos := (y - top) / height
+		if t.IsBranch != nil && t.IsBranch(target) && pos >= .25 && pos < .75 {
+			drop = treeDrop{parent: target, index: len(t.childUIDs(target)), target: target, into: true}
+		} else {
+			parent, index, _ := t.findParent(target)
+			drop = treeDrop{parent: parent, index: index, target: target, after: pos >= .5}
+			if drop.after {
+				drop.index++
+			}
+		}
+	}
+
+	if parent, index, found := t.findParent(uid); found && parent == drop.parent && index < drop.index {
+		drop.index-- // the node is removed from before the drop position
 	}
+	return drop, true
 }
 
 func (t *Tree) ensureOpenMap() {
@@ -338,6 +610,123 @@ func (t *Tree) findBottom() (y float32, size gui.Size) {
 	return
 }
 
+// findParent returns the parent of the visible node uid and its index among the children of the parent.
+func (t *Tree) findParent(uid TreeNodeID) (parent TreeNodeID, index int, found bool) {
+	var search func(TreeNodeID) bool
+	search = func(p TreeNodeID) bool {
+		for i, c := range t.childUIDs(p) {
+			if c == uid {
+				parent, index, found = p, i, true
+				return true
+			}
+			if t.IsBranch != nil && t.IsBranch(c) && t.IsBranchOpen(c) && search(c) {
+				return true
+			}
+		}
+		return false
+	}
+	search(t.Root)
+	return
+}
+
+// isAncestor returns true if the node ancestor is a parent, grandparent or further ancestor of uid.
+func (t *Tree) isAncestor(ancestor, uid TreeNodeID) bool {
+	for p, _, ok := t.findParent(uid); ok; p, _, ok = t.findParent(p) {
+		if p == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *Tree) isSelected(uid TreeNodeID) bool {
+	return containsTreeNode(t.selected, uid)
+}
+
+// moveDropIndicator shows where a dragged node would be moved to, or hides the indicator if show is false.
+func (t *Tree) moveDropIndicator(drop treeDrop, show bool) {
+	if t.dropIndicator == nil {
+		return
+	}
+	if !show {
+		t.dropIndicator.Hide()
+		return
+	}
+
+	y, size, _ := t.offsetAndSize(drop.target)
+	y -= t.offset.Y
+	thickness := theme.SeparatorThicknessSize() * 2
+	if drop.into {
+		t.dropIndicator.FillColor = color.Transparent
+		t.dropIndicator.StrokeColor = theme.PrimaryColor()
+		t.dropIndicator.StrokeWidth = thickness
+		t.dropIndicator.Move(gui.NewPos(0, y))
+		t.dropIndicator.Resize(gui.NewSize(t.Size().Width, size.Height))
+	} else {
+		if drop.after {
+			y += size.Height
+		} else {
+			y -= thickness
+		}
+		t.dropIndicator.FillColor = theme.PrimaryColor()
+		t.dropIndicator.StrokeWidth = 0
+		t.dropIndicator.Move(gui.NewPos(0, gui.Max(0, gui.Min(y, t.Size().Height-thickness))))
+		t.dropIndicator.Resize(gui.NewSize(t.Size().Width, thickness))
+	}
+	t.dropIndicator.Show()
+	t.dropIndicator.Refresh()
+}
+
+// nodeAt returns the visible node at y, relative to the top of all nodes, with its offset and height.
+func (t *Tree) nodeAt(y float32) (uid TreeNodeID, top, height float32, found bool) {
+	offset := float32(0)
+	first := true
+	t.walkAll(func(id TreeNodeID, branch bool, _ int) {
+		// Root node is not rendered unless it has been customized
+		if found || (t.Root == "" && id == "") {
+			return
+		}
+
+		h := t.leafMinSize.Height
+		if branch {
+			h = t.branchMinSize.Height
+		}
+		if !first {
+			offset += theme.SeparatorThicknessSize()
+		}
+		first = false
+		if y < offset+h {
+			uid, top, height, found = id, offset, h, true
+		}
+		offset += h
+	})
+	return
+}
+
+// nodeTapped updates the selection for a tap on a node, honouring the modifiers held at the time.
+func (t *Tree) nodeTapped(uid TreeNodeID) {
+	focusCollection(t.super())
+	mods := t.keys.tapModifiers()
+	if t.SelectionMode != SelectionMultiple {
+		t.Select(uid)
+		return
+	}
+
+	switch {
+	case mods&gui.KeyModifierShift != 0:
+		t.selectRange(uid)
+	case mods&gui.KeyModifierShortcutDefault != 0:
+		if t.isSelected(uid) {
+			t.anchor, t.cursor = uid, uid
+			t.Unselect(uid)
+		} else {
+			t.Select(uid)
+		}
+	default:
+		t.selectOnly(uid)
+	}
+}
+
 func (t *Tree) offsetAndSize(uid TreeNodeID) (y float32, size gui.Size, found bool) {
 	t.walkAll(func(id TreeNodeID, branch bool, _ int) {
 		m := t.leafMinSize
@@ -372,6 +761,89 @@ func (t *Tree) offsetUpdated(pos gui.Position) {
 	t.scroller.Content.Refresh()
 }
 
+// pageLength returns the number of leaf nodes that fit in the visible area.
+func (t *Tree) pageLength() int {
+	if t.scroller == nil {
+		return 1
+	}
+	nodes := int(t.scroller.Size().Height / (t.leafMinSize.Height + theme.SeparatorThicknessSize()))
+	if nodes < 1 {
+		return 1
+	}
+	return nodes
+}
+
+// selectOnly replaces the selection with the node identified by uid.
+func (t *Tree) selectOnly(uid TreeNodeID) {
+	t.anchor, t.cursor = uid, uid
+	t.ScrollTo(uid)
+	if len(t.selected) == 1 && t.selected[0] == uid {
+		return
+	}
+	t.setSelection([]TreeNodeID{uid})
+}
+
+// selectRange replaces the selection with all visible nodes between the anchor and uid.
+func (t *Tree) selectRange(uid TreeNodeID) {
+	t.cursor = uid
+	var selected []TreeNodeID
+	inRange := false
+	for _, id := range t.visibleNodes() {
+		edge := id == t.anchor || id == uid
+		if !inRange && !edge {
+			continue
+		}
+		selected = append(selected, id)
+		if edge && (inRange || t.anchor == uid) {
+			break // reached the other end of the range
+		}
+		inRange = true
+	}
+	if !containsTreeNode(selected, t.anchor) {
+		t.selectOnly(uid) // the anchor has been hidden, start a new range
+		return
+	}
+	t.ScrollTo(uid)
+	t.setSelection(selected)
+}
+
+// setSelection stores the new selection, refreshes and then notifies the callbacks of any changes.
+func (t *Tree) setSelection(selected []TreeNodeID) {
+	old := t.selected
+	t.selected = selected
+	t.Refresh()
+
+	if f := t.OnUnselected; f != nil {
+		for _, uid := range old {
+			if !t.isSelected(uid) {
+				f(uid)
+			}
+		}
+	}
+	if f := t.OnSelected; f != nil {
+		for _, uid := range selected {
+			if !containsTreeNode(old, uid) {
+				f(uid)
+			}
+		}
+	}
+	if f := t.OnSelectionChanged; f != nil {
+		f(t.Selected())
+	}
+}
+
+// visibleNodes returns the IDs of all nodes that are shown, in the order they are displayed.
+func (t *Tree) visibleNodes() (nodes []TreeNodeID) {
+	t.walkAll(func(uid TreeNodeID, _ bool, _ int) {
+		// Root node is not rendered unless it has been customized
+		if t.Root == "" && uid == "" {
+			return
+		}
+		nodes = append(nodes, uid)
+	})
+	return
+}
+
 func (t *Tree) walk(uid string, depth int, onNode func(string, bool, int)) {
 	if isBranch := t.IsBranch; isBranch != nil {
 		if isBranch(uid) {
@@ -682,8 +1154,13 @@ func (r *treeContentRenderer) getLeaf() (l *leaf) {
 }
 
 var _ desktop.Hoverable = (*treeNode)(nil)
+var _ gui.Accessible = (*treeNode)(nil)
 var _ gui.CanvasObject = (*treeNode)(nil)
 var _ gui.Tappable = (*treeNode)(nil)
+var _ desktop.Mouseable = (*treeNode)(nil)
+var _ gui.Draggable = (*treeNode)(nil)
+var _ gui.DragSource = (*treeNode)(nil)
+var _ gui.DragPreviewer = (*treeNode)(nil)
 
 type treeNode struct {
 	BaseWidget
@@ -694,6 +1171,17 @@ type treeNode struct {
 	icon     gui.CanvasObject
 	isBranch bool
 	content  gui.CanvasObject
+	tracker  dnd.Tracker
+}
+
+// AccessibilityInfo describes this node to assistive technology.
+//
+// Implements: gui.Accessible
+func (n *treeNode) AccessibilityInfo() gui.AccessibilityInfo {
+	info := gui.AccessibilityInfo{Role: gui.AccessibleRoleTreeItem}
+	info.State.Expanded = n.isBranch && n.tree.IsBranchOpen(n.uid)
+	info.State.Selected = n.tree.isSelected(n.uid)
+	return info
 }
 
 func (n *treeNode) Content() gui.CanvasObject {
@@ -710,6 +1198,56 @@ func (n *treeNode) CreateRenderer() gui.WidgetRenderer {
 	}
 }
 
+// DragData returns the ID of this node as the payload when the nodes of the tree can be moved.
+//
+// Implements: gui.DragSource
+func (n *treeNode) DragData(gui.Position) *gui.DragData {
+	if n.tree.OnMoved == nil {
+		return nil
+	}
+	return &gui.DragData{Type: treeNodeDragType, Value: n.uid}
+}
+
+// DragEnd is called when a drag of this node ends.
+func (n *treeNode) DragEnd() {
+	if n.tracker.Active() {
+		n.tracker.DragEnd()
+	} else if n.tree.scroller != nil {
+		n.tree.scroller.DragEnd()
+	}
+}
+
+// DragFinished is called once a drag and drop of this node is complete.
+//
+// Implements: gui.DragSource
+func (n *treeNode) DragFinished(*gui.DragData, bool) {
+}
+
+// DragPreview returns a highlighted copy of the content of this node to show while it is dragged.
+//
+// Implements: gui.DragPreviewer
+func (n *treeNode) DragPreview(*gui.DragData) gui.CanvasObject {
+	if n.tree.CreateNode == nil {
+		return nil
+	}
+
+	content := n.tree.CreateNode(n.isBranch)
+	if f := n.tree.UpdateNode; f != nil {
+		f(n.uid, n.isBranch, content)
+	}
+	return gui.NewContainerWithLayout(layout.NewMaxLayout(), canvas.NewRectangle(theme.SelectionColor()), content)
+}
+
+// Dragged is called when this node is dragged. It starts moving the node if the tree allows it,
+// otherwise the tree is scrolled.
+func (n *treeNode) Dragged(ev *gui.DragEvent) {
+	if n.tree.OnMoved != nil {
+		n.tracker.Dragged(n.super(), ev)
+	} else if n.tree.scroller != nil {
+		n.tree.scroller.Dragged(ev)
+	}
+}
+
 func (n *treeNode) Indent() float32 {
 	return float32(n.depth) * (theme.IconInlineSize() + theme.Padding())
 }
@@ -720,6 +1258,11 @@ func (n *treeNode) MouseIn(*desktop.MouseEvent) {
 	n.partialRefresh()
 }
 
+// MouseDown is called when a desktop pointer is pressed over the widget
+func (n *treeNode) MouseDown(ev *desktop.MouseEvent) {
+	n.tree.keys.mouseDown(ev)
+}
+
 // MouseMoved is called when a desktop pointer hovers over the widget
 func (n *treeNode) MouseMoved(*desktop.MouseEvent) {
 }
@@ -730,8 +1273,12 @@ func (n *treeNode) MouseOut() {
 	n.partialRefresh()
 }
 
+// MouseUp is called when a desktop pointer is released over the widget
+func (n *treeNode) MouseUp(*desktop.MouseEvent) {
+}
+
 func (n *treeNode) Tapped(*gui.PointEvent) {
-	n.tree.Select(n.uid)
+	n.tree.nodeTapped(n.uid)
 }
 
 func (n *treeNode) partialRefresh() {
@@ -806,7 +1353,7 @@ func (r *treeNodeRenderer) partialRefresh() {
 	if r.treeNode.icon != nil {
 		r.treeNode.icon.Refresh()
 	}
-	if len(r.treeNode.tree.selected) > 0 && r.treeNode.uid == r.treeNode.tree.selected[0] {
+	if r.treeNode.tree.isSelected(r.treeNode.uid) {
 		r.background.FillColor = theme.SelectionColor()
 		r.background.Show()
 	} else if r.treeNode.hovered {
@@ -895,3 +1442,12 @@ func newLeaf(tree *Tree, content gui.CanvasObject) (l *leaf) {
 	l.ExtendBaseWidget(l)
 	return
 }
+
+func containsTreeNode(nodes []TreeNodeID, uid TreeNodeID) bool {
+	for _, n := range nodes {
+		if n == uid {
+			return true
+		}
+	}
+	return false
+}
@@ -21,6 +21,7 @@ package widget
 // THE SOFTWARE.
 
 import (
+	"fmt"
 	"image/color"
 	"testing"
 	"time"
@@ -423,6 +424,164 @@ func TestTree_Select_Unselects(t *testing.T) {
 	}
 }
 
+func TestTree_SelectMultiple(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	data := make(map[string][]string)
+	addTreePath(data, "A")
+	addTreePath(data, "B", "C")
+	addTreePath(data, "D", "E")
+	tree := NewTreeWithStrings(data)
+	tree.SelectionMode = SelectionMultiple
+	tree.OpenBranch("B")
+	var changed []TreeNodeID
+	tree.OnSelectionChanged = func(selected []TreeNodeID) {
+		changed = selected
+	}
+
+	w := test.NewWindow(tree)
+	defer w.Close()
+	w.Resize(gui.NewSize(220, 220))
+
+	tapWith := func(node *treeNode, mod gui.KeyModifier) {
+		node.MouseDown(&desktop.MouseEvent{Modifier: mod})
+		node.Tapped(&gui.PointEvent{})
+	}
+	highlighted := func(node gui.Widget) bool {
+		return test.WidgetRenderer(node).(*treeNodeRenderer).background.Visible()
+	}
+	a := getLeaf(t, tree, "A")
+	b := getBranch(t, tree, "B")
+	c := getLeaf(t, tree, "C")
+
+	tapWith(a.treeNode, 0)
+	assert.Equal(t, []TreeNodeID{"A"}, changed)
+	tapWith(c.treeNode, gui.KeyModifierShortcutDefault)
+	assert.Equal(t, []TreeNodeID{"A", "C"}, changed)
+	assert.True(t, highlighted(a))
+	assert.False(t, highlighted(b))
+	assert.True(t, highlighted(c))
+	assert.Equal(t, tree, w.Canvas().Focused())
+
+	tapWith(a.treeNode, gui.KeyModifierShortcutDefault)
+	assert.Equal(t, []TreeNodeID{"C"}, changed)
+	assert.False(t, highlighted(a))
+
+	tapWith(getBranch(t, tree, "D").treeNode, gui.KeyModifierShift)
+	assert.Equal(t, []TreeNodeID{"A", "B", "C", "D"}, changed)
+	tapWith(b.treeNode, 0)
+	assert.Equal(t, []TreeNodeID{"B"}, changed)
+}
+
+func TestTree_SelectionKeys(t *testing.T) {
+	data := make(map[string][]string)
+	addTreePath(data, "A")
+	addTreePath(data, "B", "C")
+	addTreePath(data, "D", "E")
+	tree := NewTreeWithStrings(data)
+	tree.SelectionMode = SelectionMultiple
+	tree.Refresh() // Force layout
+
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []TreeNodeID{"A"}, tree.Selected())
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []TreeNodeID{"B"}, tree.Selected())
+
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyRight})
+	assert.True(t, tree.IsBranchOpen("B"))
+	tree.KeyDown(&gui.KeyEvent{Name: desktop.KeyShiftRight})
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []TreeNodeID{"B", "C", "D"}, tree.Selected())
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyHome})
+	assert.Equal(t, []TreeNodeID{"A", "B"}, tree.Selected())
+	tree.KeyUp(&gui.KeyEvent{Name: desktop.KeyShiftRight})
+
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyEnd})
+	assert.Equal(t, []TreeNodeID{"D"}, tree.Selected())
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeySpace})
+	assert.Empty(t, tree.Selected())
+
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyUp})
+	assert.Equal(t, []TreeNodeID{"B"}, tree.Selected())
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyLeft})
+	assert.False(t, tree.IsBranchOpen("B"))
+	tree.TypedKey(&gui.KeyEvent{Name: gui.KeyDown})
+	assert.Equal(t, []TreeNodeID{"D"}, tree.Selected())
+}
+
+func TestTree_OnMoved(t *testing.T) {
+	test.NewApp()
+	defer test.NewApp()
+
+	data := make(map[string][]string)
+	addTreePath(data, "A")
+	addTreePath(data, "B", "C")
+	addTreePath(data, "B", "D")
+	addTreePath(data, "E")
+	tree := NewTreeWithStrings(data)
+	tree.OpenBranch("B")
+	var moves []string
+	tree.OnMoved = func(uid, parent TreeNodeID, index int) {
+		moves = append(moves, fmt.Sprintf("%s>%s:%d", uid, parent, index))
+		old, _, _ := tree.findParent(uid)
+		for i, c := range data[old] {
+			if c == uid {
+				data[old] = append(data[old][:i], data[old][i+1:]...)
+				break
+			}
+		}
+		children := append([]string{}, data[parent][:index]...)
+		data[parent] = append(append(children, uid), data[parent][index:]...)
+	}
+
+	w := test.NewWindow(tree)
+	defer w.Close()
+	w.SetPadded(false)
+	w.Resize(gui.NewSize(200, 300))
+	nodeAt := func(uid TreeNodeID, fraction float32) gui.Position {
+		y, size, ok := tree.offsetAndSize(uid)
+		assert.True(t, ok)
+		return gui.NewPos(100, y+size.Height*fraction)
+	}
+
+	test.DragAndDrop(w.Canvas(), nodeAt("A", .5), nodeAt("B", .5))
+	assert.Equal(t, []string{"A>B:2"}, moves)
+	assert.Equal(t, []string{"C", "D", "A"}, data["B"])
+	assert.Equal(t, []string{"B", "E"}, data[""])
+
+	test.DragAndDrop(w.Canvas(), nodeAt("E", .5), nodeAt("C", .1))
+	assert.Equal(t, []string{"C", "D", "A"}, data["B"][1:])
+	assert.Equal(t, "E>B:0", moves[1])
+
+	test.DragAndDrop(w.Canvas(), nodeAt("C", .5), nodeAt("A", .9))
+	assert.Equal(t, "C>B:3", moves[2])
+	assert.Equal(t, []string{"E", "D", "A", "C"}, data["B"])
+
+	test.DragAndDrop(w.Canvas(), nodeAt("B", .5), nodeAt("D", .5))
+	assert.Len(t, moves, 3, "a branch can not be moved into its own children")
+
+	test.DragAndDrop(w.Canvas(), nodeAt("D", .5), gui.NewPos(100, 290))
+	assert.Equal(t, "D>:1", moves[3])
+	assert.Equal(t, []string{"B", "D"}, data[""])
+	assert.False(t, tree.dropIndicator.Visible())
+	assert.Empty(t, w.Canvas().Overlays().List())
+
+	d := getLeaf(t, tree, "D")
+	assert.True(t, tree.DragOver(&gui.DragData{Type: treeNodeDragType, Value: "D", Source: d}, nodeAt("B", .5)))
+	assert.Equal(t, color.Transparent, tree.dropIndicator.FillColor)
+	assert.True(t, tree.DragOver(&gui.DragData{Type: treeNodeDragType, Value: "D", Source: d}, nodeAt("B", .1)))
+	assert.Equal(t, theme.PrimaryColor(), tree.dropIndicator.FillColor)
+	tree.DragLeave()
+	assert.False(t, tree.dropIndicator.Visible())
+
+	tree.OnMoved = nil
+	test.DragAndDrop(w.Canvas(), nodeAt("D", .5), nodeAt("B", .5))
+	assert.Len(t, moves, 4)
+}
+
 func TestTree_ScrollTo(t *testing.T) {
 	test.NewApp()
 	defer test.NewApp()
@@ -21,6 +21,8 @@ package frontend
 // THE SOFTWARE.
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
 	"os"
@@ -47,6 +49,13 @@ type (
 			Rank string
 		}
 		Log string
+		TLS tlsClient
+	}
+
+	tlsClient struct {
+		Enabled    bool
+		CACert     string `toml:"ca_cert"`
+		ServerName string `toml:"server_name"`
 	}
 
 	server struct {
@@ -99,6 +108,26 @@ func (c *client) GetLogFileName() string {
 	return logname
 }
 
+//tlsConfig returns the TLS settings used to connect to the backend server, or nil if TLS is not enabled
+func (c *client) tlsConfig() (*tls.Config, error) {
+	if !c.TLS.Enabled {
+		return nil, nil
+	}
+
+	cfg := &tls.Config{ServerName: c.TLS.ServerName, MinVersion: tls.VersionTLS12}
+	if c.TLS.CACert != "" {
+		pem, err := os.ReadFile(c.TLS.CACert)
+		if err != nil {
+			return nil, fmt.Errorf("Error loading CA certificate: %v\n", err)
+		}
+		cfg.RootCAs = x509.NewCertPool()
+		if !cfg.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("Error loading CA certificate: no certificates in %s\n", c.TLS.CACert)
+		}
+	}
+	return cfg, nil
+}
+
 //LoadConfig load configuration from file
 func LoadConfig(confPath string) error {
 	f := "client.toml"
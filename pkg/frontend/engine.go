@@ -21,47 +21,83 @@ package frontend
 // THE SOFTWARE.
 
 import (
+	"crypto/tls"
 	"fmt"
-	"io"
 	"log"
 	"net"
+	"strings"
 
+	"github.com/bhojpur/gui/pkg/backend"
 	"github.com/bhojpur/gui/pkg/engine/widget"
 )
 
-func ConfirmSend(form *widget.Form, btnSave *widget.Button, lblSuccess *widget.Label, lblError *widget.Label, data string) {
+//ConfirmSend sends the records to the backend server and shows its response
+func ConfirmSend(form *widget.Form, btnSave *widget.Button, lblSuccess *widget.Label, lblError *widget.Label, records ...backend.Record) {
 	localhost := defaultConfig.GetDefaultServer()
-	if err := sendData(localhost, data); err != nil {
+	replies, err := sendRecords(localhost, records...)
+	if err != nil {
 		log.Println(err)
+		lblSuccess.SetText("")
 		lblError.SetText(err.Error())
-	} else {
-		lblSuccess.SetText("Saved successfully")
+		return
+	}
+
+	var acks, errs []string
+	for _, reply := range replies {
+		if reply.Type == backend.MessageAck {
+			acks = append(acks, reply.Text)
+		} else {
+			errs = append(errs, reply.Text)
+		}
 	}
+	lblSuccess.SetText(strings.Join(acks, "\n"))
+	lblError.SetText(strings.Join(errs, "\n"))
 }
 
-//sendData sends data to backend server
-func sendData(addr, data string) error {
+//dialServer connects to the backend server, using TLS if it is enabled in the configuration
+func dialServer(addr string) (net.Conn, error) {
+	cfg, err := defaultConfig.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return net.Dial("tcp", addr)
+	}
+	return tls.Dial("tcp", addr, cfg)
+}
+
+//sendRecords sends records to backend server and returns its reply to each of them
+func sendRecords(addr string, records ...backend.Record) ([]*backend.Message, error) {
 	// connect with server
-	conn, err := net.Dial("tcp", addr)
+	conn, err := dialServer(addr)
 	if err != nil {
-		return fmt.Errorf("Error connecting to backend server: %v\n", err)
+		return nil, fmt.Errorf("Error connecting to backend server: %v\n", err)
 	}
+	defer conn.Close()
 
 	// send data to backend server
-	if _, err := conn.Write([]byte(data)); err != nil {
-		return fmt.Errorf("Error sending data to backend server: %v\n", err)
+	for i, rec := range records {
+		msg := &backend.Message{Type: backend.MessageRecord, ID: uint64(i + 1), Record: rec}
+		if err := backend.WriteMessage(conn, msg); err != nil {
+			return nil, fmt.Errorf("Error sending data to backend server: %v\n", err)
+		}
+		log.Printf("Send data: %v\n", rec)
 	}
 
-	log.Printf("Send data: %v\n", data)
+	replies := make([]*backend.Message, 0, len(records))
+	for len(replies) < len(records) {
+		reply, err := backend.ReadMessage(conn)
+		if err != nil {
+			return nil, fmt.Errorf("Response error: %v\n", err)
+		}
+		// a reply without ID reports a protocol error after which the server hangs up
+		if reply.ID == 0 {
+			return nil, fmt.Errorf("Response error: %v\n", reply.Text)
+		}
 
-	resp := make([]byte, 512)
-	respLen, err := conn.Read(resp)
-	if err != nil && err != io.EOF {
-		return fmt.Errorf("Response error: %v\n", err)
+		log.Println("Packet processing: ", reply.Text)
+		replies = append(replies, reply)
 	}
 
-	log.Println("Packet processing: ", string(resp[:respLen]))
-
-	// close connection
-	return conn.Close()
+	return replies, nil
 }
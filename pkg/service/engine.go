@@ -0,0 +1,165 @@
+package service
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// engine is a single Engine known to the service together with its log output
+type engine struct {
+	mu       sync.RWMutex
+	status   *v1.EngineStatus
+	onUpdate func(*v1.EngineStatus)
+
+	logs    io.WriteCloser
+	cancel  context.CancelFunc
+	stopped bool
+	done    chan struct{}
+}
+
+func newEngine(name string, md *v1.EngineMetadata, logs io.WriteCloser, cancel context.CancelFunc, onUpdate func(*v1.EngineStatus)) *engine {
+	return &engine{
+		status: &v1.EngineStatus{
+			Name:       name,
+			Metadata:   md,
+			Phase:      v1.EnginePhase_PHASE_PREPARING,
+			Conditions: &v1.EngineConditions{},
+		},
+		onUpdate: onUpdate,
+		logs:     logs,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+}
+
+// Status returns a copy of the current Engine status
+func (e *engine) Status() *v1.EngineStatus {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return proto.Clone(e.status).(*v1.EngineStatus)
+}
+
+// Done returns a channel which is closed once the Engine has finished
+func (e *engine) Done() <-chan struct{} {
+	return e.done
+}
+
+// Update modifies the Engine status and notifies everyone interested in the change
+func (e *engine) Update(mod func(st *v1.EngineStatus)) {
+	e.mu.Lock()
+	mod(e.status)
+	st := proto.Clone(e.status).(*v1.EngineStatus)
+	e.mu.Unlock()
+
+	if e.onUpdate != nil {
+		e.onUpdate(st)
+	}
+}
+
+// Stop cancels the Engine. Stopping an Engine which has already finished has no effect.
+func (e *engine) Stop() {
+	e.mu.Lock()
+	e.stopped = true
+	e.mu.Unlock()
+
+	e.cancel()
+}
+
+// Run moves the Engine through its phases and executes it using the runner
+func (e *engine) Run(ctx context.Context, runner Runner, spec *EngineSpec, waitUntil time.Time) {
+	defer close(e.done)
+	defer e.cancel()
+
+	if !waitUntil.IsZero() && time.Now().Before(waitUntil) {
+		e.phase(v1.EnginePhase_PHASE_WAITING, func(st *v1.EngineStatus) {
+			st.Conditions.WaitUntil = timestamppb.New(waitUntil)
+		})
+
+		t := time.NewTimer(time.Until(waitUntil))
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			e.finish(nil, ctx.Err())
+			return
+		}
+	}
+
+	e.phase(v1.EnginePhase_PHASE_STARTING, nil)
+	e.phase(v1.EnginePhase_PHASE_RUNNING, func(st *v1.EngineStatus) {
+		st.Conditions.DidExecute = true
+	})
+
+	results, err := runner(ctx, spec, e.logs)
+	if err == nil {
+		err = ctx.Err()
+	}
+	e.finish(results, err)
+}
+
+// phase transitions the Engine to a new phase and marks that transition in the log
+func (e *engine) phase(phase v1.EnginePhase, mod func(st *v1.EngineStatus)) {
+	fmt.Fprintf(e.logs, "[%s|PHASE] %s\n", phaseName(phase), phaseName(phase))
+	e.Update(func(st *v1.EngineStatus) {
+		st.Phase = phase
+		if mod != nil {
+			mod(st)
+		}
+	})
+}
+
+// finish marks the Engine as done and closes its log
+func (e *engine) finish(results []*v1.EngineResult, err error) {
+	e.mu.RLock()
+	stopped := e.stopped
+	e.mu.RUnlock()
+
+	e.phase(v1.EnginePhase_PHASE_DONE, func(st *v1.EngineStatus) {
+		st.Metadata.Finished = timestamppb.Now()
+		st.Results = append(st.Results, results...)
+		st.Conditions.Success = err == nil
+		switch {
+		case err == nil:
+		case stopped:
+			st.Details = "engine was stopped"
+			st.Conditions.FailureCount++
+		default:
+			st.Details = err.Error()
+			st.Conditions.FailureCount++
+		}
+	})
+	e.logs.Close()
+}
+
+// phaseName returns the lower-case name of a phase, e.g. "running"
+func phaseName(p v1.EnginePhase) string {
+	return strings.ToLower(strings.TrimPrefix(p.String(), "PHASE_"))
+}
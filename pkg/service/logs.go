@@ -0,0 +1,126 @@
+package service
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"bufio"
+	"html"
+	"io"
+	"strings"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+)
+
+// sliceTypes maps the type markers used in log lines to their slice type
+var sliceTypes = map[string]v1.LogSliceType{
+	"PHASE":  v1.LogSliceType_SLICE_PHASE,
+	"DONE":   v1.LogSliceType_SLICE_DONE,
+	"FAIL":   v1.LogSliceType_SLICE_FAIL,
+	"RESULT": v1.LogSliceType_SLICE_RESULT,
+}
+
+// sliceLogs splits the log into slice events until the log ends or send returns false.
+//
+// A line of the form "[name] payload" is content of the slice name, "[name|TYPE] payload"
+// marks a PHASE, DONE, FAIL or RESULT event of that slice. Lines without a marker belong
+// to the unnamed slice. The first line of a slice produces a SLICE_START event, slices
+// which never saw DONE or FAIL are abandoned when the log ends.
+func sliceLogs(in io.Reader, mode v1.ListenRequestLogs, send func(evt *v1.LogSliceEvent) bool) error {
+	var (
+		scanner = bufio.NewScanner(in)
+		open    = make(map[string]struct{})
+		order   []string
+	)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	emit := func(name string, tpe v1.LogSliceType, payload string) bool {
+		if mode == v1.ListenRequestLogs_LOGS_HTML {
+			payload = html.EscapeString(payload)
+		}
+		return send(&v1.LogSliceEvent{Name: name, Type: tpe, Payload: payload})
+	}
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if mode == v1.ListenRequestLogs_LOGS_UNSLICED {
+			if !emit("", v1.LogSliceType_SLICE_CONTENT, line) {
+				return nil
+			}
+			continue
+		}
+
+		name, tpe, payload := parseLogLine(line)
+		if tpe == v1.LogSliceType_SLICE_PHASE {
+			if !emit(name, tpe, payload) {
+				return nil
+			}
+			continue
+		}
+
+		if _, ok := open[name]; !ok {
+			open[name] = struct{}{}
+			order = append(order, name)
+			if !emit(name, v1.LogSliceType_SLICE_START, "") {
+				return nil
+			}
+		}
+		if !emit(name, tpe, payload) {
+			return nil
+		}
+		if tpe == v1.LogSliceType_SLICE_DONE || tpe == v1.LogSliceType_SLICE_FAIL {
+			delete(open, name)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	for _, name := range order {
+		if _, ok := open[name]; !ok {
+			continue
+		}
+		if !emit(name, v1.LogSliceType_SLICE_ABANDONED, "") {
+			return nil
+		}
+		delete(open, name)
+	}
+	return nil
+}
+
+func parseLogLine(line string) (name string, tpe v1.LogSliceType, payload string) {
+	tpe = v1.LogSliceType_SLICE_CONTENT
+	if !strings.HasPrefix(line, "[") {
+		return "", tpe, line
+	}
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return "", tpe, line
+	}
+
+	name = line[1:end]
+	payload = strings.TrimPrefix(line[end+1:], " ")
+	if i := strings.LastIndex(name, "|"); i >= 0 {
+		if t, ok := sliceTypes[name[i+1:]]; ok {
+			name, tpe = name[:i], t
+		}
+	}
+	return name, tpe, payload
+}
@@ -0,0 +1,648 @@
+package service
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	"github.com/bhojpur/gui/pkg/store"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Runner executes a single Engine. Everything written to logs becomes part of the
+// Engine's log output. Returning an error marks the Engine as failed.
+type Runner func(ctx context.Context, spec *EngineSpec, logs io.Writer) ([]*v1.EngineResult, error)
+
+// EngineSpec is everything a Runner needs to know to execute an Engine.
+type EngineSpec struct {
+	Name        string
+	Metadata    *v1.EngineMetadata
+	EnginePath  string
+	ConfigYAML  []byte
+	EngineYAML  []byte
+	Application []byte
+}
+
+// Config configures a Service
+type Config struct {
+	// Runner executes the Engine(s) started through the service. If nil, the
+	// Engine YAML is echoed to the log and the Engine succeeds.
+	Runner Runner
+
+	// Engines stores the status of all Engine(s). If nil, an in-memory store is used.
+	Engines store.Engines
+
+	// Logs stores the log output of all Engine(s). If nil, an in-memory store is used.
+	Logs store.Logs
+
+	// Instance names this service among all services that share the stores. Engine(s) are annotated
+	// with the instance that started them, and a restarted service only recovers its own Engine(s).
+	// If empty, the host name is used.
+	Instance string
+}
+
+// instanceAnnotation is the annotation naming the service instance that started an Engine
+const instanceAnnotation = "gui.instance"
+
+// Service is an in-process implementation of the GuiService
+type Service struct {
+	v1.UnimplementedGuiServiceServer
+
+	runner   Runner
+	engines  store.Engines
+	logs     store.Logs
+	instance string
+
+	mu      sync.RWMutex
+	running map[string]*engine
+	subs    map[*subscription]struct{}
+}
+
+// NewService creates a new GuiService implementation. Engine(s) of this instance which the
+// store lists as unfinished were interrupted by a restart and are marked as failed.
+func NewService(cfg Config) (*Service, error) {
+	s := &Service{
+		runner:   cfg.Runner,
+		engines:  cfg.Engines,
+		logs:     cfg.Logs,
+		instance: cfg.Instance,
+		running:  make(map[string]*engine),
+		subs:     make(map[*subscription]struct{}),
+	}
+	if s.runner == nil {
+		s.runner = echoRunner
+	}
+	if s.engines == nil {
+		s.engines = store.NewInMemoryEngines()
+	}
+	if s.logs == nil {
+		s.logs = store.NewInMemoryLogs()
+	}
+	if s.instance == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return nil, fmt.Errorf("cannot name service instance: %w", err)
+		}
+		s.instance = host
+	}
+
+	err := s.recover(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// echoRunner is the default Runner which copies the Engine YAML to the log
+func echoRunner(ctx context.Context, spec *EngineSpec, logs io.Writer) ([]*v1.EngineResult, error) {
+	_, err := logs.Write(spec.EngineYAML)
+	return nil, err
+}
+
+// recover finishes the Engine(s) of this instance which were still running when it last stopped.
+// Engine(s) of other instances sharing the store may still be running and are left alone.
+func (s *Service) recover(ctx context.Context) error {
+	unfinished := []*v1.FilterExpression{
+		{Terms: []*v1.FilterTerm{{Field: "phase", Value: phaseName(v1.EnginePhase_PHASE_DONE), Negate: true}}},
+		{Terms: []*v1.FilterTerm{{Field: "annotation." + instanceAnnotation, Value: s.instance}}},
+	}
+	orphans, _, err := s.engines.Find(ctx, unfinished, nil, 0, 0)
+	if err != nil {
+		return fmt.Errorf("cannot find unfinished engines: %w", err)
+	}
+
+	for _, st := range orphans {
+		st.Phase = v1.EnginePhase_PHASE_DONE
+		st.Details = "engine was interrupted by a restart of the service"
+		if st.Conditions == nil {
+			st.Conditions = &v1.EngineConditions{}
+		}
+		st.Conditions.Success = false
+		st.Conditions.FailureCount++
+		if st.Metadata == nil {
+			st.Metadata = &v1.EngineMetadata{}
+		}
+		st.Metadata.Finished = timestamppb.Now()
+
+		err = s.engines.Store(ctx, st)
+		if err != nil {
+			return fmt.Errorf("cannot store engine %s: %w", st.Name, err)
+		}
+		err = s.logs.Close(st.Name)
+		if err != nil && err != store.ErrNotFound {
+			return fmt.Errorf("cannot close log of engine %s: %w", st.Name, err)
+		}
+		log.WithField("name", st.Name).Warn("marked interrupted engine as failed")
+	}
+	return nil
+}
+
+// StartLocalEngine starts an Engine whose configuration and application are uploaded by the client
+func (s *Service) StartLocalEngine(srv v1.GuiService_StartLocalEngineServer) error {
+	var (
+		spec EngineSpec
+		done bool
+	)
+	for !done {
+		req, err := srv.Recv()
+		if err == io.EOF {
+			return status.Error(codes.InvalidArgument, "missing application tar done marker")
+		}
+		if err != nil {
+			return err
+		}
+
+		switch content := req.Content.(type) {
+		case *v1.StartLocalEngineRequest_Metadata:
+			if spec.Metadata != nil {
+				return status.Error(codes.InvalidArgument, "metadata was sent more than once")
+			}
+			spec.Metadata = content.Metadata
+		case *v1.StartLocalEngineRequest_ConfigYaml:
+			spec.ConfigYAML = append(spec.ConfigYAML, content.ConfigYaml...)
+		case *v1.StartLocalEngineRequest_EngineYaml:
+			spec.EngineYAML = append(spec.EngineYAML, content.EngineYaml...)
+		case *v1.StartLocalEngineRequest_ApplicationTar:
+			spec.Application = append(spec.Application, content.ApplicationTar...)
+		case *v1.StartLocalEngineRequest_ApplicationTarDone:
+			done = content.ApplicationTarDone
+		default:
+			return status.Error(codes.InvalidArgument, "unknown request content")
+		}
+	}
+	if spec.Metadata == nil {
+		return status.Error(codes.InvalidArgument, "metadata is required")
+	}
+	if len(spec.EngineYAML) == 0 {
+		return status.Error(codes.InvalidArgument, "engine YAML is required")
+	}
+
+	e, err := s.startEngine(srv.Context(), &spec, "", time.Time{})
+	if err != nil {
+		return err
+	}
+	return srv.SendAndClose(&v1.StartEngineResponse{Status: e.Status()})
+}
+
+// StartFromPreviousEngine starts a new Engine from the specification of a previous one
+func (s *Service) StartFromPreviousEngine(ctx context.Context, req *v1.StartFromPreviousEngineRequest) (*v1.StartEngineResponse, error) {
+	if req.PreviousEngine == "" {
+		return nil, status.Error(codes.InvalidArgument, "previous engine is required")
+	}
+	waitUntil, err := waitUntilTime(req.WaitUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	prev, err := s.engines.Get(ctx, req.PreviousEngine)
+	if err == store.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "engine %s not found", req.PreviousEngine)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get engine %s: %v", req.PreviousEngine, err)
+	}
+	if !prev.GetConditions().GetCanReplay() {
+		return nil, status.Errorf(codes.FailedPrecondition, "engine %s cannot be replayed", req.PreviousEngine)
+	}
+
+	prevSpec, err := s.engines.GetSpec(ctx, req.PreviousEngine)
+	if err == store.ErrNotFound {
+		return nil, status.Errorf(codes.FailedPrecondition, "engine %s cannot be replayed: specification is gone", req.PreviousEngine)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get specification of engine %s: %v", req.PreviousEngine, err)
+	}
+
+	md := proto.Clone(prev.Metadata).(*v1.EngineMetadata)
+	md.Created = nil
+	md.Trigger = v1.EngineTrigger_TRIGGER_MANUAL
+
+	spec := &EngineSpec{
+		Metadata:    md,
+		EnginePath:  prevSpec.EnginePath,
+		ConfigYAML:  prevSpec.ConfigYAML,
+		EngineYAML:  prevSpec.EngineYAML,
+		Application: prevSpec.Application,
+	}
+	e, err := s.startEngine(ctx, spec, "", waitUntil)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.StartEngineResponse{Status: e.Status()}, nil
+}
+
+// StartEngine starts a new Engine based on its specification
+func (s *Service) StartEngine(ctx context.Context, req *v1.StartEngineRequest) (*v1.StartEngineResponse, error) {
+	if req.Metadata == nil {
+		return nil, status.Error(codes.InvalidArgument, "metadata is required")
+	}
+	if len(req.EngineYaml) == 0 && req.EnginePath == "" {
+		return nil, status.Error(codes.InvalidArgument, "either engine YAML or engine path is required")
+	}
+	waitUntil, err := waitUntilTime(req.WaitUntil)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &EngineSpec{
+		Metadata:    req.Metadata,
+		EnginePath:  req.EnginePath,
+		EngineYAML:  req.EngineYaml,
+		Application: req.Sideload,
+	}
+	e, err := s.startEngine(ctx, spec, req.NameSuffix, waitUntil)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.StartEngineResponse{Status: e.Status()}, nil
+}
+
+// ListEngines searches for Engine(s) known to this service
+func (s *Service) ListEngines(ctx context.Context, req *v1.ListEnginesRequest) (*v1.ListEnginesResponse, error) {
+	if req.Start < 0 || req.Limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "start and limit must not be negative")
+	}
+
+	res, total, err := s.engines.Find(ctx, req.Filter, req.Order, int(req.Start), int(req.Limit))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot list engines: %v", err)
+	}
+
+	return &v1.ListEnginesResponse{
+		Total:  int32(total),
+		Result: res,
+	}, nil
+}
+
+// Subscribe streams updates of all Engine(s) matching the request filter
+func (s *Service) Subscribe(req *v1.SubscribeRequest, srv v1.GuiService_SubscribeServer) error {
+	sub := s.subscribe("", req.Filter)
+	defer s.unsubscribe(sub)
+
+	for {
+		select {
+		case <-srv.Context().Done():
+			return nil
+		case st := <-sub.updates:
+			err := srv.Send(&v1.SubscribeResponse{Result: st})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetEngine retrieves details of a single Engine
+func (s *Service) GetEngine(ctx context.Context, req *v1.GetEngineRequest) (*v1.GetEngineResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if e := s.runningEngine(req.Name); e != nil {
+		return &v1.GetEngineResponse{Result: e.Status()}, nil
+	}
+
+	st, err := s.getStatus(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.GetEngineResponse{Result: st}, nil
+}
+
+// Listen streams status updates and log output of a single Engine until that Engine is done
+func (s *Service) Listen(req *v1.ListenRequest, srv v1.GuiService_ListenServer) error {
+	if req.Name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+
+	ctx, cancel := context.WithCancel(srv.Context())
+	defer cancel()
+
+	// subscribe before reading the current status so that we do not miss an update in between
+	sub := s.subscribe(req.Name, nil)
+	defer s.unsubscribe(sub)
+
+	// Engine(s) of this instance report their updates, those running on another instance are polled from the store
+	var (
+		initial *v1.EngineStatus
+		current func() (*v1.EngineStatus, error)
+		done    <-chan struct{}
+		poll    <-chan time.Time
+	)
+	if e := s.runningEngine(req.Name); e != nil {
+		initial = e.Status()
+		current = func() (*v1.EngineStatus, error) { return e.Status(), nil }
+		done = e.Done()
+	} else {
+		st, err := s.getStatus(ctx, req.Name)
+		if err != nil {
+			return err
+		}
+		initial = st
+		current = func() (*v1.EngineStatus, error) { return s.getStatus(ctx, req.Name) }
+		if st.Phase != v1.EnginePhase_PHASE_DONE {
+			ticker := time.NewTicker(remotePollInterval)
+			defer ticker.Stop()
+			poll = ticker.C
+		}
+	}
+
+	var (
+		wg   sync.WaitGroup
+		msgs = make(chan *v1.ListenResponse)
+		errs = make(chan error, 1)
+	)
+	fail := func(err error) {
+		select {
+		case errs <- err:
+		default:
+		}
+	}
+	if req.Updates {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			st := initial
+			for {
+				select {
+				case msgs <- &v1.ListenResponse{Content: &v1.ListenResponse_Update{Update: st}}:
+				case <-ctx.Done():
+					return
+				}
+				if st.Phase == v1.EnginePhase_PHASE_DONE {
+					return
+				}
+
+				// only send the status again once it has changed
+				next := st
+				for proto.Equal(next, st) {
+					select {
+					case next = <-sub.updates:
+					case <-done:
+						done = nil
+						next, _ = current()
+					case <-poll:
+						if latest, err := current(); err == nil {
+							next = latest
+						}
+					case <-ctx.Done():
+						return
+					}
+				}
+				st = next
+			}
+		}()
+	}
+	if req.Logs != v1.ListenRequestLogs_LOGS_DISABLED {
+		logs, err := s.logs.Read(ctx, req.Name)
+		if err == store.ErrNotFound {
+			return status.Errorf(codes.NotFound, "logs of engine %s not found", req.Name)
+		}
+		if err != nil {
+			return status.Errorf(codes.Internal, "cannot read logs: %v", err)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := sliceLogs(logs, req.Logs, func(evt *v1.LogSliceEvent) bool {
+				select {
+				case msgs <- &v1.ListenResponse{Content: &v1.ListenResponse_Slice{Slice: evt}}:
+					return true
+				case <-ctx.Done():
+					return false
+				}
+			})
+			if err != nil && err != context.Canceled {
+				fail(err)
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(msgs)
+	}()
+
+	for msg := range msgs {
+		err := srv.Send(msg)
+		if err != nil {
+			cancel()
+			return err
+		}
+	}
+
+	select {
+	case err := <-errs:
+		return status.Errorf(codes.Internal, "cannot read logs: %v", err)
+	default:
+		return nil
+	}
+}
+
+// StopEngine stops a currently running Engine. Stopping an Engine which is done already has no effect.
+func (s *Service) StopEngine(ctx context.Context, req *v1.StopEngineRequest) (*v1.StopEngineResponse, error) {
+	if req.Name == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	if e := s.runningEngine(req.Name); e != nil {
+		e.Stop()
+		return &v1.StopEngineResponse{}, nil
+	}
+
+	_, err := s.getStatus(ctx, req.Name)
+	if err != nil {
+		return nil, err
+	}
+	return &v1.StopEngineResponse{}, nil
+}
+
+// remotePollInterval is how often Listen reads the status of an Engine which runs on another instance
+var remotePollInterval = time.Second
+
+func waitUntilTime(ts *timestamppb.Timestamp) (time.Time, error) {
+	if ts == nil {
+		return time.Time{}, nil
+	}
+	if err := ts.CheckValid(); err != nil {
+		return time.Time{}, status.Errorf(codes.InvalidArgument, "invalid wait_until: %v", err)
+	}
+	return ts.AsTime(), nil
+}
+
+func (s *Service) runningEngine(name string) *engine {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.running[name]
+}
+
+func (s *Service) getStatus(ctx context.Context, name string) (*v1.EngineStatus, error) {
+	st, err := s.engines.Get(ctx, name)
+	if err == store.ErrNotFound {
+		return nil, status.Errorf(codes.NotFound, "engine %s not found", name)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot get engine %s: %v", name, err)
+	}
+	return st, nil
+}
+
+// startEngine registers a new Engine and runs it in the background
+func (s *Service) startEngine(ctx context.Context, spec *EngineSpec, suffix string, waitUntil time.Time) (*engine, error) {
+	md := proto.Clone(spec.Metadata).(*v1.EngineMetadata)
+	if md.Created == nil {
+		md.Created = timestamppb.Now()
+	}
+	md.Finished = nil
+	setAnnotation(md, instanceAnnotation, s.instance)
+	spec.Metadata = md
+
+	base := md.EngineSpecName
+	if base == "" {
+		base = "engine"
+	}
+	nr, err := s.engines.NextNumber(ctx, base)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot name engine: %v", err)
+	}
+	name := fmt.Sprintf("%s.%d", base, nr)
+	if suffix != "" {
+		name += "." + suffix
+	}
+	spec.Name = name
+
+	logs, err := s.logs.Open(name)
+	if err == store.ErrAlreadyExists {
+		return nil, status.Errorf(codes.AlreadyExists, "engine %s exists already", name)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "cannot open engine log: %v", err)
+	}
+
+	canReplay := true
+	err = s.engines.StoreSpec(ctx, name, &store.EngineSpec{
+		EnginePath:  spec.EnginePath,
+		ConfigYAML:  spec.ConfigYAML,
+		EngineYAML:  spec.EngineYAML,
+		Application: spec.Application,
+	})
+	if err != nil {
+		log.WithError(err).WithField("name", name).Warn("cannot store engine specification - engine cannot be replayed")
+		canReplay = false
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	e := newEngine(name, md, logs, cancel, s.update)
+	s.mu.Lock()
+	s.running[name] = e
+	s.mu.Unlock()
+
+	e.Update(func(st *v1.EngineStatus) {
+		st.Conditions.CanReplay = canReplay
+	})
+	go func() {
+		e.Run(runCtx, s.runner, spec, waitUntil)
+
+		s.mu.Lock()
+		delete(s.running, name)
+		s.mu.Unlock()
+	}()
+
+	log.WithField("name", name).Debug("started engine")
+	return e, nil
+}
+
+// update persists a status update and fans it out to all interested subscribers
+func (s *Service) update(st *v1.EngineStatus) {
+	err := s.engines.Store(context.Background(), st)
+	if err != nil {
+		log.WithError(err).WithField("name", st.Name).Warn("cannot store engine status")
+	}
+	s.broadcast(st)
+}
+
+// subscription receives status updates of all Engine(s) matching its name and filter
+type subscription struct {
+	name    string
+	filter  []*v1.FilterExpression
+	updates chan *v1.EngineStatus
+}
+
+func (s *Service) subscribe(name string, filter []*v1.FilterExpression) *subscription {
+	sub := &subscription{
+		name:    name,
+		filter:  filter,
+		updates: make(chan *v1.EngineStatus, 64),
+	}
+
+	s.mu.Lock()
+	s.subs[sub] = struct{}{}
+	s.mu.Unlock()
+	return sub
+}
+
+func (s *Service) unsubscribe(sub *subscription) {
+	s.mu.Lock()
+	delete(s.subs, sub)
+	s.mu.Unlock()
+}
+
+// broadcast fans a status update out to all interested subscribers
+func (s *Service) broadcast(st *v1.EngineStatus) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for sub := range s.subs {
+		if sub.name != "" && sub.name != st.Name {
+			continue
+		}
+		if !store.MatchesFilter(st, sub.filter) {
+			continue
+		}
+
+		select {
+		case sub.updates <- st:
+		default:
+			log.WithField("name", st.Name).Warn("subscriber is too slow - dropping engine update")
+		}
+	}
+}
+
+// setAnnotation sets the value of an annotation, replacing any previous value
+func setAnnotation(md *v1.EngineMetadata, key, value string) {
+	for _, a := range md.Annotations {
+		if a.Key == key {
+			a.Value = value
+			return
+		}
+	}
+	md.Annotations = append(md.Annotations, &v1.Annotation{Key: key, Value: value})
+}
@@ -0,0 +1,487 @@
+package service
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	"github.com/bhojpur/gui/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTestClient(t *testing.T, cfg Config) v1.GuiServiceClient {
+	l := bufconn.Listen(1024 * 1024)
+	svc, err := NewService(cfg)
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	v1.RegisterGuiServiceServer(srv, svc)
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return v1.NewGuiServiceClient(conn)
+}
+
+func waitForPhase(t *testing.T, client v1.GuiServiceClient, name string, phase v1.EnginePhase) *v1.EngineStatus {
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		resp, err := client.GetEngine(context.Background(), &v1.GetEngineRequest{Name: name})
+		require.NoError(t, err)
+		if resp.Result.Phase == phase {
+			return resp.Result
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("engine %s did not reach phase %s", name, phase)
+	return nil
+}
+
+func TestService_StartEngine(t *testing.T) {
+	client := newTestClient(t, Config{})
+	ctx := context.Background()
+
+	_, err := client.StartEngine(ctx, &v1.StartEngineRequest{EngineYaml: []byte("a")})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	resp, err := client.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{Owner: "bhojpur", EngineSpecName: "build"},
+		EngineYaml: []byte("pod: {}\n"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "build.1", resp.Status.Name)
+	assert.NotNil(t, resp.Status.Metadata.Created)
+
+	st := waitForPhase(t, client, "build.1", v1.EnginePhase_PHASE_DONE)
+	assert.True(t, st.Conditions.Success)
+	assert.True(t, st.Conditions.DidExecute)
+	assert.NotNil(t, st.Metadata.Finished)
+
+	_, err = client.GetEngine(ctx, &v1.GetEngineRequest{Name: "does-not-exist"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestService_StartLocalEngine(t *testing.T) {
+	client := newTestClient(t, Config{})
+
+	srv, err := client.StartLocalEngine(context.Background())
+	require.NoError(t, err)
+	reqs := []*v1.StartLocalEngineRequest{
+		{Content: &v1.StartLocalEngineRequest_Metadata{Metadata: &v1.EngineMetadata{EngineSpecName: "local"}}},
+		{Content: &v1.StartLocalEngineRequest_ConfigYaml{ConfigYaml: []byte("rules: {}")}},
+		{Content: &v1.StartLocalEngineRequest_EngineYaml{EngineYaml: []byte("pod: {}")}},
+		{Content: &v1.StartLocalEngineRequest_ApplicationTar{ApplicationTar: []byte{1, 2, 3}}},
+		{Content: &v1.StartLocalEngineRequest_ApplicationTarDone{ApplicationTarDone: true}},
+	}
+	for _, req := range reqs {
+		require.NoError(t, srv.Send(req))
+	}
+	resp, err := srv.CloseAndRecv()
+	require.NoError(t, err)
+	assert.Equal(t, "local.1", resp.Status.Name)
+
+	waitForPhase(t, client, "local.1", v1.EnginePhase_PHASE_DONE)
+}
+
+func TestService_StopEngine(t *testing.T) {
+	client := newTestClient(t, Config{
+		Runner: func(ctx context.Context, spec *EngineSpec, logs io.Writer) ([]*v1.EngineResult, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	ctx := context.Background()
+
+	resp, err := client.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{EngineSpecName: "forever"},
+		EngineYaml: []byte("pod: {}"),
+	})
+	require.NoError(t, err)
+	waitForPhase(t, client, resp.Status.Name, v1.EnginePhase_PHASE_RUNNING)
+
+	_, err = client.StopEngine(ctx, &v1.StopEngineRequest{Name: resp.Status.Name})
+	require.NoError(t, err)
+
+	st := waitForPhase(t, client, resp.Status.Name, v1.EnginePhase_PHASE_DONE)
+	assert.False(t, st.Conditions.Success)
+	assert.Equal(t, int32(1), st.Conditions.FailureCount)
+	assert.Equal(t, "engine was stopped", st.Details)
+}
+
+func TestService_WaitUntil(t *testing.T) {
+	client := newTestClient(t, Config{})
+	ctx := context.Background()
+
+	resp, err := client.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{},
+		EngineYaml: []byte("pod: {}"),
+		NameSuffix: "later",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "engine.1.later", resp.Status.Name)
+
+	resp, err = client.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{},
+		EngineYaml: []byte("pod: {}"),
+		WaitUntil:  timestamppb.New(time.Now().Add(time.Hour)),
+	})
+	require.NoError(t, err)
+	st := waitForPhase(t, client, resp.Status.Name, v1.EnginePhase_PHASE_WAITING)
+	assert.NotNil(t, st.Conditions.WaitUntil)
+	assert.False(t, st.Conditions.DidExecute)
+}
+
+func TestService_StartFromPreviousEngine(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		specs []*EngineSpec
+	)
+	client := newTestClient(t, Config{
+		Runner: func(ctx context.Context, spec *EngineSpec, logs io.Writer) ([]*v1.EngineResult, error) {
+			mu.Lock()
+			specs = append(specs, spec)
+			mu.Unlock()
+			return nil, nil
+		},
+	})
+	ctx := context.Background()
+
+	_, err := client.StartFromPreviousEngine(ctx, &v1.StartFromPreviousEngineRequest{PreviousEngine: "build.1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{Owner: "bhojpur", EngineSpecName: "build", Trigger: v1.EngineTrigger_TRIGGER_PUSH},
+		EnginePath: "engines/build.yaml",
+		EngineYaml: []byte("pod: {}"),
+		Sideload:   []byte{1, 2, 3},
+	})
+	require.NoError(t, err)
+	prev := waitForPhase(t, client, "build.1", v1.EnginePhase_PHASE_DONE)
+	assert.True(t, prev.Conditions.CanReplay)
+
+	resp, err := client.StartFromPreviousEngine(ctx, &v1.StartFromPreviousEngineRequest{PreviousEngine: "build.1"})
+	require.NoError(t, err)
+	assert.Equal(t, "build.2", resp.Status.Name)
+	assert.Equal(t, v1.EngineTrigger_TRIGGER_MANUAL, resp.Status.Metadata.Trigger)
+	assert.Equal(t, "bhojpur", resp.Status.Metadata.Owner)
+	waitForPhase(t, client, "build.2", v1.EnginePhase_PHASE_DONE)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, specs, 2)
+	assert.Equal(t, "engines/build.yaml", specs[1].EnginePath)
+	assert.Equal(t, []byte("pod: {}"), specs[1].EngineYAML)
+	assert.Equal(t, []byte{1, 2, 3}, specs[1].Application)
+}
+
+func TestService_Restart(t *testing.T) {
+	engines, logs := store.NewInMemoryEngines(), store.NewInMemoryLogs()
+	client := newTestClient(t, Config{
+		Engines:  engines,
+		Logs:     logs,
+		Instance: "one",
+		Runner: func(ctx context.Context, spec *EngineSpec, logs io.Writer) ([]*v1.EngineResult, error) {
+			fmt.Fprintln(logs, "started")
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	})
+	ctx := context.Background()
+
+	_, err := client.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{EngineSpecName: "build"},
+		EngineYaml: []byte("pod: {}"),
+	})
+	require.NoError(t, err)
+	waitForPhase(t, client, "build.1", v1.EnginePhase_PHASE_RUNNING)
+
+	// another instance sharing the stores leaves the engine alone
+	client = newTestClient(t, Config{Engines: engines, Logs: logs, Instance: "two"})
+	resp, err := client.GetEngine(ctx, &v1.GetEngineRequest{Name: "build.1"})
+	require.NoError(t, err)
+	assert.Equal(t, v1.EnginePhase_PHASE_RUNNING, resp.Result.Phase)
+
+	// a restart of the instance sees the engine that was running before
+	client = newTestClient(t, Config{Engines: engines, Logs: logs, Instance: "one"})
+	st := waitForPhase(t, client, "build.1", v1.EnginePhase_PHASE_DONE)
+	assert.False(t, st.Conditions.Success)
+	assert.Equal(t, "engine was interrupted by a restart of the service", st.Details)
+
+	lst, err := client.Listen(ctx, &v1.ListenRequest{Name: "build.1", Logs: v1.ListenRequestLogs_LOGS_UNSLICED})
+	require.NoError(t, err)
+	var lines []string
+	for {
+		msg, err := lst.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		lines = append(lines, msg.GetSlice().Payload)
+	}
+	assert.Contains(t, lines, "started")
+
+	started, err := client.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{EngineSpecName: "build"},
+		EngineYaml: []byte("pod: {}"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "build.2", started.Status.Name)
+}
+
+func TestService_ListEngines(t *testing.T) {
+	client := newTestClient(t, Config{})
+	ctx := context.Background()
+
+	for i, owner := range []string{"alice", "bob", "carol"} {
+		_, err := client.StartEngine(ctx, &v1.StartEngineRequest{
+			Metadata: &v1.EngineMetadata{
+				Owner:          owner,
+				EngineSpecName: "spec",
+				Annotations:    []*v1.Annotation{{Key: "index", Value: fmt.Sprint(i)}},
+			},
+			EngineYaml: []byte("pod: {}"),
+		})
+		require.NoError(t, err)
+		waitForPhase(t, client, fmt.Sprintf("spec.%d", i+1), v1.EnginePhase_PHASE_DONE)
+	}
+
+	resp, err := client.ListEngines(ctx, &v1.ListEnginesRequest{
+		Order: []*v1.OrderExpression{{Field: "owner", Ascending: false}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), resp.Total)
+	assert.Equal(t, []string{"carol", "bob", "alice"}, owners(resp.Result))
+
+	resp, err = client.ListEngines(ctx, &v1.ListEnginesRequest{
+		Filter: []*v1.FilterExpression{
+			{Terms: []*v1.FilterTerm{
+				{Field: "owner", Value: "alice"},
+				{Field: "owner", Value: "car", Operation: v1.FilterOp_OP_STARTS_WITH},
+			}},
+			{Terms: []*v1.FilterTerm{{Field: "phase", Value: "done"}}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "carol"}, owners(resp.Result))
+
+	resp, err = client.ListEngines(ctx, &v1.ListEnginesRequest{
+		Filter: []*v1.FilterExpression{
+			{Terms: []*v1.FilterTerm{{Field: "annotation.index", Value: "1", Negate: true}}},
+		},
+		Start: 1,
+		Limit: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), resp.Total)
+	assert.Equal(t, []string{"carol"}, owners(resp.Result))
+}
+
+func TestService_Subscribe(t *testing.T) {
+	client := newTestClient(t, Config{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sub, err := client.Subscribe(ctx, &v1.SubscribeRequest{
+		Filter: []*v1.FilterExpression{{Terms: []*v1.FilterTerm{{Field: "owner", Value: "alice"}}}},
+	})
+	require.NoError(t, err)
+	// make sure the subscription is established before we start engines
+	time.Sleep(50 * time.Millisecond)
+
+	for _, owner := range []string{"bob", "alice"} {
+		_, err = client.StartEngine(ctx, &v1.StartEngineRequest{
+			Metadata:   &v1.EngineMetadata{Owner: owner},
+			EngineYaml: []byte("pod: {}"),
+		})
+		require.NoError(t, err)
+	}
+
+	var phases []v1.EnginePhase
+	for {
+		resp, err := sub.Recv()
+		require.NoError(t, err)
+		assert.Equal(t, "alice", resp.Result.Metadata.Owner)
+		phases = append(phases, resp.Result.Phase)
+		if resp.Result.Phase == v1.EnginePhase_PHASE_DONE {
+			break
+		}
+	}
+	assert.Equal(t, []v1.EnginePhase{
+		v1.EnginePhase_PHASE_PREPARING,
+		v1.EnginePhase_PHASE_STARTING,
+		v1.EnginePhase_PHASE_RUNNING,
+		v1.EnginePhase_PHASE_DONE,
+	}, phases)
+}
+
+func TestService_Listen(t *testing.T) {
+	release := make(chan struct{})
+	client := newTestClient(t, Config{
+		Runner: func(ctx context.Context, spec *EngineSpec, logs io.Writer) ([]*v1.EngineResult, error) {
+			<-release
+			fmt.Fprintln(logs, "hello <world>")
+			fmt.Fprintln(logs, "[build] compiling")
+			fmt.Fprintln(logs, "[build|DONE] ok")
+			fmt.Fprintln(logs, "[test] testing")
+			return nil, errors.New("tests failed")
+		},
+	})
+	ctx := context.Background()
+
+	resp, err := client.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{},
+		EngineYaml: []byte("pod: {}"),
+	})
+	require.NoError(t, err)
+
+	lst, err := client.Listen(ctx, &v1.ListenRequest{
+		Name:    resp.Status.Name,
+		Updates: true,
+		Logs:    v1.ListenRequestLogs_LOGS_HTML,
+	})
+	require.NoError(t, err)
+	close(release)
+
+	var (
+		slices []*v1.LogSliceEvent
+		last   *v1.EngineStatus
+	)
+	for {
+		msg, err := lst.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		if u := msg.GetUpdate(); u != nil {
+			last = u
+		}
+		if s := msg.GetSlice(); s != nil && s.Type != v1.LogSliceType_SLICE_PHASE {
+			slices = append(slices, s)
+		}
+	}
+
+	require.NotNil(t, last)
+	assert.Equal(t, v1.EnginePhase_PHASE_DONE, last.Phase)
+	assert.Equal(t, "tests failed", last.Details)
+
+	type slice struct {
+		Name    string
+		Type    v1.LogSliceType
+		Payload string
+	}
+	var actual []slice
+	for _, s := range slices {
+		actual = append(actual, slice{s.Name, s.Type, s.Payload})
+	}
+	assert.Equal(t, []slice{
+		{"", v1.LogSliceType_SLICE_START, ""},
+		{"", v1.LogSliceType_SLICE_CONTENT, "hello &lt;world&gt;"},
+		{"build", v1.LogSliceType_SLICE_START, ""},
+		{"build", v1.LogSliceType_SLICE_CONTENT, "compiling"},
+		{"build", v1.LogSliceType_SLICE_DONE, "ok"},
+		{"test", v1.LogSliceType_SLICE_START, ""},
+		{"test", v1.LogSliceType_SLICE_CONTENT, "testing"},
+		{"", v1.LogSliceType_SLICE_ABANDONED, ""},
+		{"test", v1.LogSliceType_SLICE_ABANDONED, ""},
+	}, actual)
+}
+
+func TestService_ListenOtherInstance(t *testing.T) {
+	interval := remotePollInterval
+	remotePollInterval = 10 * time.Millisecond
+	defer func() { remotePollInterval = interval }()
+
+	engines, logs := store.NewInMemoryEngines(), store.NewInMemoryLogs()
+	release := make(chan struct{})
+	one := newTestClient(t, Config{
+		Engines:  engines,
+		Logs:     logs,
+		Instance: "one",
+		Runner: func(ctx context.Context, spec *EngineSpec, logs io.Writer) ([]*v1.EngineResult, error) {
+			<-release
+			return nil, nil
+		},
+	})
+	two := newTestClient(t, Config{Engines: engines, Logs: logs, Instance: "two"})
+	ctx := context.Background()
+
+	resp, err := one.StartEngine(ctx, &v1.StartEngineRequest{
+		Metadata:   &v1.EngineMetadata{},
+		EngineYaml: []byte("pod: {}"),
+	})
+	require.NoError(t, err)
+	waitForPhase(t, one, resp.Status.Name, v1.EnginePhase_PHASE_RUNNING)
+
+	lst, err := two.Listen(ctx, &v1.ListenRequest{Name: resp.Status.Name, Updates: true})
+	require.NoError(t, err)
+	updates := make(chan *v1.EngineStatus, 100)
+	go func() {
+		defer close(updates)
+		for {
+			msg, err := lst.Recv()
+			if err != nil {
+				return
+			}
+			updates <- msg.GetUpdate()
+		}
+	}()
+
+	// the unchanged status of an engine of another instance is sent only once
+	time.Sleep(100 * time.Millisecond)
+	require.Len(t, updates, 1)
+	assert.Equal(t, v1.EnginePhase_PHASE_RUNNING, (<-updates).Phase)
+
+	close(release)
+	var last *v1.EngineStatus
+	for st := range updates {
+		last = st
+	}
+	require.NotNil(t, last)
+	assert.Equal(t, v1.EnginePhase_PHASE_DONE, last.Phase)
+	assert.True(t, last.Conditions.Success)
+}
+
+func owners(res []*v1.EngineStatus) []string {
+	var owners []string
+	for _, st := range res {
+		owners = append(owners, st.Metadata.Owner)
+	}
+	return owners
+}
@@ -0,0 +1,154 @@
+package service
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+// UIConfig configures a UIService
+type UIConfig struct {
+	// SpecDir is the directory which is scanned for Engine YAML specs
+	SpecDir string
+
+	// Repository is reported as the repository of all Engine specs
+	Repository *v1.Repository
+
+	// ReadOnly is reported to the UI which then prevents starting Engine(s)
+	ReadOnly bool
+}
+
+// engineSpecFile is the part of an Engine YAML spec the UI is interested in
+type engineSpecFile struct {
+	Desc string `yaml:"desc"`
+	Args []struct {
+		Name     string `yaml:"name"`
+		Required bool   `yaml:"req"`
+		Desc     string `yaml:"desc"`
+	} `yaml:"args"`
+}
+
+// configFileName is the configuration next to the Engine specs which is not a spec itself
+const configFileName = "config.yaml"
+
+// UIService implements the GuiUI service based on a directory of Engine specs
+type UIService struct {
+	v1.UnimplementedGuiUIServer
+
+	cfg UIConfig
+}
+
+// NewUIService creates a new GuiUI service implementation
+func NewUIService(cfg UIConfig) *UIService {
+	return &UIService{cfg: cfg}
+}
+
+// ListEngineSpecs streams all Engine specs found in the spec directory ordered by their path.
+// Files which are not valid Engine specs are skipped.
+func (s *UIService) ListEngineSpecs(req *v1.ListEngineSpecsRequest, srv v1.GuiUI_ListEngineSpecsServer) error {
+	if s.cfg.SpecDir == "" {
+		return nil
+	}
+
+	var paths []string
+	err := filepath.Walk(s.cfg.SpecDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || info.Name() == configFileName {
+			return nil
+		}
+		if ext := filepath.Ext(path); ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		paths = append(paths, path)
+		return nil
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "cannot scan engine specs: %v", err)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		resp, err := s.readSpec(path)
+		if err != nil {
+			log.WithError(err).WithField("path", path).Warn("skipping invalid engine spec")
+			continue
+		}
+
+		err = srv.Send(resp)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *UIService) readSpec(path string) (*v1.ListEngineSpecsResponse, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var spec engineSpecFile
+	err = yaml.Unmarshal(data, &spec)
+	if err != nil {
+		return nil, err
+	}
+
+	rel, err := filepath.Rel(s.cfg.SpecDir, path)
+	if err != nil {
+		return nil, err
+	}
+	rel = filepath.ToSlash(rel)
+
+	args := make([]*v1.DesiredAnnotation, 0, len(spec.Args))
+	for _, arg := range spec.Args {
+		args = append(args, &v1.DesiredAnnotation{
+			Name:        arg.Name,
+			Required:    arg.Required,
+			Description: arg.Desc,
+		})
+	}
+
+	return &v1.ListEngineSpecsResponse{
+		Repo:        s.cfg.Repository,
+		Name:        strings.TrimSuffix(filepath.Base(rel), filepath.Ext(rel)),
+		Path:        rel,
+		Description: spec.Desc,
+		Arguments:   args,
+	}, nil
+}
+
+// IsReadOnly returns true if the UI is read-only
+func (s *UIService) IsReadOnly(ctx context.Context, req *v1.IsReadOnlyRequest) (*v1.IsReadOnlyResponse, error) {
+	return &v1.IsReadOnlyResponse{Readonly: s.cfg.ReadOnly}, nil
+}
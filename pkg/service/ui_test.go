@@ -0,0 +1,119 @@
+package service
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestUIClient(t *testing.T, cfg UIConfig) v1.GuiUIClient {
+	l := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	v1.RegisterGuiUIServer(srv, NewUIService(cfg))
+	go srv.Serve(l)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return l.Dial() }),
+		grpc.WithInsecure(),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return v1.NewGuiUIClient(conn)
+}
+
+func TestUIService_ListEngineSpecs(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gui-specs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"build.yaml": `desc: Builds the application
+args:
+- name: version
+  req: true
+  desc: Version to build
+- name: debug
+pod: {}
+`,
+		"config.yaml":        "rules: {}\n",
+		"invalid.yaml":       "desc: [\n",
+		"README.md":          "# Engines\n",
+		"release/deploy.yml": "desc: Deploys a release\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	repo := &v1.Repository{Host: "github.com", Owner: "bhojpur", Repo: "gui"}
+	client := newTestUIClient(t, UIConfig{SpecDir: dir, Repository: repo})
+	stream, err := client.ListEngineSpecs(context.Background(), &v1.ListEngineSpecsRequest{})
+	require.NoError(t, err)
+
+	var specs []*v1.ListEngineSpecsResponse
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		specs = append(specs, resp)
+	}
+
+	require.Len(t, specs, 2)
+	assert.Equal(t, "build", specs[0].Name)
+	assert.Equal(t, "build.yaml", specs[0].Path)
+	assert.Equal(t, "Builds the application", specs[0].Description)
+	assert.Equal(t, "gui", specs[0].Repo.Repo)
+	require.Len(t, specs[0].Arguments, 2)
+	assert.Equal(t, "version", specs[0].Arguments[0].Name)
+	assert.True(t, specs[0].Arguments[0].Required)
+	assert.Equal(t, "Version to build", specs[0].Arguments[0].Description)
+	assert.False(t, specs[0].Arguments[1].Required)
+
+	assert.Equal(t, "deploy", specs[1].Name)
+	assert.Equal(t, "release/deploy.yml", specs[1].Path)
+	assert.Empty(t, specs[1].Arguments)
+}
+
+func TestUIService_IsReadOnly(t *testing.T) {
+	for _, readonly := range []bool{true, false} {
+		client := newTestUIClient(t, UIConfig{ReadOnly: readonly})
+		resp, err := client.IsReadOnly(context.Background(), &v1.IsReadOnlyRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, readonly, resp.Readonly)
+	}
+}
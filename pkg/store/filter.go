@@ -0,0 +1,189 @@
+package store
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MatchesFilter returns true if the Engine status matches all filter expressions.
+// An expression matches if any of its terms matches.
+//
+// Supported fields are name, phase, owner, trigger, spec, success, repo.host,
+// repo.owner, repo.repo, repo.ref, repo.rev, created, finished and
+// annotation.<key>.
+func MatchesFilter(st *v1.EngineStatus, filter []*v1.FilterExpression) bool {
+	for _, expr := range filter {
+		if len(expr.Terms) == 0 {
+			continue
+		}
+
+		var match bool
+		for _, term := range expr.Terms {
+			if matchesTerm(st, term) {
+				match = true
+				break
+			}
+		}
+		if !match {
+			return false
+		}
+	}
+	return true
+}
+
+// SortEngines orders the Engine(s) according to the order expressions. Engines that are
+// equal with respect to all expressions keep their creation order.
+func SortEngines(engines []*v1.EngineStatus, order []*v1.OrderExpression) {
+	sort.SliceStable(engines, func(i, j int) bool {
+		for _, o := range order {
+			c := compareField(engines[i], engines[j], o.Field)
+			if c == 0 {
+				continue
+			}
+			if o.Ascending {
+				return c < 0
+			}
+			return c > 0
+		}
+		return compareField(engines[i], engines[j], "created") < 0
+	})
+}
+
+func matchesTerm(st *v1.EngineStatus, term *v1.FilterTerm) bool {
+	val, ok := fieldValue(st, term.Field)
+
+	var res bool
+	switch term.Operation {
+	case v1.FilterOp_OP_EXISTS:
+		res = ok
+	case v1.FilterOp_OP_EQUALS:
+		res = ok && val == term.Value
+	case v1.FilterOp_OP_STARTS_WITH:
+		res = ok && strings.HasPrefix(val, term.Value)
+	case v1.FilterOp_OP_ENDS_WITH:
+		res = ok && strings.HasSuffix(val, term.Value)
+	case v1.FilterOp_OP_CONTAINS:
+		res = ok && strings.Contains(val, term.Value)
+	}
+
+	if term.Negate {
+		return !res
+	}
+	return res
+}
+
+// fieldValue returns the string representation of a field of the Engine status.
+// The second return value is false if the status does not have that field.
+func fieldValue(st *v1.EngineStatus, field string) (string, bool) {
+	md := st.GetMetadata()
+	repo := md.GetRepository()
+
+	switch field {
+	case "name":
+		return st.Name, true
+	case "phase":
+		return phaseName(st.Phase), true
+	case "owner":
+		return md.GetOwner(), md != nil
+	case "trigger":
+		return strings.ToLower(strings.TrimPrefix(md.GetTrigger().String(), "TRIGGER_")), md != nil
+	case "spec":
+		return md.GetEngineSpecName(), md.GetEngineSpecName() != ""
+	case "success":
+		return strconv.FormatBool(st.GetConditions().GetSuccess()), true
+	case "created":
+		return timestampValue(md.GetCreated())
+	case "finished":
+		return timestampValue(md.GetFinished())
+	case "repo.host":
+		return repo.GetHost(), repo != nil
+	case "repo.owner":
+		return repo.GetOwner(), repo != nil
+	case "repo.repo":
+		return repo.GetRepo(), repo != nil
+	case "repo.ref":
+		return repo.GetRef(), repo != nil
+	case "repo.rev":
+		return repo.GetRevision(), repo != nil
+	}
+
+	if key := strings.TrimPrefix(field, "annotation."); key != field {
+		for _, a := range md.GetAnnotations() {
+			if a.Key == key {
+				return a.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+// compareField compares a field of two Engine(s). Timestamps are compared in time,
+// everything else lexicographically. Missing fields sort first.
+func compareField(a, b *v1.EngineStatus, field string) int {
+	switch field {
+	case "created":
+		return compareTimestamps(a.GetMetadata().GetCreated(), b.GetMetadata().GetCreated())
+	case "finished":
+		return compareTimestamps(a.GetMetadata().GetFinished(), b.GetMetadata().GetFinished())
+	}
+
+	va, _ := fieldValue(a, field)
+	vb, _ := fieldValue(b, field)
+	return strings.Compare(va, vb)
+}
+
+func compareTimestamps(a, b *timestamppb.Timestamp) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+
+	ta, tb := a.AsTime(), b.AsTime()
+	switch {
+	case ta.Before(tb):
+		return -1
+	case ta.After(tb):
+		return 1
+	}
+	return 0
+}
+
+func timestampValue(ts *timestamppb.Timestamp) (string, bool) {
+	if ts == nil {
+		return "", false
+	}
+	return ts.AsTime().Format("2006-01-02T15:04:05Z07:00"), true
+}
+
+// phaseName returns the lower-case name of a phase, e.g. "running"
+func phaseName(p v1.EnginePhase) string {
+	return strings.ToLower(strings.TrimPrefix(p.String(), "PHASE_"))
+}
@@ -0,0 +1,239 @@
+package store
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewInMemoryEngines creates a new in-memory Engine store
+func NewInMemoryEngines() *InMemoryEngines {
+	return &InMemoryEngines{
+		status:  make(map[string]*v1.EngineStatus),
+		specs:   make(map[string]*EngineSpec),
+		numbers: make(map[string]int),
+	}
+}
+
+// InMemoryEngines is an Engine store which keeps everything in memory. Its content
+// is lost when the process ends.
+type InMemoryEngines struct {
+	mu      sync.RWMutex
+	status  map[string]*v1.EngineStatus
+	specs   map[string]*EngineSpec
+	numbers map[string]int
+}
+
+var _ Engines = &InMemoryEngines{}
+
+// Store stores the status of an Engine
+func (s *InMemoryEngines) Store(ctx context.Context, status *v1.EngineStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.status[status.Name] = proto.Clone(status).(*v1.EngineStatus)
+	return nil
+}
+
+// Get retrieves the status of a single Engine
+func (s *InMemoryEngines) Get(ctx context.Context, name string) (*v1.EngineStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	st, ok := s.status[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return proto.Clone(st).(*v1.EngineStatus), nil
+}
+
+// Find returns all Engine(s) matching the filter
+func (s *InMemoryEngines) Find(ctx context.Context, filter []*v1.FilterExpression, order []*v1.OrderExpression, start, limit int) (result []*v1.EngineStatus, total int, err error) {
+	s.mu.RLock()
+	res := make([]*v1.EngineStatus, 0, len(s.status))
+	for _, st := range s.status {
+		if !MatchesFilter(st, filter) {
+			continue
+		}
+		res = append(res, proto.Clone(st).(*v1.EngineStatus))
+	}
+	s.mu.RUnlock()
+
+	SortEngines(res, order)
+	return paginate(res, start, limit), len(res), nil
+}
+
+// StoreSpec stores the specification an Engine was started from
+func (s *InMemoryEngines) StoreSpec(ctx context.Context, name string, spec *EngineSpec) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cpy := *spec
+	s.specs[name] = &cpy
+	return nil
+}
+
+// GetSpec retrieves the specification an Engine was started from
+func (s *InMemoryEngines) GetSpec(ctx context.Context, name string) (*EngineSpec, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	spec, ok := s.specs[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	cpy := *spec
+	return &cpy, nil
+}
+
+// NextNumber returns the next number in a sequence
+func (s *InMemoryEngines) NextNumber(ctx context.Context, group string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.numbers[group]++
+	return s.numbers[group], nil
+}
+
+// NewInMemoryLogs creates a new in-memory log store
+func NewInMemoryLogs() *InMemoryLogs {
+	return &InMemoryLogs{logs: make(map[string]*logBuffer)}
+}
+
+// InMemoryLogs is a log store which keeps all logs in memory
+type InMemoryLogs struct {
+	mu   sync.Mutex
+	logs map[string]*logBuffer
+}
+
+var _ Logs = &InMemoryLogs{}
+
+// Open creates a new log
+func (s *InMemoryLogs) Open(name string) (io.WriteCloser, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.logs[name]; exists {
+		return nil, ErrAlreadyExists
+	}
+	buf := newLogBuffer()
+	s.logs[name] = buf
+	return buf, nil
+}
+
+// Read returns a reader following the log
+func (s *InMemoryLogs) Read(ctx context.Context, name string) (io.Reader, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf, ok := s.logs[name]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return &logReader{buf: buf, ctx: ctx}, nil
+}
+
+// Close marks the end of a log
+func (s *InMemoryLogs) Close(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	buf, ok := s.logs[name]
+	if !ok {
+		return ErrNotFound
+	}
+	return buf.Close()
+}
+
+// logBuffer keeps the complete content of a log in memory and wakes up all
+// following readers whenever content is added.
+type logBuffer struct {
+	mu      sync.Mutex
+	data    []byte
+	closed  bool
+	changed chan struct{}
+}
+
+func newLogBuffer() *logBuffer {
+	return &logBuffer{changed: make(chan struct{})}
+}
+
+// Write appends to the log
+func (l *logBuffer) Write(p []byte) (n int, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return 0, io.ErrClosedPipe
+	}
+	l.data = append(l.data, p...)
+	close(l.changed)
+	l.changed = make(chan struct{})
+	return len(p), nil
+}
+
+// Close marks the end of the log. Readers return io.EOF once they have consumed all data.
+func (l *logBuffer) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil
+	}
+	l.closed = true
+	close(l.changed)
+	return nil
+}
+
+type logReader struct {
+	buf *logBuffer
+	ctx context.Context
+	off int
+}
+
+func (r *logReader) Read(p []byte) (n int, err error) {
+	for {
+		r.buf.mu.Lock()
+		if r.off < len(r.buf.data) {
+			n = copy(p, r.buf.data[r.off:])
+			r.off += n
+			r.buf.mu.Unlock()
+			return n, nil
+		}
+		if r.buf.closed {
+			r.buf.mu.Unlock()
+			return 0, io.EOF
+		}
+		changed := r.buf.changed
+		r.buf.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,552 @@
+package store
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+	"github.com/lib/pq"
+	"google.golang.org/protobuf/proto"
+)
+
+// sqlSchema is applied whenever a SQL store is created
+const sqlSchema = `
+CREATE TABLE IF NOT EXISTS engine_status (
+	name TEXT PRIMARY KEY,
+	created TIMESTAMPTZ,
+	data BYTEA NOT NULL
+);
+ALTER TABLE engine_status
+	ADD COLUMN IF NOT EXISTS phase TEXT,
+	ADD COLUMN IF NOT EXISTS owner TEXT,
+	ADD COLUMN IF NOT EXISTS finished TIMESTAMPTZ;
+CREATE INDEX IF NOT EXISTS engine_status_created ON engine_status (created, name);
+CREATE INDEX IF NOT EXISTS engine_status_phase ON engine_status (phase);
+CREATE TABLE IF NOT EXISTS engine_spec (
+	name TEXT PRIMARY KEY,
+	engine_path TEXT NOT NULL,
+	config_yaml BYTEA,
+	engine_yaml BYTEA,
+	application BYTEA
+);
+CREATE TABLE IF NOT EXISTS engine_number (
+	grp TEXT PRIMARY KEY,
+	val INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS engine_log (
+	name TEXT PRIMARY KEY,
+	closed BOOLEAN NOT NULL DEFAULT FALSE
+);
+CREATE TABLE IF NOT EXISTS engine_log_chunk (
+	id BIGSERIAL PRIMARY KEY,
+	name TEXT NOT NULL REFERENCES engine_log(name),
+	data BYTEA NOT NULL
+);
+CREATE INDEX IF NOT EXISTS engine_log_chunk_name ON engine_log_chunk (name, id);
+`
+
+// pgUniqueViolation is the PostgreSQL error code for unique constraint violations
+const pgUniqueViolation = "23505"
+
+// sqlLogPollInterval is how often readers check for log content written by other processes
+var sqlLogPollInterval = time.Second
+
+func migrate(db *sql.DB) error {
+	_, err := db.Exec(sqlSchema)
+	return err
+}
+
+// NewSQLEngines creates a new SQL Engine store, creating the required tables if necessary.
+// The store expects a PostgreSQL database.
+func NewSQLEngines(db *sql.DB) (*SQLEngines, error) {
+	err := migrate(db)
+	if err != nil {
+		return nil, err
+	}
+	s := &SQLEngines{DB: db}
+	err = s.fillColumns(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SQLEngines stores Engine(s) in a PostgreSQL database
+type SQLEngines struct {
+	DB *sql.DB
+}
+
+var _ Engines = &SQLEngines{}
+
+// Store stores the status of an Engine
+func (s *SQLEngines) Store(ctx context.Context, status *v1.EngineStatus) error {
+	data, err := proto.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	var created, finished, owner interface{}
+	if c := status.GetMetadata().GetCreated(); c != nil {
+		created = c.AsTime()
+	}
+	if f := status.GetMetadata().GetFinished(); f != nil {
+		finished = f.AsTime()
+	}
+	if md := status.GetMetadata(); md != nil {
+		owner = md.Owner
+	}
+	_, err = s.DB.ExecContext(ctx, `
+		INSERT INTO engine_status (name, created, data, phase, owner, finished) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name) DO UPDATE SET
+			created = EXCLUDED.created,
+			data = EXCLUDED.data,
+			phase = EXCLUDED.phase,
+			owner = EXCLUDED.owner,
+			finished = EXCLUDED.finished`,
+		status.Name, created, data, phaseName(status.Phase), owner, finished,
+	)
+	return err
+}
+
+// fillColumns sets the filter and order columns of Engine(s) stored before the columns existed
+func (s *SQLEngines) fillColumns(ctx context.Context) error {
+	rows, err := s.DB.QueryContext(ctx, `SELECT data FROM engine_status WHERE phase IS NULL`)
+	if err != nil {
+		return err
+	}
+	var outdated []*v1.EngineStatus
+	for rows.Next() {
+		var data []byte
+		if err = rows.Scan(&data); err != nil {
+			rows.Close()
+			return err
+		}
+		var st v1.EngineStatus
+		if err = proto.Unmarshal(data, &st); err != nil {
+			rows.Close()
+			return err
+		}
+		outdated = append(outdated, &st)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for _, st := range outdated {
+		if err = s.Store(ctx, st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Get retrieves the status of a single Engine
+func (s *SQLEngines) Get(ctx context.Context, name string) (*v1.EngineStatus, error) {
+	var data []byte
+	err := s.DB.QueryRowContext(ctx, `SELECT data FROM engine_status WHERE name = $1`, name).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res v1.EngineStatus
+	err = proto.Unmarshal(data, &res)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// Find returns all Engine(s) matching the filter. Filters on the name, phase and owner and ordering
+// by those fields or the created and finished times are done by the database, as is pagination if
+// nothing else is needed. Any other expressions are evaluated in-process like the in-memory store.
+func (s *SQLEngines) Find(ctx context.Context, filter []*v1.FilterExpression, order []*v1.OrderExpression, start, limit int) (result []*v1.EngineStatus, total int, err error) {
+	where, args, rest := sqlWhere(filter)
+	orderBy, sorted := sqlOrderBy(order)
+	if len(rest) > 0 || !sorted {
+		res, err := s.query(ctx, `SELECT data FROM engine_status`+where+` ORDER BY `+orderBy, args...)
+		if err != nil {
+			return nil, 0, err
+		}
+		matching := res[:0]
+		for _, st := range res {
+			if MatchesFilter(st, rest) {
+				matching = append(matching, st)
+			}
+		}
+		if !sorted {
+			SortEngines(matching, order)
+		}
+		return paginate(matching, start, limit), len(matching), nil
+	}
+
+	err = s.DB.QueryRowContext(ctx, `SELECT count(*) FROM engine_status`+where, args...).Scan(&total)
+	if err != nil {
+		return nil, 0, err
+	}
+	page := fmt.Sprintf(" OFFSET %d", start)
+	if limit > 0 {
+		page += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	res, err := s.query(ctx, `SELECT data FROM engine_status`+where+` ORDER BY `+orderBy+page, args...)
+	if err != nil {
+		return nil, 0, err
+	}
+	return res, total, nil
+}
+
+func (s *SQLEngines) query(ctx context.Context, query string, args ...interface{}) ([]*v1.EngineStatus, error) {
+	rows, err := s.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var res []*v1.EngineStatus
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&data)
+		if err != nil {
+			return nil, err
+		}
+
+		var st v1.EngineStatus
+		err = proto.Unmarshal(data, &st)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &st)
+	}
+	return res, rows.Err()
+}
+
+// sqlTextColumns are the fields that Find can filter on in SQL, text is compared byte by byte like in MatchesFilter
+var sqlTextColumns = map[string]string{
+	"name":  `name COLLATE "C"`,
+	"phase": `phase COLLATE "C"`,
+	"owner": `owner COLLATE "C"`,
+}
+
+// sqlWhere returns the SQL condition and arguments for the filter expressions that only use
+// columns of the engine_status table, and the remaining expressions that need to be evaluated in-process.
+func sqlWhere(filter []*v1.FilterExpression) (where string, args []interface{}, rest []*v1.FilterExpression) {
+	var conds []string
+	for _, expr := range filter {
+		if len(expr.Terms) == 0 {
+			continue
+		}
+
+		used := len(args)
+		terms := make([]string, 0, len(expr.Terms))
+		for _, term := range expr.Terms {
+			col, ok := sqlTextColumns[term.Field]
+			if !ok {
+				break
+			}
+
+			var cond string
+			switch term.Operation {
+			case v1.FilterOp_OP_EXISTS:
+				cond = col + ` IS NOT NULL`
+			case v1.FilterOp_OP_EQUALS:
+				args = append(args, term.Value)
+				cond = fmt.Sprintf(`%s = $%d::text`, col, len(args))
+			case v1.FilterOp_OP_STARTS_WITH:
+				args = append(args, term.Value)
+				cond = fmt.Sprintf(`left(%s, length($%[2]d::text)) = $%[2]d::text`, col, len(args))
+			case v1.FilterOp_OP_ENDS_WITH:
+				args = append(args, term.Value)
+				cond = fmt.Sprintf(`right(%s, length($%[2]d::text)) = $%[2]d::text`, col, len(args))
+			case v1.FilterOp_OP_CONTAINS:
+				args = append(args, term.Value)
+				cond = fmt.Sprintf(`strpos(%s, $%d::text) > 0`, col, len(args))
+			default:
+				cond = `FALSE`
+			}
+			cond = `COALESCE(` + cond + `, FALSE)` // a missing field does not match, so a negated term on it does
+			if term.Negate {
+				cond = `NOT ` + cond
+			}
+			terms = append(terms, cond)
+		}
+		if len(terms) < len(expr.Terms) {
+			args = args[:used]
+			rest = append(rest, expr)
+			continue
+		}
+		conds = append(conds, `(`+strings.Join(terms, ` OR `)+`)`)
+	}
+
+	if len(conds) == 0 {
+		return "", args, rest
+	}
+	return ` WHERE ` + strings.Join(conds, ` AND `), args, rest
+}
+
+// sqlOrderBy returns the SQL ordering for the order expressions, ties are ordered by creation like
+// SortEngines. The second return value is false if an expression cannot be ordered by in SQL,
+// the ordering returned is then only by creation.
+func sqlOrderBy(order []*v1.OrderExpression) (string, bool) {
+	const byCreation = `created ASC NULLS FIRST, name COLLATE "C"`
+
+	terms := make([]string, 0, len(order)+1)
+	for _, o := range order {
+		var term string
+		switch o.Field {
+		case "created", "finished":
+			term = o.Field
+			if o.Ascending {
+				term += ` ASC NULLS FIRST` // missing times sort first, see compareTimestamps
+			} else {
+				term += ` DESC NULLS LAST`
+			}
+		case "name", "phase", "owner":
+			term = `COALESCE(` + o.Field + `, '') COLLATE "C"`
+			if !o.Ascending {
+				term += ` DESC`
+			}
+		default:
+			return byCreation, false
+		}
+		terms = append(terms, term)
+	}
+	return strings.Join(append(terms, byCreation), ", "), true
+}
+
+// StoreSpec stores the specification an Engine was started from
+func (s *SQLEngines) StoreSpec(ctx context.Context, name string, spec *EngineSpec) error {
+	_, err := s.DB.ExecContext(ctx, `
+		INSERT INTO engine_spec (name, engine_path, config_yaml, engine_yaml, application) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (name) DO UPDATE SET
+			engine_path = EXCLUDED.engine_path,
+			config_yaml = EXCLUDED.config_yaml,
+			engine_yaml = EXCLUDED.engine_yaml,
+			application = EXCLUDED.application`,
+		name, spec.EnginePath, spec.ConfigYAML, spec.EngineYAML, spec.Application,
+	)
+	return err
+}
+
+// GetSpec retrieves the specification an Engine was started from
+func (s *SQLEngines) GetSpec(ctx context.Context, name string) (*EngineSpec, error) {
+	var res EngineSpec
+	err := s.DB.QueryRowContext(ctx, `SELECT engine_path, config_yaml, engine_yaml, application FROM engine_spec WHERE name = $1`, name).
+		Scan(&res.EnginePath, &res.ConfigYAML, &res.EngineYAML, &res.Application)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// NextNumber returns the next number in a sequence
+func (s *SQLEngines) NextNumber(ctx context.Context, group string) (int, error) {
+	var val int
+	err := s.DB.QueryRowContext(ctx, `
+		INSERT INTO engine_number (grp, val) VALUES ($1, 1)
+		ON CONFLICT (grp) DO UPDATE SET val = engine_number.val + 1
+		RETURNING val`,
+		group,
+	).Scan(&val)
+	return val, err
+}
+
+// NewSQLLogs creates a new SQL log store, creating the required tables if necessary.
+// The store expects a PostgreSQL database.
+func NewSQLLogs(db *sql.DB) (*SQLLogs, error) {
+	err := migrate(db)
+	if err != nil {
+		return nil, err
+	}
+	return &SQLLogs{
+		DB:      db,
+		changed: make(map[string]chan struct{}),
+	}, nil
+}
+
+// SQLLogs stores logs in a PostgreSQL database. Every write is stored as a chunk of its own.
+type SQLLogs struct {
+	DB *sql.DB
+
+	mu      sync.Mutex
+	changed map[string]chan struct{}
+}
+
+var _ Logs = &SQLLogs{}
+
+// Open creates a new log
+func (s *SQLLogs) Open(name string) (io.WriteCloser, error) {
+	_, err := s.DB.Exec(`INSERT INTO engine_log (name) VALUES ($1)`, name)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+		return nil, ErrAlreadyExists
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sqlLogWriter{store: s, name: name}, nil
+}
+
+// Read returns a reader following the log
+func (s *SQLLogs) Read(ctx context.Context, name string) (io.Reader, error) {
+	var closed bool
+	err := s.DB.QueryRowContext(ctx, `SELECT closed FROM engine_log WHERE name = $1`, name).Scan(&closed)
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &sqlLogReader{store: s, name: name, ctx: ctx}, nil
+}
+
+// Close marks the end of a log
+func (s *SQLLogs) Close(name string) error {
+	res, err := s.DB.Exec(`UPDATE engine_log SET closed = TRUE WHERE name = $1`, name)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err == nil && n == 0 {
+		return ErrNotFound
+	}
+	s.notify(name)
+	return nil
+}
+
+// notify wakes up all readers of a log in this process
+func (s *SQLLogs) notify(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.changed[name]; ok {
+		close(c)
+		delete(s.changed, name)
+	}
+}
+
+// wait returns a channel which is closed when the log is written to by this process
+func (s *SQLLogs) wait(name string) <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c, ok := s.changed[name]
+	if !ok {
+		c = make(chan struct{})
+		s.changed[name] = c
+	}
+	return c
+}
+
+type sqlLogWriter struct {
+	store *SQLLogs
+	name  string
+}
+
+func (w *sqlLogWriter) Write(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+	_, err = w.store.DB.Exec(`INSERT INTO engine_log_chunk (name, data) VALUES ($1, $2)`, w.name, p)
+	if err != nil {
+		return 0, err
+	}
+	w.store.notify(w.name)
+	return len(p), nil
+}
+
+func (w *sqlLogWriter) Close() error {
+	return w.store.Close(w.name)
+}
+
+type sqlLogReader struct {
+	store   *SQLLogs
+	name    string
+	ctx     context.Context
+	lastID  int64
+	pending []byte
+}
+
+func (r *sqlLogReader) Read(p []byte) (n int, err error) {
+	for len(r.pending) == 0 {
+		changed := r.store.wait(r.name)
+
+		// check for closed before reading the chunks: everything written before the log
+		// was closed is then guaranteed to be part of the result.
+		var closed bool
+		err = r.store.DB.QueryRowContext(r.ctx, `SELECT closed FROM engine_log WHERE name = $1`, r.name).Scan(&closed)
+		if err != nil {
+			return 0, err
+		}
+		err = r.fetch()
+		if err != nil {
+			return 0, err
+		}
+		if len(r.pending) > 0 {
+			break
+		}
+		if closed {
+			return 0, io.EOF
+		}
+
+		select {
+		case <-changed:
+		case <-time.After(sqlLogPollInterval):
+		case <-r.ctx.Done():
+			return 0, r.ctx.Err()
+		}
+	}
+
+	n = copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// fetch reads the next chunks of the log into the pending buffer
+func (r *sqlLogReader) fetch() error {
+	rows, err := r.store.DB.QueryContext(r.ctx, `SELECT id, data FROM engine_log_chunk WHERE name = $1 AND id > $2 ORDER BY id LIMIT 100`, r.name, r.lastID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var data []byte
+		err = rows.Scan(&r.lastID, &data)
+		if err != nil {
+			return err
+		}
+		r.pending = append(r.pending, data...)
+	}
+	return rows.Err()
+}
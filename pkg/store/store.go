@@ -0,0 +1,94 @@
+package store
+
+// Copyright (c) 2018 Bhojpur Consulting Private Limited, India. All rights reserved.
+
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	v1 "github.com/bhojpur/gui/pkg/api/v1"
+)
+
+// ErrNotFound is returned by Get and GetSpec if the Engine is unknown
+var ErrNotFound = errors.New("not found")
+
+// ErrAlreadyExists is returned by Logs.Open if a log of that name exists already
+var ErrAlreadyExists = errors.New("exists already")
+
+// EngineSpec is the specification an Engine was started from. It is kept so that the
+// Engine can be replayed later on.
+type EngineSpec struct {
+	EnginePath  string
+	ConfigYAML  []byte
+	EngineYAML  []byte
+	Application []byte
+}
+
+// Engines stores the status and specification of Engine(s)
+type Engines interface {
+	// Store stores the status of an Engine, replacing a previous status of the same name
+	Store(ctx context.Context, status *v1.EngineStatus) error
+
+	// Get retrieves the status of a single Engine
+	Get(ctx context.Context, name string) (*v1.EngineStatus, error)
+
+	// Find returns all Engine(s) matching the filter in the requested order. If limit
+	// is zero, all Engine(s) starting at start are returned. Total is the number of
+	// matching Engine(s) regardless of start and limit.
+	Find(ctx context.Context, filter []*v1.FilterExpression, order []*v1.OrderExpression, start, limit int) (result []*v1.EngineStatus, total int, err error)
+
+	// StoreSpec stores the specification an Engine was started from
+	StoreSpec(ctx context.Context, name string, spec *EngineSpec) error
+
+	// GetSpec retrieves the specification an Engine was started from
+	GetSpec(ctx context.Context, name string) (*EngineSpec, error)
+
+	// NextNumber returns the next number in a sequence used for naming Engine(s).
+	// The first number of each group is 1.
+	NextNumber(ctx context.Context, group string) (int, error)
+}
+
+// Logs stores the log output of Engine(s)
+type Logs interface {
+	// Open creates a new log. Closing the writer marks the end of the log.
+	Open(name string) (io.WriteCloser, error)
+
+	// Read returns a reader starting at the beginning of the log. While the log is still
+	// open the reader blocks for new content until the log is closed or the context is done.
+	Read(ctx context.Context, name string) (io.Reader, error)
+
+	// Close marks the end of a log whose writer is gone, e.g. because the process writing
+	// it was restarted. Closing a log which is closed already has no effect.
+	Close(name string) error
+}
+
+// paginate applies start and limit to a slice of Engine(s)
+func paginate(res []*v1.EngineStatus, start, limit int) []*v1.EngineStatus {
+	if start > len(res) {
+		start = len(res)
+	}
+	end := len(res)
+	if limit > 0 && start+limit < end {
+		end = start + limit
+	}
+	return res[start:end]
+}